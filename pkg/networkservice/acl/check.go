@@ -0,0 +1,109 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package acl
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/edwarnicke/govpp/binapi/acl"
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/reconcile"
+)
+
+const checkName = "acl"
+
+// check returns the reconcile check recreating the ACLs of the connection if they are not applied to the interface
+// anymore (e.g. were deleted or detached by hand). The interface may have the ACLs contributed by the other elements.
+func (a *aclServer) check(id string, swIfIndex interface_types.InterfaceIndex) reconcile.Check {
+	return func(ctx context.Context) (int, error) {
+		indices, ok := a.aclIndices.Load(id)
+		if !ok {
+			return 0, nil
+		}
+		applied, err := dumpInterfaceACLs(ctx, a.vppConn, swIfIndex)
+		if err != nil {
+			return 0, err
+		}
+		if containsIndices(applied, indices) {
+			return 0, nil
+		}
+
+		for _, index := range indices {
+			// The ACL may be already deleted
+			_, _ = acl.NewServiceClient(a.vppConn).ACLDel(ctx, &acl.ACLDel{ACLIndex: index})
+		}
+		a.aclIndices.Delete(id)
+		if indices, err = setACLList(ctx, a.vppConn, aclTag, swIfIndex, a.rules(a.aclRules.Load())); err != nil {
+			return 0, err
+		}
+		a.aclIndices.Store(id, indices)
+		return 1, nil
+	}
+}
+
+func dumpInterfaceACLs(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex) ([]uint32, error) {
+	now := time.Now()
+	client, err := acl.NewServiceClient(vppConn).ACLInterfaceListDump(ctx, &acl.ACLInterfaceListDump{
+		SwIfIndex: swIfIndex,
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", swIfIndex).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "ACLInterfaceListDump").Debug("completed")
+
+	var rv []uint32
+	for {
+		details, err := client.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if details.SwIfIndex == swIfIndex {
+			rv = append(rv, details.Acls...)
+		}
+	}
+	return rv, nil
+}
+
+// containsIndices returns true if all the indices of b are in a
+func containsIndices(a, b []uint32) bool {
+	for _, index := range b {
+		found := false
+		for _, applied := range a {
+			if applied == index {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
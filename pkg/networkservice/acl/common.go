@@ -24,8 +24,10 @@ import (
 	"git.fd.io/govpp.git/api"
 	"github.com/edwarnicke/govpp/binapi/acl"
 	"github.com/edwarnicke/govpp/binapi/acl_types"
+	"github.com/edwarnicke/govpp/binapi/interface_types"
 	"github.com/pkg/errors"
 
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/aclmanager"
 	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
 
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
@@ -45,6 +47,12 @@ func create(ctx context.Context, vppConn api.Connection, tag string, isClient bo
 	}
 	logger.Infof(fmt.Sprintf("swIfIndex=%v", swIfIndex))
 
+	return setACLList(ctx, vppConn, tag, swIfIndex, aRules)
+}
+
+func setACLList(ctx context.Context, vppConn api.Connection, tag string, swIfIndex interface_types.InterfaceIndex, aRules []acl_types.ACLRule) ([]uint32, error) {
+	logger := log.FromContext(ctx).WithField("acl_server", "create")
+
 	interfaceACLList := &acl.ACLInterfaceSetACLList{
 		SwIfIndex: swIfIndex,
 	}
@@ -65,7 +73,9 @@ func create(ctx context.Context, vppConn api.Connection, tag string, isClient bo
 	interfaceACLList.Acls = append(interfaceACLList.Acls, egressACLIndeces...)
 	interfaceACLList.Count = uint8(len(interfaceACLList.Acls))
 
-	_, err = acl.NewServiceClient(vppConn).ACLInterfaceSetACLList(ctx, interfaceACLList)
+	// The ACLs are composed with the ones contributed by the other elements to the interface
+	err = aclmanager.Set(ctx, vppConn, swIfIndex, tag, aclmanager.ACLPriority,
+		interfaceACLList.Acls[:interfaceACLList.NInput], interfaceACLList.Acls[interfaceACLList.NInput:])
 	if err != nil {
 		logger.Info("error setting acl list for interface")
 		return nil, errors.WithStack(err)
@@ -95,7 +105,8 @@ func aclAdd(tag string, egress bool, aRules []acl_types.ACLRule) *acl.ACLAddRepl
 		ACLIndex: ^uint32(0),
 		Tag:      tag,
 		Count:    uint32(len(aRules)),
-		R:        aRules,
+		// The egress rules are swapped, so the rules shared by the connections are copied
+		R: append([]acl_types.ACLRule(nil), aRules...),
 	}
 	if egress {
 		for i := range aclAddReplace.R {
@@ -0,0 +1,197 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package acl
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"git.fd.io/govpp.git/adapter"
+	"github.com/edwarnicke/govpp/binapi/acl_types"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/types"
+)
+
+const (
+	defaultDenialLogSize     = 100
+	defaultDenialLogInterval = 10 * time.Second
+)
+
+// Denial is the record of the packets of the connection denied by an ACL rule
+type Denial struct {
+	// ConnectionID - id of the connection
+	ConnectionID string
+	// Direction - "ingress" or "egress"
+	Direction string
+	// Rule - index of the denying rule, the indexes past the configured rules stand for the default deny
+	Rule int
+	// Default - the packets matched no configured rule
+	Default bool
+	// Packets, Bytes - the packets and bytes denied since the previous record
+	Packets, Bytes uint64
+	// Time - the time the denial was noticed
+	Time time.Time
+}
+
+// DenialLog keeps the recent ACL denials of the connections
+type DenialLog struct {
+	size     int
+	interval time.Duration
+
+	mu      sync.Mutex
+	denials []Denial
+	last    map[string][]adapter.CombinedCounter
+}
+
+// NewDenialLog returns a log keeping the size most recent denials (100 if not positive), the ACL counters are polled
+// every interval (10s if not positive)
+func NewDenialLog(size int, interval time.Duration) *DenialLog {
+	if size <= 0 {
+		size = defaultDenialLogSize
+	}
+	if interval <= 0 {
+		interval = defaultDenialLogInterval
+	}
+	return &DenialLog{
+		size:     size,
+		interval: interval,
+		last:     make(map[string][]adapter.CombinedCounter),
+	}
+}
+
+// Recent returns the recent denials, the oldest first
+func (d *DenialLog) Recent() []Denial {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]Denial(nil), d.denials...)
+}
+
+// record compares the counters of the ACL with the previous ones and records the increased counters of the deny rules
+func (d *DenialLog) record(ctx context.Context, id, direction string, rules []acl_types.ACLRule, configured int, counters []adapter.CombinedCounter) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	key := id + "/" + direction
+	last := d.last[key]
+	d.last[key] = counters
+	for rule, counter := range counters {
+		if rule >= len(rules) || rules[rule].IsPermit != acl_types.ACL_ACTION_API_DENY {
+			continue
+		}
+		var prev adapter.CombinedCounter
+		if rule < len(last) {
+			prev = last[rule]
+		}
+		// The counters are reset when the ACL is replaced
+		if counter.Packets() <= prev.Packets() {
+			continue
+		}
+		denial := Denial{
+			ConnectionID: id,
+			Direction:    direction,
+			Rule:         rule,
+			Default:      rule >= configured,
+			Packets:      counter.Packets() - prev.Packets(),
+			Bytes:        counter.Bytes() - prev.Bytes(),
+			Time:         time.Now(),
+		}
+		log.FromContext(ctx).
+			WithField("id", id).
+			WithField("direction", direction).
+			WithField("rule", rule).
+			WithField("default", denial.Default).
+			WithField("packets", denial.Packets).
+			Info("ACL denied packets")
+		d.denials = append(d.denials, denial)
+		if len(d.denials) > d.size {
+			d.denials = d.denials[len(d.denials)-d.size:]
+		}
+	}
+}
+
+func (d *DenialLog) forget(id string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, direction := range []string{"ingress", "egress"} {
+		delete(d.last, id+"/"+direction)
+	}
+}
+
+// denyAll is the explicit form of the default deny of the ACLs, its hit counters are the packets matching no rule
+func denyAll() []acl_types.ACLRule {
+	var rv []acl_types.ACLRule
+	for _, prefix := range []*net.IPNet{
+		{IP: net.IPv4zero, Mask: net.CIDRMask(0, net.IPv4len*8)},
+		{IP: net.IPv6zero, Mask: net.CIDRMask(0, net.IPv6len*8)},
+	} {
+		rv = append(rv, acl_types.ACLRule{
+			IsPermit:              acl_types.ACL_ACTION_API_DENY,
+			SrcPrefix:             types.ToVppPrefix(prefix),
+			DstPrefix:             types.ToVppPrefix(prefix),
+			SrcportOrIcmptypeLast: ^uint16(0),
+			DstportOrIcmpcodeLast: ^uint16(0),
+		})
+	}
+	return rv
+}
+
+// rules returns the ACL rules followed by the explicit default deny if the denials are logged
+func (a *aclServer) rules(aclRules []acl_types.ACLRule) []acl_types.ACLRule {
+	if a.denials == nil || len(aclRules) == 0 {
+		return aclRules
+	}
+	return append(append([]acl_types.ACLRule(nil), aclRules...), denyAll()...)
+}
+
+// watchDenials polls the ACL counters of the connections and records the denials until ctx is done
+func (a *aclServer) watchDenials(ctx context.Context) {
+	ticker := time.NewTicker(a.denials.interval)
+	defer ticker.Stop()
+	directions := []string{"ingress", "egress"}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		// The counters are enabled on the next tick if vpp has failed to enable them
+		if err := a.hitCounters.init(ctx, a.vppConn); err != nil {
+			log.FromContext(ctx).Warnf("ACL denials are not logged: %v", err)
+			continue
+		}
+		configured := a.aclRules.Load()
+		rules := a.rules(configured)
+		a.aclIndices.Range(func(id string, indices []uint32) bool {
+			for i, aclIndex := range indices {
+				if i >= len(directions) {
+					break
+				}
+				counters, err := a.hitCounters.perRule(aclIndex)
+				if err != nil {
+					log.FromContext(ctx).WithField("id", id).Errorf("%v", err)
+					continue
+				}
+				a.denials.record(ctx, id, directions[i], rules, len(configured), counters)
+			}
+			return true
+		})
+	}
+}
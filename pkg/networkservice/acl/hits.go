@@ -0,0 +1,128 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package acl
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"git.fd.io/govpp.git/adapter"
+	"git.fd.io/govpp.git/api"
+	"github.com/edwarnicke/govpp/binapi/acl"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// StatsConn - connection to the vpp stats segment, e.g. the *stats.Conn shared with the stats elements
+type StatsConn interface {
+	// Dump returns the raw stats entries matching the patterns
+	Dump(patterns ...string) ([]adapter.StatEntry, error)
+}
+
+// hitCounters reads the per rule ACL hit counters from the vpp stats segment
+type hitCounters struct {
+	statsConn StatsConn
+
+	enabled     uint32
+	enableMutex sync.Mutex
+}
+
+// init enables the ACL counters in vpp, a failed attempt is retried on the next call
+func (h *hitCounters) init(ctx context.Context, vppConn api.Connection) error {
+	if atomic.LoadUint32(&h.enabled) > 0 {
+		return nil
+	}
+	h.enableMutex.Lock()
+	defer h.enableMutex.Unlock()
+	if atomic.LoadUint32(&h.enabled) > 0 {
+		return nil
+	}
+
+	now := time.Now()
+	if _, err := acl.NewServiceClient(vppConn).ACLStatsIntfCountersEnable(ctx, &acl.ACLStatsIntfCountersEnable{
+		Enable: true,
+	}); err != nil {
+		return errors.Wrap(err, "vpp error enabling acl counters")
+	}
+	log.FromContext(ctx).
+		WithField("enable", true).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "ACLStatsIntfCountersEnable").Debug("completed")
+	atomic.StoreUint32(&h.enabled, 1)
+	return nil
+}
+
+// retrieve stores the hit counters of the rules of the ingress and egress ACLs in the segment metrics
+func (h *hitCounters) retrieve(ctx context.Context, indices []uint32, segment *networkservice.PathSegment) {
+	directions := []string{"ingress", "egress"}
+	for i, aclIndex := range indices {
+		if i >= len(directions) {
+			break
+		}
+		counters, err := h.perRule(aclIndex)
+		if err != nil {
+			log.FromContext(ctx).Errorf("%v", err)
+			continue
+		}
+		if len(counters) > 0 && segment.Metrics == nil {
+			segment.Metrics = make(map[string]string)
+		}
+		for rule, counter := range counters {
+			prefix := fmt.Sprintf("acl_%s_rule_%d_", directions[i], rule)
+			segment.Metrics[prefix+"packets"] = strconv.FormatUint(counter.Packets(), 10)
+			segment.Metrics[prefix+"bytes"] = strconv.FormatUint(counter.Bytes(), 10)
+		}
+	}
+}
+
+// perRule returns the hit counters of the rules of the ACL summed over the threads
+func (h *hitCounters) perRule(aclIndex uint32) ([]adapter.CombinedCounter, error) {
+	if h.statsConn == nil {
+		return nil, errors.New("no stats connection to read the acl counters from")
+	}
+	entries, err := h.statsConn.Dump(fmt.Sprintf("^/acl/%d/matches$", aclIndex))
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to dump acl %d counters", aclIndex)
+	}
+	for _, entry := range entries {
+		if counters, ok := entry.Data.(adapter.CombinedCounterStat); ok {
+			return sumPerRule(counters), nil
+		}
+	}
+	return nil, nil
+}
+
+// sumPerRule sums the per thread counters
+func sumPerRule(counters adapter.CombinedCounterStat) []adapter.CombinedCounter {
+	var rv []adapter.CombinedCounter
+	for _, perThread := range counters {
+		for rule, counter := range perThread {
+			for len(rv) <= rule {
+				rv = append(rv, adapter.CombinedCounter{})
+			}
+			rv[rule][0] += counter[0]
+			rv[rule][1] += counter[1]
+		}
+	}
+	return rv
+}
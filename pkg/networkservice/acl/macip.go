@@ -0,0 +1,98 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package acl
+
+import (
+	"context"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/edwarnicke/govpp/binapi/acl"
+	"github.com/edwarnicke/govpp/binapi/acl_types"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/payload"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/teardown"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/aclmanager"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+)
+
+type macipKey struct{}
+
+// createMACIP applies the MACIP ACL to the input of the interface of the Ethernet payload connection, so the bridged
+// (l2 xconnect or bridge domain) traffic is filtered by the source MAC and IP
+func createMACIP(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, isClient bool, rules []acl_types.MacipACLRule) error {
+	if conn.GetPayload() != payload.Ethernet || len(rules) == 0 {
+		return nil
+	}
+	if _, ok := metadata.Map(ctx, isClient).Load(macipKey{}); ok {
+		return nil
+	}
+	swIfIndex, ok := ifindex.Load(ctx, isClient)
+	if !ok {
+		return nil
+	}
+
+	now := time.Now()
+	rsp, err := acl.NewServiceClient(vppConn).MacipACLAddReplace(ctx, &acl.MacipACLAddReplace{
+		ACLIndex: ^uint32(0),
+		Tag:      aclTag,
+		Count:    uint32(len(rules)),
+		R:        rules,
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("aclIndex", rsp.ACLIndex).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "MacipACLAddReplace").Debug("completed")
+	metadata.Map(ctx, isClient).Store(macipKey{}, rsp.ACLIndex)
+
+	return aclmanager.SetMACIP(ctx, vppConn, swIfIndex, aclTag, aclmanager.ACLPriority, rsp.ACLIndex)
+}
+
+// deleteMACIP detaches and deletes the MACIP ACL
+func deleteMACIP(ctx context.Context, vppConn api.Connection, isClient bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).LoadAndDelete(macipKey{})
+	if !ok {
+		return
+	}
+	aclIndex := rawValue.(uint32)
+
+	_ = teardown.Do(ctx, teardown.ACLs, func(ctx context.Context) error {
+		if swIfIndex, ok := ifindex.Load(ctx, isClient); ok {
+			if err := aclmanager.RemoveMACIP(ctx, vppConn, swIfIndex, aclTag); err != nil {
+				log.FromContext(ctx).Errorf("unable to detach the MACIP ACL %d: %v", aclIndex, err)
+			}
+		}
+		now := time.Now()
+		if _, err := acl.NewServiceClient(vppConn).MacipACLDel(ctx, &acl.MacipACLDel{ACLIndex: aclIndex}); err != nil {
+			log.FromContext(ctx).Errorf("unable to delete the MACIP ACL %d: %v", aclIndex, err)
+			return nil
+		}
+		log.FromContext(ctx).
+			WithField("aclIndex", aclIndex).
+			WithField("duration", time.Since(now)).
+			WithField("vppapi", "MacipACLDel").Debug("completed")
+		return nil
+	})
+}
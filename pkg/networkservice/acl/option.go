@@ -0,0 +1,77 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package acl
+
+import (
+	"context"
+
+	"github.com/edwarnicke/govpp/binapi/acl_types"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/hotreload"
+)
+
+type aclOptions struct {
+	chainCtx    context.Context
+	statsConn   StatsConn
+	hitCounters bool
+	rules       *hotreload.Value[[]acl_types.ACLRule]
+	macipRules  []acl_types.MacipACLRule
+	denials     *DenialLog
+}
+
+// Option is an option pattern for acl server
+type Option func(o *aclOptions)
+
+// WithHitCounters enables the per rule ACL hit counters. The counters are read from the vpp stats segment over
+// statsConn (e.g. the *stats.Conn shared with the stats elements) and stored in the PathSegment.Metrics of the
+// connection.
+func WithHitCounters(statsConn StatsConn) Option {
+	return func(o *aclOptions) {
+		o.statsConn = statsConn
+		o.hitCounters = true
+	}
+}
+
+// WithDenialLog records the packets denied by the ACLs of the connections into denials and logs them. The default deny
+// of the ACLs is made explicit to count the packets matching no rule. The ACL counters are polled from the vpp stats
+// segment over statsConn (e.g. the *stats.Conn shared with the stats elements) until chainCtx is done. vpp can't punt
+// the denied packets, so only their counts are recorded.
+func WithDenialLog(chainCtx context.Context, statsConn StatsConn, denials *DenialLog) Option {
+	return func(o *aclOptions) {
+		o.chainCtx = chainCtx
+		o.statsConn = statsConn
+		o.denials = denials
+	}
+}
+
+// WithRules sets the ACL rules updated at runtime, overriding the rules passed to NewServer. The new rules replace the
+// rules of the ACLs of the existing connections in place until chainCtx is done.
+func WithRules(chainCtx context.Context, rules *hotreload.Value[[]acl_types.ACLRule]) Option {
+	return func(o *aclOptions) {
+		o.chainCtx = chainCtx
+		o.rules = rules
+	}
+}
+
+// WithMACIPRules sets the MACIP ACL rules applied to the input of the interfaces of the Ethernet payload connections.
+// The ACLs set by the rules passed to NewServer match only the IP traffic, the MACIP ACL filters the bridged traffic
+// by the source MAC and IP.
+func WithMACIPRules(macipRules []acl_types.MacipACLRule) Option {
+	return func(o *aclOptions) {
+		o.macipRules = macipRules
+	}
+}
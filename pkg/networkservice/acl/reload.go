@@ -0,0 +1,54 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package acl
+
+import (
+	"context"
+	"time"
+
+	"github.com/edwarnicke/govpp/binapi/acl"
+	"github.com/edwarnicke/govpp/binapi/acl_types"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// update replaces the rules of the ACLs of the existing connections in place, the ACLs of a connection are the
+// ingress one followed by the egress one
+func (a *aclServer) update(ctx context.Context, aclRules []acl_types.ACLRule) {
+	if len(aclRules) == 0 {
+		log.FromContext(ctx).Warn("the empty ACL rules are not applied to the existing connections")
+		return
+	}
+	a.aclIndices.Range(func(id string, indices []uint32) bool {
+		for i, index := range indices {
+			aclAddReplace := aclAdd(aclTag, i > 0, a.rules(aclRules))
+			aclAddReplace.ACLIndex = index
+
+			now := time.Now()
+			if _, err := acl.NewServiceClient(a.vppConn).ACLAddReplace(ctx, aclAddReplace); err != nil {
+				log.FromContext(ctx).WithField("id", id).Errorf("unable to update the ACL %d: %v", index, err)
+				continue
+			}
+			log.FromContext(ctx).
+				WithField("id", id).
+				WithField("aclIndex", index).
+				WithField("duration", time.Since(now)).
+				WithField("vppapi", "ACLAddReplace").Debug("completed")
+		}
+		return true
+	})
+}
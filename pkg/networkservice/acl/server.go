@@ -31,20 +31,51 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
 	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/reconcile"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/teardown"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/aclmanager"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/hotreload"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
 )
 
 type aclServer struct {
-	vppConn    api.Connection
-	aclRules   []acl_types.ACLRule
-	aclIndices aclIndicesMap
+	vppConn     api.Connection
+	aclRules    *hotreload.Value[[]acl_types.ACLRule]
+	aclIndices  aclIndicesMap
+	hitCounters *hitCounters
+	hitMetrics  bool
+	macipRules  []acl_types.MacipACLRule
+	denials     *DenialLog
 }
 
 // NewServer creates a NetworkServiceServer chain element to set the ACL on a vpp interface
-func NewServer(vppConn api.Connection, aclrules []acl_types.ACLRule) networkservice.NetworkServiceServer {
-	return &aclServer{
-		vppConn:  vppConn,
-		aclRules: aclrules,
+func NewServer(vppConn api.Connection, aclrules []acl_types.ACLRule, options ...Option) networkservice.NetworkServiceServer {
+	opts := &aclOptions{}
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	rv := &aclServer{
+		vppConn:    vppConn,
+		aclRules:   hotreload.NewValue(aclrules),
+		macipRules: opts.macipRules,
+		denials:    opts.denials,
+		hitMetrics: opts.hitCounters,
+	}
+	if opts.rules != nil {
+		rv.aclRules = opts.rules
+		opts.rules.Watch(opts.chainCtx, rv.update)
+	}
+	if opts.hitCounters || opts.denials != nil {
+		rv.hitCounters = &hitCounters{
+			statsConn: opts.statsConn,
+		}
+	}
+	if opts.denials != nil {
+		go rv.watchDenials(opts.chainCtx)
 	}
+	return rv
 }
 
 func (a *aclServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
@@ -56,9 +87,9 @@ func (a *aclServer) Request(ctx context.Context, request *networkservice.Network
 	}
 
 	_, loaded := a.aclIndices.Load(conn.GetId())
-	if !loaded && len(a.aclRules) > 0 {
+	if aclRules := a.aclRules.Load(); !loaded && len(aclRules) > 0 {
 		var indices []uint32
-		if indices, err = create(ctx, a.vppConn, aclTag, metadata.IsClient(a), a.aclRules); err != nil {
+		if indices, err = create(ctx, a.vppConn, aclTag, metadata.IsClient(a), a.rules(aclRules)); err != nil {
 			closeCtx, cancelClose := postponeCtxFunc()
 			defer cancelClose()
 
@@ -70,19 +101,62 @@ func (a *aclServer) Request(ctx context.Context, request *networkservice.Network
 		}
 
 		a.aclIndices.Store(conn.GetId(), indices)
+		if swIfIndex, ok := ifindex.Load(ctx, metadata.IsClient(a)); ok {
+			reconcile.Store(ctx, metadata.IsClient(a), checkName, a.check(conn.GetId(), swIfIndex))
+		}
+	}
+
+	if err = createMACIP(ctx, conn, a.vppConn, metadata.IsClient(a), a.macipRules); err != nil {
+		closeCtx, cancelClose := postponeCtxFunc()
+		defer cancelClose()
+
+		if _, closeErr := a.Close(closeCtx, conn); closeErr != nil {
+			err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+		}
+
+		return nil, err
+	}
+
+	if a.hitMetrics {
+		a.retrieveHits(ctx, conn)
 	}
 
 	return conn, nil
 }
 
 func (a *aclServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	reconcile.Delete(ctx, metadata.IsClient(a), checkName)
+	deleteMACIP(ctx, a.vppConn, metadata.IsClient(a))
 	indices, _ := a.aclIndices.LoadAndDelete(conn.GetId())
-	for ind := range indices {
-		_, err := acl.NewServiceClient(a.vppConn).ACLDel(ctx, &acl.ACLDel{ACLIndex: uint32(ind)})
-		if err != nil {
-			log.FromContext(ctx).Infof("ACL_SERVER: error deleting acls")
-		}
+	if a.denials != nil {
+		a.denials.forget(conn.GetId())
 	}
+	_ = teardown.Do(ctx, teardown.ACLs, func(ctx context.Context) error {
+		if swIfIndex, ok := ifindex.Load(ctx, metadata.IsClient(a)); ok && len(indices) > 0 {
+			if err := aclmanager.Remove(ctx, a.vppConn, swIfIndex, aclTag); err != nil {
+				log.FromContext(ctx).Errorf("unable to detach the ACLs %v: %v", indices, err)
+			}
+		}
+		for ind := range indices {
+			_, err := acl.NewServiceClient(a.vppConn).ACLDel(ctx, &acl.ACLDel{ACLIndex: uint32(ind)})
+			if err != nil {
+				log.FromContext(ctx).Infof("ACL_SERVER: error deleting acls")
+			}
+		}
+		return nil
+	})
 
 	return next.Server(ctx).Close(ctx, conn)
 }
+
+func (a *aclServer) retrieveHits(ctx context.Context, conn *networkservice.Connection) {
+	indices, ok := a.aclIndices.Load(conn.GetId())
+	if !ok {
+		return
+	}
+	if err := a.hitCounters.init(ctx, a.vppConn); err != nil {
+		log.FromContext(ctx).Errorf("%v", err)
+		return
+	}
+	a.hitCounters.retrieve(ctx, indices, conn.GetPath().GetPathSegments()[conn.GetPath().GetIndex()])
+}
@@ -0,0 +1,23 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package admission provides chain elements tracking the forwarder resource usage (connections, vpp interfaces,
+// tunnels, vector rate of the workers) and rejecting the new Requests with vpperrors.ErrResourceExhausted when the
+// configured limits are reached, so the NSMgr can pick another forwarder instead of overloading this one
+package admission
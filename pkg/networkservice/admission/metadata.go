@@ -0,0 +1,42 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package admission
+
+import (
+	"context"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+type tunnelKey struct{}
+
+// storeTunnel stores if the client side of the connection is a tunnel
+func storeTunnel(ctx context.Context, tunnel bool) {
+	metadata.Map(ctx, true).Store(tunnelKey{}, tunnel)
+}
+
+func loadTunnel(ctx context.Context) (value, ok bool) {
+	rawValue, ok := metadata.Map(ctx, true).Load(tunnelKey{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(bool)
+	return value, ok
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package admission
+
+import (
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/stats"
+)
+
+type options struct {
+	maxConnections int
+	maxInterfaces  int
+	maxTunnels     int
+	maxVectorRate  uint64
+	statsConn      *stats.Conn
+}
+
+// Option is an option pattern for admission server
+type Option func(o *options)
+
+// WithMaxConnections sets the limit of the connections. Default: unlimited
+func WithMaxConnections(maxConnections int) Option {
+	return func(o *options) {
+		o.maxConnections = maxConnections
+	}
+}
+
+// WithMaxInterfaces sets the limit of the vpp interfaces created for the connections. Default: unlimited
+func WithMaxInterfaces(maxInterfaces int) Option {
+	return func(o *options) {
+		o.maxInterfaces = maxInterfaces
+	}
+}
+
+// WithMaxTunnels sets the limit of the remote mechanism tunnels (vxlan, wireguard, ipsec...). Default: unlimited
+func WithMaxTunnels(maxTunnels int) Option {
+	return func(o *options) {
+		o.maxTunnels = maxTunnels
+	}
+}
+
+// WithMaxVectorRate sets the limit of the vpp vector rate read from statsConn, the workers are considered overloaded
+// above it. Default: unlimited
+func WithMaxVectorRate(maxVectorRate uint64, statsConn *stats.Conn) Option {
+	return func(o *options) {
+		o.maxVectorRate = maxVectorRate
+		o.statsConn = statsConn
+	}
+}
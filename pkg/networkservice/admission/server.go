@@ -0,0 +1,163 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package admission
+
+import (
+	"context"
+	"sync"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/vpperrors"
+)
+
+// resources - the resources used by the connection
+type resources struct {
+	interfaces int
+	tunnels    int
+}
+
+type admissionServer struct {
+	opts  *options
+	usage map[string]*resources
+	mutex sync.Mutex
+}
+
+// NewServer returns a Server chain element rejecting the new Requests with vpperrors.ErrResourceExhausted when the
+// limits set by the options are reached. The refreshes of the admitted connections are never rejected.
+func NewServer(opts ...Option) networkservice.NetworkServiceServer {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &admissionServer{
+		opts:  o,
+		usage: make(map[string]*resources),
+	}
+}
+
+func (a *admissionServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	connID := request.GetConnection().GetId()
+	isNew, err := a.admit(ctx, connID)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		if isNew {
+			a.release(connID)
+		}
+		return nil, err
+	}
+
+	a.update(ctx, conn)
+	return conn, nil
+}
+
+func (a *admissionServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	a.release(conn.GetId())
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+// admit reserves the resources for the new connection if the limits are not reached, returns if the connection is new
+func (a *admissionServer) admit(ctx context.Context, connID string) (isNew bool, err error) {
+	a.mutex.Lock()
+	_, ok := a.usage[connID]
+	a.mutex.Unlock()
+	if ok {
+		return false, nil
+	}
+
+	// The stats are read out of the lock not to block the other Requests
+	if a.opts.maxVectorRate > 0 {
+		vectorRate, err := a.vectorRate()
+		if err != nil {
+			log.FromContext(ctx).Warnf("unable to check the vector rate: %v", err)
+		} else if vectorRate > a.opts.maxVectorRate {
+			return false, errors.Wrapf(vpperrors.ErrResourceExhausted, "forwarder vector rate %d is over the limit %d", vectorRate, a.opts.maxVectorRate)
+		}
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	var total resources
+	for _, r := range a.usage {
+		total.interfaces += r.interfaces
+		total.tunnels += r.tunnels
+	}
+	switch {
+	case a.opts.maxConnections > 0 && len(a.usage) >= a.opts.maxConnections:
+		return false, errors.Wrapf(vpperrors.ErrResourceExhausted, "forwarder has reached the connections limit %d", a.opts.maxConnections)
+	case a.opts.maxInterfaces > 0 && total.interfaces >= a.opts.maxInterfaces:
+		return false, errors.Wrapf(vpperrors.ErrResourceExhausted, "forwarder has reached the interfaces limit %d", a.opts.maxInterfaces)
+	case a.opts.maxTunnels > 0 && total.tunnels >= a.opts.maxTunnels:
+		return false, errors.Wrapf(vpperrors.ErrResourceExhausted, "forwarder has reached the tunnels limit %d", a.opts.maxTunnels)
+	}
+	a.usage[connID] = new(resources)
+	return true, nil
+}
+
+// update sets the resources actually used by the connection
+func (a *admissionServer) update(ctx context.Context, conn *networkservice.Connection) {
+	r := new(resources)
+	for _, isClient := range []bool{false, true} {
+		if _, ok := ifindex.Load(ctx, isClient); ok {
+			r.interfaces++
+		}
+	}
+	if conn.GetMechanism().GetCls() == cls.REMOTE {
+		r.tunnels++
+	}
+	if tunnel, ok := loadTunnel(ctx); ok && tunnel {
+		r.tunnels++
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.usage[conn.GetId()] = r
+}
+
+func (a *admissionServer) release(connID string) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	delete(a.usage, connID)
+}
+
+func (a *admissionServer) vectorRate() (uint64, error) {
+	statsConn, err := a.opts.statsConn.Get()
+	if err != nil {
+		return 0, err
+	}
+	systemStats := new(api.SystemStats)
+	if err := statsConn.GetSystemStats(systemStats); err != nil {
+		return 0, errors.Wrap(err, "failed to get the system stats")
+	}
+	return systemStats.VectorRate, nil
+}
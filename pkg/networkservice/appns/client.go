@@ -0,0 +1,99 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appns
+
+import (
+	"context"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+)
+
+type appnsClient struct {
+	vppConn api.Connection
+	options *options
+	session sessionLayer
+}
+
+// NewClient returns a client chain element adding the application namespace bound to the interface of the local
+// connection. The namespace is passed to the endpoint in the parameters of the local mechanism preferences, the
+// namespace is added once the local mechanism is selected.
+func NewClient(vppConn api.Connection, opts ...Option) networkservice.NetworkServiceClient {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &appnsClient{
+		vppConn: vppConn,
+		options: o,
+	}
+}
+
+func (a *appnsClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	ns, err := newNamespace(request.GetConnection(), a.options)
+	if err != nil {
+		return nil, err
+	}
+	ns = loadOrStore(ctx, metadata.IsClient(a), ns)
+	for _, mechanism := range request.GetMechanismPreferences() {
+		if mechanism.GetCls() == cls.LOCAL {
+			setParameters(mechanism, ns)
+		}
+	}
+
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil {
+		if !ns.created {
+			loadAndDelete(ctx, metadata.IsClient(a))
+		}
+		return nil, err
+	}
+
+	if conn.GetMechanism().GetCls() != cls.LOCAL {
+		return conn, nil
+	}
+	err = a.session.enable(ctx, a.vppConn)
+	if err == nil {
+		err = create(ctx, conn, a.vppConn, ns, metadata.IsClient(a))
+	}
+	if err != nil {
+		closeCtx, cancelClose := postponeCtxFunc()
+		defer cancelClose()
+
+		if _, closeErr := a.Close(closeCtx, conn, opts...); closeErr != nil {
+			err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+		}
+
+		return nil, err
+	}
+	return conn, nil
+}
+
+func (a *appnsClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	del(ctx, a.vppConn, metadata.IsClient(a))
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
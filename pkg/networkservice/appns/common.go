@@ -0,0 +1,148 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appns
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/edwarnicke/govpp/binapi/session"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/vrf"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+)
+
+// sessionLayer enables the vpp session layer once it is needed
+type sessionLayer struct {
+	mu      sync.Mutex
+	enabled bool
+}
+
+func (s *sessionLayer) enable(ctx context.Context, vppConn api.Connection) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.enabled {
+		return nil
+	}
+	now := time.Now()
+	if _, err := session.NewServiceClient(vppConn).SessionEnableDisable(ctx, &session.SessionEnableDisable{
+		IsEnable: true,
+	}); err != nil {
+		return errors.Wrap(err, "failed to enable the session layer")
+	}
+	log.FromContext(ctx).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "SessionEnableDisable").Debug("completed")
+	s.enabled = true
+	return nil
+}
+
+func newNamespace(conn *networkservice.Connection, o *options) (*namespace, error) {
+	var secret [8]byte
+	if _, err := rand.Read(secret[:]); err != nil {
+		return nil, errors.Wrap(err, "failed to generate the application namespace secret")
+	}
+	ns := &namespace{
+		// The namespace ID is limited to 64 characters, the connection ID is an UUID
+		id:     conn.GetId(),
+		secret: binary.BigEndian.Uint64(secret[:]),
+	}
+	if len(ns.id) > 64 {
+		ns.id = ns.id[:64]
+	}
+	if o.socketDir != "" {
+		ns.socket = filepath.Join(o.socketDir, ns.id)
+	}
+	return ns, nil
+}
+
+// setParameters passes the namespace to the application in the mechanism parameters
+func setParameters(mechanism *networkservice.Mechanism, ns *namespace) {
+	if mechanism.GetParameters() == nil {
+		mechanism.Parameters = make(map[string]string)
+	}
+	mechanism.GetParameters()[NamespaceIDParam] = ns.id
+	mechanism.GetParameters()[NamespaceSecretParam] = strconv.FormatUint(ns.secret, 10)
+	if ns.socket != "" {
+		mechanism.GetParameters()[NamespaceSocketParam] = ns.socket
+	}
+}
+
+// create adds the namespace bound to the interface of the local connection
+func create(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, ns *namespace, isClient bool) error {
+	if ns.created || conn.GetMechanism().GetCls() != cls.LOCAL {
+		return nil
+	}
+	swIfIndex, ok := ifindex.Load(ctx, isClient)
+	if !ok {
+		return nil
+	}
+	ip4FibID, _ := vrf.Load(ctx, isClient, false)
+	ip6FibID, _ := vrf.Load(ctx, isClient, true)
+
+	now := time.Now()
+	rsp, err := session.NewServiceClient(vppConn).AppNamespaceAddDelV3(ctx, &session.AppNamespaceAddDelV3{
+		Secret:      ns.secret,
+		IsAdd:       true,
+		SwIfIndex:   swIfIndex,
+		IP4FibID:    ip4FibID,
+		IP6FibID:    ip6FibID,
+		NamespaceID: ns.id,
+		SockName:    ns.socket,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to add the application namespace %s", ns.id)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", swIfIndex).
+		WithField("namespaceID", ns.id).
+		WithField("appnsIndex", rsp.AppnsIndex).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "AppNamespaceAddDelV3").Debug("completed")
+	ns.created = true
+	return nil
+}
+
+func del(ctx context.Context, vppConn api.Connection, isClient bool) {
+	ns, ok := loadAndDelete(ctx, isClient)
+	if !ok || !ns.created {
+		return
+	}
+	now := time.Now()
+	if _, err := session.NewServiceClient(vppConn).AppNamespaceAddDelV3(ctx, &session.AppNamespaceAddDelV3{
+		IsAdd:       false,
+		NamespaceID: ns.id,
+	}); err != nil {
+		log.FromContext(ctx).Errorf("failed to delete the application namespace %s: %v", ns.id, err)
+		return
+	}
+	log.FromContext(ctx).
+		WithField("namespaceID", ns.id).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "AppNamespaceAddDelV3").Debug("completed")
+}
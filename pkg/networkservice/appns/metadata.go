@@ -0,0 +1,51 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appns
+
+import (
+	"context"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+type key struct{}
+
+// namespace - the application namespace of the connection
+type namespace struct {
+	id      string
+	secret  uint64
+	socket  string
+	created bool
+}
+
+func loadOrStore(ctx context.Context, isClient bool, ns *namespace) *namespace {
+	rawValue, _ := metadata.Map(ctx, isClient).LoadOrStore(key{}, ns)
+	value, ok := rawValue.(*namespace)
+	if !ok {
+		return ns
+	}
+	return value
+}
+
+func loadAndDelete(ctx context.Context, isClient bool) (value *namespace, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).LoadAndDelete(key{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(*namespace)
+	return value, ok
+}
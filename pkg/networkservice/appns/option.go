@@ -0,0 +1,32 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appns
+
+type options struct {
+	socketDir string
+}
+
+// Option is an option pattern for NewClient, NewServer
+type Option func(o *options)
+
+// WithSocketDir makes vpp listen on the session API socket per application namespace in socketDir, so the application
+// doesn't need the access to the vpp API socket. The directory must be shared with the applications.
+func WithSocketDir(socketDir string) Option {
+	return func(o *options) {
+		o.socketDir = socketDir
+	}
+}
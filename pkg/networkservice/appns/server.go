@@ -0,0 +1,97 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appns
+
+import (
+	"context"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+)
+
+type appnsServer struct {
+	vppConn api.Connection
+	options *options
+	session sessionLayer
+}
+
+// NewServer returns a server chain element adding the application namespace bound to the interface of the local
+// connection. The namespace is returned to the client in the parameters of the connection mechanism.
+func NewServer(vppConn api.Connection, opts ...Option) networkservice.NetworkServiceServer {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &appnsServer{
+		vppConn: vppConn,
+		options: o,
+	}
+}
+
+func (a *appnsServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.add(ctx, conn); err != nil {
+		closeCtx, cancelClose := postponeCtxFunc()
+		defer cancelClose()
+
+		if _, closeErr := a.Close(closeCtx, conn); closeErr != nil {
+			err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+		}
+
+		return nil, err
+	}
+	return conn, nil
+}
+
+func (a *appnsServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	del(ctx, a.vppConn, metadata.IsClient(a))
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func (a *appnsServer) add(ctx context.Context, conn *networkservice.Connection) error {
+	if conn.GetMechanism().GetCls() != cls.LOCAL {
+		return nil
+	}
+	ns, err := newNamespace(conn, a.options)
+	if err != nil {
+		return err
+	}
+	ns = loadOrStore(ctx, metadata.IsClient(a), ns)
+	if err := a.session.enable(ctx, a.vppConn); err != nil {
+		return err
+	}
+	if err := create(ctx, conn, a.vppConn, ns, metadata.IsClient(a)); err != nil {
+		return err
+	}
+	if ns.created {
+		setParameters(conn.GetMechanism(), ns)
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bgpexport provides a chain element announcing the connection prefixes into a BGP speaker on Request and
+// withdrawing them on Close, so the external fabrics can route to the NSM services. The speaker is plugged in with the
+// Speaker interface, the package doesn't depend on the BGP implementation. The GoBGP backed Speaker is provided by the
+// separate bgpexport/gobgp module, so the GoBGP dependencies are not pulled by the users not needing them.
+package bgpexport
@@ -0,0 +1,13 @@
+module github.com/networkservicemesh/sdk-vpp/pkg/networkservice/bgpexport/gobgp
+
+go 1.18
+
+require (
+	github.com/networkservicemesh/sdk-vpp v0.0.0-00010101000000-000000000000
+	github.com/osrg/gobgp/v3 v3.11.0
+	github.com/pkg/errors v0.9.1
+	google.golang.org/grpc v1.49.0
+	google.golang.org/protobuf v1.28.1
+)
+
+replace github.com/networkservicemesh/sdk-vpp => ../../../..
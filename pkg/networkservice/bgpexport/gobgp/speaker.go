@@ -0,0 +1,135 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gobgp
+
+import (
+	"context"
+
+	api "github.com/osrg/gobgp/v3/api"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/bgpexport"
+)
+
+var (
+	ipv4Unicast = &api.Family{Afi: api.Family_AFI_IP, Safi: api.Family_SAFI_UNICAST}
+	ipv6Unicast = &api.Family{Afi: api.Family_AFI_IP6, Safi: api.Family_SAFI_UNICAST}
+)
+
+type speaker struct {
+	client api.GobgpApiClient
+}
+
+// NewSpeaker returns the bgpexport.Speaker adding the routes to the global rib of the GoBGP daemon the client is
+// connected to and deleting them from it
+func NewSpeaker(client api.GobgpApiClient) bgpexport.Speaker {
+	return &speaker{
+		client: client,
+	}
+}
+
+// Dial returns the bgpexport.Speaker of the GoBGP daemon listening on target (e.g. "127.0.0.1:50051") and the
+// connection to close once the speaker is not needed anymore
+func Dial(ctx context.Context, target string, opts ...grpc.DialOption) (bgpexport.Speaker, *grpc.ClientConn, error) {
+	cc, err := grpc.DialContext(ctx, target, opts...)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to dial the GoBGP daemon %s", target)
+	}
+	return NewSpeaker(api.NewGobgpApiClient(cc)), cc, nil
+}
+
+func (s *speaker) Announce(ctx context.Context, route *bgpexport.Route) error {
+	path, err := toPath(route)
+	if err != nil {
+		return err
+	}
+	if _, err := s.client.AddPath(ctx, &api.AddPathRequest{
+		TableType: api.TableType_GLOBAL,
+		Path:      path,
+	}); err != nil {
+		return errors.Wrapf(err, "failed to add the path %s via %s", route.Prefix, route.NextHop)
+	}
+	return nil
+}
+
+func (s *speaker) Withdraw(ctx context.Context, route *bgpexport.Route) error {
+	path, err := toPath(route)
+	if err != nil {
+		return err
+	}
+	if _, err := s.client.DeletePath(ctx, &api.DeletePathRequest{
+		TableType: api.TableType_GLOBAL,
+		Family:    path.GetFamily(),
+		Path:      path,
+	}); err != nil {
+		return errors.Wrapf(err, "failed to delete the path %s via %s", route.Prefix, route.NextHop)
+	}
+	return nil
+}
+
+// toPath returns the GoBGP path of the route: the IPv4 routes carry the NEXT_HOP attribute, the IPv6 ones the
+// MP_REACH_NLRI attribute with the next hop
+func toPath(route *bgpexport.Route) (*api.Path, error) {
+	isIPv6 := route.Prefix.IP.To4() == nil
+	if (route.NextHop.To4() == nil) != isIPv6 {
+		return nil, errors.Errorf("next hop %s is not of the prefix %s family", route.NextHop, route.Prefix)
+	}
+	ones, _ := route.Prefix.Mask.Size()
+	nlri, err := anypb.New(&api.IPAddressPrefix{
+		Prefix:    route.Prefix.IP.Mask(route.Prefix.Mask).String(),
+		PrefixLen: uint32(ones),
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	family := ipv4Unicast
+	var nextHop proto.Message = &api.NextHopAttribute{NextHop: route.NextHop.String()}
+	if isIPv6 {
+		family = ipv6Unicast
+		nextHop = &api.MpReachNLRIAttribute{
+			Family:   ipv6Unicast,
+			NextHops: []string{route.NextHop.String()},
+			Nlris:    []*anypb.Any{nlri},
+		}
+	}
+	// The origin is IGP
+	attrs, err := toAny(&api.OriginAttribute{Origin: 0}, nextHop)
+	if err != nil {
+		return nil, err
+	}
+	return &api.Path{
+		Family: family,
+		Nlri:   nlri,
+		Pattrs: attrs,
+	}, nil
+}
+
+func toAny(messages ...proto.Message) ([]*anypb.Any, error) {
+	var rv []*anypb.Any
+	for _, m := range messages {
+		a, err := anypb.New(m)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		rv = append(rv, a)
+	}
+	return rv, nil
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgpexport
+
+import (
+	"net"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+type options struct {
+	nextHops []net.IP
+	prefixes func(conn *networkservice.Connection) []*net.IPNet
+}
+
+// Option is an option pattern for NewServer
+type Option func(o *options)
+
+// WithNextHop sets the next hops of the announced routes, the addresses the fabric reaches the forwarder at. The
+// route is announced with the next hop of its prefix family, the prefixes of the family without the next hop are not
+// announced. The nil next hops are ignored.
+func WithNextHop(nextHops ...net.IP) Option {
+	return func(o *options) {
+		o.nextHops = nil
+		for _, ip := range nextHops {
+			if ip != nil {
+				o.nextHops = append(o.nextHops, ip)
+			}
+		}
+	}
+}
+
+// WithPrefixes sets the function selecting the prefixes of the connection to announce (default - the prefixes of the
+// NSE addresses of the connection)
+func WithPrefixes(prefixes func(conn *networkservice.Connection) []*net.IPNet) Option {
+	return func(o *options) {
+		o.prefixes = prefixes
+	}
+}
+
+// nextHop returns the next hop of the prefix family, nil if there is none
+func nextHop(nextHops []net.IP, prefix *net.IPNet) net.IP {
+	isIPv6 := prefix.IP.To4() == nil
+	for _, ip := range nextHops {
+		if (ip.To4() == nil) == isIPv6 {
+			return ip
+		}
+	}
+	return nil
+}
+
+func dstPrefixes(conn *networkservice.Connection) []*net.IPNet {
+	var rv []*net.IPNet
+	for _, ipNet := range conn.GetContext().GetIpContext().GetDstIPNets() {
+		rv = append(rv, &net.IPNet{IP: ipNet.IP.Mask(ipNet.Mask), Mask: ipNet.Mask})
+	}
+	return rv
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgpexport
+
+import (
+	"context"
+	"net"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+)
+
+type bgpExportServer struct {
+	announcer *announcer
+	nextHops  []net.IP
+	prefixes  func(conn *networkservice.Connection) []*net.IPNet
+}
+
+// NewServer returns a server chain element announcing the connection prefixes into the speaker on Request and
+// withdrawing them on Close
+func NewServer(speaker Speaker, opts ...Option) networkservice.NetworkServiceServer {
+	o := &options{
+		prefixes: dstPrefixes,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return &bgpExportServer{
+		announcer: newAnnouncer(speaker),
+		nextHops:  o.nextHops,
+		prefixes:  o.prefixes,
+	}
+}
+
+func (b *bgpExportServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := b.export(ctx, conn); err != nil {
+		closeCtx, cancelClose := postponeCtxFunc()
+		defer cancelClose()
+
+		if _, closeErr := b.Close(closeCtx, conn); closeErr != nil {
+			err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+		}
+
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (b *bgpExportServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	routes, _ := loadAndDelete(ctx, metadata.IsClient(b))
+	for _, route := range routes {
+		if err := b.announcer.withdraw(ctx, route); err != nil {
+			log.FromContext(ctx).WithField("prefix", route.Prefix).Errorf("failed to withdraw the route: %v", err)
+		}
+	}
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+// export announces the routes of conn, withdrawing the ones announced by the previous Request and not needed anymore
+func (b *bgpExportServer) export(ctx context.Context, conn *networkservice.Connection) error {
+	var routes []*Route
+	for _, prefix := range b.prefixes(conn) {
+		nh := nextHop(b.nextHops, prefix)
+		if nh == nil {
+			log.FromContext(ctx).WithField("prefix", prefix).Warn("no next hop of the prefix family, the route is not announced")
+			continue
+		}
+		routes = append(routes, &Route{Prefix: prefix, NextHop: nh})
+	}
+
+	prev, _ := load(ctx, metadata.IsClient(b))
+	var current []*Route
+	for _, route := range prev {
+		if contains(routes, route) {
+			current = append(current, route)
+			continue
+		}
+		if err := b.announcer.withdraw(ctx, route); err != nil {
+			log.FromContext(ctx).WithField("prefix", route.Prefix).Errorf("failed to withdraw the route: %v", err)
+		}
+	}
+	defer func() { store(ctx, metadata.IsClient(b), current) }()
+
+	for _, route := range routes {
+		if contains(current, route) {
+			continue
+		}
+		if err := b.announcer.announce(ctx, route); err != nil {
+			return errors.Wrapf(err, "failed to announce the route %s", route.Prefix)
+		}
+		log.FromContext(ctx).
+			WithField("prefix", route.Prefix).
+			WithField("nextHop", route.NextHop).
+			Debug("route announced")
+		current = append(current, route)
+	}
+	return nil
+}
+
+func contains(routes []*Route, route *Route) bool {
+	for _, r := range routes {
+		if r.key() == route.key() {
+			return true
+		}
+	}
+	return false
+}
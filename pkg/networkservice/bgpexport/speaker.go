@@ -0,0 +1,85 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgpexport
+
+import (
+	"context"
+	"net"
+	"sync"
+)
+
+// Route - the route announced into the BGP speaker
+type Route struct {
+	Prefix  *net.IPNet
+	NextHop net.IP
+}
+
+func (r *Route) key() string {
+	return r.Prefix.String() + "-" + r.NextHop.String()
+}
+
+// Speaker - the BGP speaker the routes are announced to
+type Speaker interface {
+	// Announce announces the route, the route may be announced again on the refresh of the forwarder
+	Announce(ctx context.Context, route *Route) error
+	// Withdraw withdraws the previously announced route
+	Withdraw(ctx context.Context, route *Route) error
+}
+
+// announcer refcounts the routes shared by the connections (e.g. the same NSE prefix reached by many clients), so
+// the route is announced by the first connection and withdrawn by the last one
+type announcer struct {
+	speaker Speaker
+	refs    map[string]int
+	mutex   sync.Mutex
+}
+
+func newAnnouncer(speaker Speaker) *announcer {
+	return &announcer{
+		speaker: speaker,
+		refs:    make(map[string]int),
+	}
+}
+
+func (a *announcer) announce(ctx context.Context, route *Route) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.refs[route.key()] == 0 {
+		if err := a.speaker.Announce(ctx, route); err != nil {
+			return err
+		}
+	}
+	a.refs[route.key()]++
+	return nil
+}
+
+func (a *announcer) withdraw(ctx context.Context, route *Route) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	refs, ok := a.refs[route.key()]
+	if !ok {
+		return nil
+	}
+	if refs > 1 {
+		a.refs[route.key()]--
+		return nil
+	}
+	delete(a.refs, route.key())
+	return a.speaker.Withdraw(ctx, route)
+}
@@ -20,6 +20,7 @@
 package forwarder
 
 import (
+	"net"
 	"net/url"
 	"time"
 
@@ -28,8 +29,45 @@ import (
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/cleanup"
 
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/admission"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/appns"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/bgpexport"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/coalesce"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/connectioncontext/dnscontext"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/connectioncontext/mtu"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/conntrack"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/description"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/gso"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/hooks"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/inventory"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/ipsecstats"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/linuxcp"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/lldp"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/ipsec"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/kernel/ipv6defaultroute"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/kernel/kernelroutes"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/kernel/kerneltap"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/vlan"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/vxlan"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/wireguard"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/nsim"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/promisc"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/punt"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/quiesce"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/quota"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/rawvpp"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/reassembly"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/stats"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/teardown"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/underlayaddr"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/up"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/vrrp"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/xconnect/l2bridgedomain"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/xconnect/l2xconnect"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/allocator"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/hotreload"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/memifdir"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/vppbudget"
 )
 
 type forwarderOptions struct {
@@ -43,6 +81,78 @@ type forwarderOptions struct {
 	statsOpts                        []stats.Option
 	cleanupOpts                      []cleanup.Option
 	vxlanOpts                        []vxlan.Option
+	vlanOpts                         []vlan.Option
+	wireguardOpts                    []wireguard.Option
+	ipsecOpts                        []ipsec.Option
+	upOpts                           []up.Option
+	vppBudgetOpts                    []vppbudget.Option
+	latencyMetrics                   bool
+	dryRun                           bool
+	ipsecStats                       bool
+	ipsecStatsOpts                   []ipsecstats.Option
+	l2BridgeDomainOpts               []l2bridgedomain.Option
+	payloadAdapter                   bool
+	vrrp                             bool
+	vrrpOpts                         []vrrp.Option
+	appns                            bool
+	appnsOpts                        []appns.Option
+	xdp                              bool
+	closeVerify                      bool
+	coalesce                         bool
+	coalesceOpts                     []coalesce.Option
+	tagPrefix                        string
+	externalInterfaces               bool
+	promisc                          bool
+	promiscOpts                      []promisc.Option
+	dnsContext                       bool
+	dnsContextOpts                   []dnscontext.Option
+	ipv6SrcAddr                      bool
+	mtuAdvertisement                 bool
+	jumboFrames                      bool
+	sharedBackend                    allocator.Backend
+	sharedOwner                      string
+	mechanismPriorities              *hotreload.Value[[]string]
+	mtuOverride                      *hotreload.Value[uint32]
+	mtuNetworkServiceLabels          mtu.NetworkServiceLabelsFunc
+	memifSocketDirs                  *memifdir.Dirs
+	l2XconnectOpts                   []l2xconnect.Option
+	teardownOpts                     []teardown.Option
+	ipv6TunnelIP                     net.IP
+	underlayPool                     *underlayaddr.Pool
+	kernelTapOpts                    []kerneltap.Option
+	gso                              bool
+	gsoOpts                          []gso.Option
+	conntrack                        bool
+	conntrackOpts                    []conntrack.Option
+	reassembly                       bool
+	reassemblyOpts                   []reassembly.Option
+	punt                             bool
+	puntOpts                         []punt.Option
+	lldp                             bool
+	lldpOpts                         []lldp.Option
+	nsim                             bool
+	nsimOpts                         []nsim.Option
+	rawvppServerOpts                 []rawvpp.Option
+	rawvppClientOpts                 []rawvpp.Option
+	kernelRoutes                     bool
+	kernelRoutesOpts                 []kernelroutes.Option
+	ipv6DefaultRoute                 bool
+	ipv6DefaultRouteOpts             []ipv6defaultroute.Option
+	description                      bool
+	descriptionOpts                  []description.Option
+	admission                        bool
+	admissionOpts                    []admission.Option
+	quota                            bool
+	quotaOpts                        []quota.Option
+	quiesce                          bool
+	quiesceOpts                      []quiesce.Option
+	inventory                        *inventory.Inventory
+	handoffFile                      string
+	hooks                            *hooks.Registry
+	linuxCP                          bool
+	linuxCPOpts                      []linuxcp.Option
+	bgpSpeaker                       bgpexport.Speaker
+	bgpExportOpts                    []bgpexport.Option
 	dialOpts                         []grpc.DialOption
 	clientAdditionalFunctionality    []networkservice.NetworkServiceClient
 }
@@ -126,6 +236,42 @@ func WithVxlanOptions(opts ...vxlan.Option) Option {
 	}
 }
 
+// WithVlanOptions sets vlan options, e.g. the uplinks the via label is resolved against
+func WithVlanOptions(opts ...vlan.Option) Option {
+	return func(o *forwarderOptions) {
+		o.vlanOpts = opts
+	}
+}
+
+// WithWireguardOptions sets wireguard options
+func WithWireguardOptions(opts ...wireguard.Option) Option {
+	return func(o *forwarderOptions) {
+		o.wireguardOpts = opts
+	}
+}
+
+// WithIPSecOptions sets ipsec options
+func WithIPSecOptions(opts ...ipsec.Option) Option {
+	return func(o *forwarderOptions) {
+		o.ipsecOpts = opts
+	}
+}
+
+// WithL2BridgeDomainOptions sets the l2 bridge domain options
+func WithL2BridgeDomainOptions(opts ...l2bridgedomain.Option) Option {
+	return func(o *forwarderOptions) {
+		o.l2BridgeDomainOpts = opts
+	}
+}
+
+// WithIPSecStats enables exporting the counters of the IPSec SAs of the connections in the path segment metrics
+func WithIPSecStats(opts ...ipsecstats.Option) Option {
+	return func(o *forwarderOptions) {
+		o.ipsecStats = true
+		o.ipsecStatsOpts = opts
+	}
+}
+
 // WithDialOptions sets dial options
 func WithDialOptions(opts ...grpc.DialOption) Option {
 	return func(o *forwarderOptions) {
@@ -139,3 +285,374 @@ func WithClientAdditionalFunctionality(additionalFunctionality ...networkservice
 		o.clientAdditionalFunctionality = additionalFunctionality
 	}
 }
+
+// WithIPv6TunnelIP sets an additional IPv6 tunnel IP used by remote mechanisms when the remote side uses IPv6
+func WithIPv6TunnelIP(ipv6TunnelIP net.IP) Option {
+	return func(o *forwarderOptions) {
+		o.ipv6TunnelIP = ipv6TunnelIP
+	}
+}
+
+// WithUnderlayAddressPool sets the pool of additional uplink addresses used as per tenant tunnel source addresses
+func WithUnderlayAddressPool(pool *underlayaddr.Pool) Option {
+	return func(o *forwarderOptions) {
+		o.underlayPool = pool
+	}
+}
+
+// WithKernelTapOptions sets the options of the kernel taps, e.g. kerneltap.WithPersistence()
+func WithKernelTapOptions(opts ...kerneltap.Option) Option {
+	return func(o *forwarderOptions) {
+		o.kernelTapOpts = opts
+	}
+}
+
+// WithGSO enables GSO on the kernel taps and the gso segmentation on the interfaces connected to them
+func WithGSO(opts ...gso.Option) Option {
+	return func(o *forwarderOptions) {
+		o.gso = true
+		o.gsoOpts = opts
+	}
+}
+
+// WithConnTrack enables the vpp connection tracking tuning (session table size, hash table sizes, session timeouts)
+func WithConnTrack(opts ...conntrack.Option) Option {
+	return func(o *forwarderOptions) {
+		o.conntrack = true
+		o.conntrackOpts = opts
+	}
+}
+
+// WithReassembly enables the IP reassembly and sets the fragment MTU on the tunnel interfaces, the labels of the
+// connection override the defaults
+func WithReassembly(opts ...reassembly.Option) Option {
+	return func(o *forwarderOptions) {
+		o.reassembly = true
+		o.reassemblyOpts = opts
+	}
+}
+
+// WithPunt enables the punting of the selected control plane protocols out of vpp to the host or a client socket
+func WithPunt(opts ...punt.Option) Option {
+	return func(o *forwarderOptions) {
+		o.punt = true
+		o.puntOpts = opts
+	}
+}
+
+// WithLLDP enables the LLDP announcements carrying the connection id and the NetworkService on the kernel interfaces
+func WithLLDP(opts ...lldp.Option) Option {
+	return func(o *forwarderOptions) {
+		o.lldp = true
+		o.lldpOpts = opts
+	}
+}
+
+// WithNSim enables the delay, loss and reordering injection driven by the connection labels. For testing only.
+func WithNSim(opts ...nsim.Option) Option {
+	return func(o *forwarderOptions) {
+		o.nsim = true
+		o.nsimOpts = opts
+	}
+}
+
+// WithRawVPPServer executes the templated binapi calls on the incoming connection interfaces
+func WithRawVPPServer(opts ...rawvpp.Option) Option {
+	return func(o *forwarderOptions) {
+		o.rawvppServerOpts = opts
+	}
+}
+
+// WithRawVPPClient executes the templated binapi calls on the outgoing connection interfaces
+func WithRawVPPClient(opts ...rawvpp.Option) Option {
+	return func(o *forwarderOptions) {
+		o.rawvppClientOpts = opts
+	}
+}
+
+// WithKernelRoutes enables the programming of the routes into a non-main routing table and the ip rules looking it up
+// on the kernel interfaces
+func WithKernelRoutes(opts ...kernelroutes.Option) Option {
+	return func(o *forwarderOptions) {
+		o.kernelRoutes = true
+		o.kernelRoutesOpts = opts
+	}
+}
+
+// WithIPv6DefaultRoute enables the programming of the IPv6 default route with the metric on the kernel interfaces
+func WithIPv6DefaultRoute(opts ...ipv6defaultroute.Option) Option {
+	return func(o *forwarderOptions) {
+		o.ipv6DefaultRoute = true
+		o.ipv6DefaultRouteOpts = opts
+	}
+}
+
+// WithDescription enables naming the vpp interfaces and setting the alias of the kernel interfaces to a human-readable
+// description of the connection
+func WithDescription(opts ...description.Option) Option {
+	return func(o *forwarderOptions) {
+		o.description = true
+		o.descriptionOpts = opts
+	}
+}
+
+// WithAdmission enables rejecting the new Requests when the forwarder capacity limits set by the options are reached
+func WithAdmission(opts ...admission.Option) Option {
+	return func(o *forwarderOptions) {
+		o.admission = true
+		o.admissionOpts = opts
+	}
+}
+
+// WithQuotas enables enforcing the per NetworkService quotas set by the options
+func WithQuotas(opts ...quota.Option) Option {
+	return func(o *forwarderOptions) {
+		o.quota = true
+		o.quotaOpts = opts
+	}
+}
+
+// WithQuiesce enables setting the interfaces admin-down and draining them on Close before they are deleted
+func WithQuiesce(opts ...quiesce.Option) Option {
+	return func(o *forwarderOptions) {
+		o.quiesce = true
+		o.quiesceOpts = opts
+	}
+}
+
+// WithInventory enables keeping the inventory of the connections and their vpp objects, the inventory gRPC service is
+// registered with the forwarder
+func WithInventory(inv *inventory.Inventory) Option {
+	return func(o *forwarderOptions) {
+		o.inventory = inv
+	}
+}
+
+// WithHandoff enables handing the vpp state of the connections over to the next forwarder through the file, so the
+// connections survive the forwarder restarts as long as vpp stays up
+func WithHandoff(filename string) Option {
+	return func(o *forwarderOptions) {
+		o.handoffFile = filename
+	}
+}
+
+// WithHooks enables delivering the dataplane events of the connections to the hooks of the registry
+func WithHooks(registry *hooks.Registry) Option {
+	return func(o *forwarderOptions) {
+		o.hooks = registry
+	}
+}
+
+// WithLinuxCP enables mirroring the vpp interfaces of the connections into the host with the linux-cp plugin
+func WithLinuxCP(opts ...linuxcp.Option) Option {
+	return func(o *forwarderOptions) {
+		o.linuxCP = true
+		o.linuxCPOpts = opts
+	}
+}
+
+// WithBGPExport enables announcing the connection prefixes into the BGP speaker, the next hops default to the tunnel IP
+// and the IPv6 tunnel IP for the prefixes of their family
+func WithBGPExport(speaker bgpexport.Speaker, opts ...bgpexport.Option) Option {
+	return func(o *forwarderOptions) {
+		o.bgpSpeaker = speaker
+		o.bgpExportOpts = opts
+	}
+}
+
+// WithPayloadAdapter enables converting the connection payload between the ETHERNET and the IP payloads on the remote
+// hop, requested with the payloadadapter.RemotePayloadLabel label. Both forwarders of the connection need it enabled.
+func WithPayloadAdapter() Option {
+	return func(o *forwarderOptions) {
+		o.payloadAdapter = true
+	}
+}
+
+// WithUpOptions sets the options of the up elements, e.g. the init functions doing the one time vpp setup
+func WithUpOptions(opts ...up.Option) Option {
+	return func(o *forwarderOptions) {
+		o.upOpts = opts
+	}
+}
+
+// WithVPPBudgetOptions sets the options of the vpp connection bounding the vpp calls by the Request deadline
+func WithVPPBudgetOptions(opts ...vppbudget.Option) Option {
+	return func(o *forwarderOptions) {
+		o.vppBudgetOpts = opts
+	}
+}
+
+// WithLatencyMetrics enables measuring the time every element of the forwarder spends in Request and Close, exported
+// as the element_duration_seconds histogram
+func WithLatencyMetrics() Option {
+	return func(o *forwarderOptions) {
+		o.latencyMetrics = true
+	}
+}
+
+// WithDryRun enables the dry-run mode: the elements log the binapi messages instead of sending them to vpp, the vpp
+// connection passed to NewServer is not used
+func WithDryRun() Option {
+	return func(o *forwarderOptions) {
+		o.dryRun = true
+	}
+}
+
+// WithVRRP enables the VRRP virtual routers on the bridge domain BVIs, so two forwarders provide the redundant gateway
+// for the L2 NetworkService
+func WithVRRP(opts ...vrrp.Option) Option {
+	return func(o *forwarderOptions) {
+		o.vrrp = true
+		o.vrrpOpts = opts
+	}
+}
+
+// WithAppNamespace enables adding the vpp host stack application namespaces bound to the interfaces of the local
+// connections, so the VCL applications terminate TCP/UDP directly in vpp
+func WithAppNamespace(opts ...appns.Option) Option {
+	return func(o *forwarderOptions) {
+		o.appns = true
+		o.appnsOpts = opts
+	}
+}
+
+// WithXDP enables the xdp local mechanism: the veth of the clients preferring it to the kernel mechanism is attached
+// to vpp over AF_XDP
+func WithXDP() Option {
+	return func(o *forwarderOptions) {
+		o.xdp = true
+	}
+}
+
+// WithCloseVerification enables verifying after Close that the vpp interfaces of the connection are deleted and force
+// deleting the leftovers
+func WithCloseVerification() Option {
+	return func(o *forwarderOptions) {
+		o.closeVerify = true
+	}
+}
+
+// WithCoalesce enables answering the bursts of the identical refresh Requests of a connection with the result of the
+// first one instead of programming vpp for each of them
+func WithCoalesce(opts ...coalesce.Option) Option {
+	return func(o *forwarderOptions) {
+		o.coalesce = true
+		o.coalesceOpts = opts
+	}
+}
+
+// WithTagPrefix sets the prefix of the tags of the vpp interfaces, the tag is the prefix followed by the connection ID.
+// The prefix specific to the forwarder instance tells its objects from the ones of the other consumers of the same vpp.
+func WithTagPrefix(prefix string) Option {
+	return func(o *forwarderOptions) {
+		o.tagPrefix = prefix
+	}
+}
+
+// WithExternalInterfaces enables the external local mechanism: the clients request the vpp interfaces pre-created by
+// the operator or the CNI by the name or the tag, the forwarder adopts them without creating or deleting them
+func WithExternalInterfaces() Option {
+	return func(o *forwarderOptions) {
+		o.externalInterfaces = true
+	}
+}
+
+// WithPromisc enables setting the promiscuous mode and the MAC allow-list on the client facing interfaces, the labels
+// of the connections override the options
+func WithPromisc(opts ...promisc.Option) Option {
+	return func(o *forwarderOptions) {
+		o.promisc = true
+		o.promiscOpts = opts
+	}
+}
+
+// WithDNSContext enables writing the DNS configs of the connections to the resolv.conf of the kernel mechanism clients,
+// the original is restored on Close. The forwarder needs the host PID namespace to reach the client file systems.
+func WithDNSContext(opts ...dnscontext.Option) Option {
+	return func(o *forwarderOptions) {
+		o.dnsContext = true
+		o.dnsContextOpts = opts
+	}
+}
+
+// WithIPv6SrcAddr enables setting the NSM IPv6 address as the src of the IPv6 routes via the kernel interfaces, so the
+// clients having many IPv6 addresses use the NSM source for the NSM destinations
+func WithIPv6SrcAddr() Option {
+	return func(o *forwarderOptions) {
+		o.ipv6SrcAddr = true
+	}
+}
+
+// WithMTUAdvertisement enables advertising the effective MTU of the data path back to the clients in the connection
+// context, so the endpoint applications size the payloads correctly without probing
+func WithMTUAdvertisement() Option {
+	return func(o *forwarderOptions) {
+		o.mtuAdvertisement = true
+	}
+}
+
+// WithMemifSocketDirs makes the forwarder serve the memif connections over the sockets in the per connection
+// directories owned by the clients instead of the abstract sockets
+func WithMemifSocketDirs(dirs *memifdir.Dirs) Option {
+	return func(o *forwarderOptions) {
+		o.memifSocketDirs = dirs
+	}
+}
+
+// WithL2XconnectOptions sets the options of the l2 cross connect of the Ethernet payload connections, e.g. the hub mode
+func WithL2XconnectOptions(opts ...l2xconnect.Option) Option {
+	return func(o *forwarderOptions) {
+		o.l2XconnectOpts = opts
+	}
+}
+
+// WithTeardownOptions sets the options of the teardown on Close, e.g. the Close and the per step deadlines independent
+// of the incoming ones
+func WithTeardownOptions(opts ...teardown.Option) Option {
+	return func(o *forwarderOptions) {
+		o.teardownOpts = opts
+	}
+}
+
+// WithJumboFrames makes the forwarder raise the MTU of the uplinks for the tunnels to carry the jumbo frames end to end,
+// as far as the uplink links allow
+func WithJumboFrames() Option {
+	return func(o *forwarderOptions) {
+		o.jumboFrames = true
+	}
+}
+
+// WithSharedAllocations makes the forwarder reserve the VNIs of the vxlan connections for the owner in backend shared
+// with the other forwarders of the node, releasing the reservations left by the previous run of the owner on startup.
+// The owner should be stable across the restarts, e.g. the pod name. The wireguard ports are reserved in the same
+// backend with wireguard.WithSharedPortRange passed to WithWireguardOptions with the same owner.
+func WithSharedAllocations(backend allocator.Backend, owner string) Option {
+	return func(o *forwarderOptions) {
+		o.sharedBackend = backend
+		o.sharedOwner = owner
+	}
+}
+
+// WithMechanismPriorityValue sets the mechanism priority list updated at runtime, overriding WithMechanismPriority.
+// The new list applies to the connections selecting the mechanism after the update.
+func WithMechanismPriorityValue(priorities *hotreload.Value[[]string]) Option {
+	return func(o *forwarderOptions) {
+		o.mechanismPriorities = priorities
+	}
+}
+
+// WithMTUOverride sets the MTU overriding the computed MTU of the connections, updated at runtime (0 - not
+// overridden). The new MTU is set on the vpp interfaces of the existing connections, see mtu.WithOverride.
+func WithMTUOverride(mtu *hotreload.Value[uint32]) Option {
+	return func(o *forwarderOptions) {
+		o.mtuOverride = mtu
+	}
+}
+
+// WithMTUNetworkServiceLabels sets the source of the network service labels, the mtu.MTULabel of the network service
+// overrides the computed MTU of its connections without their own label, see mtu.WithNetworkServiceLabels.
+func WithMTUNetworkServiceLabels(nsLabels mtu.NetworkServiceLabelsFunc) Option {
+	return func(o *forwarderOptions) {
+		o.mtuNetworkServiceLabels = nsLabels
+	}
+}
@@ -30,6 +30,7 @@ import (
 
 	"git.fd.io/govpp.git/api"
 	"github.com/google/uuid"
+	"google.golang.org/grpc"
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 	ipsecapi "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/ipsec"
@@ -41,12 +42,13 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/connect"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/discover"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/filtermechanisms"
-	"github.com/networkservicemesh/sdk/pkg/networkservice/common/mechanismpriority"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/mechanisms"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/mechanisms/recvfd"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/mechanisms/sendfd"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/mechanismtranslation"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/null"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/roundrobin"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
 	authmonitor "github.com/networkservicemesh/sdk/pkg/tools/monitorconnection/authorize"
 	"github.com/networkservicemesh/sdk/pkg/tools/token"
 
@@ -57,20 +59,61 @@ import (
 	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/networkservice/connectioncontextkernel"
 	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/networkservice/ethernetcontext"
 
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/admission"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/appns"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/bgpexport"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/closeverify"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/coalesce"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/connectioncontext/dnscontext"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/connectioncontext/mtu"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/conntrack"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/description"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/featurearc"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/gso"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/handoff"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/hooks"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/inventory"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/ipsecstats"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/latency"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/linuxcp"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/lldp"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanismpriority"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/external"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/ipsec"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/kernel"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/kernel/ipv6defaultroute"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/kernel/ipv6srcaddr"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/kernel/kernelroutes"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/kernel/kerneltap"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/memif"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/vlan"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/vxlan"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/wireguard"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/xdp"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/nsim"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/nsmonitor"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/pinhole"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/promisc"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/punt"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/quiesce"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/quota"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/rawvpp"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/reassembly"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/stats"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/tag"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/teardown"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/underlayaddr"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/up"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/vrrp"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/xconnect"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/xconnect/l2bridgedomain"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/xconnect/payloadadapter"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/binapicompat"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/dryrun"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/hotreload"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/vppbudget"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/vppcaps"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/vpperrors"
 )
 
 // Connection aggregates the api.Connection and api.ChannelProvider interfaces
@@ -81,6 +124,15 @@ type Connection interface {
 
 type xconnectNSServer struct {
 	endpoint.Endpoint
+	register func(s *grpc.Server)
+}
+
+// Register - registers the endpoint and the optional forwarder gRPC services with s
+func (x *xconnectNSServer) Register(s *grpc.Server) {
+	x.Endpoint.Register(s)
+	if x.register != nil {
+		x.register(s)
+	}
 }
 
 // NewServer - returns an implementation of the xconnectns network service
@@ -96,6 +148,13 @@ func NewServer(ctx context.Context, tokenGenerator token.GeneratorFunc, vppConn
 	for _, opt := range options {
 		opt(opts)
 	}
+	// The binapi messages are only logged in the dry-run mode
+	if opts.dryRun {
+		vppConn = dryrun.NewConnection()
+	}
+	// The elements return the typed errors of the vpp calls bounded by the Request deadline
+	vppConn = vpperrors.NewConnection(vppbudget.NewConnection(vppConn, opts.vppBudgetOpts...))
+
 	nseClient := registryclient.NewNetworkServiceEndpointRegistryClient(ctx,
 		registryclient.WithClientURL(opts.clientURL),
 		registryclient.WithNSEAdditionalFunctionality(
@@ -108,30 +167,335 @@ func NewServer(ctx context.Context, tokenGenerator token.GeneratorFunc, vppConn
 		registryclient.WithClientURL(opts.clientURL),
 		registryclient.WithDialOptions(opts.dialOpts...))
 
+	vxlanOpts := append([]vxlan.Option{vxlan.WithIPv6TunnelIP(opts.ipv6TunnelIP)}, opts.vxlanOpts...)
+	if opts.sharedBackend != nil {
+		// The reservations left by the previous run are not used by anyone anymore
+		if releaseErr := opts.sharedBackend.ReleaseAll(ctx, opts.sharedOwner); releaseErr != nil {
+			log.FromContext(ctx).Warnf("unable to release the reservations of %s: %v", opts.sharedOwner, releaseErr)
+		}
+		vxlanOpts = append(vxlanOpts, vxlan.WithSharedVNIs(opts.sharedBackend, opts.sharedOwner))
+	}
+	wireguardOpts := append([]wireguard.Option{wireguard.WithIPv6TunnelIP(opts.ipv6TunnelIP)}, opts.wireguardOpts...)
+	ipsecOpts := append([]ipsec.Option{ipsec.WithIPv6TunnelIP(opts.ipv6TunnelIP)}, opts.ipsecOpts...)
+	kernelTapOpts := append([]kerneltap.Option{kerneltap.WithTagPrefix(opts.tagPrefix)}, opts.kernelTapOpts...)
+
+	// The stats socket connection is shared between the stats client and server and the ipsec stats elements
+	statsConn := stats.NewConn(ctx, opts.statsOpts...)
+	statsOpts := append([]stats.Option{stats.WithConn(statsConn)}, opts.statsOpts...)
+
+	if !opts.dryRun {
+		if checkErr := binapicompat.Check(ctx, vppConn, binapicompat.Required...); checkErr != nil {
+			log.FromContext(ctx).Errorf("the forwarder may not work with this vpp: %v", checkErr)
+		}
+	}
+
+	mechanismPriorities := opts.mechanismPriorities
+	if mechanismPriorities == nil {
+		mechanismPriorities = hotreload.NewValue(opts.mechanismPrioriyList)
+	}
+	var mtuOpts []mtu.Option
+	if opts.mtuOverride != nil {
+		mtuOpts = append(mtuOpts, mtu.WithOverride(ctx, opts.mtuOverride))
+	}
+	if opts.mtuNetworkServiceLabels != nil {
+		mtuOpts = append(mtuOpts, mtu.WithNetworkServiceLabels(opts.mtuNetworkServiceLabels))
+	}
+
+	if opts.jumboFrames && !opts.dryRun {
+		if raiseErr := mtu.RaiseUplinkMTU(ctx, vppConn, tunnelIP, opts.ipv6TunnelIP); raiseErr != nil {
+			log.FromContext(ctx).Warnf("unable to raise the uplink MTU for the jumbo frames: %v", raiseErr)
+		}
+	}
+
+	// The elements of the plugins missing in vpp are not used
+	var caps *vppcaps.Caps
+	if !opts.dryRun {
+		var probeErr error
+		if caps, probeErr = vppcaps.Probe(ctx, vppConn); probeErr != nil {
+			log.FromContext(ctx).Warnf("unable to probe the vpp capabilities: %v", probeErr)
+		}
+	}
+	missing := func(plugin vppcaps.Plugin) bool {
+		if caps == nil || caps.Has(plugin) {
+			return false
+		}
+		log.FromContext(ctx).Warnf("%s plugin is not loaded in vpp %s, its elements are not used", plugin, caps.Version)
+		return true
+	}
+
+	memifOpts := []memif.Option{memif.WithDirectMemif(), memif.WithChangeNetNS()}
+	if opts.memifSocketDirs != nil {
+		memifOpts = append(memifOpts, memif.WithSocketDirs(opts.memifSocketDirs))
+	}
+
+	serverMechanisms := map[string]networkservice.NetworkServiceServer{
+		memif.MECHANISM:     memif.NewServer(ctx, vppConn, memifOpts...),
+		kernel.MECHANISM:    kernel.NewServer(vppConn, kernelTapOpts...),
+		vxlan.MECHANISM:     vxlan.NewServer(vppConn, tunnelIP, vxlanOpts...),
+		wireguard.MECHANISM: wireguard.NewServer(vppConn, tunnelIP, wireguardOpts...),
+		ipsecapi.MECHANISM:  ipsec.NewServer(vppConn, tunnelIP, ipsecOpts...),
+	}
+	wireguardClient := wireguard.NewClient(vppConn, tunnelIP, wireguardOpts...)
+	if missing(vppcaps.Wireguard) {
+		delete(serverMechanisms, wireguard.MECHANISM)
+		wireguardClient = null.NewClient()
+	}
+	if opts.externalInterfaces {
+		serverMechanisms[external.MECHANISM] = external.NewServer(vppConn)
+	}
+
+	gsoServer, gsoClient := null.NewServer(), null.NewClient()
+	if opts.gso {
+		gsoServer, gsoClient = gso.NewServer(vppConn, opts.gsoOpts...), gso.NewClient(opts.gsoOpts...)
+	}
+
+	conntrackServer := null.NewServer()
+	if opts.conntrack {
+		conntrackServer = conntrack.NewServer(vppConn, opts.conntrackOpts...)
+	}
+
+	puntServer := null.NewServer()
+	if opts.punt {
+		puntServer = punt.NewServer(vppConn, opts.puntOpts...)
+	}
+
+	lldpServer, lldpClient := null.NewServer(), null.NewClient()
+	if opts.lldp {
+		lldpServer, lldpClient = lldp.NewServer(vppConn, opts.lldpOpts...), lldp.NewClient(vppConn, opts.lldpOpts...)
+	}
+
+	nsimServer, nsimClient := null.NewServer(), null.NewClient()
+	if opts.nsim && !missing(vppcaps.NSim) {
+		nsimServer, nsimClient = nsim.NewServer(vppConn, opts.nsimOpts...), nsim.NewClient(vppConn, opts.nsimOpts...)
+	}
+
+	kernelRoutesServer, kernelRoutesClient := null.NewServer(), null.NewClient()
+	if opts.kernelRoutes {
+		kernelRoutesServer, kernelRoutesClient = kernelroutes.NewServer(opts.kernelRoutesOpts...), kernelroutes.NewClient(opts.kernelRoutesOpts...)
+	}
+
+	ipv6SrcAddrServer, ipv6SrcAddrClient := null.NewServer(), null.NewClient()
+	if opts.ipv6SrcAddr {
+		ipv6SrcAddrServer, ipv6SrcAddrClient = ipv6srcaddr.NewServer(), ipv6srcaddr.NewClient()
+	}
+
+	ipv6DefaultRouteServer, ipv6DefaultRouteClient := null.NewServer(), null.NewClient()
+	if opts.ipv6DefaultRoute {
+		ipv6DefaultRouteServer, ipv6DefaultRouteClient = ipv6defaultroute.NewServer(opts.ipv6DefaultRouteOpts...), ipv6defaultroute.NewClient(opts.ipv6DefaultRouteOpts...)
+	}
+
+	admissionServer, admissionClient := null.NewServer(), null.NewClient()
+	if opts.admission {
+		admissionServer, admissionClient = admission.NewServer(opts.admissionOpts...), admission.NewClient()
+	}
+
+	quotaServer := null.NewServer()
+	if opts.quota {
+		quotaServer = quota.NewServer(ctx, opts.quotaOpts...)
+	}
+
+	descriptionServer, descriptionClient := null.NewServer(), null.NewClient()
+	if opts.description {
+		descriptionServer, descriptionClient = description.NewServer(vppConn, opts.descriptionOpts...), description.NewClient(vppConn, opts.descriptionOpts...)
+	}
+
+	// The server element quiesces the interfaces of both the server and the client sides
+	quiesceServer := null.NewServer()
+	if opts.quiesce {
+		quiesceServer = quiesce.NewServer(vppConn, opts.quiesceOpts...)
+	}
+
+	linuxCPServer, linuxCPClient := null.NewServer(), null.NewClient()
+	if opts.linuxCP && !missing(vppcaps.LinuxCP) {
+		linuxCPServer, linuxCPClient = linuxcp.NewServer(vppConn, opts.linuxCPOpts...), linuxcp.NewClient(vppConn, opts.linuxCPOpts...)
+	}
+
+	hooksServer, hooksClient := null.NewServer(), null.NewClient()
+	if opts.hooks != nil {
+		hooksServer, hooksClient = hooks.NewServer(opts.hooks), hooks.NewClient(opts.hooks)
+	}
+
+	// The routes are announced once the connection is established and withdrawn before it is closed
+	bgpExportServer := null.NewServer()
+	if opts.bgpSpeaker != nil {
+		bgpExportServer = bgpexport.NewServer(opts.bgpSpeaker,
+			append([]bgpexport.Option{bgpexport.WithNextHop(tunnelIP, opts.ipv6TunnelIP)}, opts.bgpExportOpts...)...)
+	}
+
+	rawvppServer, rawvppClient := null.NewServer(), null.NewClient()
+	if len(opts.rawvppServerOpts) > 0 {
+		rawvppServer = rawvpp.NewServer(vppConn, opts.rawvppServerOpts...)
+	}
+	if len(opts.rawvppClientOpts) > 0 {
+		rawvppClient = rawvpp.NewClient(vppConn, opts.rawvppClientOpts...)
+	}
+
+	reassemblyServer, reassemblyClient := null.NewServer(), null.NewClient()
+	if opts.reassembly {
+		reassemblyServer, reassemblyClient = reassembly.NewServer(vppConn, opts.reassemblyOpts...), reassembly.NewClient(vppConn, opts.reassemblyOpts...)
+	}
+
+	ipsecStatsServer, ipsecStatsClient := null.NewServer(), null.NewClient()
+	if opts.ipsecStats {
+		ipsecStatsOpts := append([]ipsecstats.Option{ipsecstats.WithStatsConn(statsConn)}, opts.ipsecStatsOpts...)
+		ipsecStatsServer, ipsecStatsClient = ipsecstats.NewServer(ctx, vppConn, ipsecStatsOpts...), ipsecstats.NewClient(ctx, vppConn, ipsecStatsOpts...)
+	}
+
+	payloadAdapterServer, payloadAdapterClient := null.NewServer(), null.NewClient()
+	if opts.payloadAdapter {
+		payloadAdapterServer, payloadAdapterClient = payloadadapter.NewServer(vppConn), payloadadapter.NewClient()
+	}
+
+	vrrpServer := null.NewServer()
+	if opts.vrrp {
+		vrrpServer = vrrp.NewServer(vppConn, opts.vrrpOpts...)
+	}
+
+	appnsServer, appnsClient := null.NewServer(), null.NewClient()
+	if opts.appns {
+		appnsServer, appnsClient = appns.NewServer(vppConn, opts.appnsOpts...), appns.NewClient(vppConn, opts.appnsOpts...)
+	}
+
+	xdpServer := null.NewServer()
+	if opts.xdp {
+		xdpServer = xdp.NewServer()
+	}
+
+	closeVerifyServer, closeVerifyClient := null.NewServer(), null.NewClient()
+	if opts.closeVerify {
+		closeVerifyServer, closeVerifyClient = closeverify.NewServer(vppConn, closeverify.WithTagPrefix(opts.tagPrefix)),
+			closeverify.NewClient(vppConn, closeverify.WithTagPrefix(opts.tagPrefix))
+	}
+
+	dnsContextServer := null.NewServer()
+	if opts.dnsContext {
+		dnsContextServer = dnscontext.NewServer(opts.dnsContextOpts...)
+	}
+
+	mtuAdvertiseServer := null.NewServer()
+	if opts.mtuAdvertisement {
+		mtuAdvertiseServer = mtu.NewAdvertiseServer()
+	}
+
+	promiscServer := null.NewServer()
+	if opts.promisc {
+		promiscServer = promisc.NewServer(vppConn, opts.promiscOpts...)
+	}
+
+	coalesceServer := null.NewServer()
+	if opts.coalesce {
+		coalesceServer = coalesce.NewServer(opts.coalesceOpts...)
+	}
+
 	rv := &xconnectNSServer{}
+	inventoryServer := null.NewServer()
+	if opts.inventory != nil {
+		inventoryServer = inventory.NewServer(opts.inventory)
+		rv.register = func(s *grpc.Server) {
+			inventory.Register(s, opts.inventory, vppConn)
+		}
+	}
+	handoffServer := null.NewServer()
+	if opts.handoffFile != "" {
+		handoffServer = handoff.NewServer(handoff.NewStore(ctx, opts.handoffFile, vppConn))
+	}
 	pinholeMutex := new(sync.Mutex)
+	clientAdditionalFunctionality := append([]networkservice.NetworkServiceClient{
+		cleanup.NewClient(ctx, opts.cleanupOpts...),
+		mechanismtranslation.NewClient(),
+		closeVerifyClient,
+		payloadAdapterClient,
+		hooksClient,
+		admissionClient,
+		ipv6SrcAddrClient,
+		ipv6DefaultRouteClient,
+		connectioncontextkernel.NewClient(),
+		kernelRoutesClient,
+		stats.NewClient(ctx, statsOpts...),
+		ipsecStatsClient,
+		up.NewClient(ctx, vppConn, opts.upOpts...),
+		reassemblyClient,
+		lldpClient,
+		nsimClient,
+		rawvppClient,
+		appnsClient,
+		mtu.NewClient(vppConn, mtuOpts...),
+		tag.NewClient(ctx, vppConn, tag.WithPrefix(opts.tagPrefix)),
+		descriptionClient,
+		linuxCPClient,
+		featurearc.NewClient(vppConn),
+		gsoClient,
+		underlayaddr.NewClient(vppConn, tunnelIP, opts.underlayPool, underlayaddr.WithIPv6TunnelIP(opts.ipv6TunnelIP)),
+		// mechanisms
+		memif.NewClient(ctx, vppConn,
+			memif.WithChangeNetNS(),
+		),
+		kernel.NewClient(vppConn, kernelTapOpts...),
+		vxlan.NewClient(vppConn, tunnelIP, vxlanOpts...),
+		wireguardClient,
+		ipsec.NewClient(vppConn, tunnelIP, ipsecOpts...),
+		vlan.NewClient(vppConn, opts.domain2Device, opts.vlanOpts...),
+		filtermechanisms.NewClient(),
+		mechanismpriority.NewClient(ctx, mechanismPriorities),
+		pinhole.NewClient(vppConn, pinhole.WithSharedMutex(pinholeMutex)),
+		recvfd.NewClient(),
+		nsmonitor.NewClient(ctx),
+		sendfd.NewClient(),
+	},
+		opts.clientAdditionalFunctionality...,
+	)
+	// The latency of every element is measured separately
+	if opts.latencyMetrics {
+		for i := range clientAdditionalFunctionality {
+			clientAdditionalFunctionality[i] = latency.NewClient(clientAdditionalFunctionality[i])
+		}
+	}
 	additionalFunctionality := []networkservice.NetworkServiceServer{
+		coalesceServer,
+		teardown.NewServer(opts.teardownOpts...),
+		closeVerifyServer,
+		handoffServer,
+		inventoryServer,
+		hooksServer,
 		recvfd.NewServer(),
 		sendfd.NewServer(),
+		admissionServer,
+		quotaServer,
+		quiesceServer,
+		bgpExportServer,
 		discover.NewServer(nsClient, nseClient),
 		roundrobin.NewServer(),
-		stats.NewServer(ctx, opts.statsOpts...),
-		up.NewServer(ctx, vppConn),
-		xconnect.NewServer(vppConn),
-		l2bridgedomain.NewServer(vppConn),
+		stats.NewServer(ctx, statsOpts...),
+		ipsecStatsServer,
+		conntrackServer,
+		puntServer,
+		up.NewServer(ctx, vppConn, opts.upOpts...),
+		reassemblyServer,
+		promiscServer,
+		lldpServer,
+		nsimServer,
+		rawvppServer,
+		appnsServer,
+		xconnect.NewServer(vppConn, opts.l2XconnectOpts...),
+		vrrpServer,
+		l2bridgedomain.NewServer(vppConn, opts.l2BridgeDomainOpts...),
+		payloadAdapterServer,
+		ipv6SrcAddrServer,
+		ipv6DefaultRouteServer,
+		xdpServer,
+		dnsContextServer,
 		connectioncontextkernel.NewServer(),
+		kernelRoutesServer,
 		ethernetcontext.NewVFServer(),
-		tag.NewServer(ctx, vppConn),
-		mtu.NewServer(vppConn),
-		mechanisms.NewServer(map[string]networkservice.NetworkServiceServer{
-			memif.MECHANISM: memif.NewServer(ctx, vppConn,
-				memif.WithDirectMemif(),
-				memif.WithChangeNetNS()),
-			kernel.MECHANISM:    kernel.NewServer(vppConn),
-			vxlan.MECHANISM:     vxlan.NewServer(vppConn, tunnelIP, opts.vxlanOpts...),
-			wireguard.MECHANISM: wireguard.NewServer(vppConn, tunnelIP),
-			ipsecapi.MECHANISM:  ipsec.NewServer(vppConn, tunnelIP),
-		}),
+		tag.NewServer(ctx, vppConn, tag.WithPrefix(opts.tagPrefix)),
+		descriptionServer,
+		linuxCPServer,
+		featurearc.NewServer(vppConn),
+		gsoServer,
+		mtu.NewServer(vppConn, mtuOpts...),
+		mtuAdvertiseServer,
+		underlayaddr.NewServer(vppConn, tunnelIP, opts.underlayPool, underlayaddr.WithIPv6TunnelIP(opts.ipv6TunnelIP)),
+		mechanisms.NewServer(serverMechanisms),
 		pinhole.NewServer(vppConn, pinhole.WithSharedMutex(pinholeMutex)),
 		connect.NewServer(
 			client.NewClient(ctx,
@@ -139,38 +503,17 @@ func NewServer(ctx context.Context, tokenGenerator token.GeneratorFunc, vppConn
 				client.WithName(opts.name),
 				client.WithDialOptions(opts.dialOpts...),
 				client.WithDialTimeout(opts.dialTimeout),
-				client.WithAdditionalFunctionality(
-					append([]networkservice.NetworkServiceClient{
-						cleanup.NewClient(ctx, opts.cleanupOpts...),
-						mechanismtranslation.NewClient(),
-						connectioncontextkernel.NewClient(),
-						stats.NewClient(ctx, opts.statsOpts...),
-						up.NewClient(ctx, vppConn),
-						mtu.NewClient(vppConn),
-						tag.NewClient(ctx, vppConn),
-						// mechanisms
-						memif.NewClient(ctx, vppConn,
-							memif.WithChangeNetNS(),
-						),
-						kernel.NewClient(vppConn),
-						vxlan.NewClient(vppConn, tunnelIP, opts.vxlanOpts...),
-						wireguard.NewClient(vppConn, tunnelIP),
-						ipsec.NewClient(vppConn, tunnelIP),
-						vlan.NewClient(vppConn, opts.domain2Device),
-						filtermechanisms.NewClient(),
-						mechanismpriority.NewClient(opts.mechanismPrioriyList...),
-						pinhole.NewClient(vppConn, pinhole.WithSharedMutex(pinholeMutex)),
-						recvfd.NewClient(),
-						nsmonitor.NewClient(ctx),
-						sendfd.NewClient(),
-					},
-						opts.clientAdditionalFunctionality...,
-					)...,
-				),
+				client.WithAdditionalFunctionality(clientAdditionalFunctionality...),
 			),
 		),
 	}
 
+	if opts.latencyMetrics {
+		for i := range additionalFunctionality {
+			additionalFunctionality[i] = latency.NewServer(additionalFunctionality[i])
+		}
+	}
+
 	rv.Endpoint = endpoint.NewServer(ctx, tokenGenerator,
 		endpoint.WithName(opts.name),
 		endpoint.WithAuthorizeServer(opts.authorizeServer),
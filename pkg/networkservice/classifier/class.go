@@ -0,0 +1,125 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package classifier
+
+import (
+	"encoding/binary"
+	"net"
+)
+
+const (
+	// vectorSize - size of the vpp classifier match vector
+	vectorSize = 16
+	// l2HeaderSize - size of the ethernet header preceding the ip header in the vpp classifier mask
+	l2HeaderSize = 14
+
+	ip4HeaderSize = 20
+	ip6HeaderSize = 40
+	l4PortsSize   = 4
+)
+
+// Class - traffic class routed in the separate FIB table.
+// Zero value fields match any traffic.
+type Class struct {
+	// DSCP - DSCP value of the traffic class
+	DSCP *uint8
+	// Protocol - IP protocol number (e.g. 6 - TCP, 17 - UDP)
+	Protocol uint8
+	// SrcIPNet - source addresses of the traffic class
+	SrcIPNet *net.IPNet
+	// DstIPNet - destination addresses of the traffic class
+	DstIPNet *net.IPNet
+	// SrcPort - source TCP/UDP port of the traffic class
+	SrcPort uint16
+	// DstPort - destination TCP/UDP port of the traffic class
+	DstPort uint16
+	// TableID - ID of the FIB table the traffic class is routed in
+	TableID uint32
+}
+
+// appliesTo returns true if the class matches the traffic of the ip family
+func (c *Class) appliesTo(isIPv6 bool) bool {
+	for _, ipNet := range []*net.IPNet{c.SrcIPNet, c.DstIPNet} {
+		if ipNet != nil && (ipNet.IP.To4() == nil) != isIPv6 {
+			return false
+		}
+	}
+	return true
+}
+
+// maskAndMatch returns the vpp classifier mask and match of the class.
+// Both start with the l2 header and are padded to the whole number of vectors.
+func (c *Class) maskAndMatch(isIPv6 bool) (mask, match []byte) {
+	size := l2HeaderSize + ip4HeaderSize
+	if isIPv6 {
+		size = l2HeaderSize + ip6HeaderSize
+	}
+	if c.SrcPort != 0 || c.DstPort != 0 {
+		size += l4PortsSize
+	}
+	size = (size + vectorSize - 1) / vectorSize * vectorSize
+	mask, match = make([]byte, size), make([]byte, size)
+
+	ipHeader, ipHeaderSize := l2HeaderSize, ip4HeaderSize
+	protocolOffset, srcOffset, dstOffset := 9, 12, 16
+	if isIPv6 {
+		ipHeaderSize = ip6HeaderSize
+		protocolOffset, srcOffset, dstOffset = 6, 8, 24
+	}
+
+	if c.DSCP != nil {
+		if isIPv6 {
+			// Traffic class occupies the bits 4-11 of the ipv6 header, DSCP is its upper 6 bits
+			mask[ipHeader], match[ipHeader] = 0x0f, *c.DSCP>>2&0x0f
+			mask[ipHeader+1], match[ipHeader+1] = 0xc0, *c.DSCP<<6
+		} else {
+			mask[ipHeader+1], match[ipHeader+1] = 0xfc, *c.DSCP<<2
+		}
+	}
+	if c.Protocol != 0 {
+		mask[ipHeader+protocolOffset], match[ipHeader+protocolOffset] = 0xff, c.Protocol
+	}
+	setIPNet(mask[ipHeader+srcOffset:], match[ipHeader+srcOffset:], c.SrcIPNet, isIPv6)
+	setIPNet(mask[ipHeader+dstOffset:], match[ipHeader+dstOffset:], c.DstIPNet, isIPv6)
+
+	l4Header := ipHeader + ipHeaderSize
+	if c.SrcPort != 0 {
+		binary.BigEndian.PutUint16(mask[l4Header:], 0xffff)
+		binary.BigEndian.PutUint16(match[l4Header:], c.SrcPort)
+	}
+	if c.DstPort != 0 {
+		binary.BigEndian.PutUint16(mask[l4Header+2:], 0xffff)
+		binary.BigEndian.PutUint16(match[l4Header+2:], c.DstPort)
+	}
+	return mask, match
+}
+
+func setIPNet(mask, match []byte, ipNet *net.IPNet, isIPv6 bool) {
+	if ipNet == nil {
+		return
+	}
+	ip, ipMask := ipNet.IP.To4(), ipNet.Mask
+	if isIPv6 {
+		ip = ipNet.IP.To16()
+	}
+	if len(ipMask) != len(ip) {
+		ipMask = ipMask[len(ipMask)-len(ip):]
+	}
+	for i := range ip {
+		mask[i], match[i] = ipMask[i], ip[i]&ipMask[i]
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package classifier
+
+import (
+	"context"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/edwarnicke/govpp/binapi/classify"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+)
+
+const (
+	// noTable - ~0 used by the vpp classifier api for 'no table'
+	noTable = ^uint32(0)
+	// nbuckets - number of buckets of the classifier table holding a single session
+	nbuckets = 2
+	// memorySize - memory size of the classifier table holding a single session
+	memorySize = 1 << 16
+)
+
+func create(ctx context.Context, vppConn api.Connection, classes []*Class, isClient bool) error {
+	swIfIndex, ok := ifindex.Load(ctx, isClient)
+	if !ok {
+		return nil
+	}
+	if prev, ok := load(ctx, isClient); ok {
+		if prev.swIfIndex == swIfIndex {
+			return nil
+		}
+		// The interface has been recreated on heal
+		if err := del(ctx, vppConn, isClient); err != nil {
+			return err
+		}
+	}
+
+	t := &tables{swIfIndex: swIfIndex}
+	var err error
+	if t.ip4, err = createChain(ctx, vppConn, classes, false); err != nil {
+		return err
+	}
+	if t.ip6, err = createChain(ctx, vppConn, classes, true); err == nil {
+		err = inputACLSetInterface(ctx, vppConn, t, true)
+	}
+	if err != nil {
+		for _, tableIndex := range append(t.ip4, t.ip6...) {
+			_ = delTable(ctx, vppConn, tableIndex)
+		}
+		return err
+	}
+	store(ctx, isClient, t)
+
+	return nil
+}
+
+func del(ctx context.Context, vppConn api.Connection, isClient bool) error {
+	t, ok := loadAndDelete(ctx, isClient)
+	if !ok {
+		return nil
+	}
+	if err := inputACLSetInterface(ctx, vppConn, t, false); err != nil {
+		return err
+	}
+	for _, tableIndex := range append(t.ip4, t.ip6...) {
+		if err := delTable(ctx, vppConn, tableIndex); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createChain creates the chain of classifier tables (one per class, as the classes use different masks) classifying
+// the traffic of the ip family. The head of the chain is the first element of the result.
+func createChain(ctx context.Context, vppConn api.Connection, classes []*Class, isIPv6 bool) ([]uint32, error) {
+	var chain []uint32
+	nextTableIndex := noTable
+	for i := len(classes) - 1; i >= 0; i-- {
+		if !classes[i].appliesTo(isIPv6) {
+			continue
+		}
+		tableIndex, err := createTable(ctx, vppConn, classes[i], isIPv6, nextTableIndex)
+		if err != nil {
+			for _, index := range chain {
+				_ = delTable(ctx, vppConn, index)
+			}
+			return nil, err
+		}
+		chain = append([]uint32{tableIndex}, chain...)
+		nextTableIndex = tableIndex
+	}
+	return chain, nil
+}
+
+func createTable(ctx context.Context, vppConn api.Connection, class *Class, isIPv6 bool, nextTableIndex uint32) (uint32, error) {
+	mask, match := class.maskAndMatch(isIPv6)
+
+	now := time.Now()
+	tableReply, err := classify.NewServiceClient(vppConn).ClassifyAddDelTable(ctx, &classify.ClassifyAddDelTable{
+		IsAdd:          true,
+		TableIndex:     noTable,
+		Nbuckets:       nbuckets,
+		MemorySize:     memorySize,
+		MatchNVectors:  uint32(len(mask) / vectorSize),
+		NextTableIndex: nextTableIndex,
+		MissNextIndex:  noTable,
+		MaskLen:        uint32(len(mask)),
+		Mask:           mask,
+	})
+	if err != nil {
+		return noTable, errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("tableIndex", tableReply.NewTableIndex).
+		WithField("nextTableIndex", nextTableIndex).
+		WithField("isIPv6", isIPv6).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "ClassifyAddDelTable").Debug("completed")
+
+	action := classify.CLASSIFY_API_ACTION_SET_IP4_FIB_INDEX
+	if isIPv6 {
+		action = classify.CLASSIFY_API_ACTION_SET_IP6_FIB_INDEX
+	}
+	now = time.Now()
+	if _, err := classify.NewServiceClient(vppConn).ClassifyAddDelSession(ctx, &classify.ClassifyAddDelSession{
+		IsAdd:        true,
+		TableIndex:   tableReply.NewTableIndex,
+		HitNextIndex: noTable,
+		OpaqueIndex:  noTable,
+		Action:       action,
+		// vpp expects the FIB table ID in the metadata of the SET_IP*_FIB_INDEX actions
+		Metadata: class.TableID,
+		MatchLen: uint32(len(match)),
+		Match:    match,
+	}); err != nil {
+		_ = delTable(ctx, vppConn, tableReply.NewTableIndex)
+		return noTable, errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("tableIndex", tableReply.NewTableIndex).
+		WithField("tableID", class.TableID).
+		WithField("action", action).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "ClassifyAddDelSession").Debug("completed")
+
+	return tableReply.NewTableIndex, nil
+}
+
+func delTable(ctx context.Context, vppConn api.Connection, tableIndex uint32) error {
+	now := time.Now()
+	if _, err := classify.NewServiceClient(vppConn).ClassifyAddDelTable(ctx, &classify.ClassifyAddDelTable{
+		IsAdd:          false,
+		TableIndex:     tableIndex,
+		NextTableIndex: noTable,
+		MissNextIndex:  noTable,
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("tableIndex", tableIndex).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "ClassifyAddDelTable").Debug("completed")
+	return nil
+}
+
+func inputACLSetInterface(ctx context.Context, vppConn api.Connection, t *tables, isAdd bool) error {
+	ip4TableIndex, ip6TableIndex := noTable, noTable
+	if len(t.ip4) > 0 {
+		ip4TableIndex = t.ip4[0]
+	}
+	if len(t.ip6) > 0 {
+		ip6TableIndex = t.ip6[0]
+	}
+	if ip4TableIndex == noTable && ip6TableIndex == noTable {
+		return nil
+	}
+
+	now := time.Now()
+	if _, err := classify.NewServiceClient(vppConn).InputACLSetInterface(ctx, &classify.InputACLSetInterface{
+		SwIfIndex:     t.swIfIndex,
+		IP4TableIndex: ip4TableIndex,
+		IP6TableIndex: ip6TableIndex,
+		L2TableIndex:  noTable,
+		IsAdd:         isAdd,
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", t.swIfIndex).
+		WithField("ip4TableIndex", ip4TableIndex).
+		WithField("ip6TableIndex", ip6TableIndex).
+		WithField("isAdd", isAdd).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "InputACLSetInterface").Debug("completed")
+	return nil
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package classifier
+
+import (
+	"context"
+
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+type tablesKey struct{}
+
+// tables - vpp classifier tables attached to the swIfIndex input acl
+type tables struct {
+	swIfIndex interface_types.InterfaceIndex
+	ip4       []uint32
+	ip6       []uint32
+}
+
+// store sets the classifier tables, stored in per Connection.Id metadata
+func store(ctx context.Context, isClient bool, value *tables) {
+	metadata.Map(ctx, isClient).Store(tablesKey{}, value)
+}
+
+// load returns the classifier tables, stored in per Connection.Id metadata
+func load(ctx context.Context, isClient bool) (value *tables, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).Load(tablesKey{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(*tables)
+	return value, ok
+}
+
+// loadAndDelete deletes the classifier tables, stored in per Connection.Id metadata,
+// returning the previous value if any. The loaded result reports whether the key was present.
+func loadAndDelete(ctx context.Context, isClient bool) (value *tables, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).LoadAndDelete(tablesKey{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(*tables)
+	return value, ok
+}
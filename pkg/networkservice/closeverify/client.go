@@ -0,0 +1,65 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package closeverify
+
+import (
+	"context"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/teardown"
+)
+
+type closeVerifyClient struct {
+	vppConn   api.Connection
+	tagPrefix string
+}
+
+// NewClient returns a client chain element verifying after Close that no vpp interface tagged with the connection ID
+// is left and force deleting the leftovers. The verification is the last teardown phase, so it runs after the teardown
+// steps deferred by the rest of the chain.
+// The interfaces are found by the tags set by tag.NewClient.
+func NewClient(vppConn api.Connection, opts ...Option) networkservice.NetworkServiceClient {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return &closeVerifyClient{
+		vppConn:   vppConn,
+		tagPrefix: o.tagPrefix,
+	}
+}
+
+func (c *closeVerifyClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	return next.Client(ctx).Request(ctx, request, opts...)
+}
+
+func (c *closeVerifyClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	rv, err := next.Client(ctx).Close(ctx, conn, opts...)
+	if verifyErr := teardown.Do(ctx, teardown.Verify, func(ctx context.Context) error {
+		return verify(ctx, c.vppConn, conn, c.tagPrefix)
+	}); err == nil {
+		err = verifyErr
+	}
+	return rv, err
+}
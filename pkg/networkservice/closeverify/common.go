@@ -0,0 +1,187 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package closeverify
+
+import (
+	"context"
+	"io"
+	"strings"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/edwarnicke/govpp/binapi/af_packet"
+	"github.com/edwarnicke/govpp/binapi/af_xdp"
+	interfaces "github.com/edwarnicke/govpp/binapi/interface"
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+	"github.com/edwarnicke/govpp/binapi/ipip"
+	"github.com/edwarnicke/govpp/binapi/memif"
+	"github.com/edwarnicke/govpp/binapi/tapv2"
+	"github.com/edwarnicke/govpp/binapi/vxlan"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vlan"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/wireguard"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/external"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/tag"
+)
+
+const (
+	afPacketNamePrefix = "host-"
+)
+
+// verify force deletes the interfaces tagged with the connection ID left after Close
+func verify(ctx context.Context, vppConn api.Connection, conn *networkservice.Connection, tagPrefix string) error {
+	// The vlan and wireguard interfaces are shared by the connections, the last connection to tag them is not
+	// necessarily their owner. The external interfaces are not owned at all.
+	switch conn.GetMechanism().GetType() {
+	case vlan.MECHANISM, wireguard.MECHANISM, external.MECHANISM:
+		return nil
+	}
+	leftovers, err := dumpTagged(ctx, vppConn, tag.Format(tagPrefix, conn.GetId()))
+	if err != nil {
+		return err
+	}
+	for _, details := range leftovers {
+		logger := log.FromContext(ctx).
+			WithField("swIfIndex", details.SwIfIndex).
+			WithField("interfaceName", details.InterfaceName).
+			WithField("devType", details.InterfaceDevType)
+		logger.Warn("the interface is left after Close, force deleting")
+		if err := forceDelete(ctx, vppConn, details); err != nil {
+			logger.Errorf("unable to force delete the interface: %v", err)
+			continue
+		}
+		logger.Info("force deleted")
+	}
+	return nil
+}
+
+func dumpTagged(ctx context.Context, vppConn api.Connection, ifTag string) ([]*interfaces.SwInterfaceDetails, error) {
+	now := time.Now()
+	client, err := interfaces.NewServiceClient(vppConn).SwInterfaceDump(ctx, &interfaces.SwInterfaceDump{
+		SwIfIndex: ^interface_types.InterfaceIndex(0),
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	var rv []*interfaces.SwInterfaceDetails
+	for {
+		details, err := client.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		// The sub-interfaces are the vlans of the uplinks
+		if details.Tag == ifTag && details.Type != interface_types.IF_API_TYPE_SUB {
+			rv = append(rv, details)
+		}
+	}
+	log.FromContext(ctx).
+		WithField("tag", ifTag).
+		WithField("leftovers", len(rv)).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "SwInterfaceDump").Debug("completed")
+	return rv, nil
+}
+
+func forceDelete(ctx context.Context, vppConn api.Connection, details *interfaces.SwInterfaceDetails) error {
+	now := time.Now()
+	var vppapi string
+	var err error
+	switch strings.ToLower(details.InterfaceDevType) {
+	case "virtio":
+		vppapi = "TapDeleteV2"
+		_, err = tapv2.NewServiceClient(vppConn).TapDeleteV2(ctx, &tapv2.TapDeleteV2{
+			SwIfIndex: details.SwIfIndex,
+		})
+	case "memif":
+		vppapi = "MemifDelete"
+		_, err = memif.NewServiceClient(vppConn).MemifDelete(ctx, &memif.MemifDelete{
+			SwIfIndex: details.SwIfIndex,
+		})
+	case "af_packet":
+		vppapi = "AfPacketDelete"
+		_, err = af_packet.NewServiceClient(vppConn).AfPacketDelete(ctx, &af_packet.AfPacketDelete{
+			HostIfName: strings.TrimPrefix(details.InterfaceName, afPacketNamePrefix),
+		})
+	case "af_xdp":
+		vppapi = "AfXdpDelete"
+		_, err = af_xdp.NewServiceClient(vppConn).AfXdpDelete(ctx, &af_xdp.AfXdpDelete{
+			SwIfIndex: details.SwIfIndex,
+		})
+	case "ipip":
+		vppapi = "IpipDelTunnel"
+		_, err = ipip.NewServiceClient(vppConn).IpipDelTunnel(ctx, &ipip.IpipDelTunnel{
+			SwIfIndex: details.SwIfIndex,
+		})
+	case "vxlan":
+		vppapi = "VxlanAddDelTunnelV2"
+		err = delVxlan(ctx, vppConn, details.SwIfIndex)
+	default:
+		return errors.Errorf("unsupported interface type %s", details.InterfaceDevType)
+	}
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", details.SwIfIndex).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", vppapi).Debug("completed")
+	return nil
+}
+
+// delVxlan deletes the vxlan tunnel, vpp identifies it by the tunnel parameters rather than by the interface
+func delVxlan(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex) error {
+	client, err := vxlan.NewServiceClient(vppConn).VxlanTunnelV2Dump(ctx, &vxlan.VxlanTunnelV2Dump{
+		SwIfIndex: swIfIndex,
+	})
+	if err != nil {
+		return err
+	}
+	var tunnel *vxlan.VxlanTunnelV2Details
+	for {
+		details, err := client.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		tunnel = details
+	}
+	if tunnel == nil {
+		return nil
+	}
+	_, err = vxlan.NewServiceClient(vppConn).VxlanAddDelTunnelV2(ctx, &vxlan.VxlanAddDelTunnelV2{
+		IsAdd:          false,
+		Instance:       tunnel.Instance,
+		SrcAddress:     tunnel.SrcAddress,
+		DstAddress:     tunnel.DstAddress,
+		SrcPort:        tunnel.SrcPort,
+		DstPort:        tunnel.DstPort,
+		McastSwIfIndex: tunnel.McastSwIfIndex,
+		EncapVrfID:     tunnel.EncapVrfID,
+		DecapNextIndex: tunnel.DecapNextIndex,
+		Vni:            tunnel.Vni,
+	})
+	return err
+}
@@ -0,0 +1,31 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package closeverify
+
+type options struct {
+	tagPrefix string
+}
+
+// Option is an option pattern for closeverify client/server
+type Option func(o *options)
+
+// WithTagPrefix sets the prefix of the tags, must be the same as tag.WithPrefix of the tag chain elements
+func WithTagPrefix(prefix string) Option {
+	return func(o *options) {
+		o.tagPrefix = prefix
+	}
+}
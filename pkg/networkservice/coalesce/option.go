@@ -0,0 +1,38 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coalesce
+
+import "time"
+
+const (
+	defaultWindow = time.Second
+)
+
+type options struct {
+	window time.Duration
+}
+
+// Option is an option pattern for NewServer
+type Option func(o *options)
+
+// WithWindow sets the time after the completed Request the identical Requests of the connection are answered with
+// its result without passing them down the chain. Default: 1s
+func WithWindow(window time.Duration) Option {
+	return func(o *options) {
+		o.window = window
+	}
+}
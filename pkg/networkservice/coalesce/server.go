@@ -0,0 +1,103 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coalesce
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+type coalesceServer struct {
+	window time.Duration
+}
+
+// NewServer returns a server chain element answering the Request identical to the last one of the connection (the
+// path aside) completed within the window with the last result, without passing it down the chain. The path segments
+// up to the current one are taken from the Request, the rest from the last result.
+// The Requests of the connection are serialized by the chain (begin), so the burst of the refreshes reaches the element
+// one by one; it must follow the metadata element.
+func NewServer(opts ...Option) networkservice.NetworkServiceServer {
+	o := &options{
+		window: defaultWindow,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &coalesceServer{
+		window: o.window,
+	}
+}
+
+func (c *coalesceServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	if last, ok := load(ctx, metadata.IsClient(c)); ok && time.Since(last.at) < c.window && identical(last.request, request) {
+		log.FromContext(ctx).WithField("coalesce", "server").Debugf("identical Request within %s, returning the last result", c.window)
+		return withPath(last.conn, request.GetConnection().GetPath()), nil
+	}
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		del(ctx, metadata.IsClient(c))
+		return nil, err
+	}
+	store(ctx, metadata.IsClient(c), &result{
+		request: withoutPath(request),
+		conn:    conn.Clone(),
+		at:      time.Now(),
+	})
+	return conn, nil
+}
+
+func (c *coalesceServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	del(ctx, metadata.IsClient(c))
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func withoutPath(request *networkservice.NetworkServiceRequest) *networkservice.NetworkServiceRequest {
+	request = request.Clone()
+	if request.GetConnection() != nil {
+		request.GetConnection().Path = nil
+	}
+	return request
+}
+
+// identical compares the requests ignoring the path: the tokens and the expiration times change with every refresh
+func identical(last, request *networkservice.NetworkServiceRequest) bool {
+	return proto.Equal(last, withoutPath(request))
+}
+
+// withPath returns the clone of conn with the path segments up to the current one replaced by the ones of path
+func withPath(conn *networkservice.Connection, path *networkservice.Path) *networkservice.Connection {
+	conn = conn.Clone()
+	if conn.GetPath() == nil || path == nil {
+		return conn
+	}
+	for i := 0; i <= int(path.GetIndex()) && i < len(path.GetPathSegments()); i++ {
+		if i < len(conn.GetPath().GetPathSegments()) {
+			conn.GetPath().GetPathSegments()[i] = proto.Clone(path.GetPathSegments()[i]).(*networkservice.PathSegment)
+		}
+	}
+	conn.GetPath().Index = path.GetIndex()
+	return conn
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coalesce
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+func pathOf(index uint32, tokens ...string) *networkservice.Path {
+	path := &networkservice.Path{Index: index}
+	for _, token := range tokens {
+		path.PathSegments = append(path.PathSegments, &networkservice.PathSegment{
+			Name:  "segment-" + token,
+			Token: token,
+		})
+	}
+	return path
+}
+
+func Test_Identical_IgnoresPath(t *testing.T) {
+	request := &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:             "id",
+			NetworkService: "ns",
+			Path:           pathOf(0, "token-1"),
+		},
+	}
+	last := withoutPath(request)
+
+	refresh := request.Clone()
+	refresh.GetConnection().Path = pathOf(0, "token-2")
+	require.True(t, identical(last, refresh))
+
+	changed := refresh.Clone()
+	changed.GetConnection().Labels = map[string]string{"app": "nsc"}
+	require.False(t, identical(last, changed))
+
+	// The request passed in is not modified
+	require.Equal(t, "token-2", refresh.GetConnection().GetPath().GetPathSegments()[0].GetToken())
+}
+
+func Test_WithPath_ReplacesSegmentsUpToCurrent(t *testing.T) {
+	conn := &networkservice.Connection{
+		Id:   "id",
+		Path: pathOf(2, "old-0", "old-1", "old-2", "nse"),
+	}
+
+	rv := withPath(conn, pathOf(1, "new-0", "new-1"))
+	require.Equal(t, uint32(1), rv.GetPath().GetIndex())
+	var tokens []string
+	for _, segment := range rv.GetPath().GetPathSegments() {
+		tokens = append(tokens, segment.GetToken())
+	}
+	require.Equal(t, []string{"new-0", "new-1", "old-2", "nse"}, tokens)
+
+	// The last result is not modified
+	require.Equal(t, "old-0", conn.GetPath().GetPathSegments()[0].GetToken())
+	require.Equal(t, uint32(2), conn.GetPath().GetIndex())
+
+	// No path to take the segments from
+	require.Equal(t, "old-0", withPath(conn, nil).GetPath().GetPathSegments()[0].GetToken())
+}
@@ -0,0 +1,211 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package dnscontext
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+const (
+	header = "# Generated by the NSM forwarder, the original is restored when the connections are closed"
+)
+
+// registry keeps the configs of the connections per file, the many connections of a client share its resolv.conf
+type registry struct {
+	dropIn bool
+	mu     sync.Mutex
+	files  map[string]*file
+}
+
+type file struct {
+	original []byte
+	existed  bool
+	configs  map[string][]*networkservice.DNSConfig
+}
+
+func newRegistry(dropIn bool) *registry {
+	return &registry{
+		dropIn: dropIn,
+		files:  make(map[string]*file),
+	}
+}
+
+// set writes the file with the configs of the connection merged with the configs of the other connections
+func (r *registry) set(path, connID string, configs []*networkservice.DNSConfig) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	f, ok := r.files[path]
+	if !ok {
+		original, err := os.ReadFile(filepath.Clean(path))
+		switch {
+		case err == nil:
+		case os.IsNotExist(err):
+			if err = os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+				return errors.WithStack(err)
+			}
+		default:
+			return errors.Wrapf(err, "failed to read %s", path)
+		}
+		f = &file{
+			original: original,
+			existed:  err == nil,
+			configs:  make(map[string][]*networkservice.DNSConfig),
+		}
+		r.files[path] = f
+	}
+	f.configs[connID] = configs
+	return r.write(path, f)
+}
+
+// remove rewrites the file without the configs of the connection, the original is restored with the last one
+func (r *registry) remove(path, connID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	f, ok := r.files[path]
+	if !ok {
+		return nil
+	}
+	delete(f.configs, connID)
+	if len(f.configs) > 0 {
+		return r.write(path, f)
+	}
+	delete(r.files, path)
+	if !f.existed {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return errors.WithStack(err)
+		}
+		return nil
+	}
+	return errors.Wrapf(os.WriteFile(path, f.original, 0o644), "failed to restore %s", path) // #nosec G306
+}
+
+func (r *registry) write(path string, f *file) error {
+	servers, domains := f.merged()
+	var data string
+	if r.dropIn {
+		data = renderDropIn(servers, domains)
+	} else {
+		data = renderResolvConf(servers, domains, string(f.original))
+	}
+	// The file is rewritten in place, the resolv.conf is often a bind mount
+	return errors.Wrapf(os.WriteFile(path, []byte(data), 0o644), "failed to write %s", path) // #nosec G306
+}
+
+// merged returns the DNS servers and the search domains of the connections in the order of the connection IDs
+func (f *file) merged() (servers, domains []string) {
+	var connIDs []string
+	for connID := range f.configs {
+		connIDs = append(connIDs, connID)
+	}
+	sort.Strings(connIDs)
+	for _, connID := range connIDs {
+		for _, config := range f.configs[connID] {
+			servers = appendUnique(servers, config.GetDnsServerIps()...)
+			domains = appendUnique(domains, config.GetSearchDomains()...)
+		}
+	}
+	return servers, domains
+}
+
+// renderResolvConf puts the servers and the domains in front of the original ones, the rest of the original is kept
+func renderResolvConf(servers, domains []string, original string) string {
+	var rest []string
+	for _, line := range strings.Split(original, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || line == header {
+			continue
+		}
+		switch fields[0] {
+		case "nameserver":
+			servers = appendUnique(servers, fields[1:]...)
+		case "search", "domain":
+			domains = appendUnique(domains, fields[1:]...)
+		default:
+			rest = append(rest, line)
+		}
+	}
+	lines := []string{header}
+	if len(domains) > 0 {
+		lines = append(lines, "search "+strings.Join(domains, " "))
+	}
+	for _, server := range servers {
+		lines = append(lines, "nameserver "+server)
+	}
+	return strings.Join(append(lines, rest...), "\n") + "\n"
+}
+
+func renderDropIn(servers, domains []string) string {
+	return header + "\n[Resolve]\nDNS=" + strings.Join(servers, " ") + "\nDomains=" + strings.Join(domains, " ") + "\n"
+}
+
+func appendUnique(values []string, added ...string) []string {
+	for _, a := range added {
+		found := false
+		for _, v := range values {
+			found = found || v == a
+		}
+		if !found {
+			values = append(values, a)
+		}
+	}
+	return values
+}
+
+// rootOf returns the root of the file system of the process the netns URL (file:///proc/<pid>/ns/net) refers to
+func rootOf(netNSURL string) (string, bool) {
+	u, err := url.Parse(netNSURL)
+	if err != nil || u.Scheme != "file" {
+		return "", false
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 2 || parts[0] != "proc" {
+		return "", false
+	}
+	if _, err := strconv.Atoi(parts[1]); err != nil {
+		return "", false
+	}
+	return filepath.Join("/", parts[0], parts[1], "root"), true
+}
+
+// resolve returns the path of the file in the client file system, the absolute symlinks (e.g. to the
+// systemd-resolved stub) are resolved within the client root
+func resolve(root, path string) string {
+	full := filepath.Join(root, path)
+	target, err := os.Readlink(full)
+	if err != nil {
+		return full
+	}
+	if filepath.IsAbs(target) {
+		return filepath.Join(root, target)
+	}
+	return filepath.Join(filepath.Dir(full), target)
+}
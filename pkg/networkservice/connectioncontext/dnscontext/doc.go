@@ -0,0 +1,21 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dnscontext provides a chain element writing the DNS configs of the connection context to the resolv.conf
+// (or a systemd-resolved drop-in) of the kernel mechanism clients, for the clients not handling the DNS context
+// themselves. The client file system is reached over /proc/<pid>/root of the process the netns URL of the mechanism
+// refers to, so the forwarder needs the host PID namespace.
+package dnscontext
@@ -0,0 +1,54 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package dnscontext
+
+const (
+	defaultResolvConfPath = "/etc/resolv.conf"
+	defaultDropInPath     = "/etc/systemd/resolved.conf.d/nsm.conf"
+)
+
+type options struct {
+	path   string
+	dropIn bool
+}
+
+// Option is an option pattern for dnscontext server
+type Option func(o *options)
+
+// WithResolvConfPath sets the path of the resolv.conf in the client file system, /etc/resolv.conf by default
+func WithResolvConfPath(path string) Option {
+	return func(o *options) {
+		o.path = path
+		o.dropIn = false
+	}
+}
+
+// WithSystemdResolved writes the systemd-resolved drop-in at the path (/etc/systemd/resolved.conf.d/nsm.conf if
+// empty) instead of the resolv.conf, for the clients using systemd-resolved. systemd-resolved must be restarted in the
+// client to apply it.
+func WithSystemdResolved(path string) Option {
+	return func(o *options) {
+		o.path = path
+		if o.path == "" {
+			o.path = defaultDropInPath
+		}
+		o.dropIn = true
+	}
+}
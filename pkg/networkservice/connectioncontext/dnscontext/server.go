@@ -0,0 +1,117 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package dnscontext
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+)
+
+type dnsContextServer struct {
+	path     string
+	registry *registry
+}
+
+// NewServer returns a server chain element writing the DNS configs of the connection context to the resolv.conf of
+// the kernel mechanism client on Request and restoring it on Close
+func NewServer(opts ...Option) networkservice.NetworkServiceServer {
+	o := &options{
+		path: defaultResolvConfPath,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return &dnsContextServer{
+		path:     o.path,
+		registry: newRegistry(o.dropIn),
+	}
+}
+
+func (d *dnsContextServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := d.apply(ctx, conn); err != nil {
+		closeCtx, cancelClose := postponeCtxFunc()
+		defer cancelClose()
+
+		if _, closeErr := d.Close(closeCtx, conn); closeErr != nil {
+			err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+		}
+
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (d *dnsContextServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	if path, ok := loadAndDelete(ctx, metadata.IsClient(d)); ok {
+		if err := d.registry.remove(path, conn.GetId()); err != nil {
+			log.FromContext(ctx).WithField("dnscontext", "server").Errorf("error while restoring the DNS config: %v", err)
+		}
+	}
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func (d *dnsContextServer) apply(ctx context.Context, conn *networkservice.Connection) error {
+	var path string
+	if mechanism := kernel.ToMechanism(conn.GetMechanism()); mechanism != nil {
+		if root, ok := rootOf(mechanism.GetNetNSURL()); ok {
+			path = resolve(root, d.path)
+		}
+	}
+	configs := conn.GetContext().GetDnsContext().GetConfigs()
+
+	// The client may have been restarted with the other process or the DNS configs may have been removed
+	if prev, ok := load(ctx, metadata.IsClient(d)); ok && (prev != path || len(configs) == 0) {
+		loadAndDelete(ctx, metadata.IsClient(d))
+		if err := d.registry.remove(prev, conn.GetId()); err != nil {
+			return err
+		}
+	}
+	if path == "" || len(configs) == 0 {
+		return nil
+	}
+
+	store(ctx, metadata.IsClient(d), path)
+	if err := d.registry.set(path, conn.GetId(), configs); err != nil {
+		return err
+	}
+	log.FromContext(ctx).
+		WithField("path", path).
+		WithField("configs", len(configs)).
+		Debug("DNS config written")
+	return nil
+}
@@ -84,7 +84,7 @@ func (i *ipaddressClient) Request(ctx context.Context, request *networkservice.N
 		return nil, err
 	}
 
-	if err := addDel(ctx, conn, i.vppConn, i.loadIfIndex, metadata.IsClient(i), true); err != nil {
+	if err := add(ctx, conn, i.vppConn, i.loadIfIndex, metadata.IsClient(i)); err != nil {
 		closeCtx, cancelClose := postponeCtxFunc()
 		defer cancelClose()
 
@@ -35,7 +35,7 @@ import (
 	"github.com/networkservicemesh/sdk-vpp/pkg/tools/types"
 )
 
-func addDel(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, loadIfIndex ifIndexFunc, isClient, isAdd bool) error {
+func add(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, loadIfIndex ifIndexFunc, isClient bool) error {
 	swIfIndex, ok := loadIfIndex(ctx, isClient)
 	if !ok {
 		return errors.New("no swIfIndex available")
@@ -44,49 +44,79 @@ func addDel(ctx context.Context, conn *networkservice.Connection, vppConn api.Co
 	if isClient {
 		ipNets = conn.GetContext().GetIpContext().GetSrcIPNets()
 	}
-	if ipNets == nil {
-		return nil
-	}
 
-	var curIPs []net.IP
-	if isAdd {
-		var err error
-		if curIPs, err = dumpIps(ctx, vppConn, swIfIndex); err != nil {
-			return err
+	// Remove the addresses set by the previous Request and dropped from the IPContext since then (e.g. alias IPs
+	// released by IPAM), the addresses still present are left as is
+	current := &programmed{swIfIndex: swIfIndex}
+	if prev, ok := load(ctx, isClient); ok && prev.swIfIndex == swIfIndex {
+		for i, ipNet := range prev.ipNets {
+			if containsIPNet(ipNets, ipNet) {
+				continue
+			}
+			if err := addDelAddress(ctx, vppConn, swIfIndex, ipNet, false); err != nil {
+				current.ipNets = append(current.ipNets, prev.ipNets[i:]...)
+				store(ctx, isClient, current)
+				return err
+			}
 		}
 	}
+	defer store(ctx, isClient, current)
+
+	if len(ipNets) == 0 {
+		return nil
+	}
+	curIPs, err := dumpIps(ctx, vppConn, swIfIndex)
+	if err != nil {
+		return err
+	}
 	for _, ipNet := range ipNets {
 		// Сheck if the interface already has ipNet
-		if isAdd {
-			has := false
-			for _, addr := range curIPs {
-				if addr.Equal(ipNet.IP) {
-					has = true
-					break
-				}
-			}
-			if has {
-				continue
+		if !containsIP(curIPs, ipNet.IP) {
+			if err := addDelAddress(ctx, vppConn, swIfIndex, ipNet, true); err != nil {
+				return err
 			}
 		}
-		now := time.Now()
-		if _, err := interfaces.NewServiceClient(vppConn).SwInterfaceAddDelAddress(ctx, &interfaces.SwInterfaceAddDelAddress{
-			SwIfIndex: swIfIndex,
-			IsAdd:     isAdd,
-			Prefix:    types.ToVppAddressWithPrefix(ipNet),
-		}); err != nil {
-			return errors.WithStack(err)
-		}
-		log.FromContext(ctx).
-			WithField("swIfIndex", swIfIndex).
-			WithField("prefix", ipNets).
-			WithField("isAdd", isAdd).
-			WithField("duration", time.Since(now)).
-			WithField("vppapi", "SwInterfaceAddDelAddress").Debug("completed")
+		current.ipNets = append(current.ipNets, ipNet)
 	}
 	return nil
 }
 
+func addDelAddress(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, ipNet *net.IPNet, isAdd bool) error {
+	now := time.Now()
+	if _, err := interfaces.NewServiceClient(vppConn).SwInterfaceAddDelAddress(ctx, &interfaces.SwInterfaceAddDelAddress{
+		SwIfIndex: swIfIndex,
+		IsAdd:     isAdd,
+		Prefix:    types.ToVppAddressWithPrefix(ipNet),
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", swIfIndex).
+		WithField("prefix", ipNet).
+		WithField("isAdd", isAdd).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "SwInterfaceAddDelAddress").Debug("completed")
+	return nil
+}
+
+func containsIP(ips []net.IP, ip net.IP) bool {
+	for _, addr := range ips {
+		if addr.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsIPNet(ipNets []*net.IPNet, ipNet *net.IPNet) bool {
+	for _, n := range ipNets {
+		if n.String() == ipNet.String() {
+			return true
+		}
+	}
+	return false
+}
+
 func dumpIps(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex) ([]net.IP, error) {
 	var ips []net.IP
 	for _, isIPv6 := range []bool{false, true} {
@@ -0,0 +1,49 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipaddress
+
+import (
+	"context"
+	"net"
+
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+type ipNetsKey struct{}
+
+// programmed - addresses set on the swIfIndex vpp interface
+type programmed struct {
+	swIfIndex interface_types.InterfaceIndex
+	ipNets    []*net.IPNet
+}
+
+// store sets the addresses set in vpp, stored in per Connection.Id metadata
+func store(ctx context.Context, isClient bool, value *programmed) {
+	metadata.Map(ctx, isClient).Store(ipNetsKey{}, value)
+}
+
+// load returns the addresses set in vpp, stored in per Connection.Id metadata
+func load(ctx context.Context, isClient bool) (value *programmed, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).Load(ipNetsKey{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(*programmed)
+	return value, ok
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routes
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+	"github.com/edwarnicke/govpp/binapi/ip"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/reconcile"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/vrf"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/types"
+)
+
+const checkName = "routes"
+
+// storeCheck stores the reconcile check re-adding the programmed routes missing in vpp
+func storeCheck(ctx context.Context, vppConn api.Connection, current *programmed, isClient bool) {
+	tableIDs := loadTableIDs(ctx, isClient)
+	swIfIndex, multipath, routes := current.swIfIndex, current.multipath, append([]*networkservice.Route(nil), current.routes...)
+
+	reconcile.Store(ctx, isClient, checkName, func(ctx context.Context) (corrections int, err error) {
+		return validate(ctx, vppConn, swIfIndex, tableIDs, multipath, routes)
+	})
+}
+
+func loadTableIDs(ctx context.Context, isClient bool) map[bool]uint32 {
+	tableIDs := make(map[bool]uint32)
+	for _, isIPv6 := range []bool{false, true} {
+		tableIDs[isIPv6], _ = vrf.Load(ctx, isClient, isIPv6)
+	}
+	return tableIDs
+}
+
+// validate dumps the routes via swIfIndex and re-adds the routes missing in vpp
+func validate(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, tableIDs map[bool]uint32, multipath bool, routes []*networkservice.Route) (corrections int, err error) {
+	for _, isIPv6 := range []bool{false, true} {
+		var familyRoutes []*networkservice.Route
+		for _, route := range routes {
+			if prefix := route.GetPrefixIPNet(); prefix != nil && (prefix.IP.To4() == nil) == isIPv6 {
+				familyRoutes = append(familyRoutes, route)
+			}
+		}
+		if len(familyRoutes) == 0 {
+			continue
+		}
+		present, err := dumpPrefixes(ctx, vppConn, swIfIndex, tableIDs[isIPv6], isIPv6)
+		if err != nil {
+			return corrections, err
+		}
+		for _, route := range familyRoutes {
+			if present[route.GetPrefixIPNet().String()] {
+				continue
+			}
+			if err := vppRouteAddDel(ctx, vppConn, swIfIndex, tableIDs[isIPv6], true, multipath, route); err != nil {
+				return corrections, err
+			}
+			corrections++
+		}
+	}
+	return corrections, nil
+}
+
+// dumpPrefixes returns the prefixes of the table routed via swIfIndex
+func dumpPrefixes(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, tableID uint32, isIPv6 bool) (map[string]bool, error) {
+	now := time.Now()
+	client, err := ip.NewServiceClient(vppConn).IPRouteDump(ctx, &ip.IPRouteDump{
+		Table: ip.IPTable{
+			TableID: tableID,
+			IsIP6:   isIPv6,
+		},
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("tableID", tableID).
+		WithField("isIPv6", isIPv6).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "IPRouteDump").Debug("completed")
+
+	rv := make(map[string]bool)
+	for {
+		details, err := client.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		for _, path := range details.Route.Paths {
+			if path.SwIfIndex == uint32(swIfIndex) {
+				rv[types.FromVppPrefix(details.Route.Prefix).String()] = true
+				break
+			}
+		}
+	}
+	return rv, nil
+}
@@ -32,6 +32,7 @@ import (
 
 type routesClient struct {
 	vppConn api.Connection
+	opts    *options
 }
 
 // NewClient creates a NetworkServiceClient chain element to set routes in vpp
@@ -54,9 +55,15 @@ type routesClient struct {
 //	|                           |
 //	|                           |
 //	+---------------------------+
-func NewClient(vppConn api.Connection) networkservice.NetworkServiceClient {
+func NewClient(vppConn api.Connection, opts ...Option) networkservice.NetworkServiceClient {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
 	return &routesClient{
 		vppConn: vppConn,
+		opts:    o,
 	}
 }
 
@@ -68,7 +75,7 @@ func (r *routesClient) Request(ctx context.Context, request *networkservice.Netw
 		return nil, err
 	}
 
-	if err := addDel(ctx, conn, r.vppConn, metadata.IsClient(r), true); err != nil {
+	if err := add(ctx, conn, r.vppConn, r.opts, metadata.IsClient(r)); err != nil {
 		closeCtx, cancelClose := postponeCtxFunc()
 		defer cancelClose()
 
@@ -83,6 +90,6 @@ func (r *routesClient) Request(ctx context.Context, request *networkservice.Netw
 }
 
 func (r *routesClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
-	_ = addDel(ctx, conn, r.vppConn, metadata.IsClient(r), false)
+	_ = del(ctx, conn, r.vppConn, metadata.IsClient(r))
 	return next.Client(ctx).Close(ctx, conn, opts...)
 }
@@ -29,16 +29,102 @@ import (
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
 
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/reconcile"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/teardown"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/vrf"
 	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
 	"github.com/networkservicemesh/sdk-vpp/pkg/tools/types"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/vpperrors"
 )
 
-func addDel(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, isClient, isAdd bool) error {
+func add(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, o *options, isClient bool) error {
 	swIfIndex, ok := ifindex.Load(ctx, isClient)
 	if !ok {
 		return nil
 	}
+	routes := connRoutes(conn, isClient)
+	if err := checkLimit(ctx, len(routes), o); err != nil {
+		return err
+	}
+
+	// Diff against the routes programmed by the previous Request, so that the routes added or removed on refresh
+	// (e.g. alias IPs assigned by IPAM mid-lifetime) don't cause the full re-programming
+	current := &programmed{swIfIndex: swIfIndex, multipath: o.multipath}
+	prev, refresh := load(ctx, isClient)
+	if refresh && prev.swIfIndex == swIfIndex {
+		for i, route := range prev.routes {
+			if containsRoute(routes, route) {
+				current.routes = append(current.routes, route)
+				continue
+			}
+			if err := routeAddDel(ctx, vppConn, swIfIndex, isClient, false, o.multipath, route); err != nil {
+				current.routes = append(current.routes, prev.routes[i:]...)
+				store(ctx, isClient, current)
+				return err
+			}
+		}
+	}
+	defer store(ctx, isClient, current)
+
+	for _, route := range routes {
+		if containsRoute(current.routes, route) {
+			continue
+		}
+		if err := routeAddDel(ctx, vppConn, swIfIndex, isClient, true, o.multipath, route); err != nil {
+			return err
+		}
+		current.routes = append(current.routes, route)
+	}
+	if o.validate && refresh {
+		corrections, err := validate(ctx, vppConn, swIfIndex, loadTableIDs(ctx, isClient), o.multipath, current.routes)
+		if err != nil {
+			return err
+		}
+		if corrections > 0 {
+			log.FromContext(ctx).
+				WithField("swIfIndex", swIfIndex).
+				WithField("corrections", corrections).
+				Warn("routes missing in vpp re-added")
+		}
+	}
+	storeCheck(ctx, vppConn, current, isClient)
+	return nil
+}
+
+func del(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, isClient bool) error {
+	swIfIndex, ok := ifindex.Load(ctx, isClient)
+	if !ok {
+		return nil
+	}
+	reconcile.Delete(ctx, isClient, checkName)
+	routes, multipath := connRoutes(conn, isClient), false
+	if prev, ok := loadAndDelete(ctx, isClient); ok && prev.swIfIndex == swIfIndex {
+		routes, multipath = prev.routes, prev.multipath
+	}
+	// The vrf tables are loaded now, the routes may be removed after the vrf element is closed
+	var tableIDs [2]uint32
+	tableIDs[0], _ = vrf.Load(ctx, isClient, false)
+	tableIDs[1], _ = vrf.Load(ctx, isClient, true)
+	return teardown.Do(ctx, teardown.Routes, func(ctx context.Context) error {
+		for _, route := range routes {
+			if route.GetPrefixIPNet() == nil {
+				return errors.New("vppRoute prefix must not be nil")
+			}
+			isIPv6 := route.GetPrefixIPNet().IP.To4() == nil
+			tableID := tableIDs[0]
+			if isIPv6 {
+				tableID = tableIDs[1]
+			}
+			if err := vppRouteAddDel(ctx, vppConn, swIfIndex, tableID, false, multipath, route); err != nil {
+				return err
+			}
+			countProgrammed(ctx, tableID, isIPv6, -1)
+		}
+		return nil
+	})
+}
+
+func connRoutes(conn *networkservice.Connection, isClient bool) []*networkservice.Route {
 	var routes []*networkservice.Route
 	if isClient {
 		// Prepend any routes needed to be able to reach the SrcIPs
@@ -48,27 +134,55 @@ func addDel(ctx context.Context, conn *networkservice.Connection, vppConn api.Co
 		routes = conn.GetContext().GetIpContext().GetSrcIPRoutes()
 		routes = append(routes, conn.GetContext().GetIpContext().GetDstRoutesWithExplicitNextHop()...)
 	}
+	return routes
+}
 
-	for _, route := range routes {
-		if err := routeAddDel(ctx, vppConn, swIfIndex, isClient, isAdd, route); err != nil {
-			return err
+func containsRoute(routes []*networkservice.Route, route *networkservice.Route) bool {
+	for _, r := range routes {
+		if r.GetPrefix() == route.GetPrefix() && r.GetNextHopIP().Equal(route.GetNextHopIP()) {
+			return true
 		}
 	}
-
-	return nil
+	return false
 }
 
-func routeAddDel(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, isClient, isAdd bool, route *networkservice.Route) error {
+func routeAddDel(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, isClient, isAdd, multipath bool, route *networkservice.Route) error {
 	if route.GetPrefixIPNet() == nil {
 		return errors.New("vppRoute prefix must not be nil")
 	}
 	isIPV6 := route.GetPrefixIPNet().IP.To4() == nil
 	tableID, _ := vrf.Load(ctx, isClient, isIPV6)
+	if err := vppRouteAddDel(ctx, vppConn, swIfIndex, tableID, isAdd, multipath, route); err != nil {
+		return err
+	}
+	delta := 1
+	if !isAdd {
+		delta = -1
+	}
+	countProgrammed(ctx, tableID, isIPV6, delta)
+	return nil
+}
+
+func checkLimit(ctx context.Context, n int, o *options) error {
+	if o.maxLimit > 0 && n > o.maxLimit {
+		return errors.Wrapf(vpperrors.ErrResourceExhausted, "request asks to program %d routes, max: %d", n, o.maxLimit)
+	}
+	if o.warnLimit > 0 && n > o.warnLimit {
+		log.FromContext(ctx).
+			WithField("routes", n).
+			WithField("warnLimit", o.warnLimit).
+			Warn("request asks to program too many routes")
+	}
+	return nil
+}
+
+func vppRouteAddDel(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, tableID uint32, isAdd, multipath bool, route *networkservice.Route) error {
+	isIPV6 := route.GetPrefixIPNet().IP.To4() == nil
 	vppRoute := toRoute(route, swIfIndex, tableID)
 	now := time.Now()
 	if _, err := ip.NewServiceClient(vppConn).IPRouteAddDel(ctx, &ip.IPRouteAddDel{
 		IsAdd:       isAdd,
-		IsMultipath: false,
+		IsMultipath: multipath,
 		Route:       vppRoute,
 	}); err != nil {
 		return errors.WithStack(err)
@@ -79,6 +193,7 @@ func routeAddDel(ctx context.Context, vppConn api.Connection, swIfIndex interfac
 		WithField("isIpV6", isIPV6).
 		WithField("tableID", tableID).
 		WithField("isAdd", isAdd).
+		WithField("isMultipath", multipath).
 		WithField("duration", time.Since(now)).
 		WithField("vppapi", "IPRouteAddDel").Info("completed")
 	return nil
@@ -0,0 +1,61 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routes
+
+import (
+	"context"
+
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+type routesKey struct{}
+
+// programmed - routes programmed in vpp via the swIfIndex
+type programmed struct {
+	swIfIndex interface_types.InterfaceIndex
+	multipath bool
+	routes    []*networkservice.Route
+}
+
+// store sets the routes programmed in vpp, stored in per Connection.Id metadata
+func store(ctx context.Context, isClient bool, value *programmed) {
+	metadata.Map(ctx, isClient).Store(routesKey{}, value)
+}
+
+// load returns the routes programmed in vpp, stored in per Connection.Id metadata
+func load(ctx context.Context, isClient bool) (value *programmed, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).Load(routesKey{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(*programmed)
+	return value, ok
+}
+
+// loadAndDelete deletes the routes programmed in vpp, stored in per Connection.Id metadata,
+// returning the previous value if any. The loaded result reports whether the key was present.
+func loadAndDelete(ctx context.Context, isClient bool) (value *programmed, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).LoadAndDelete(routesKey{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(*programmed)
+	return value, ok
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routes
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// programmedMetric - the number of the FIB entries programmed by the routes elements per vrf table and IP family
+const programmedMetric = "routes_programmed"
+
+// TableCount - the number of the FIB entries programmed by the routes elements in the vrf table
+type TableCount struct {
+	TableID uint32
+	IsIPv6  bool
+	Routes  int
+}
+
+type tableKey struct {
+	tableID uint32
+	isIPv6  bool
+}
+
+var programmedRoutes struct {
+	once    sync.Once
+	counter syncint64.UpDownCounter
+	mu      sync.Mutex
+	counts  map[tableKey]int
+}
+
+// Counts returns the number of the FIB entries programmed by the routes elements per vrf table and IP family
+func Counts() []TableCount {
+	programmedRoutes.mu.Lock()
+	defer programmedRoutes.mu.Unlock()
+	var rv []TableCount
+	for key, n := range programmedRoutes.counts {
+		rv = append(rv, TableCount{TableID: key.tableID, IsIPv6: key.isIPv6, Routes: n})
+	}
+	return rv
+}
+
+// countProgrammed adds delta to the number of the routes programmed into the table
+func countProgrammed(ctx context.Context, tableID uint32, isIPv6 bool, delta int) {
+	programmedRoutes.once.Do(func() {
+		programmedRoutes.counts = make(map[tableKey]int)
+		var err error
+		if programmedRoutes.counter, err = global.Meter("").SyncInt64().UpDownCounter(programmedMetric); err != nil {
+			log.FromContext(ctx).Warnf("failed to create %s counter: %v", programmedMetric, err)
+		}
+	})
+
+	programmedRoutes.mu.Lock()
+	key := tableKey{tableID: tableID, isIPv6: isIPv6}
+	if programmedRoutes.counts[key] += delta; programmedRoutes.counts[key] <= 0 {
+		delete(programmedRoutes.counts, key)
+	}
+	programmedRoutes.mu.Unlock()
+
+	if programmedRoutes.counter == nil {
+		return
+	}
+	family := "ipv4"
+	if isIPv6 {
+		family = "ipv6"
+	}
+	programmedRoutes.counter.Add(ctx, int64(delta),
+		attribute.String("table_id", strconv.FormatUint(uint64(tableID), 10)),
+		attribute.String("family", family),
+	)
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routes
+
+type options struct {
+	validate  bool
+	multipath bool
+	warnLimit int
+	maxLimit  int
+}
+
+// Option is an option pattern for routesClient/Server
+type Option func(o *options)
+
+// WithDumpValidation - on refresh, dumps the routes programmed via the interface and re-adds the ones missing in vpp.
+// Only the routes added or removed since the previous Request are programmed otherwise.
+func WithDumpValidation() Option {
+	return func(o *options) {
+		o.validate = true
+	}
+}
+
+// WithMultipath - programs the routes as the paths of the multipath routes, so the same prefix routed via several
+// connections (e.g. the vL3 prefixes advertised by every peer of the mesh) keeps the paths of the other connections.
+// Removing the route of a connection removes only its path.
+func WithMultipath() Option {
+	return func(o *options) {
+		o.multipath = true
+	}
+}
+
+// WithRouteLimit - warns when a single Request asks to program more than warn routes and rejects the Request asking
+// for more than max routes with vpperrors.ErrResourceExhausted, before any of them is programmed.
+// Zero disables the corresponding check.
+func WithRouteLimit(warn, max int) Option {
+	return func(o *options) {
+		o.warnLimit = warn
+		o.maxLimit = max
+	}
+}
@@ -31,6 +31,7 @@ import (
 
 type routesServer struct {
 	vppConn api.Connection
+	opts    *options
 }
 
 // NewServer creates a NetworkServiceServer chain element to set the ip address on a vpp interface
@@ -55,9 +56,15 @@ type routesServer struct {
 //	                    |                           |
 //	                    |                           |
 //	                    +---------------------------+
-func NewServer(vppConn api.Connection) networkservice.NetworkServiceServer {
+func NewServer(vppConn api.Connection, opts ...Option) networkservice.NetworkServiceServer {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
 	return &routesServer{
 		vppConn: vppConn,
+		opts:    o,
 	}
 }
 
@@ -69,7 +76,7 @@ func (r *routesServer) Request(ctx context.Context, request *networkservice.Netw
 		return nil, err
 	}
 
-	if err := addDel(ctx, conn, r.vppConn, metadata.IsClient(r), true); err != nil {
+	if err := add(ctx, conn, r.vppConn, r.opts, metadata.IsClient(r)); err != nil {
 		closeCtx, cancelClose := postponeCtxFunc()
 		defer cancelClose()
 
@@ -84,7 +91,7 @@ func (r *routesServer) Request(ctx context.Context, request *networkservice.Netw
 }
 
 func (r *routesServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
-	if err := addDel(ctx, conn, r.vppConn, metadata.IsClient(r), false); err != nil {
+	if err := del(ctx, conn, r.vppConn, metadata.IsClient(r)); err != nil {
 		return nil, err
 	}
 	return next.Server(ctx).Close(ctx, conn)
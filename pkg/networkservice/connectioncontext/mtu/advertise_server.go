@@ -0,0 +1,50 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mtu
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+)
+
+type advertiseServer struct{}
+
+// NewAdvertiseServer creates a NetworkServiceServer chain element writing the effective MTU of the data path (the
+// smallest MTU of its hops, the mechanism overheads already subtracted) into the ConnectionContext returned to the
+// client, so the endpoint applications can size the payloads without probing.
+// The ConnectionContext.MTU is lowered to the effective MTU and it is also reported in ConnectionContext.ExtraContext
+// under EffectiveMTUKey. The MTULabel is advertised as is.
+func NewAdvertiseServer() networkservice.NetworkServiceServer {
+	return &advertiseServer{}
+}
+
+func (a *advertiseServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	advertiseMTU(ctx, conn)
+	return conn, nil
+}
+
+func (a *advertiseServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
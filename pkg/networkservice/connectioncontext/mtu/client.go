@@ -31,10 +31,15 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
 	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/hotreload"
 )
 
 type mtuClient struct {
-	vppConn api.Connection
+	vppConn  api.Connection
+	override *hotreload.Value[uint32]
+	nsLabels NetworkServiceLabelsFunc
+	conns    *connInterfaces
 }
 
 // NewClient creates a NetworkServiceClient chain element to set the mtu on a vpp interface
@@ -59,14 +64,24 @@ type mtuClient struct {
 //	|                           |
 //	|                           |
 //	+---------------------------+
-func NewClient(vppConn api.Connection) networkservice.NetworkServiceClient {
+func NewClient(vppConn api.Connection, opts ...Option) networkservice.NetworkServiceClient {
+	o := newOptions(opts...)
 	return &mtuClient{
-		vppConn: vppConn,
+		vppConn:  vppConn,
+		override: o.override,
+		nsLabels: o.nsLabels,
+		conns:    newConnInterfaces(vppConn, o),
 	}
 }
 
 func (m *mtuClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
-	setConnContextMTU(request)
+	setConnContextMTU(ctx, request)
+	raiseGSOMTU(ctx, request)
+
+	labeled, err := labelMTU(ctx, request.GetConnection(), m.nsLabels)
+	if err != nil {
+		return nil, err
+	}
 
 	postponeCtxFunc := postpone.ContextWithValues(ctx)
 
@@ -80,7 +95,7 @@ func (m *mtuClient) Request(ctx context.Context, request *networkservice.Network
 		return conn, nil
 	}
 
-	if err := setVPPMTU(ctx, conn, m.vppConn, metadata.IsClient(m)); err != nil {
+	if err = m.conns.apply(ctx, conn, metadata.IsClient(m), labeled, m.override); err != nil {
 		if closeErr := m.closeOnFailure(postponeCtxFunc, conn, opts); closeErr != nil {
 			err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
 		}
@@ -100,5 +115,6 @@ func (m *mtuClient) closeOnFailure(postponeCtxFunc func() (context.Context, cont
 }
 
 func (m *mtuClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	m.conns.delete(conn.GetId())
 	return next.Client(ctx).Close(ctx, conn, opts...)
 }
@@ -18,36 +18,59 @@ package mtu
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 	"time"
 
 	"git.fd.io/govpp.git/api"
 	interfaces "github.com/edwarnicke/govpp/binapi/interface"
+	"github.com/edwarnicke/govpp/binapi/interface_types"
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
 	"github.com/pkg/errors"
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/memif"
 
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/gso"
 	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/mtupath"
 )
 
 const (
 	jumboFrameSize = 9000
+	// gsoFrameSize - the MTU of the connections having GSO enabled end to end, the super-sized frames are segmented
+	// by vpp or the kernel before reaching a segment that can't carry them
+	gsoFrameSize = 65000
+	requesterHop = "requester"
+	remoteHop    = "remote"
+	labelHop     = "label"
+	minMTU       = 576
+
+	// ConstraintKey - ConnectionContext.ExtraContext key the hop preventing the default MTU (the jumbo or the GSO frame
+	// size) end to end is reported with, in the form "<hop>:<mtu>"
+	ConstraintKey = "mtu_constrained_by"
+
+	// MTULabel - connection label overriding the MTU computed from the data path for the connection, for the legacy
+	// appliances requiring the exact MTU. It must be within [576, 65000].
+	MTULabel = "mtu"
+
+	// EffectiveMTUKey - ConnectionContext.ExtraContext key the effective MTU of the data path is advertised with
+	EffectiveMTUKey = "effective_mtu"
 )
 
 func setVPPMTU(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, isClient bool) error {
-	now := time.Now()
 	swIfIndex, ok := ifindex.Load(ctx, isClient)
 	if !ok || conn.GetContext().GetMTU() == 0 {
 		return nil
 	}
+	return setInterfaceMTU(ctx, vppConn, swIfIndex, conn.GetContext().GetMTU())
+}
+
+func setInterfaceMTU(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, mtu uint32) error {
+	now := time.Now()
 	setMTU := &interfaces.SwInterfaceSetMtu{
 		SwIfIndex: swIfIndex,
-		Mtu: []uint32{
-			conn.GetContext().GetMTU(),
-			conn.GetContext().GetMTU(),
-			conn.GetContext().GetMTU(),
-			conn.GetContext().GetMTU(),
-		},
+		Mtu:       []uint32{mtu, mtu, mtu, mtu},
 	}
 	_, err := interfaces.NewServiceClient(vppConn).SwInterfaceSetMtu(ctx, setMTU)
 	if err != nil {
@@ -68,7 +91,57 @@ func setVPPMTU(ctx context.Context, conn *networkservice.Connection, vppConn api
 	return nil
 }
 
-func setConnContextMTU(request *networkservice.NetworkServiceRequest) {
+func inBounds(mtu uint32) bool {
+	return mtu >= minMTU && mtu <= gsoFrameSize
+}
+
+// labelMTU returns the MTULabel value of the conn labels, or of its network service labels if the conn has no
+// MTULabel, 0 if neither has it
+func labelMTU(ctx context.Context, conn *networkservice.Connection, nsLabels NetworkServiceLabelsFunc) (uint32, error) {
+	label, ok := conn.GetLabels()[MTULabel]
+	if !ok && nsLabels != nil {
+		label, ok = nsLabels(ctx, conn.GetNetworkService())[MTULabel]
+	}
+	if !ok {
+		return 0, nil
+	}
+	mtu, err := strconv.ParseUint(label, 10, 32)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid %s label %q", MTULabel, label)
+	}
+	if !inBounds(uint32(mtu)) {
+		return 0, errors.Errorf("%s label %d is out of bounds [%d, %d]", MTULabel, mtu, minMTU, gsoFrameSize)
+	}
+	return uint32(mtu), nil
+}
+
+// overrideMTU sets the ConnectionContext.MTU to the labeled MTU, or to the override if the conn is not labeled
+func overrideMTU(ctx context.Context, conn *networkservice.Connection, isClient bool, labeled, override uint32) {
+	mtu := labeled
+	if mtu == 0 {
+		if override == 0 {
+			return
+		}
+		if !inBounds(override) {
+			log.FromContext(ctx).Warnf("MTU override %d is out of bounds [%d, %d], not applied", override, minMTU, gsoFrameSize)
+			return
+		}
+		mtu = override
+	}
+	if conn.GetContext() == nil {
+		conn.Context = &networkservice.ConnectionContext{}
+	}
+	if computed := conn.GetContext().GetMTU(); computed != 0 && mtu > computed {
+		log.FromContext(ctx).
+			WithField("MTU", mtu).
+			WithField("computedMTU", computed).
+			Warn("MTU override exceeds the MTU of the data path, the oversized packets may be dropped")
+	}
+	mtupath.Store(ctx, isClient, labelHop, mtu)
+	conn.GetContext().MTU = mtu
+}
+
+func setConnContextMTU(ctx context.Context, request *networkservice.NetworkServiceRequest) {
 	if request.GetConnection().GetContext().GetMTU() != 0 {
 		return
 	}
@@ -80,3 +153,150 @@ func setConnContextMTU(request *networkservice.NetworkServiceRequest) {
 	}
 	request.GetConnection().GetContext().MTU = jumboFrameSize
 }
+
+// isMemif returns true if the mechanism of the request is memif or, if it is not selected yet, all the preferred
+// mechanisms are memif
+func isMemif(request *networkservice.NetworkServiceRequest) bool {
+	if mechanism := request.GetConnection().GetMechanism(); mechanism != nil {
+		return memif.ToMechanism(mechanism) != nil
+	}
+	for _, mechanism := range request.GetMechanismPreferences() {
+		if memif.ToMechanism(mechanism) == nil {
+			return false
+		}
+	}
+	return len(request.GetMechanismPreferences()) > 0
+}
+
+// raiseGSOMTU raises the default MTU of the request to the size of the super-sized GSO frames if the connection is
+// memif to memif with GSO enabled on both sides: such frames never leave vpp, so no hop needs them segmented. The
+// MTU set by the requester is left as is.
+func raiseGSOMTU(ctx context.Context, request *networkservice.NetworkServiceRequest) {
+	if request.GetConnection().GetContext().GetMTU() != jumboFrameSize || hasHop(ctx, false, requesterHop) {
+		return
+	}
+	if !gso.IsEnabled(ctx, false) || !gso.IsEnabled(ctx, true) || !loadServerMemif(ctx) || !isMemif(request) {
+		return
+	}
+	request.GetConnection().GetContext().MTU = gsoFrameSize
+	storeTargetMTU(ctx, gsoFrameSize)
+}
+
+// clampToInterface lowers the MTU of the conn to the max frame size of its vpp interface, the super-sized frames may
+// exceed it
+func clampToInterface(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, isClient bool) error {
+	swIfIndex, ok := ifindex.Load(ctx, isClient)
+	if !ok || conn.GetContext().GetMTU() <= jumboFrameSize {
+		return nil
+	}
+	maxFrameSize, err := getMaxFrameSize(ctx, vppConn, swIfIndex)
+	if err != nil || maxFrameSize == 0 || maxFrameSize >= conn.GetContext().GetMTU() {
+		return err
+	}
+	log.FromContext(ctx).
+		WithField("MTU", conn.GetContext().GetMTU()).
+		WithField("maxFrameSize", maxFrameSize).
+		Debugf("MTU is lowered to the max frame size of swIfIndex %d", swIfIndex)
+	mtupath.Store(ctx, isClient, mtupath.InterfaceHop, maxFrameSize)
+	conn.GetContext().MTU = maxFrameSize
+	return nil
+}
+
+func getMaxFrameSize(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex) (uint32, error) {
+	now := time.Now()
+	client, err := interfaces.NewServiceClient(vppConn).SwInterfaceDump(ctx, &interfaces.SwInterfaceDump{
+		SwIfIndex: swIfIndex,
+	})
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	defer func() { _ = client.Close() }()
+
+	details, err := client.Recv()
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable to get the details of swIfIndex %d", swIfIndex)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", swIfIndex).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "SwInterfaceDump").Debug("completed")
+	return uint32(details.LinkMtu), nil
+}
+
+func hasHop(ctx context.Context, isClient bool, name string) bool {
+	hops, _ := mtupath.Load(ctx, isClient)
+	for _, hop := range hops {
+		if hop.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// isOverridden returns true if the MTU of the connection is overridden by the MTULabel or the override
+func isOverridden(ctx context.Context) bool {
+	return hasHop(ctx, false, labelHop) || hasHop(ctx, true, labelHop)
+}
+
+func storeRequesterMTU(ctx context.Context, request *networkservice.NetworkServiceRequest, isClient bool) {
+	if request.GetConnection().GetContext().GetMTU() == 0 || hasHop(ctx, isClient, requesterHop) {
+		return
+	}
+	mtupath.Store(ctx, isClient, requesterHop, request.GetConnection().GetContext().GetMTU())
+}
+
+func validateMTU(ctx context.Context, conn *networkservice.Connection) {
+	mtu := conn.GetContext().GetMTU()
+	if mtu == 0 {
+		return
+	}
+	if mtu >= loadTargetMTU(ctx) {
+		delete(conn.GetContext().GetExtraContext(), ConstraintKey)
+		return
+	}
+
+	serverHops, _ := mtupath.Load(ctx, false)
+	clientHops, _ := mtupath.Load(ctx, true)
+	var hops []mtupath.Hop
+	hops = append(hops, serverHops...)
+	hops = append(hops, clientHops...)
+	hops = append(hops, mtupath.Hop{Name: remoteHop, MTU: mtu})
+	hop, _ := mtupath.Constraint(hops...)
+
+	if conn.GetContext().GetExtraContext() == nil {
+		conn.GetContext().ExtraContext = make(map[string]string)
+	}
+	conn.GetContext().GetExtraContext()[ConstraintKey] = fmt.Sprintf("%s:%d", hop.Name, hop.MTU)
+	log.FromContext(ctx).
+		WithField("MTU", mtu).
+		WithField("hops", hops).
+		Infof("MTU %d is not supported end to end, MTU is constrained by %s hop", loadTargetMTU(ctx), hop.Name)
+}
+
+// advertiseMTU lowers the ConnectionContext.MTU of the conn to the smallest MTU of the data path hops and reports it
+// under EffectiveMTUKey
+func advertiseMTU(ctx context.Context, conn *networkservice.Connection) {
+	mtu := conn.GetContext().GetMTU()
+	if mtu == 0 {
+		return
+	}
+	if !isOverridden(ctx) {
+		serverHops, _ := mtupath.Load(ctx, false)
+		clientHops, _ := mtupath.Load(ctx, true)
+		var hops []mtupath.Hop
+		hops = append(hops, serverHops...)
+		hops = append(hops, clientHops...)
+		if hop, ok := mtupath.Constraint(hops...); ok && hop.MTU < mtu {
+			log.FromContext(ctx).
+				WithField("MTU", mtu).
+				WithField("effectiveMTU", hop.MTU).
+				Debugf("MTU is lowered to the MTU of %s hop", hop.Name)
+			mtu = hop.MTU
+			conn.GetContext().MTU = mtu
+		}
+	}
+	if conn.GetContext().GetExtraContext() == nil {
+		conn.GetContext().ExtraContext = make(map[string]string)
+	}
+	conn.GetContext().GetExtraContext()[EffectiveMTUKey] = strconv.FormatUint(uint64(mtu), 10)
+}
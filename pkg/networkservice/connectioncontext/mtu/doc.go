@@ -15,4 +15,20 @@
 // limitations under the License.
 
 // Package mtu provides networkservice chain elements to set the mtu on vpp interfaces
+//
+// The ConnectionContext.MTU defaults to the jumbo frame size (9000). The memif to memif connections with GSO enabled
+// on both sides (see gso.IsEnabled) default to the size of the super-sized GSO frames (65000) instead, as such frames
+// never leave vpp, lowered to the max frame size of the vpp interfaces if they can't carry it.
+// If some hop of the data path (the requester, the kernel interface, a tunnel, the uplink under it or the remote side)
+// can't carry the default MTU, mtu.NewServer reports the constraining hop in ConnectionContext.ExtraContext under
+// ConstraintKey. RaiseUplinkMTU raises the uplinks for the tunnels over them to carry the jumbo frames where the links
+// allow it, the kernel interfaces are created with the MTU of the connection.
+//
+// mtu.NewAdvertiseServer advertises the effective MTU of the data path back to the client in ConnectionContext.MTU and
+// in ConnectionContext.ExtraContext under EffectiveMTUKey.
+//
+// The MTULabel connection label, or the network service one (see WithNetworkServiceLabels), overrides the computed MTU
+// of the connection regardless of the data path, the Request with an invalid label is refused before it reaches the
+// rest of the chain. WithOverride overrides the MTU of all the connections without the label with a value updated at
+// runtime.
 package mtu
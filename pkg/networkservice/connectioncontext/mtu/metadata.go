@@ -0,0 +1,53 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mtu
+
+import (
+	"context"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+type serverMemifKey struct{}
+
+type targetKey struct{}
+
+// storeServerMemif records whether the server side of the connection is memif, for the client side to find the
+// memif to memif connections
+func storeServerMemif(ctx context.Context, isMemif bool) {
+	metadata.Map(ctx, false).Store(serverMemifKey{}, isMemif)
+}
+
+func loadServerMemif(ctx context.Context) bool {
+	rawValue, _ := metadata.Map(ctx, false).Load(serverMemifKey{})
+	isMemif, _ := rawValue.(bool)
+	return isMemif
+}
+
+// storeTargetMTU records the MTU the connection is expected to carry end to end if no hop constrains it
+func storeTargetMTU(ctx context.Context, mtu uint32) {
+	metadata.Map(ctx, false).Store(targetKey{}, mtu)
+}
+
+// loadTargetMTU returns the MTU stored by storeTargetMTU or the jumbo frame size if there is none
+func loadTargetMTU(ctx context.Context) uint32 {
+	rawValue, _ := metadata.Map(ctx, false).Load(targetKey{})
+	if mtu, ok := rawValue.(uint32); ok {
+		return mtu
+	}
+	return jumboFrameSize
+}
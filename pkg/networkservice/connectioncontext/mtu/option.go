@@ -0,0 +1,64 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mtu
+
+import (
+	"context"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/hotreload"
+)
+
+type options struct {
+	chainCtx context.Context
+	override *hotreload.Value[uint32]
+	nsLabels NetworkServiceLabelsFunc
+}
+
+// NetworkServiceLabelsFunc returns the labels of the network service, e.g. the labels the selected endpoint registered
+// the network service with
+type NetworkServiceLabelsFunc func(ctx context.Context, networkService string) map[string]string
+
+// Option is an option pattern for mtu server/client
+type Option func(o *options)
+
+// WithOverride sets the MTU overriding the computed MTU of the connections, updated at runtime (0 - not overridden).
+// The MTULabel of the connection takes precedence over it. The new MTU is set on the vpp interfaces of the existing
+// connections until chainCtx is done, their ConnectionContext.MTU is updated on the next refresh.
+func WithOverride(chainCtx context.Context, mtu *hotreload.Value[uint32]) Option {
+	return func(o *options) {
+		o.chainCtx = chainCtx
+		o.override = mtu
+	}
+}
+
+// WithNetworkServiceLabels sets the source of the network service labels, the MTULabel of the network service applies
+// to the connections without their own MTULabel
+func WithNetworkServiceLabels(nsLabels NetworkServiceLabelsFunc) Option {
+	return func(o *options) {
+		o.nsLabels = nsLabels
+	}
+}
+
+func newOptions(opts ...Option) *options {
+	o := &options{
+		override: hotreload.NewValue[uint32](0),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mtu
+
+import (
+	"context"
+	"sync"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/hotreload"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/keymutex"
+)
+
+// connInterface - vpp interface of the connection and the MTU computed for it from the data path
+type connInterface struct {
+	swIfIndex interface_types.InterfaceIndex
+	computed  uint32
+}
+
+// connInterfaces - vpp interfaces of the existing connections not having the MTULabel, the MTU override updates are
+// applied to
+type connInterfaces struct {
+	vppConn api.Connection
+	ifaces  map[string]connInterface
+	mu      sync.Mutex
+}
+
+func newConnInterfaces(vppConn api.Connection, o *options) *connInterfaces {
+	c := &connInterfaces{
+		vppConn: vppConn,
+		ifaces:  make(map[string]connInterface),
+	}
+	if o.chainCtx != nil {
+		o.override.Watch(o.chainCtx, c.update)
+	}
+	return c
+}
+
+// apply tracks the vpp interface of the conn, overrides the MTU of the conn and sets it on the interface holding the
+// interface mutex, so the override updates are not interleaved with it. The conn is tracked before the override is
+// loaded, so the update of the override racing with apply is applied to the interface too.
+func (c *connInterfaces) apply(ctx context.Context, conn *networkservice.Connection, isClient bool, labeled uint32, override *hotreload.Value[uint32]) error {
+	swIfIndex, ok := ifindex.Load(ctx, isClient)
+	if ok {
+		var unlock func()
+		ctx, unlock = keymutex.LockInterface(ctx, swIfIndex)
+		defer unlock()
+	}
+	if err := clampToInterface(ctx, conn, c.vppConn, isClient); err != nil {
+		c.delete(conn.GetId())
+		return err
+	}
+	c.track(conn, swIfIndex, ok && labeled == 0, conn.GetContext().GetMTU())
+
+	overrideMTU(ctx, conn, isClient, labeled, override.Load())
+	if err := setVPPMTU(ctx, conn, c.vppConn, isClient); err != nil {
+		c.delete(conn.GetId())
+		return err
+	}
+	return nil
+}
+
+// track stores the vpp interface of the conn with its computed MTU if the conn is tracked (not labeled with the
+// MTULabel)
+func (c *connInterfaces) track(conn *networkservice.Connection, swIfIndex interface_types.InterfaceIndex, tracked bool, computed uint32) {
+	if !tracked || computed == 0 {
+		c.delete(conn.GetId())
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ifaces[conn.GetId()] = connInterface{
+		swIfIndex: swIfIndex,
+		computed:  computed,
+	}
+}
+
+func (c *connInterfaces) delete(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.ifaces, id)
+}
+
+// update sets the MTU of the vpp interfaces of the existing connections to the override, or back to the computed MTU
+// if the override is cleared
+func (c *connInterfaces) update(ctx context.Context, override uint32) {
+	if override != 0 && !inBounds(override) {
+		log.FromContext(ctx).Warnf("MTU override %d is out of bounds [%d, %d], not applied", override, minMTU, gsoFrameSize)
+		return
+	}
+
+	c.mu.Lock()
+	ifaces := make(map[string]connInterface, len(c.ifaces))
+	for id, iface := range c.ifaces {
+		ifaces[id] = iface
+	}
+	c.mu.Unlock()
+
+	for id, iface := range ifaces {
+		mtu := override
+		if mtu == 0 {
+			mtu = iface.computed
+		}
+		ifaceCtx, unlock := keymutex.LockInterface(ctx, iface.swIfIndex)
+		if err := setInterfaceMTU(ifaceCtx, c.vppConn, iface.swIfIndex, mtu); err != nil {
+			log.FromContext(ctx).WithField("id", id).Errorf("unable to apply the MTU override: %v", err)
+		}
+		unlock()
+	}
+}
@@ -27,10 +27,15 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
 	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/hotreload"
 )
 
 type mtuServer struct {
-	vppConn api.Connection
+	vppConn  api.Connection
+	override *hotreload.Value[uint32]
+	nsLabels NetworkServiceLabelsFunc
+	conns    *connInterfaces
 }
 
 // NewServer creates a NetworkServiceServer chain element to set the MTU on a vpp interface
@@ -55,14 +60,25 @@ type mtuServer struct {
 //	                    |                           |
 //	                    |                           |
 //	                    +---------------------------+
-func NewServer(vppConn api.Connection) networkservice.NetworkServiceServer {
+func NewServer(vppConn api.Connection, opts ...Option) networkservice.NetworkServiceServer {
+	o := newOptions(opts...)
 	return &mtuServer{
-		vppConn: vppConn,
+		vppConn:  vppConn,
+		override: o.override,
+		nsLabels: o.nsLabels,
+		conns:    newConnInterfaces(vppConn, o),
 	}
 }
 
 func (m *mtuServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
-	setConnContextMTU(request)
+	storeRequesterMTU(ctx, request, metadata.IsClient(m))
+	setConnContextMTU(ctx, request)
+	storeServerMemif(ctx, isMemif(request))
+
+	labeled, err := labelMTU(ctx, request.GetConnection(), m.nsLabels)
+	if err != nil {
+		return nil, err
+	}
 
 	postponeCtxFunc := postpone.ContextWithValues(ctx)
 
@@ -71,12 +87,13 @@ func (m *mtuServer) Request(ctx context.Context, request *networkservice.Network
 		return nil, err
 	}
 
-	if err := setVPPMTU(ctx, conn, m.vppConn, metadata.IsClient(m)); err != nil {
+	if err = m.conns.apply(ctx, conn, metadata.IsClient(m), labeled, m.override); err != nil {
 		if closeErr := m.closeOnFailure(postponeCtxFunc, conn); closeErr != nil {
 			err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
 		}
 		return nil, err
 	}
+	validateMTU(ctx, conn)
 
 	return conn, nil
 }
@@ -91,5 +108,6 @@ func (m *mtuServer) closeOnFailure(postponeCtxFunc func() (context.Context, cont
 }
 
 func (m *mtuServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	m.conns.delete(conn.GetId())
 	return next.Server(ctx).Close(ctx, conn)
 }
@@ -0,0 +1,48 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mtu
+
+import (
+	"context"
+	"net"
+
+	"git.fd.io/govpp.git/api"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/uplink"
+)
+
+// maxTunnelOverhead - the largest overhead of the tunnels carried over the uplinks (ipsec over IPv6)
+const maxTunnelOverhead = 101
+
+// RaiseUplinkMTU raises the MTU of the uplinks of the tunnel IPs for any tunnel over them to carry the jumbo frames, as
+// far as the links of the uplinks allow. The uplinks still unable to carry them are reported as the constraining
+// hop of the connections (see ConstraintKey).
+func RaiseUplinkMTU(ctx context.Context, vppConn api.Connection, tunnelIPs ...net.IP) error {
+	for _, tunnelIP := range tunnelIPs {
+		if tunnelIP == nil {
+			continue
+		}
+		u, err := uplink.ByIP(ctx, vppConn, tunnelIP)
+		if err != nil {
+			return err
+		}
+		if err := uplink.RaiseMTU(ctx, vppConn, u, jumboFrameSize+maxTunnelOverhead); err != nil {
+			return err
+		}
+	}
+	return nil
+}
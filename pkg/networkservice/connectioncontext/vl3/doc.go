@@ -0,0 +1,22 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package vl3 provides networkservice chain elements applying the connectioncontext to the vpp side of the vWires
+// of the vL3 endpoints. The vL3 prefixes advertised by the peers of the mesh (the peer address, its prefix and the
+// global vL3 prefix) are routed via the connections as the multipath routes: the prefix advertised by several peers
+// keeps a path per connection, the routes follow the connection context on refresh as the mesh grows, and the
+// paths of the prefixes gone from the connection context are removed.
+package vl3
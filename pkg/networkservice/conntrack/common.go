@@ -0,0 +1,94 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conntrack
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/edwarnicke/govpp/binapi/acl"
+	"github.com/edwarnicke/govpp/binapi/vlib"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// commands returns the acl plugin cli commands applying the options.
+// The session table commands go first as the table parameters can be changed only before the table is created.
+func (o *options) commands() []string {
+	var rv []string
+	if o.maxEntries > 0 {
+		rv = append(rv, fmt.Sprintf("set acl-plugin session table max-entries %d", o.maxEntries))
+	}
+	if o.hashTableBuckets > 0 {
+		rv = append(rv, fmt.Sprintf("set acl-plugin session table hash-table-buckets %d", o.hashTableBuckets))
+	}
+	if o.hashTableMemory > 0 {
+		rv = append(rv, fmt.Sprintf("set acl-plugin session table hash-table-memory %d", o.hashTableMemory))
+	}
+	if o.udpIdleTimeout > 0 {
+		rv = append(rv, fmt.Sprintf("set acl-plugin session timeout udp idle %d", seconds(o.udpIdleTimeout)))
+	}
+	if o.tcpIdleTimeout > 0 {
+		rv = append(rv, fmt.Sprintf("set acl-plugin session timeout tcp idle %d", seconds(o.tcpIdleTimeout)))
+	}
+	if o.tcpTransientTimeout > 0 {
+		rv = append(rv, fmt.Sprintf("set acl-plugin session timeout tcp transient %d", seconds(o.tcpTransientTimeout)))
+	}
+	return rv
+}
+
+func seconds(d time.Duration) uint64 {
+	if d < time.Second {
+		return 1
+	}
+	return uint64(d / time.Second)
+}
+
+func apply(ctx context.Context, vppConn api.Connection, commands []string) error {
+	for _, cmd := range commands {
+		now := time.Now()
+		reply, err := vlib.NewServiceClient(vppConn).CliInband(ctx, &vlib.CliInband{
+			Cmd: cmd,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "vpp error running %q", cmd)
+		}
+		// The cli commands report the errors in the reply
+		if msg := strings.TrimSpace(reply.Reply); msg != "" {
+			return errors.Errorf("vpp error running %q: %s", cmd, msg)
+		}
+		log.FromContext(ctx).
+			WithField("cmd", cmd).
+			WithField("duration", time.Since(now)).
+			WithField("vppapi", "CliInband").Debug("completed")
+	}
+
+	now := time.Now()
+	reply, err := acl.NewServiceClient(vppConn).ACLPluginGetConnTableMaxEntries(ctx, &acl.ACLPluginGetConnTableMaxEntries{})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("connTableMaxEntries", reply.ConnTableMaxEntries).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "ACLPluginGetConnTableMaxEntries").Info("completed")
+	return nil
+}
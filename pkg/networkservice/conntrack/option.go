@@ -0,0 +1,75 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conntrack
+
+import (
+	"time"
+)
+
+type options struct {
+	maxEntries          uint64
+	hashTableBuckets    uint32
+	hashTableMemory     uint64
+	udpIdleTimeout      time.Duration
+	tcpIdleTimeout      time.Duration
+	tcpTransientTimeout time.Duration
+}
+
+// Option is an option pattern for conntrack server
+type Option func(o *options)
+
+// WithMaxEntries sets the max number of the sessions in the session table
+func WithMaxEntries(maxEntries uint64) Option {
+	return func(o *options) {
+		o.maxEntries = maxEntries
+	}
+}
+
+// WithHashTableBuckets sets the number of the session hash table buckets
+func WithHashTableBuckets(buckets uint32) Option {
+	return func(o *options) {
+		o.hashTableBuckets = buckets
+	}
+}
+
+// WithHashTableMemory sets the memory size of the session hash table in bytes
+func WithHashTableMemory(memory uint64) Option {
+	return func(o *options) {
+		o.hashTableMemory = memory
+	}
+}
+
+// WithUDPIdleTimeout sets the idle timeout of the UDP (and other non-TCP) sessions
+func WithUDPIdleTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.udpIdleTimeout = timeout
+	}
+}
+
+// WithTCPIdleTimeout sets the idle timeout of the established TCP sessions
+func WithTCPIdleTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.tcpIdleTimeout = timeout
+	}
+}
+
+// WithTCPTransientTimeout sets the timeout of the TCP sessions being established or closed
+func WithTCPTransientTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.tcpTransientTimeout = timeout
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conntrack
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+)
+
+type conntrackServer struct {
+	vppConn  api.Connection
+	commands []string
+
+	inited    uint32
+	initMutex sync.Mutex
+}
+
+// NewServer - returns a new server chain element applying the connection tracking tuning once per forwarder.
+// The tuning is applied before the first Request goes further, so the session table is sized before the first ACL
+// gets applied to an interface
+func NewServer(vppConn api.Connection, opts ...Option) networkservice.NetworkServiceServer {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return &conntrackServer{
+		vppConn:  vppConn,
+		commands: o.commands(),
+	}
+}
+
+func (c *conntrackServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	if err := c.init(ctx); err != nil {
+		return nil, err
+	}
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (c *conntrackServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func (c *conntrackServer) init(ctx context.Context) error {
+	if atomic.LoadUint32(&c.inited) > 0 {
+		return nil
+	}
+	c.initMutex.Lock()
+	defer c.initMutex.Unlock()
+	if atomic.LoadUint32(&c.inited) > 0 {
+		return nil
+	}
+
+	err := apply(ctx, c.vppConn, c.commands)
+	if err == nil {
+		atomic.StoreUint32(&c.inited, 1)
+	}
+	return err
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package description
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	interfaces "github.com/edwarnicke/govpp/binapi/interface"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	kernellink "github.com/networkservicemesh/sdk-kernel/pkg/kernel"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/mechutils"
+)
+
+const (
+	// maxNameLen - vpp interface name is string[64] including the trailing zero
+	maxNameLen = 63
+	shortIDLen = 8
+)
+
+func create(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, o *options, isClient bool) error {
+	swIfIndex, ok := ifindex.Load(ctx, isClient)
+	if !ok {
+		return nil
+	}
+	description := describe(conn, o)
+	if prev, ok := load(ctx, isClient); ok && prev == description {
+		return nil
+	}
+
+	name := vppName(conn, o, isClient)
+	now := time.Now()
+	if _, err := interfaces.NewServiceClient(vppConn).SwInterfaceSetInterfaceName(ctx, &interfaces.SwInterfaceSetInterfaceName{
+		SwIfIndex: swIfIndex,
+		Name:      name,
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", swIfIndex).
+		WithField("name", name).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "SwInterfaceSetInterfaceName").Debug("completed")
+
+	if mechanism := kernel.ToMechanism(conn.GetMechanism()); mechanism != nil && mechanism.GetVLAN() == 0 {
+		handle, err := kernellink.GetNetlinkHandle(mechanism.GetNetNSURL())
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		defer handle.Close()
+
+		l, err := handle.LinkByName(mechanism.GetInterfaceName())
+		if err != nil {
+			return errors.Wrapf(err, "unable to find link %s", mechanism.GetInterfaceName())
+		}
+
+		// The alias keeps the connection id set by the kernel mechanism in front
+		alias := mechutils.ToAlias(conn, isClient)
+		if description != "" {
+			alias = fmt.Sprintf("%s %s", alias, description)
+		}
+		now = time.Now()
+		if err := handle.LinkSetAlias(l, alias); err != nil {
+			return errors.WithStack(err)
+		}
+		log.FromContext(ctx).
+			WithField("link.Name", l.Attrs().Name).
+			WithField("alias", alias).
+			WithField("duration", time.Since(now)).
+			WithField("netlink", "LinkSetAlias").Debug("completed")
+	}
+
+	store(ctx, isClient, description)
+	return nil
+}
+
+// describe returns the description of the connection, e.g. "ns=my-service pod=nsc-7d4b namespace=default"
+func describe(conn *networkservice.Connection, o *options) string {
+	var fields []string
+	for _, field := range [][2]string{
+		{"ns", conn.GetNetworkService()},
+		{"pod", conn.GetLabels()[o.podLabel]},
+		{"namespace", conn.GetLabels()[o.namespaceLabel]},
+	} {
+		if field[1] != "" {
+			fields = append(fields, fmt.Sprintf("%s=%s", field[0], field[1]))
+		}
+	}
+	return strings.Join(fields, " ")
+}
+
+// vppName returns the unique vpp interface name, e.g. "my-service/default/nsc-7d4b/server-1a2b3c4d". Both the server
+// and the client interfaces of the forwarder have the same connection id, so the side is a part of the name.
+func vppName(conn *networkservice.Connection, o *options, isClient bool) string {
+	side := "server"
+	if isClient {
+		side = "client"
+	}
+	id := conn.GetId()
+	if len(id) > shortIDLen {
+		id = id[:shortIDLen]
+	}
+	suffix := fmt.Sprintf("%s-%s", side, id)
+
+	var parts []string
+	for _, part := range []string{conn.GetNetworkService(), conn.GetLabels()[o.namespaceLabel], conn.GetLabels()[o.podLabel]} {
+		if part != "" {
+			parts = append(parts, strings.ReplaceAll(part, " ", "_"))
+		}
+	}
+	prefix := strings.Join(parts, "/")
+	if len(prefix) > maxNameLen-len(suffix)-1 {
+		prefix = prefix[:maxNameLen-len(suffix)-1]
+	}
+	if prefix == "" {
+		return suffix
+	}
+	return fmt.Sprintf("%s/%s", prefix, suffix)
+}
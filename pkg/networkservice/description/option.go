@@ -0,0 +1,59 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package description
+
+const (
+	// defaultPodLabel - the label set by the clientinfo of the NSC
+	defaultPodLabel       = "podName"
+	defaultNamespaceLabel = "namespace"
+)
+
+type options struct {
+	podLabel       string
+	namespaceLabel string
+}
+
+// Option is an option pattern for description client/server
+type Option func(o *options)
+
+// WithPodLabel sets the connection label holding the name of the client pod. Default: "podName"
+func WithPodLabel(podLabel string) Option {
+	return func(o *options) {
+		o.podLabel = podLabel
+	}
+}
+
+// WithNamespaceLabel sets the connection label holding the namespace of the client pod. Default: "namespace"
+func WithNamespaceLabel(namespaceLabel string) Option {
+	return func(o *options) {
+		o.namespaceLabel = namespaceLabel
+	}
+}
+
+func newOptions(opts ...Option) *options {
+	o := &options{
+		podLabel:       defaultPodLabel,
+		namespaceLabel: defaultNamespaceLabel,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
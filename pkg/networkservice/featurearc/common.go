@@ -0,0 +1,102 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package featurearc
+
+import (
+	"context"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/edwarnicke/govpp/binapi/feature"
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+)
+
+func enable(ctx context.Context, vppConn api.Connection, isClient bool) error {
+	swIfIndex, ok := ifindex.Load(ctx, isClient)
+	if !ok {
+		return nil
+	}
+	registered, _ := Load(ctx, isClient)
+	enabled, _ := loadEnabled(ctx, isClient)
+
+	// Disable the features unregistered since the last Request
+	var rv []Feature
+	for _, f := range enabled {
+		if contains(registered, f) {
+			rv = append(rv, f)
+			continue
+		}
+		if err := enableDisable(ctx, vppConn, swIfIndex, f, false); err != nil {
+			storeEnabled(ctx, isClient, append(rv, f))
+			return err
+		}
+	}
+
+	for _, f := range registered {
+		if contains(rv, f) {
+			continue
+		}
+		if err := enableDisable(ctx, vppConn, swIfIndex, f, true); err != nil {
+			storeEnabled(ctx, isClient, rv)
+			return err
+		}
+		rv = append(rv, f)
+	}
+	storeEnabled(ctx, isClient, rv)
+	return nil
+}
+
+func disable(ctx context.Context, vppConn api.Connection, isClient bool) {
+	enabled, ok := loadAndDeleteEnabled(ctx, isClient)
+	if !ok {
+		return
+	}
+	swIfIndex, ok := ifindex.Load(ctx, isClient)
+	if !ok {
+		return
+	}
+	for _, f := range enabled {
+		if err := enableDisable(ctx, vppConn, swIfIndex, f, false); err != nil {
+			log.FromContext(ctx).Errorf("unable to disable feature %s on arc %s: %v", f.FeatureName, f.ArcName, err)
+		}
+	}
+}
+
+func enableDisable(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, f Feature, isEnable bool) error {
+	now := time.Now()
+	if _, err := feature.NewServiceClient(vppConn).FeatureEnableDisable(ctx, &feature.FeatureEnableDisable{
+		SwIfIndex:   swIfIndex,
+		Enable:      isEnable,
+		ArcName:     f.ArcName,
+		FeatureName: f.FeatureName,
+	}); err != nil {
+		return errors.Wrapf(err, "failed to set feature %s on arc %s to %t", f.FeatureName, f.ArcName, isEnable)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", swIfIndex).
+		WithField("arcName", f.ArcName).
+		WithField("featureName", f.FeatureName).
+		WithField("enable", isEnable).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "FeatureEnableDisable").Debug("completed")
+	return nil
+}
@@ -0,0 +1,30 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package featurearc provides chain elements for enabling vpp features on the input/output arcs of the
+// connection's interface.
+//
+// Third-party chain elements register the features they need using Register before the featurearc element
+// processes the Request (i.e. either before calling next or by being placed after featurearc in the chain):
+//
+//	featurearc.Register(ctx, metadata.IsClient(c), featurearc.Feature{
+//		ArcName:     "ip4-unicast",
+//		FeatureName: "my-classifier",
+//	})
+//
+// featurearc enables all registered features on the swIfIndex of the connection once it is created and disables
+// them on Close.
+package featurearc
@@ -0,0 +1,111 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package featurearc
+
+import (
+	"context"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+type registeredKey struct{}
+
+type enabledKey struct{}
+
+// Feature - vpp feature on the feature arc
+type Feature struct {
+	// ArcName - name of the feature arc (e.g. "ip4-unicast", "ip6-output", "device-input")
+	ArcName string
+	// FeatureName - name of the vpp graph node implementing the feature
+	FeatureName string
+}
+
+// Register adds the features to the list of features stored in per Connection.Id metadata.
+// Registering the same feature twice has no effect.
+func Register(ctx context.Context, isClient bool, features ...Feature) {
+	registered, _ := Load(ctx, isClient)
+	for _, feature := range features {
+		if !contains(registered, feature) {
+			registered = append(registered, feature)
+		}
+	}
+	metadata.Map(ctx, isClient).Store(registeredKey{}, registered)
+}
+
+// Unregister removes the features from the list of features stored in per Connection.Id metadata.
+// Features already enabled on the interface are disabled on the next Request of the connection.
+func Unregister(ctx context.Context, isClient bool, features ...Feature) {
+	registered, ok := Load(ctx, isClient)
+	if !ok {
+		return
+	}
+	var rv []Feature
+	for _, feature := range registered {
+		if !contains(features, feature) {
+			rv = append(rv, feature)
+		}
+	}
+	metadata.Map(ctx, isClient).Store(registeredKey{}, rv)
+}
+
+// Load returns the list of features stored in per Connection.Id metadata, or nil if no
+// value is present.
+// The ok result indicates whether value was found in the per Connection.Id metadata.
+func Load(ctx context.Context, isClient bool) (value []Feature, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).Load(registeredKey{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.([]Feature)
+	return value, ok
+}
+
+// Delete deletes the list of features stored in per Connection.Id metadata
+func Delete(ctx context.Context, isClient bool) {
+	metadata.Map(ctx, isClient).Delete(registeredKey{})
+}
+
+func storeEnabled(ctx context.Context, isClient bool, features []Feature) {
+	metadata.Map(ctx, isClient).Store(enabledKey{}, features)
+}
+
+func loadEnabled(ctx context.Context, isClient bool) (value []Feature, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).Load(enabledKey{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.([]Feature)
+	return value, ok
+}
+
+func loadAndDeleteEnabled(ctx context.Context, isClient bool) (value []Feature, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).LoadAndDelete(enabledKey{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.([]Feature)
+	return value, ok
+}
+
+func contains(features []Feature, feature Feature) bool {
+	for _, f := range features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
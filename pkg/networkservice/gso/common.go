@@ -0,0 +1,94 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gso
+
+import (
+	"context"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	gsoapi "github.com/edwarnicke/govpp/binapi/gso"
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+)
+
+// enableSegmentation enables the gso segmentation on the side of the connection which is not a kernel tap if the
+// opposite side is a kernel tap
+func enableSegmentation(ctx context.Context, vppConn api.Connection) error {
+	serverMechanism, ok := loadMechanism(ctx, false)
+	if !ok {
+		return nil
+	}
+	clientMechanism, ok := loadMechanism(ctx, true)
+	if !ok {
+		return nil
+	}
+	for _, isClient := range []bool{false, true} {
+		mechanism, peerMechanism := serverMechanism, clientMechanism
+		if isClient {
+			mechanism, peerMechanism = clientMechanism, serverMechanism
+		}
+		if mechanism == kernel.MECHANISM || peerMechanism != kernel.MECHANISM {
+			continue
+		}
+		swIfIndex, ok := ifindex.Load(ctx, isClient)
+		if !ok {
+			continue
+		}
+		if enabled, ok := loadSegmentation(ctx, isClient); ok && enabled == swIfIndex {
+			continue
+		}
+		if err := enableDisable(ctx, vppConn, swIfIndex, true); err != nil {
+			return err
+		}
+		storeSegmentation(ctx, isClient, swIfIndex)
+	}
+	return nil
+}
+
+func disableSegmentation(ctx context.Context, vppConn api.Connection) {
+	for _, isClient := range []bool{false, true} {
+		swIfIndex, ok := loadAndDeleteSegmentation(ctx, isClient)
+		if !ok {
+			continue
+		}
+		if err := enableDisable(ctx, vppConn, swIfIndex, false); err != nil {
+			log.FromContext(ctx).Errorf("unable to disable gso segmentation: %v", err)
+		}
+	}
+}
+
+func enableDisable(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, isEnable bool) error {
+	now := time.Now()
+	if _, err := gsoapi.NewServiceClient(vppConn).FeatureGsoEnableDisable(ctx, &gsoapi.FeatureGsoEnableDisable{
+		SwIfIndex:     swIfIndex,
+		EnableDisable: isEnable,
+	}); err != nil {
+		return errors.Wrapf(err, "failed to set gso segmentation on swIfIndex %v to %t", swIfIndex, isEnable)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", swIfIndex).
+		WithField("enableDisable", isEnable).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "FeatureGsoEnableDisable").Debug("completed")
+	return nil
+}
@@ -0,0 +1,24 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gso provides chain elements enabling GSO (generic segmentation offload) coherently across the
+// interfaces of a connection.
+//
+// Kernel taps of the connection are created with GSO and checksum offload (see IsEnabled), so the kernel is able to
+// send and receive packets larger than the MTU. Interfaces which can't handle such packets (memif, tunnels, vlan
+// sub-interfaces) get the vpp gso segmentation feature enabled on their output, so oversized packets coming from
+// the tap are segmented in vpp instead of being dropped.
+package gso
@@ -0,0 +1,86 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gso
+
+import (
+	"context"
+
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+type enabledKey struct{}
+
+type mechanismKey struct{}
+
+type segmentationKey struct{}
+
+// IsEnabled returns true if GSO is enabled for the connection by the gso chain element
+func IsEnabled(ctx context.Context, isClient bool) bool {
+	_, ok := metadata.Map(ctx, isClient).Load(enabledKey{})
+	return ok
+}
+
+// IsGROEnabled returns true if GRO coalescing is enabled for the connection by the gso chain element
+func IsGROEnabled(ctx context.Context, isClient bool) bool {
+	rawValue, ok := metadata.Map(ctx, isClient).Load(enabledKey{})
+	if !ok {
+		return false
+	}
+	gro, _ := rawValue.(bool)
+	return gro
+}
+
+func storeEnabled(ctx context.Context, isClient, gro bool) {
+	metadata.Map(ctx, isClient).Store(enabledKey{}, gro)
+}
+
+func storeMechanism(ctx context.Context, isClient bool, mechanismType string) {
+	metadata.Map(ctx, isClient).Store(mechanismKey{}, mechanismType)
+}
+
+func loadMechanism(ctx context.Context, isClient bool) (value string, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).Load(mechanismKey{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(string)
+	return value, ok
+}
+
+func storeSegmentation(ctx context.Context, isClient bool, swIfIndex interface_types.InterfaceIndex) {
+	metadata.Map(ctx, isClient).Store(segmentationKey{}, swIfIndex)
+}
+
+func loadAndDeleteSegmentation(ctx context.Context, isClient bool) (value interface_types.InterfaceIndex, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).LoadAndDelete(segmentationKey{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(interface_types.InterfaceIndex)
+	return value, ok
+}
+
+func loadSegmentation(ctx context.Context, isClient bool) (value interface_types.InterfaceIndex, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).Load(segmentationKey{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(interface_types.InterfaceIndex)
+	return value, ok
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gso
+
+import (
+	"context"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+)
+
+type gsoServer struct {
+	vppConn api.Connection
+	gro     bool
+}
+
+// NewServer returns a Server chain element that enables GSO on the kernel taps of the connection and the gso
+// segmentation on the interfaces which can't handle GSO packets
+func NewServer(vppConn api.Connection, opts ...Option) networkservice.NetworkServiceServer {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return &gsoServer{
+		vppConn: vppConn,
+		gro:     o.gro,
+	}
+}
+
+func (g *gsoServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	// Both the server and the client side taps are created in the chain after gso
+	storeEnabled(ctx, false, g.gro)
+	storeEnabled(ctx, true, g.gro)
+
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	storeMechanism(ctx, false, conn.GetMechanism().GetType())
+
+	if err := enableSegmentation(ctx, g.vppConn); err != nil {
+		closeCtx, cancelClose := postponeCtxFunc()
+		defer cancelClose()
+
+		if _, closeErr := g.Close(closeCtx, conn); closeErr != nil {
+			err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+		}
+
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (g *gsoServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	disableSegmentation(ctx, g.vppConn)
+	return next.Server(ctx).Close(ctx, conn)
+}
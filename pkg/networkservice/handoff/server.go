@@ -0,0 +1,95 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handoff
+
+import (
+	"context"
+
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/tunnelip"
+)
+
+type handoffServer struct {
+	store *Store
+}
+
+// NewServer returns a server chain element restoring the state handed over by the previous forwarder into the
+// metadata (of both the server and the client sides) on the first Request of the connection, and keeping the state
+// of the served connections in the store.
+// It must precede the mechanism chain elements, so they find the restored interfaces and skip creating them.
+// Note: the cleanup client closes all the connections on shutdown, so it should be disabled when handing off.
+func NewServer(store *Store) networkservice.NetworkServiceServer {
+	return &handoffServer{
+		store: store,
+	}
+}
+
+func (h *handoffServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	if state, ok := h.store.takeRestored(request.GetConnection().GetId()); ok {
+		restore(ctx, state.Server, false)
+		restore(ctx, state.Client, true)
+		log.FromContext(ctx).WithField("handoff", "server").Info("restored the handed over state")
+	}
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	h.store.store(conn.GetId(), &State{
+		Server: capture(ctx, false),
+		Client: capture(ctx, true),
+	})
+	return conn, nil
+}
+
+func (h *handoffServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	h.store.delete(conn.GetId())
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func restore(ctx context.Context, side *Side, isClient bool) {
+	if side == nil {
+		return
+	}
+	if _, ok := ifindex.Load(ctx, isClient); !ok && side.SwIfIndex != 0 {
+		ifindex.Store(ctx, isClient, interface_types.InterfaceIndex(side.SwIfIndex))
+	}
+	if _, ok := tunnelip.Load(ctx, isClient); !ok && side.TunnelIP != nil {
+		tunnelip.Store(ctx, isClient, side.TunnelIP)
+	}
+}
+
+func capture(ctx context.Context, isClient bool) *Side {
+	side := &Side{}
+	if swIfIndex, ok := ifindex.Load(ctx, isClient); ok {
+		side.SwIfIndex = uint32(swIfIndex)
+	}
+	if ip, ok := tunnelip.Load(ctx, isClient); ok {
+		side.TunnelIP = ip
+	}
+	if side.SwIfIndex == 0 && side.TunnelIP == nil {
+		return nil
+	}
+	return side
+}
@@ -0,0 +1,227 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handoff
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	interfaces "github.com/edwarnicke/govpp/binapi/interface"
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// Side is the handed over state of one side (server or client) of the connection
+type Side struct {
+	SwIfIndex     uint32 `json:"swIfIndex,omitempty"`
+	InterfaceName string `json:"interfaceName,omitempty"`
+	TunnelIP      net.IP `json:"tunnelIP,omitempty"`
+}
+
+// State is the handed over state of the connection
+type State struct {
+	Server *Side `json:"server,omitempty"`
+	Client *Side `json:"client,omitempty"`
+}
+
+// Store keeps the state of the connections served by the forwarder and the state restored from the previous one
+type Store struct {
+	filename string
+	vppConn  api.Connection
+
+	mu       sync.Mutex
+	states   map[string]*State
+	restored map[string]*State
+}
+
+// NewStore returns a store loading the state left by the previous forwarder from the file. The loaded state is
+// verified against vpp: the sides whose interfaces are gone (or were replaced by another interface with the same
+// swIfIndex) are dropped. The state is saved back into the file when ctx is done.
+func NewStore(ctx context.Context, filename string, vppConn api.Connection) *Store {
+	s := &Store{
+		filename: filename,
+		vppConn:  vppConn,
+		states:   make(map[string]*State),
+		restored: make(map[string]*State),
+	}
+	if err := s.load(ctx); err != nil {
+		log.FromContext(ctx).Warnf("failed to load the handoff state from %s: %s", filename, err.Error())
+	}
+	go func() {
+		<-ctx.Done()
+		// ctx is already done, so vpp is queried with a fresh one
+		saveCtx, cancel := context.WithTimeout(log.WithLog(context.Background(), log.FromContext(ctx)), time.Second*5)
+		defer cancel()
+		if err := s.Save(saveCtx); err != nil {
+			log.FromContext(ctx).Errorf("failed to save the handoff state to %s: %s", filename, err.Error())
+		}
+	}()
+	return s
+}
+
+// Save writes the state of the connections into the file
+func (s *Store) Save(ctx context.Context) error {
+	names, err := interfaceNames(ctx, s.vppConn)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	// The restored state not re-requested yet is handed over again
+	states := make(map[string]*State, len(s.states)+len(s.restored))
+	for id, state := range s.restored {
+		states[id] = state
+	}
+	for id, state := range s.states {
+		for _, side := range []*Side{state.Server, state.Client} {
+			if side != nil && side.SwIfIndex != 0 {
+				side.InterfaceName = names[interface_types.InterfaceIndex(side.SwIfIndex)]
+			}
+		}
+		states[id] = state
+	}
+	s.mu.Unlock()
+
+	data, err := json.Marshal(states)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	// The file is replaced atomically, so a forwarder killed in the middle never leaves a truncated state behind
+	tmp, err := os.CreateTemp(filepath.Dir(s.filename), filepath.Base(s.filename)+".*")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err = tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return errors.WithStack(err)
+	}
+	if err = tmp.Close(); err != nil {
+		return errors.WithStack(err)
+	}
+	if err = os.Rename(tmp.Name(), s.filename); err != nil {
+		return errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("filename", s.filename).
+		WithField("connections", len(states)).
+		Info("saved the handoff state")
+	return nil
+}
+
+func (s *Store) load(ctx context.Context) error {
+	data, err := os.ReadFile(s.filename)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	states := make(map[string]*State)
+	if err = json.Unmarshal(data, &states); err != nil {
+		return errors.WithStack(err)
+	}
+	names, err := interfaceNames(ctx, s.vppConn)
+	if err != nil {
+		return err
+	}
+
+	for id, state := range states {
+		state.Server = verify(state.Server, names)
+		state.Client = verify(state.Client, names)
+		if state.Server == nil && state.Client == nil {
+			continue
+		}
+		s.restored[id] = state
+	}
+	log.FromContext(ctx).
+		WithField("filename", s.filename).
+		WithField("connections", len(states)).
+		WithField("restored", len(s.restored)).
+		Info("loaded the handoff state")
+	return nil
+}
+
+func (s *Store) store(id string, state *State) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.states[id] = state
+}
+
+func (s *Store) delete(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.states, id)
+	delete(s.restored, id)
+}
+
+// takeRestored returns the restored state of the connection only once: the following Requests refresh the state
+// already held by the metadata
+func (s *Store) takeRestored(id string) (*State, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state, ok := s.restored[id]
+	delete(s.restored, id)
+	return state, ok
+}
+
+func verify(side *Side, names map[interface_types.InterfaceIndex]string) *Side {
+	if side == nil || side.SwIfIndex == 0 {
+		return side
+	}
+	if name, ok := names[interface_types.InterfaceIndex(side.SwIfIndex)]; !ok || name != side.InterfaceName {
+		return nil
+	}
+	return side
+}
+
+func interfaceNames(ctx context.Context, vppConn api.Connection) (map[interface_types.InterfaceIndex]string, error) {
+	now := time.Now()
+	client, err := interfaces.NewServiceClient(vppConn).SwInterfaceDump(ctx, &interfaces.SwInterfaceDump{
+		SwIfIndex: ^interface_types.InterfaceIndex(0),
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer func() { _ = client.Close() }()
+
+	names := make(map[interface_types.InterfaceIndex]string)
+	for {
+		details, err := client.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		names[details.SwIfIndex] = details.InterfaceName
+	}
+	log.FromContext(ctx).
+		WithField("interfaces", len(names)).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "SwInterfaceDump").Debug("completed")
+	return names, nil
+}
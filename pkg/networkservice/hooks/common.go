@@ -0,0 +1,89 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hooks
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+)
+
+// emitInterface emits InterfaceCreated and TunnelUp for the interface of the connection side created (or re-created)
+// since the previous Request
+func emitInterface(ctx context.Context, registry *Registry, conn *networkservice.Connection, isClient bool) {
+	swIfIndex, ok := ifindex.Load(ctx, isClient)
+	if !ok {
+		return
+	}
+	e := load(ctx, isClient)
+	if e.swIfIndex != swIfIndex {
+		e.swIfIndex, e.tunnelUp = swIfIndex, false
+		registry.emit(ctx, &InterfaceCreated{
+			Conn:      conn.Clone(),
+			IsClient:  isClient,
+			SwIfIndex: swIfIndex,
+		})
+	}
+	// The chain has returned, so the up element has waited for the tunnel to be up
+	if !e.tunnelUp && conn.GetMechanism().GetCls() == cls.REMOTE {
+		e.tunnelUp = true
+		registry.emit(ctx, &TunnelUp{
+			Conn:      conn.Clone(),
+			IsClient:  isClient,
+			SwIfIndex: swIfIndex,
+			Mechanism: conn.GetMechanism().GetType(),
+		})
+	}
+}
+
+// emitRoutes emits RoutesProgrammed if the routes of the connection have changed since the previous Request
+func emitRoutes(ctx context.Context, registry *Registry, conn *networkservice.Connection, isClient bool) {
+	ipContext := conn.GetContext().GetIpContext()
+	if len(ipContext.GetSrcRoutes()) == 0 && len(ipContext.GetDstRoutes()) == 0 {
+		return
+	}
+	e := load(ctx, isClient)
+	if e.hasRoutes && routesEqual(e.srcRoutes, ipContext.GetSrcRoutes()) && routesEqual(e.dstRoutes, ipContext.GetDstRoutes()) {
+		return
+	}
+	conn = conn.Clone()
+	e.srcRoutes = conn.GetContext().GetIpContext().GetSrcRoutes()
+	e.dstRoutes = conn.GetContext().GetIpContext().GetDstRoutes()
+	e.hasRoutes = true
+	registry.emit(ctx, &RoutesProgrammed{
+		Conn:      conn,
+		SrcRoutes: e.srcRoutes,
+		DstRoutes: e.dstRoutes,
+	})
+}
+
+func routesEqual(a, b []*networkservice.Route) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !proto.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hooks
+
+import (
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+// Event - the dataplane event of the connection
+type Event interface {
+	// Connection returns the connection of the event
+	Connection() *networkservice.Connection
+}
+
+// InterfaceCreated - the vpp interface of the connection side has been created
+type InterfaceCreated struct {
+	Conn      *networkservice.Connection
+	IsClient  bool
+	SwIfIndex interface_types.InterfaceIndex
+}
+
+// Connection returns the connection of the event
+func (e *InterfaceCreated) Connection() *networkservice.Connection { return e.Conn }
+
+// TunnelUp - the tunnel (the interface of the remote mechanism) of the connection side is up
+type TunnelUp struct {
+	Conn      *networkservice.Connection
+	IsClient  bool
+	SwIfIndex interface_types.InterfaceIndex
+	Mechanism string
+}
+
+// Connection returns the connection of the event
+func (e *TunnelUp) Connection() *networkservice.Connection { return e.Conn }
+
+// RoutesProgrammed - the routes of the connection have been programmed or changed
+type RoutesProgrammed struct {
+	Conn      *networkservice.Connection
+	SrcRoutes []*networkservice.Route
+	DstRoutes []*networkservice.Route
+}
+
+// Connection returns the connection of the event
+func (e *RoutesProgrammed) Connection() *networkservice.Connection { return e.Conn }
+
+// Closed - the connection has been closed
+type Closed struct {
+	Conn *networkservice.Connection
+}
+
+// Connection returns the connection of the event
+func (e *Closed) Connection() *networkservice.Connection { return e.Conn }
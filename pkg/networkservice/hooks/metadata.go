@@ -0,0 +1,46 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hooks
+
+import (
+	"context"
+
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+type key struct{}
+
+// emitted - the state the events have been emitted for
+type emitted struct {
+	swIfIndex interface_types.InterfaceIndex
+	tunnelUp  bool
+	srcRoutes []*networkservice.Route
+	dstRoutes []*networkservice.Route
+	hasRoutes bool
+}
+
+func load(ctx context.Context, isClient bool) *emitted {
+	rawValue, _ := metadata.Map(ctx, isClient).LoadOrStore(key{}, new(emitted))
+	return rawValue.(*emitted)
+}
+
+func del(ctx context.Context, isClient bool) {
+	metadata.Map(ctx, isClient).Delete(key{})
+}
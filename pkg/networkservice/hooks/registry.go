@@ -0,0 +1,74 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hooks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// Hook - the callback receiving the events, called synchronously from the chain so it must not block
+type Hook func(ctx context.Context, event Event)
+
+// Registry - the registry of the hooks
+type Registry struct {
+	hooks  map[int]Hook
+	nextID int
+	mutex  sync.RWMutex
+}
+
+// NewRegistry returns a new empty Registry
+func NewRegistry() *Registry {
+	return &Registry{
+		hooks: make(map[int]Hook),
+	}
+}
+
+// Register registers the hook, the returned function unregisters it
+func (r *Registry) Register(hook Hook) (unregister func()) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	id := r.nextID
+	r.nextID++
+	r.hooks[id] = hook
+	return func() {
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+
+		delete(r.hooks, id)
+	}
+}
+
+func (r *Registry) emit(ctx context.Context, event Event) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	for _, hook := range r.hooks {
+		func() {
+			// The failing hook must not break the chain
+			defer func() {
+				if p := recover(); p != nil {
+					log.FromContext(ctx).Errorf("hook panicked on %T: %v", event, p)
+				}
+			}()
+			hook(ctx, event)
+		}()
+	}
+}
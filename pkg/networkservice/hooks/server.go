@@ -0,0 +1,56 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hooks
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+type hooksServer struct {
+	registry *Registry
+}
+
+// NewServer returns a server chain element emitting the events of the server side of the connection, the routes and
+// Closed to the registry hooks
+func NewServer(registry *Registry) networkservice.NetworkServiceServer {
+	return &hooksServer{
+		registry: registry,
+	}
+}
+
+func (h *hooksServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	emitInterface(ctx, h.registry, conn, metadata.IsClient(h))
+	emitRoutes(ctx, h.registry, conn, metadata.IsClient(h))
+	return conn, nil
+}
+
+func (h *hooksServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	rv, err := next.Server(ctx).Close(ctx, conn)
+	del(ctx, metadata.IsClient(h))
+	h.registry.emit(ctx, &Closed{Conn: conn.Clone()})
+	return rv, err
+}
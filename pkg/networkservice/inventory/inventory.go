@@ -0,0 +1,141 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inventory
+
+import (
+	"context"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/vrf"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/tunnelip"
+)
+
+// Objects - the vpp objects created for a side of the connection
+type Objects struct {
+	SwIfIndex   interface_types.InterfaceIndex
+	TunnelIP    net.IP
+	IPv4TableID uint32
+	IPv6TableID uint32
+}
+
+// Entry - the connection and the vpp objects created for it
+type Entry struct {
+	ID             string
+	NetworkService string
+	Labels         map[string]string
+	Mechanism      string
+	// Server, Client - the objects of the server and the client sides, nil if there are none
+	Server  *Objects
+	Client  *Objects
+	Updated time.Time
+}
+
+// Filter - the filter of the entries, the empty fields match any entry
+type Filter struct {
+	NetworkService string
+	// Labels - the entry matches if it has all the labels
+	Labels map[string]string
+}
+
+func (f *Filter) match(e *Entry) bool {
+	if f.NetworkService != "" && f.NetworkService != e.NetworkService {
+		return false
+	}
+	for k, v := range f.Labels {
+		if e.Labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// Inventory - the inventory of the connections, filled by the inventory chain element
+type Inventory struct {
+	entries map[string]*Entry
+	mutex   sync.RWMutex
+}
+
+// New returns a new empty Inventory
+func New() *Inventory {
+	return &Inventory{
+		entries: make(map[string]*Entry),
+	}
+}
+
+// List returns the entries matching the filter sorted by the connection id
+func (i *Inventory) List(filter *Filter) []*Entry {
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+
+	var rv []*Entry
+	for _, e := range i.entries {
+		if filter == nil || filter.match(e) {
+			rv = append(rv, e)
+		}
+	}
+	sort.Slice(rv, func(a, b int) bool { return rv[a].ID < rv[b].ID })
+	return rv
+}
+
+func (i *Inventory) store(ctx context.Context, conn *networkservice.Connection) {
+	e := &Entry{
+		ID:             conn.GetId(),
+		NetworkService: conn.GetNetworkService(),
+		Labels:         make(map[string]string),
+		Mechanism:      conn.GetMechanism().GetType(),
+		Server:         loadObjects(ctx, false),
+		Client:         loadObjects(ctx, true),
+		Updated:        time.Now(),
+	}
+	for k, v := range conn.GetLabels() {
+		e.Labels[k] = v
+	}
+
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	i.entries[e.ID] = e
+}
+
+func (i *Inventory) delete(connID string) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	delete(i.entries, connID)
+}
+
+func loadObjects(ctx context.Context, isClient bool) *Objects {
+	swIfIndex, ok := ifindex.Load(ctx, isClient)
+	if !ok {
+		return nil
+	}
+	o := &Objects{
+		SwIfIndex: swIfIndex,
+	}
+	o.TunnelIP, _ = tunnelip.Load(ctx, isClient)
+	o.IPv4TableID, _ = vrf.Load(ctx, isClient, false)
+	o.IPv6TableID, _ = vrf.Load(ctx, isClient, true)
+	return o
+}
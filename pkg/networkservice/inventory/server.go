@@ -0,0 +1,52 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inventory
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+)
+
+type inventoryServer struct {
+	inventory *Inventory
+}
+
+// NewServer returns a server chain element storing the connection and its vpp objects (of both the server and the
+// client sides) into the inventory on Request and deleting them on Close
+func NewServer(inventory *Inventory) networkservice.NetworkServiceServer {
+	return &inventoryServer{
+		inventory: inventory,
+	}
+}
+
+func (i *inventoryServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	i.inventory.store(ctx, conn)
+	return conn, nil
+}
+
+func (i *inventoryServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	i.inventory.delete(conn.GetId())
+	return next.Server(ctx).Close(ctx, conn)
+}
@@ -0,0 +1,170 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inventory
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	interfaces "github.com/edwarnicke/govpp/binapi/interface"
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const serviceName = "sdkvpp.inventory.Inventory"
+
+type service struct {
+	inventory *Inventory
+	vppConn   api.Connection
+}
+
+// Register registers the inventory gRPC service on s. The service has the unary method
+// /sdkvpp.inventory.Inventory/List taking the google.protobuf.Struct filter
+//
+//	{"network_service": "...", "labels": {"...": "..."}}
+//
+// and returning the google.protobuf.Struct {"connections": [...]} with the entries and the current state of their vpp
+// interfaces.
+func Register(s grpc.ServiceRegistrar, inventory *Inventory, vppConn api.Connection) {
+	s.RegisterService(&grpc.ServiceDesc{
+		ServiceName: serviceName,
+		HandlerType: (*interface{})(nil),
+		Methods: []grpc.MethodDesc{
+			{
+				MethodName: "List",
+				Handler:    listHandler,
+			},
+		},
+		Metadata: "inventory",
+	}, &service{
+		inventory: inventory,
+		vppConn:   vppConn,
+	})
+}
+
+// listHandler - the grpc.MethodDesc handler, the generated handlers have the same signature
+var listHandler = func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(structpb.Struct)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	s := srv.(*service)
+	if interceptor == nil {
+		return s.list(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/" + serviceName + "/List",
+	}
+	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return s.list(ctx, req.(*structpb.Struct))
+	})
+}
+
+func (s *service) list(ctx context.Context, in *structpb.Struct) (*structpb.Struct, error) {
+	filter := &Filter{
+		NetworkService: in.GetFields()["network_service"].GetStringValue(),
+		Labels:         make(map[string]string),
+	}
+	for k, v := range in.GetFields()["labels"].GetStructValue().GetFields() {
+		filter.Labels[k] = v.GetStringValue()
+	}
+
+	details, err := dumpInterfaces(ctx, s.vppConn)
+	if err != nil {
+		return nil, err
+	}
+
+	var connections []interface{}
+	for _, e := range s.inventory.List(filter) {
+		labels := make(map[string]interface{})
+		for k, v := range e.Labels {
+			labels[k] = v
+		}
+		connections = append(connections, map[string]interface{}{
+			"id":              e.ID,
+			"network_service": e.NetworkService,
+			"labels":          labels,
+			"mechanism":       e.Mechanism,
+			"server":          toValue(e.Server, details),
+			"client":          toValue(e.Client, details),
+			"updated":         e.Updated.Format(time.RFC3339),
+		})
+	}
+	rv, err := structpb.NewStruct(map[string]interface{}{
+		"connections": connections,
+	})
+	return rv, errors.WithStack(err)
+}
+
+func toValue(o *Objects, details map[interface_types.InterfaceIndex]*interfaces.SwInterfaceDetails) interface{} {
+	if o == nil {
+		return nil
+	}
+	rv := map[string]interface{}{
+		"sw_if_index":   uint32(o.SwIfIndex),
+		"ipv4_table_id": o.IPv4TableID,
+		"ipv6_table_id": o.IPv6TableID,
+	}
+	if o.TunnelIP != nil {
+		rv["tunnel_ip"] = o.TunnelIP.String()
+	}
+	if d, ok := details[o.SwIfIndex]; ok {
+		rv["name"] = d.InterfaceName
+		rv["tag"] = d.Tag
+		rv["admin_up"] = d.Flags&interface_types.IF_STATUS_API_FLAG_ADMIN_UP != 0
+		rv["link_up"] = d.Flags&interface_types.IF_STATUS_API_FLAG_LINK_UP != 0
+	} else {
+		// The interface recorded in the inventory is missing in vpp
+		rv["missing"] = true
+	}
+	return rv
+}
+
+func dumpInterfaces(ctx context.Context, vppConn api.Connection) (map[interface_types.InterfaceIndex]*interfaces.SwInterfaceDetails, error) {
+	now := time.Now()
+	client, err := interfaces.NewServiceClient(vppConn).SwInterfaceDump(ctx, &interfaces.SwInterfaceDump{
+		SwIfIndex: ^interface_types.InterfaceIndex(0),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "vpp error dumping the interfaces")
+	}
+	defer func() { _ = client.Close() }()
+
+	rv := make(map[interface_types.InterfaceIndex]*interfaces.SwInterfaceDetails)
+	for {
+		details, err := client.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "vpp error dumping the interfaces")
+		}
+		rv[details.SwIfIndex] = details
+	}
+	log.FromContext(ctx).
+		WithField("count", len(rv)).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "SwInterfaceDump").Debug("completed")
+	return rv, nil
+}
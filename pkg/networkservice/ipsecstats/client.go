@@ -0,0 +1,58 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package ipsecstats
+
+import (
+	"context"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+)
+
+type ipsecStatsClient struct {
+	stats *saStats
+}
+
+// NewClient - returns a new client chain element storing the counters of the IPSec SAs of the connection in the path
+// segment metrics
+func NewClient(chainCtx context.Context, vppConn api.Connection, opts ...Option) networkservice.NetworkServiceClient {
+	return &ipsecStatsClient{
+		stats: newSAStats(chainCtx, vppConn, opts...),
+	}
+}
+
+func (s *ipsecStatsClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil {
+		return nil, err
+	}
+	s.stats.retrieve(ctx, conn, true)
+	return conn, nil
+}
+
+func (s *ipsecStatsClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	// The SAs are still there before the ipsec interface is deleted
+	s.stats.retrieve(ctx, conn, true)
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
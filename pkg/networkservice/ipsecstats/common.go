@@ -0,0 +1,234 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package ipsecstats
+
+import (
+	"context"
+	"io"
+	"math"
+	"strconv"
+	"time"
+
+	"git.fd.io/govpp.git/adapter"
+	"git.fd.io/govpp.git/api"
+	"github.com/edwarnicke/govpp/binapi/ikev2"
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+	"github.com/edwarnicke/govpp/binapi/ipsec"
+	"github.com/edwarnicke/govpp/binapi/ipsec_types"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	ipsecMech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/ipsec"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/stats"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/types"
+)
+
+// saCountersPattern - the SA packet and byte counters in the vpp stats segment, indexed by the SA stat_index
+const saCountersPattern = "^/net/ipsec/sa$"
+
+type saStats struct {
+	vppConn      api.Connection
+	statsConn    *stats.Conn
+	seqThreshold float64
+}
+
+func newSAStats(chainCtx context.Context, vppConn api.Connection, opts ...Option) *saStats {
+	o := &options{
+		seqThreshold: defaultSeqThreshold,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	statsConn := o.statsConn
+	if statsConn == nil {
+		statsConn = stats.NewConn(chainCtx, stats.WithSocket(o.statsSocket))
+	}
+	return &saStats{
+		vppConn:      vppConn,
+		statsConn:    statsConn,
+		seqThreshold: o.seqThreshold,
+	}
+}
+
+// retrieve stores the counters of the SAs protecting the ipsec interface of the connection in the segment metrics
+func (s *saStats) retrieve(ctx context.Context, conn *networkservice.Connection, isClient bool) {
+	mechanism := ipsecMech.ToMechanism(conn.GetMechanism())
+	if mechanism == nil {
+		return
+	}
+	swIfIndex, ok := ifindex.Load(ctx, isClient)
+	if !ok {
+		return
+	}
+	saOut, saIn, err := s.protectingSAs(ctx, swIfIndex)
+	if err != nil {
+		log.FromContext(ctx).Errorf("%v", err)
+		return
+	}
+	counters, err := s.saCounters()
+	if err != nil {
+		log.FromContext(ctx).Errorf("%v", err)
+		return
+	}
+
+	segment := conn.GetPath().GetPathSegments()[conn.GetPath().GetIndex()]
+	if segment.Metrics == nil {
+		segment.Metrics = make(map[string]string)
+	}
+	prefix := "server_ipsec_"
+	if isClient {
+		prefix = "client_ipsec_"
+	}
+	for i, saID := range append([]uint32{saOut}, saIn...) {
+		details, err := s.sa(ctx, saID)
+		if err != nil {
+			log.FromContext(ctx).Errorf("%v", err)
+			return
+		}
+		direction := "out_"
+		if i > 0 {
+			direction = "in_"
+		}
+		if int(details.StatIndex) < len(counters) {
+			segment.Metrics[prefix+direction+"packets"] = strconv.FormatUint(counters[details.StatIndex].Packets(), 10)
+			segment.Metrics[prefix+direction+"bytes"] = strconv.FormatUint(counters[details.StatIndex].Bytes(), 10)
+		}
+		if i > 0 {
+			segment.Metrics[prefix+"last_seq_in"] = strconv.FormatUint(details.LastSeqInbound, 10)
+			continue
+		}
+		segment.Metrics[prefix+"seq_out"] = strconv.FormatUint(details.SeqOutbound, 10)
+		if details.Entry.Flags&ipsec_types.IPSEC_API_SAD_FLAG_USE_ESN == 0 &&
+			float64(details.SeqOutbound) >= s.seqThreshold*math.MaxUint32 {
+			log.FromContext(ctx).
+				WithField("swIfIndex", swIfIndex).
+				WithField("saID", saID).
+				WithField("seqOutbound", details.SeqOutbound).
+				Warn("outbound SA sequence number nears the exhaustion")
+		}
+	}
+
+	if rekeys, ok := s.rekeys(ctx, mechanism, isClient); ok {
+		segment.Metrics[prefix+"rekeys"] = strconv.FormatUint(uint64(rekeys), 10)
+	}
+}
+
+// protectingSAs returns the outbound and the inbound SAs protecting the interface
+func (s *saStats) protectingSAs(ctx context.Context, swIfIndex interface_types.InterfaceIndex) (saOut uint32, saIn []uint32, err error) {
+	now := time.Now()
+	dump, err := ipsec.NewServiceClient(s.vppConn).IpsecTunnelProtectDump(ctx, &ipsec.IpsecTunnelProtectDump{
+		SwIfIndex: swIfIndex,
+	})
+	if err != nil {
+		return 0, nil, errors.Wrapf(err, "failed to dump the tunnel protection of swIfIndex %d", swIfIndex)
+	}
+	defer func() { _ = dump.Close() }()
+	details, err := dump.Recv()
+	if err != nil {
+		return 0, nil, errors.Wrapf(err, "failed to dump the tunnel protection of swIfIndex %d", swIfIndex)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", swIfIndex).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "IpsecTunnelProtectDump").Debug("completed")
+	return details.Tun.SaOut, details.Tun.SaIn, nil
+}
+
+func (s *saStats) sa(ctx context.Context, saID uint32) (*ipsec.IpsecSaV3Details, error) {
+	now := time.Now()
+	dump, err := ipsec.NewServiceClient(s.vppConn).IpsecSaV3Dump(ctx, &ipsec.IpsecSaV3Dump{
+		SaID: saID,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to dump SA %d", saID)
+	}
+	defer func() { _ = dump.Close() }()
+	details, err := dump.Recv()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to dump SA %d", saID)
+	}
+	log.FromContext(ctx).
+		WithField("saID", saID).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "IpsecSaV3Dump").Debug("completed")
+	return details, nil
+}
+
+// saCounters returns the SA counters summed over the threads, indexed by the SA stat_index
+func (s *saStats) saCounters() ([]adapter.CombinedCounter, error) {
+	entries, err := s.statsConn.Dump(saCountersPattern)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to dump the SA counters")
+	}
+	var rv []adapter.CombinedCounter
+	for _, entry := range entries {
+		counters, ok := entry.Data.(adapter.CombinedCounterStat)
+		if !ok {
+			continue
+		}
+		for _, perThread := range counters {
+			for index, counter := range perThread {
+				for len(rv) <= index {
+					rv = append(rv, adapter.CombinedCounter{})
+				}
+				rv[index][0] += counter[0]
+				rv[index][1] += counter[1]
+			}
+		}
+	}
+	return rv, nil
+}
+
+// rekeys returns the number of the rekeys of the IKEv2 SA established between the tunnel IPs of the connection
+func (s *saStats) rekeys(ctx context.Context, mechanism *ipsecMech.Mechanism, isClient bool) (uint16, bool) {
+	local, remote := mechanism.DstIP(), mechanism.SrcIP()
+	if isClient {
+		local, remote = remote, local
+	}
+	now := time.Now()
+	dump, err := ikev2.NewServiceClient(s.vppConn).Ikev2SaDump(ctx, &ikev2.Ikev2SaDump{})
+	if err != nil {
+		log.FromContext(ctx).Errorf("failed to dump the IKEv2 SAs: %v", err)
+		return 0, false
+	}
+	defer func() { _ = dump.Close() }()
+	for {
+		details, err := dump.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.FromContext(ctx).Errorf("failed to dump the IKEv2 SAs: %v", err)
+			return 0, false
+		}
+		iaddr, raddr := types.FromVppAddress(details.Sa.Iaddr), types.FromVppAddress(details.Sa.Raddr)
+		if (iaddr.Equal(local) && raddr.Equal(remote)) || (iaddr.Equal(remote) && raddr.Equal(local)) {
+			log.FromContext(ctx).
+				WithField("saIndex", details.Sa.SaIndex).
+				WithField("duration", time.Since(now)).
+				WithField("vppapi", "Ikev2SaDump").Debug("completed")
+			return details.Sa.Stats.NRekeyReq, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,20 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ipsecstats provides chain elements exporting the counters of the IPSec SAs of the connection: SA packets and
+// bytes, the outbound sequence number and the last inbound one, and the IKEv2 rekeys. A warning is logged when the
+// outbound sequence number of the SA not using ESN nears the exhaustion, so the SA can be rekeyed in time.
+package ipsecstats
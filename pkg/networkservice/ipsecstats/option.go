@@ -0,0 +1,58 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package ipsecstats
+
+import (
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/stats"
+)
+
+type options struct {
+	statsConn    *stats.Conn
+	statsSocket  string
+	seqThreshold float64
+}
+
+const defaultSeqThreshold = 0.9
+
+// Option is an option pattern for ipsecstats client/server
+type Option func(o *options)
+
+// WithStatsConn - sets the connection to the vpp stats socket the SA counters are read from, e.g. the one shared with
+// the stats elements
+func WithStatsConn(statsConn *stats.Conn) Option {
+	return func(o *options) {
+		o.statsConn = statsConn
+	}
+}
+
+// WithStatsSocket - sets the vpp stats socket the SA counters are read from if WithStatsConn is not set
+func WithStatsSocket(socket string) Option {
+	return func(o *options) {
+		o.statsSocket = socket
+	}
+}
+
+// WithSeqThreshold - sets the share of the 32-bit sequence number space used by the outbound SA not using ESN
+// the warning is logged at (default: 0.9)
+func WithSeqThreshold(threshold float64) Option {
+	return func(o *options) {
+		o.seqThreshold = threshold
+	}
+}
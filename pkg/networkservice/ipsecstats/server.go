@@ -0,0 +1,57 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package ipsecstats
+
+import (
+	"context"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+)
+
+type ipsecStatsServer struct {
+	stats *saStats
+}
+
+// NewServer - returns a new server chain element storing the counters of the IPSec SAs of the connection in the path
+// segment metrics
+func NewServer(chainCtx context.Context, vppConn api.Connection, opts ...Option) networkservice.NetworkServiceServer {
+	return &ipsecStatsServer{
+		stats: newSAStats(chainCtx, vppConn, opts...),
+	}
+}
+
+func (s *ipsecStatsServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	s.stats.retrieve(ctx, conn, false)
+	return conn, nil
+}
+
+func (s *ipsecStatsServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	// The SAs are still there before the ipsec interface is deleted
+	s.stats.retrieve(ctx, conn, false)
+	return next.Server(ctx).Close(ctx, conn)
+}
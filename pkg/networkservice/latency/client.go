@@ -0,0 +1,74 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package latency
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+)
+
+type beginLatencyClient struct {
+	measured networkservice.NetworkServiceClient
+	name     string
+}
+
+type endLatencyClient struct{}
+
+// NewClient - wraps the latency measurement around the supplied measured client
+func NewClient(measured networkservice.NetworkServiceClient) networkservice.NetworkServiceClient {
+	return next.NewNetworkServiceClient(
+		&beginLatencyClient{
+			measured: measured,
+			name:     elementName(measured),
+		},
+		&endLatencyClient{},
+	)
+}
+
+func (b *beginLatencyClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	timerCtx, t := withTimer(ctx)
+	start := time.Now()
+	conn, err := b.measured.Request(timerCtx, request, opts...)
+	record(ctx, b.name, "Request", time.Since(start)-t.nested, err)
+	return conn, err
+}
+
+func (b *beginLatencyClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	timerCtx, t := withTimer(ctx)
+	start := time.Now()
+	rv, err := b.measured.Close(timerCtx, conn, opts...)
+	record(ctx, b.name, "Close", time.Since(start)-t.nested, err)
+	return rv, err
+}
+
+func (e *endLatencyClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	start := time.Now()
+	defer func() { addNested(ctx, time.Since(start)) }()
+	return next.Client(ctx).Request(ctx, request, opts...)
+}
+
+func (e *endLatencyClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	start := time.Now()
+	defer func() { addNested(ctx, time.Since(start)) }()
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package latency
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/metric/instrument/syncfloat64"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// durationMetric - the time spent in the element per element, method and result
+const durationMetric = "element_duration_seconds"
+
+var durations struct {
+	once      sync.Once
+	histogram syncfloat64.Histogram
+}
+
+type timerKey struct{}
+
+// timer - the time spent in the next elements of the measured one
+type timer struct {
+	nested time.Duration
+}
+
+func withTimer(ctx context.Context) (context.Context, *timer) {
+	t := new(timer)
+	return context.WithValue(ctx, timerKey{}, t), t
+}
+
+// addNested adds the time spent in the next elements to the timer of the measured element
+func addNested(ctx context.Context, d time.Duration) {
+	if t, ok := ctx.Value(timerKey{}).(*timer); ok {
+		t.nested += d
+	}
+}
+
+func elementName(element interface{}) string {
+	return fmt.Sprintf("%T", element)
+}
+
+func record(ctx context.Context, element, method string, d time.Duration, err error) {
+	durations.once.Do(func() {
+		var initErr error
+		if durations.histogram, initErr = global.Meter("").SyncFloat64().Histogram(durationMetric); initErr != nil {
+			log.FromContext(ctx).Warnf("failed to create %s histogram: %v", durationMetric, initErr)
+		}
+	})
+	if durations.histogram == nil {
+		return
+	}
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+	durations.histogram.Record(ctx, d.Seconds(),
+		attribute.String("element", element),
+		attribute.String("method", method),
+		attribute.String("result", result),
+	)
+}
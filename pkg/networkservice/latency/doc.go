@@ -0,0 +1,26 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package latency provides the wrappers measuring the time the chain elements spend in Request and Close, not
+// counting the time spent in the next elements, and exporting it as the element_duration_seconds histogram:
+//
+//	chain.NewNetworkServiceServer(
+//		latency.NewServer(l2xconnect.NewServer(vppConn)),
+//		...
+//	)
+//
+// The wrappers have the signatures of next.ServerWrapper and next.ClientWrapper.
+package latency
@@ -0,0 +1,118 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linuxcp
+
+import (
+	"context"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/edwarnicke/govpp/binapi/lcp"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/ipsec"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/wireguard"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+)
+
+// maxHostIfNameLen - max length of the linux interface name (IFNAMSIZ - 1)
+const maxHostIfNameLen = 15
+
+func create(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, o *options, isClient bool) error {
+	swIfIndex, ok := ifindex.Load(ctx, isClient)
+	if !ok {
+		return nil
+	}
+	if p, ok := load(ctx, isClient); ok {
+		if p.swIfIndex == swIfIndex {
+			return nil
+		}
+		// The interface has been re-created on refresh (e.g. the remote mechanism has changed)
+		del(ctx, vppConn, isClient)
+	}
+
+	// The L3 tunnels have no MAC, so they are mirrored as tun
+	hostIfType := lcp.LCP_API_ITF_HOST_TAP
+	if t := conn.GetMechanism().GetType(); t == wireguard.MECHANISM || t == ipsec.MECHANISM {
+		hostIfType = lcp.LCP_API_ITF_HOST_TUN
+	}
+	hostIfName := hostIfName(o.namePrefix, conn.GetId(), isClient)
+
+	now := time.Now()
+	reply, err := lcp.NewServiceClient(vppConn).LcpItfPairAddDelV2(ctx, &lcp.LcpItfPairAddDelV2{
+		IsAdd:      true,
+		SwIfIndex:  swIfIndex,
+		HostIfName: hostIfName,
+		HostIfType: hostIfType,
+		Netns:      o.netNS,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to mirror the interface %d into the host", swIfIndex)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", swIfIndex).
+		WithField("hostSwIfIndex", reply.HostSwIfIndex).
+		WithField("hostIfName", hostIfName).
+		WithField("netNS", o.netNS).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "LcpItfPairAddDelV2").Debug("completed")
+
+	store(ctx, isClient, &pair{
+		swIfIndex:     swIfIndex,
+		hostSwIfIndex: reply.HostSwIfIndex,
+		hostIfName:    hostIfName,
+	})
+	return nil
+}
+
+func del(ctx context.Context, vppConn api.Connection, isClient bool) {
+	p, ok := loadAndDelete(ctx, isClient)
+	if !ok {
+		return
+	}
+	now := time.Now()
+	if _, err := lcp.NewServiceClient(vppConn).LcpItfPairAddDelV2(ctx, &lcp.LcpItfPairAddDelV2{
+		IsAdd:     false,
+		SwIfIndex: p.swIfIndex,
+	}); err != nil {
+		log.FromContext(ctx).
+			WithField("swIfIndex", p.swIfIndex).
+			Errorf("failed to delete the host interface %s: %v", p.hostIfName, err)
+		return
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", p.swIfIndex).
+		WithField("hostIfName", p.hostIfName).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "LcpItfPairAddDelV2").Debug("completed")
+}
+
+// hostIfName returns the host interface name like "nsm-c-1a2b3c4d", unique per connection side
+func hostIfName(prefix, connID string, isClient bool) string {
+	side := "-s-"
+	if isClient {
+		side = "-c-"
+	}
+	name := prefix + side + connID
+	if len(name) > maxHostIfNameLen {
+		name = name[:maxHostIfNameLen]
+	}
+	return name
+}
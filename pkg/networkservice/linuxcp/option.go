@@ -0,0 +1,52 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linuxcp
+
+const defaultNamePrefix = "nsm"
+
+type options struct {
+	netNS      string
+	namePrefix string
+}
+
+// Option is an option pattern for linuxcp client/server
+type Option func(o *options)
+
+// WithNetNS sets the host network namespace the interfaces are mirrored into. Default: the linux-cp default namespace
+func WithNetNS(netNS string) Option {
+	return func(o *options) {
+		o.netNS = netNS
+	}
+}
+
+// WithNamePrefix sets the prefix of the host interface names, the rest of the name is the side and the connection id
+// prefix. Default: "nsm"
+func WithNamePrefix(namePrefix string) Option {
+	return func(o *options) {
+		o.namePrefix = namePrefix
+	}
+}
+
+func newOptions(opts ...Option) *options {
+	o := &options{
+		namePrefix: defaultNamePrefix,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
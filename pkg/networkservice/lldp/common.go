@@ -0,0 +1,131 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lldp
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+	lldpapi "github.com/edwarnicke/govpp/binapi/lldp"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+)
+
+// lldpConfig applies the global lldp config once
+type lldpConfig struct {
+	vppConn api.Connection
+	config  *lldpapi.LldpConfig
+
+	inited    uint32
+	initMutex sync.Mutex
+}
+
+func newLLDPConfig(vppConn api.Connection, o *options) *lldpConfig {
+	return &lldpConfig{
+		vppConn: vppConn,
+		config: &lldpapi.LldpConfig{
+			TxHold:     o.txHold,
+			TxInterval: uint32(o.txInterval / time.Second),
+			SystemName: o.systemName,
+		},
+	}
+}
+
+func (c *lldpConfig) init(ctx context.Context) error {
+	if atomic.LoadUint32(&c.inited) > 0 {
+		return nil
+	}
+	c.initMutex.Lock()
+	defer c.initMutex.Unlock()
+	if atomic.LoadUint32(&c.inited) > 0 {
+		return nil
+	}
+
+	now := time.Now()
+	if _, err := lldpapi.NewServiceClient(c.vppConn).LldpConfig(ctx, c.config); err != nil {
+		return errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("systemName", c.config.SystemName).
+		WithField("txInterval", c.config.TxInterval).
+		WithField("txHold", c.config.TxHold).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "LldpConfig").Debug("completed")
+	atomic.StoreUint32(&c.inited, 1)
+	return nil
+}
+
+// portDesc returns the port description announced for the connection
+func portDesc(conn *networkservice.Connection) string {
+	return fmt.Sprintf("nsm-connection-id=%s nsm-network-service=%s", conn.GetId(), conn.GetNetworkService())
+}
+
+func enable(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, isClient bool) error {
+	if mechanism := kernel.ToMechanism(conn.GetMechanism()); mechanism == nil {
+		return nil
+	}
+	swIfIndex, ok := ifindex.Load(ctx, isClient)
+	if !ok {
+		return nil
+	}
+	if enabled, ok := load(ctx, isClient); ok && enabled == swIfIndex {
+		return nil
+	}
+	if err := enableDisable(ctx, vppConn, swIfIndex, portDesc(conn), true); err != nil {
+		return err
+	}
+	store(ctx, isClient, swIfIndex)
+	return nil
+}
+
+func disable(ctx context.Context, vppConn api.Connection, isClient bool) {
+	swIfIndex, ok := loadAndDelete(ctx, isClient)
+	if !ok {
+		return
+	}
+	if err := enableDisable(ctx, vppConn, swIfIndex, "", false); err != nil {
+		log.FromContext(ctx).Errorf("unable to disable lldp: %v", err)
+	}
+}
+
+func enableDisable(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, desc string, isEnable bool) error {
+	now := time.Now()
+	if _, err := lldpapi.NewServiceClient(vppConn).SwInterfaceSetLldp(ctx, &lldpapi.SwInterfaceSetLldp{
+		SwIfIndex: swIfIndex,
+		Enable:    isEnable,
+		PortDesc:  desc,
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", swIfIndex).
+		WithField("portDesc", desc).
+		WithField("isEnable", isEnable).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "SwInterfaceSetLldp").Debug("completed")
+	return nil
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lldp
+
+import (
+	"os"
+	"time"
+)
+
+const (
+	defaultTxHold     = 4
+	defaultTxInterval = 30 * time.Second
+)
+
+type options struct {
+	systemName string
+	txHold     uint32
+	txInterval time.Duration
+}
+
+// Option is an option pattern for lldp client/server
+type Option func(o *options)
+
+// WithSystemName sets the system name announced. Default: the hostname
+func WithSystemName(systemName string) Option {
+	return func(o *options) {
+		o.systemName = systemName
+	}
+}
+
+// WithTxInterval sets the interval between the announcements. Default: 30s
+func WithTxInterval(txInterval time.Duration) Option {
+	return func(o *options) {
+		o.txInterval = txInterval
+	}
+}
+
+// WithTxHold sets the multiplier of the tx interval giving the time the announcement is valid for. Default: 4
+func WithTxHold(txHold uint32) Option {
+	return func(o *options) {
+		o.txHold = txHold
+	}
+}
+
+func newOptions(opts ...Option) *options {
+	hostname, _ := os.Hostname()
+	o := &options{
+		systemName: hostname,
+		txHold:     defaultTxHold,
+		txInterval: defaultTxInterval,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
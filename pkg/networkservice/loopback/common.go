@@ -27,6 +27,8 @@ import (
 	"github.com/edwarnicke/govpp/binapi/interface_types"
 
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/teardown"
 )
 
 /* Create loopback interface and store it in metadata */
@@ -68,16 +70,20 @@ func createLoopbackVPP(ctx context.Context, vppConn api.Connection) (interface_t
 
 func del(ctx context.Context, vppConn api.Connection, networkService string, t *Map, isClient bool) {
 	if swIfIndex, ok := LoadAndDelete(ctx, isClient); ok {
-		<-t.exec.AsyncExec(func() {
-			t.entries[networkService].count--
+		// The loopback is deleted once the routes via it are removed
+		_ = teardown.Do(ctx, teardown.Interfaces, func(ctx context.Context) error {
+			<-t.exec.AsyncExec(func() {
+				t.entries[networkService].count--
 
-			/* If there are no more clients using the loopback - delete it */
-			if t.entries[networkService].count == 0 {
-				delete(t.entries, networkService)
-				if err := delVPP(ctx, vppConn, swIfIndex); err != nil {
-					log.FromContext(ctx).Errorf("unable to delete loopback interface: %v", err)
+				/* If there are no more clients using the loopback - delete it */
+				if t.entries[networkService].count == 0 {
+					delete(t.entries, networkService)
+					if err := delVPP(ctx, vppConn, swIfIndex); err != nil {
+						log.FromContext(ctx).Errorf("unable to delete loopback interface: %v", err)
+					}
 				}
-			}
+			})
+			return nil
 		})
 	}
 }
@@ -0,0 +1,57 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mechanismpriority
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/mechanismpriority"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/hotreload"
+)
+
+type mechanismPriorityClient struct {
+	// client - mechanismpriority client of the current priority list
+	client atomic.Value
+}
+
+// NewClient returns a new client chain element prioritizing the mechanisms according to the current list of
+// priorities, the updates are watched until chainCtx is done
+func NewClient(chainCtx context.Context, priorities *hotreload.Value[[]string]) networkservice.NetworkServiceClient {
+	c := &mechanismPriorityClient{}
+	c.set(chainCtx, priorities.Load())
+	priorities.Watch(chainCtx, c.set)
+	return c
+}
+
+func (c *mechanismPriorityClient) set(_ context.Context, priorities []string) {
+	c.client.Store(mechanismpriority.NewClient(priorities...))
+}
+
+func (c *mechanismPriorityClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	// The inner client calls the next element of this chain
+	return c.client.Load().(networkservice.NetworkServiceClient).Request(ctx, request, opts...)
+}
+
+func (c *mechanismPriorityClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return c.client.Load().(networkservice.NetworkServiceClient).Close(ctx, conn, opts...)
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mechanismpriority_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/memif"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanismpriority"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/hotreload"
+)
+
+func mechanismTypes(request *networkservice.NetworkServiceRequest) []string {
+	var types []string
+	for _, mechanism := range request.GetMechanismPreferences() {
+		types = append(types, mechanism.GetType())
+	}
+	return types
+}
+
+func Test_MechanismPriorityClient_Reload(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	priorities := hotreload.NewValue([]string{kernel.MECHANISM})
+	c := chain.NewNetworkServiceClient(mechanismpriority.NewClient(ctx, priorities))
+	newRequest := func() *networkservice.NetworkServiceRequest {
+		return &networkservice.NetworkServiceRequest{
+			MechanismPreferences: []*networkservice.Mechanism{
+				{Type: memif.MECHANISM},
+				{Type: kernel.MECHANISM},
+			},
+		}
+	}
+
+	request := newRequest()
+	_, err := c.Request(ctx, request)
+	require.NoError(t, err)
+	require.Equal(t, []string{kernel.MECHANISM, memif.MECHANISM}, mechanismTypes(request))
+
+	priorities.Store([]string{memif.MECHANISM, kernel.MECHANISM})
+	request = newRequest()
+	_, err = c.Request(ctx, request)
+	require.NoError(t, err)
+	require.Equal(t, []string{memif.MECHANISM, kernel.MECHANISM}, mechanismTypes(request))
+}
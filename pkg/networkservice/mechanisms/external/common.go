@@ -0,0 +1,116 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package external
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	interfaces "github.com/edwarnicke/govpp/binapi/interface"
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+)
+
+// adopt stores the ifindex of the pre-created interface of the connection
+func adopt(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, isClient bool) error {
+	mechanism := ToMechanism(conn.GetMechanism())
+	if mechanism == nil {
+		return nil
+	}
+	if _, ok := ifindex.Load(ctx, isClient); ok {
+		return nil
+	}
+	interfaceName, tag := mechanism.GetInterfaceName(), mechanism.GetTag()
+	if interfaceName == "" && tag == "" {
+		return errors.Errorf("neither %s nor %s is set in the external mechanism", InterfaceNameKey, TagKey)
+	}
+
+	swIfIndex, err := lookup(ctx, vppConn, interfaceName, tag)
+	if err != nil {
+		return err
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", swIfIndex).
+		WithField("interfaceName", interfaceName).
+		WithField("tag", tag).
+		Info("adopted the external interface")
+	ifindex.Store(ctx, isClient, swIfIndex)
+	return nil
+}
+
+// release forgets the adopted interface, the interface itself is kept
+func release(ctx context.Context, conn *networkservice.Connection, isClient bool) {
+	if ToMechanism(conn.GetMechanism()) == nil {
+		return
+	}
+	ifindex.Delete(ctx, isClient)
+}
+
+// lookup returns the interface having the name and the tag, the empty ones match any
+func lookup(ctx context.Context, vppConn api.Connection, interfaceName, tag string) (interface_types.InterfaceIndex, error) {
+	now := time.Now()
+	client, err := interfaces.NewServiceClient(vppConn).SwInterfaceDump(ctx, &interfaces.SwInterfaceDump{
+		SwIfIndex:       ^interface_types.InterfaceIndex(0),
+		NameFilterValid: interfaceName != "",
+		NameFilter:      interfaceName,
+	})
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	defer func() { _ = client.Close() }()
+
+	var found []interface_types.InterfaceIndex
+	for {
+		details, err := client.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, errors.WithStack(err)
+		}
+		// The name filter is a substring match
+		if interfaceName != "" && details.InterfaceName != interfaceName {
+			continue
+		}
+		if tag != "" && details.Tag != tag {
+			continue
+		}
+		found = append(found, details.SwIfIndex)
+	}
+	log.FromContext(ctx).
+		WithField("interfaceName", interfaceName).
+		WithField("tag", tag).
+		WithField("found", len(found)).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "SwInterfaceDump").Debug("completed")
+
+	switch len(found) {
+	case 0:
+		return 0, errors.Errorf("no vpp interface with the name %q and the tag %q", interfaceName, tag)
+	case 1:
+		return found[0], nil
+	default:
+		return 0, errors.Errorf("%d vpp interfaces with the name %q and the tag %q", len(found), interfaceName, tag)
+	}
+}
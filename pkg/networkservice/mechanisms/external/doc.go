@@ -0,0 +1,21 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package external provides the EXTERNAL local mechanism: the vpp interface is pre-created by the operator or the CNI
+// and identified by its name and/or tag in the mechanism parameters. sdk-vpp only adopts it (the ifindex metadata, so
+// the interface is set up and the addresses are assigned by the rest of the chain) without creating or deleting it,
+// for the appliance style integrations.
+package external
@@ -0,0 +1,65 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package external
+
+import (
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+)
+
+// Mechanism is the external mechanism helper
+type Mechanism struct {
+	*networkservice.Mechanism
+}
+
+// New returns *networkservice.Mechanism of type external identifying the interface by the name and/or the tag, the
+// empty ones are not used
+func New(interfaceName, tag string) *networkservice.Mechanism {
+	parameters := make(map[string]string)
+	if interfaceName != "" {
+		parameters[InterfaceNameKey] = interfaceName
+	}
+	if tag != "" {
+		parameters[TagKey] = tag
+	}
+	return &networkservice.Mechanism{
+		Cls:        cls.LOCAL,
+		Type:       MECHANISM,
+		Parameters: parameters,
+	}
+}
+
+// ToMechanism converts unified mechanism to the helper.
+// If Mechanism m is *not* of type external.MECHANISM, it returns nil
+func ToMechanism(m *networkservice.Mechanism) *Mechanism {
+	if m.GetType() == MECHANISM {
+		return &Mechanism{
+			Mechanism: m,
+		}
+	}
+	return nil
+}
+
+// GetInterfaceName returns the vpp interface name
+func (m *Mechanism) GetInterfaceName() string {
+	return m.GetParameters()[InterfaceNameKey]
+}
+
+// GetTag returns the vpp interface tag
+func (m *Mechanism) GetTag() string {
+	return m.GetParameters()[TagKey]
+}
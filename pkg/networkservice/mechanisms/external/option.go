@@ -0,0 +1,39 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package external
+
+type options struct {
+	interfaceName string
+	tag           string
+}
+
+// Option is an option pattern for external client
+type Option func(o *options)
+
+// WithInterfaceName sets the name of the pre-created vpp interface requested by the client
+func WithInterfaceName(interfaceName string) Option {
+	return func(o *options) {
+		o.interfaceName = interfaceName
+	}
+}
+
+// WithTag sets the tag of the pre-created vpp interface requested by the client
+func WithTag(tag string) Option {
+	return func(o *options) {
+		o.tag = tag
+	}
+}
@@ -36,21 +36,39 @@ import (
 
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/ipsec/mtu"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/pinhole"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/cryptoengine"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/keybinding"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/tunnelip"
 )
 
 type ipsecClient struct {
-	vppConn  api.Connection
-	tunnelIP net.IP
+	vppConn   api.Connection
+	tunnelIPs []net.IP
+	crypto    *cryptoengine.Selector
+	transport string
+	psks      map[string]string
+	keys      *keybinding.Binder
+	tos       tunnelTOS
 }
 
 // NewClient - returns a new client for the IPSec remote mechanism
-func NewClient(vppConn api.Connection, tunnelIP net.IP) networkservice.NetworkServiceClient {
+func NewClient(vppConn api.Connection, tunnelIP net.IP, options ...Option) networkservice.NetworkServiceClient {
+	opts := &ipsecOptions{}
+	for _, opt := range options {
+		opt(opts)
+	}
+
 	return chain.NewNetworkServiceClient(
 		&ipsecClient{
-			vppConn:  vppConn,
-			tunnelIP: tunnelIP,
+			vppConn:   vppConn,
+			tunnelIPs: []net.IP{tunnelIP, opts.ipv6TunnelIP},
+			crypto:    cryptoengine.NewSelector(vppConn, "IPSec", opts.asyncCrypto, setAsyncMode),
+			transport: opts.transport,
+			psks:      opts.psks,
+			keys:      opts.keyBinding,
+			tos:       opts.tos,
 		},
-		mtu.NewClient(vppConn, tunnelIP),
+		mtu.NewClient(vppConn, tunnelIP, opts.ipv6TunnelIP),
 	)
 }
 
@@ -58,6 +76,7 @@ func (i *ipsecClient) Request(ctx context.Context, request *networkservice.Netwo
 	if request.GetConnection().GetPayload() != payload.IP {
 		return next.Client(ctx).Request(ctx, request, opts...)
 	}
+	i.crypto.Init(ctx)
 
 	rsaKey, err := generateRSAKey()
 	if err != nil {
@@ -67,11 +86,18 @@ func (i *ipsecClient) Request(ctx context.Context, request *networkservice.Netwo
 	if err != nil {
 		return nil, err
 	}
+	srcIP := tunnelip.Select(nil, i.tunnelIPs...)
 	// If we already have a key we can reuse it
 	// else create a new one and store it after successful interface creation
 	if mechanism := ipsecMech.ToMechanism(request.GetConnection().GetMechanism()); mechanism != nil {
 		// If there is a key in mechanism then we can use it
 		publicKey = mechanism.SrcPublicKey()
+		// Use the same IP family the remote side has already advertised
+		srcIP = tunnelip.Select(mechanism.DstIP(), i.tunnelIPs...)
+	}
+	// Per connection tunnel IP overrides the default one
+	if ip, ok := tunnelip.Load(ctx, metadata.IsClient(i)); ok {
+		srcIP = ip
 	}
 	mechanism := &networkservice.Mechanism{
 		Cls:        cls.REMOTE,
@@ -80,13 +106,20 @@ func (i *ipsecClient) Request(ctx context.Context, request *networkservice.Netwo
 	}
 	ipsecMech.ToMechanism(mechanism).
 		SetSrcPublicKey(publicKey).
-		SetSrcIP(i.tunnelIP).
+		SetSrcIP(srcIP).
 		SetSrcPort(ikev2DefaultPort)
+	// The gateways are reached over the ipsec interface
+	if _, isGateway := request.GetConnection().GetLabels()[GatewayAddressLabel]; i.transport != "" && !isGateway {
+		mechanism.GetParameters()[TransportParam] = i.transport
+	}
+	if err = i.keys.Sign(mechanism, publicKey, true); err != nil {
+		return nil, err
+	}
 
 	request.MechanismPreferences = append(request.MechanismPreferences, mechanism)
 
 	// Store extra IPPort entry to allow IKE protocol - https://www.rfc-editor.org/rfc/rfc5996
-	pinhole.StoreExtra(ctx, metadata.IsClient(i), pinhole.NewIPPort(i.tunnelIP.String(), 500))
+	pinhole.StoreExtra(ctx, metadata.IsClient(i), pinhole.NewIPPort(srcIP.String(), 500))
 
 	postponeCtxFunc := postpone.ContextWithValues(ctx)
 
@@ -95,7 +128,14 @@ func (i *ipsecClient) Request(ctx context.Context, request *networkservice.Netwo
 		return nil, err
 	}
 
-	if err = create(ctx, conn, i.vppConn, rsaKey, metadata.IsClient(i)); err != nil {
+	gw, err := gatewayFromLabels(conn.GetLabels(), i.psks)
+	if mechanism := ipsecMech.ToMechanism(conn.GetMechanism()); err == nil && gw == nil && mechanism != nil {
+		err = i.keys.Verify(conn.GetMechanism(), mechanism.DstPublicKey(), false)
+	}
+	if err == nil {
+		err = create(ctx, conn, i.vppConn, rsaKey, gw, &i.tos, metadata.IsClient(i))
+	}
+	if err != nil {
 		closeCtx, cancelClose := postponeCtxFunc()
 		defer cancelClose()
 
@@ -105,6 +145,9 @@ func (i *ipsecClient) Request(ctx context.Context, request *networkservice.Netwo
 
 		return nil, err
 	}
+	if ipsecMech.ToMechanism(conn.GetMechanism()) != nil {
+		i.crypto.SetMetric(conn)
+	}
 
 	return conn, nil
 }
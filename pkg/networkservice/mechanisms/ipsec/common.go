@@ -25,7 +25,6 @@ import (
 	"encoding/base64"
 	"encoding/pem"
 	"fmt"
-	"io"
 	"math/big"
 	"net"
 	"os"
@@ -34,15 +33,15 @@ import (
 
 	"git.fd.io/govpp.git/api"
 	"github.com/edwarnicke/govpp/binapi/ikev2_types"
-	interfaces "github.com/edwarnicke/govpp/binapi/interface"
 	"github.com/edwarnicke/govpp/binapi/interface_types"
-	"github.com/edwarnicke/govpp/binapi/ip"
 	"github.com/edwarnicke/govpp/binapi/ip_types"
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
 	"github.com/pkg/errors"
 
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/teardown"
 	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
 	"github.com/networkservicemesh/sdk-vpp/pkg/tools/types"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/uplink"
 
 	"github.com/edwarnicke/govpp/binapi/ikev2"
 	ipsecapi "github.com/edwarnicke/govpp/binapi/ipsec"
@@ -50,8 +49,8 @@ import (
 	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/ipsec"
 )
 
-// create - creates IPSEC with IKEv2
-func create(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, privateKey *rsa.PrivateKey, isClient bool) error {
+// create - creates IPSEC with IKEv2. If gw is not nil, the SAs are negotiated with the non-NSM IKEv2 gateway.
+func create(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, privateKey *rsa.PrivateKey, gw *gateway, tos *tunnelTOS, isClient bool) error {
 	if mechanism := ipsec.ToMechanism(conn.GetMechanism()); mechanism != nil {
 		_, ok := ifindex.Load(ctx, isClient)
 		if ok {
@@ -60,7 +59,7 @@ func create(ctx context.Context, conn *networkservice.Connection, vppConn api.Co
 		profileName := fmt.Sprintf("%s-%s", isClientPrefix(isClient), conn.Id)
 
 		// *** CREATE IP TUNNEL *** //
-		swIfIndex, err := createIPSecTunnel(ctx, vppConn)
+		swIfIndex, err := createTunnel(ctx, vppConn, conn, tos, isClient)
 		if err != nil {
 			return errors.WithStack(err)
 		}
@@ -78,13 +77,21 @@ func create(ctx context.Context, conn *networkservice.Connection, vppConn api.Co
 		}
 
 		// *** SET KEYS *** //
-		err = setKeys(ctx, vppConn, profileName, mechanism, privateKey, isClient)
+		if gw != nil {
+			err = setGatewayAuth(ctx, vppConn, profileName, gw, privateKey)
+		} else {
+			err = setKeys(ctx, vppConn, profileName, mechanism, privateKey, isClient)
+		}
 		if err != nil {
 			return errors.WithStack(err)
 		}
 
 		// *** SET FQDN *** //
-		err = setFQDN(ctx, vppConn, mechanism, profileName, isClient)
+		if gw != nil {
+			err = setGatewayIDs(ctx, vppConn, profileName, gw, mechanism.SrcIP())
+		} else {
+			err = setFQDN(ctx, vppConn, mechanism, profileName, isClient)
+		}
 		if err != nil {
 			return errors.WithStack(err)
 		}
@@ -103,7 +110,11 @@ func create(ctx context.Context, conn *networkservice.Connection, vppConn api.Co
 
 		// *** INITIATOR STEPS *** //
 		if isClient {
-			err = initiate(ctx, vppConn, mechanism, profileName)
+			responderIP := mechanism.DstIP()
+			if gw != nil {
+				responderIP = gw.address
+			}
+			err = initiate(ctx, vppConn, mechanism.SrcIP(), responderIP, profileName)
 			if err != nil {
 				return err
 			}
@@ -114,14 +125,14 @@ func create(ctx context.Context, conn *networkservice.Connection, vppConn api.Co
 	return nil
 }
 
-func initiate(ctx context.Context, vppConn api.Connection, mechanism *ipsec.Mechanism, profileName string) error {
-	hostSwIfIndex, err := getSwIfIndexByIP(ctx, vppConn, mechanism.SrcIP())
+func initiate(ctx context.Context, vppConn api.Connection, srcIP, responderIP net.IP, profileName string) error {
+	host, err := uplink.ByIP(ctx, vppConn, srcIP)
 	if err != nil {
 		return err
 	}
 
 	// *** SET RESPONDER *** //
-	err = setResponder(ctx, vppConn, profileName, hostSwIfIndex, mechanism.DstIP())
+	err = setResponder(ctx, vppConn, profileName, host.SwIfIndex, responderIP)
 	if err != nil {
 		return err
 	}
@@ -147,47 +158,6 @@ func initiate(ctx context.Context, vppConn api.Connection, mechanism *ipsec.Mech
 	return nil
 }
 
-func getSwIfIndexByIP(ctx context.Context, vppConn api.Connection, interfaceIP net.IP) (interface_types.InterfaceIndex, error) {
-	client, err := interfaces.NewServiceClient(vppConn).SwInterfaceDump(ctx, &interfaces.SwInterfaceDump{})
-	if err != nil {
-		return 0, errors.Wrapf(err, "error attempting to get interface dump client for IP %q", interfaceIP)
-	}
-	defer func() { _ = client.Close() }()
-
-	for {
-		details, err := client.Recv()
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			return 0, errors.Wrapf(err, "error attempting to get interface details for IP %q", interfaceIP)
-		}
-
-		ipAddressClient, err := ip.NewServiceClient(vppConn).IPAddressDump(ctx, &ip.IPAddressDump{
-			SwIfIndex: details.SwIfIndex,
-			IsIPv6:    interfaceIP.To4() == nil,
-		})
-		if err != nil {
-			return 0, errors.Wrapf(err, "error attempting to get ip address dump client for vpp interface %q ", details.InterfaceName)
-		}
-		defer func() { _ = ipAddressClient.Close() }()
-
-		for {
-			ipAddressDetails, err := ipAddressClient.Recv()
-			if err == io.EOF {
-				break
-			}
-			if err != nil {
-				return 0, errors.Wrapf(err, "error attempting to get ip address for %q (swIfIndex: %q)", details.InterfaceName, details.SwIfIndex)
-			}
-			if types.FromVppAddressWithPrefix(ipAddressDetails.Prefix).IP.Equal(interfaceIP) {
-				return details.SwIfIndex, nil
-			}
-		}
-	}
-	return interface_types.InterfaceIndex(^uint32(0)), errors.Errorf("unable to find interface in vpp with IP: %q", interfaceIP)
-}
-
 func createIPSecTunnel(ctx context.Context, vppConn api.Connection) (interface_types.InterfaceIndex, error) {
 	now := time.Now()
 
@@ -204,13 +174,8 @@ func createIPSecTunnel(ctx context.Context, vppConn api.Connection) (interface_t
 	return reply.SwIfIndex, nil
 }
 
-func delIPSecTunnel(ctx context.Context, vppConn api.Connection, isClient bool) error {
+func delIPSecTunnel(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex) error {
 	now := time.Now()
-	swIfIndex, ok := ifindex.LoadAndDelete(ctx, isClient)
-	if !ok {
-		return nil
-	}
-
 	_, err := ipsecapi.NewServiceClient(vppConn).IpsecItfDelete(ctx, &ipsecapi.IpsecItfDelete{SwIfIndex: swIfIndex})
 	if err != nil {
 		return err
@@ -513,8 +478,14 @@ func saInit(ctx context.Context, vppConn api.Connection, profileName string) err
 func delInterface(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, isClient bool) {
 	if mechanism := ipsec.ToMechanism(conn.GetMechanism()); mechanism != nil {
 		profileName := fmt.Sprintf("%s-%s", isClientPrefix(isClient), conn.Id)
-		_ = addDelProfile(ctx, vppConn, profileName, false)
-		_ = delIPSecTunnel(ctx, vppConn, isClient)
+		swIfIndex, ok := ifindex.LoadAndDelete(ctx, isClient)
+		_ = teardown.Do(ctx, teardown.Interfaces, func(ctx context.Context) error {
+			_ = addDelProfile(ctx, vppConn, profileName, false)
+			if ok {
+				_ = delTunnel(ctx, vppConn, conn, swIfIndex, isClient)
+			}
+			return nil
+		})
 	}
 }
 
@@ -0,0 +1,43 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipsec
+
+import (
+	"context"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	ipsecapi "github.com/edwarnicke/govpp/binapi/ipsec"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// setAsyncMode enables the async crypto handoff of the IPSec tunnels
+func setAsyncMode(ctx context.Context, vppConn api.Connection) error {
+	now := time.Now()
+	if _, err := ipsecapi.NewServiceClient(vppConn).IpsecSetAsyncMode(ctx, &ipsecapi.IpsecSetAsyncMode{
+		AsyncEnable: true,
+	}); err != nil {
+		return errors.Wrap(err, "vpp error enabling async crypto mode")
+	}
+	log.FromContext(ctx).
+		WithField("asyncEnable", true).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "IpsecSetAsyncMode").Debug("completed")
+	return nil
+}
@@ -16,4 +16,8 @@
 
 // Package ipsec provides networkservice.NetworkService{Client,Server} chain elements for the ipsec mechanism
 // The implementation is based on IKEv2 protocol
+//
+// The ESP options of the SAs are not configurable: vpp creates the SAs negotiated by IKEv2 with the anti-replay
+// check on and the fixed 64 packets window, and has no TFC padding. The vpp API (ikev2, ipsec) has no knobs for
+// them, the replay window of the SAs is only reported by IpsecSaV3Dump.
 package ipsec
@@ -0,0 +1,192 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipsec
+
+import (
+	"context"
+	"crypto/rsa"
+	"net"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/edwarnicke/govpp/binapi/ikev2"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const (
+	// GatewayAddressLabel - connection label with the address of the non-NSM IKEv2 responder (e.g. cloud VPN gateway)
+	// the client negotiates the SAs with instead of the remote sdk-vpp peer
+	GatewayAddressLabel = "ipsec-gateway"
+	// GatewayIDLabel - connection label with the IKEv2 identity (FQDN) of the gateway.
+	// The gateway address is used as the identity if the label is not set.
+	GatewayIDLabel = "ipsec-gateway-id"
+	// GatewayLocalIDLabel - connection label with the IKEv2 identity (FQDN) the client presents to the gateway
+	GatewayLocalIDLabel = "ipsec-gateway-local-id"
+	// GatewayAuthLabel - connection label with the gateway authentication method: GatewayAuthPSK or GatewayAuthCert
+	GatewayAuthLabel = "ipsec-gateway-auth"
+	// GatewayCertLabel - connection label with the path to the gateway certificate file (GatewayAuthCert)
+	GatewayCertLabel = "ipsec-gateway-cert"
+
+	// GatewayAuthPSK - pre-shared key authentication, the keys are provided with WithPreSharedKeys
+	GatewayAuthPSK = "psk"
+	// GatewayAuthCert - certificate (rsa-sig) authentication
+	GatewayAuthCert = "cert"
+)
+
+const (
+	authMethodRSASig    = 1
+	authMethodSharedKey = 2
+
+	idTypeIPv4 = 1
+	idTypeFQDN = 2
+	idTypeIPv6 = 5
+)
+
+// gateway - non-NSM IKEv2 responder
+type gateway struct {
+	address  net.IP
+	id       string
+	localID  string
+	auth     string
+	psk      string
+	certFile string
+}
+
+// gatewayFromLabels returns the gateway the connection labels point to, or nil if the connection is to the sdk-vpp peer
+func gatewayFromLabels(labels map[string]string, preSharedKeys map[string]string) (*gateway, error) {
+	addr, ok := labels[GatewayAddressLabel]
+	if !ok {
+		return nil, nil
+	}
+	gw := &gateway{
+		address: net.ParseIP(addr),
+		id:      labels[GatewayIDLabel],
+		localID: labels[GatewayLocalIDLabel],
+		auth:    labels[GatewayAuthLabel],
+	}
+	if gw.address == nil {
+		return nil, errors.Errorf("invalid %s label: %q", GatewayAddressLabel, addr)
+	}
+	switch gw.auth {
+	case GatewayAuthPSK, "":
+		gw.auth = GatewayAuthPSK
+		psk, ok := preSharedKeys[gw.identity()]
+		if !ok {
+			return nil, errors.Errorf("no pre-shared key for the IKEv2 gateway %s", gw.identity())
+		}
+		gw.psk = psk
+	case GatewayAuthCert:
+		if gw.certFile = labels[GatewayCertLabel]; gw.certFile == "" {
+			return nil, errors.Errorf("%s label is required for the %s authentication", GatewayCertLabel, GatewayAuthCert)
+		}
+	default:
+		return nil, errors.Errorf("unsupported IKEv2 gateway authentication method: %s", gw.auth)
+	}
+	return gw, nil
+}
+
+// identity returns the identity of the gateway
+func (g *gateway) identity() string {
+	if g.id != "" {
+		return g.id
+	}
+	return g.address.String()
+}
+
+func setGatewayAuth(ctx context.Context, vppConn api.Connection, profileName string, gw *gateway, privateKey *rsa.PrivateKey) error {
+	authMethod, data := uint8(authMethodSharedKey), []byte(gw.psk)
+	if gw.auth == GatewayAuthCert {
+		authMethod, data = authMethodRSASig, []byte(gw.certFile)
+	}
+
+	now := time.Now()
+	if _, err := ikev2.NewServiceClient(vppConn).Ikev2ProfileSetAuth(ctx, &ikev2.Ikev2ProfileSetAuth{
+		Name:       profileName,
+		AuthMethod: authMethod,
+		DataLen:    uint32(len(data)),
+		Data:       data,
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("Name", profileName).
+		WithField("AuthMethod", gw.auth).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "Ikev2ProfileSetAuth").Debug("completed")
+
+	if gw.auth != GatewayAuthCert {
+		return nil
+	}
+	privateKeyFileName, err := dumpPrivateKeyToFile(privateKey, profileName, true)
+	if err != nil {
+		return err
+	}
+	now = time.Now()
+	if _, err := ikev2.NewServiceClient(vppConn).Ikev2SetLocalKey(ctx, &ikev2.Ikev2SetLocalKey{
+		KeyFile: privateKeyFileName,
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "Ikev2SetLocalKey").Debug("completed")
+	return nil
+}
+
+func setGatewayIDs(ctx context.Context, vppConn api.Connection, profileName string, gw *gateway, localIP net.IP) error {
+	localIDType, localID := toIKEv2ID(gw.localID, localIP)
+	remoteIDType, remoteID := toIKEv2ID(gw.id, gw.address)
+	for _, id := range []struct {
+		isLocal bool
+		idType  uint8
+		data    []byte
+	}{
+		{isLocal: true, idType: localIDType, data: localID},
+		{isLocal: false, idType: remoteIDType, data: remoteID},
+	} {
+		now := time.Now()
+		if _, err := ikev2.NewServiceClient(vppConn).Ikev2ProfileSetID(ctx, &ikev2.Ikev2ProfileSetID{
+			Name:    profileName,
+			IsLocal: id.isLocal,
+			IDType:  id.idType,
+			DataLen: uint32(len(id.data)),
+			Data:    id.data,
+		}); err != nil {
+			return errors.WithStack(err)
+		}
+		log.FromContext(ctx).
+			WithField("Name", profileName).
+			WithField("IsLocal", id.isLocal).
+			WithField("IDType", id.idType).
+			WithField("duration", time.Since(now)).
+			WithField("vppapi", "Ikev2ProfileSetID").Debug("completed")
+	}
+	return nil
+}
+
+// toIKEv2ID returns the FQDN IKEv2 identity if fqdn is set, otherwise the address identity
+func toIKEv2ID(fqdn string, ip net.IP) (idType uint8, data []byte) {
+	if fqdn != "" {
+		return idTypeFQDN, []byte(fqdn)
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		return idTypeIPv4, ip4
+	}
+	return idTypeIPv6, ip.To16()
+}
@@ -19,8 +19,6 @@ package mtu
 import (
 	"context"
 	"net"
-	"sync"
-	"sync/atomic"
 
 	"git.fd.io/govpp.git/api"
 	"google.golang.org/grpc"
@@ -30,41 +28,52 @@ import (
 	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/ipsec"
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/mtupath"
 )
 
 type mtuClient struct {
-	vppConn  api.Connection
-	tunnelIP net.IP
-	mtu      uint32
-
-	inited    uint32
-	initMutex sync.Mutex
+	mtus *tunnelMTUs
 }
 
-// NewClient - returns client chain element to manage ipsec MTU
-func NewClient(vppConn api.Connection, tunnelIP net.IP) networkservice.NetworkServiceClient {
+// NewClient - returns client chain element to manage ipsec MTU. The MTU is computed for the tunnel IP of the
+// family of the remote end of the tunnel, pass the optional IPv6 tunnel IP to compute it for the IPv6 tunnels.
+func NewClient(vppConn api.Connection, tunnelIP net.IP, ipv6TunnelIP ...net.IP) networkservice.NetworkServiceClient {
 	return &mtuClient{
-		vppConn:  vppConn,
-		tunnelIP: tunnelIP,
+		mtus: newTunnelMTUs(vppConn, append([]net.IP{tunnelIP}, ipv6TunnelIP...)...),
 	}
 }
 
 func (m *mtuClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
-	if err := m.init(ctx); err != nil {
-		return nil, err
-	}
-	if mechanism := ipsec.ToMechanism(request.GetConnection().GetMechanism()); mechanism != nil && (mechanism.MTU() == 0 || mechanism.MTU() > m.mtu) {
-		mechanism.SetMTU(m.mtu)
-	}
-	for _, mech := range request.GetMechanismPreferences() {
-		if mechanism := ipsec.ToMechanism(mech); mechanism != nil && (mechanism.MTU() == 0 || mechanism.MTU() > m.mtu) {
-			mechanism.SetMTU(m.mtu)
+	mechanisms := append([]*networkservice.Mechanism{request.GetConnection().GetMechanism()}, request.GetMechanismPreferences()...)
+	for _, mech := range mechanisms {
+		mechanism := ipsec.ToMechanism(mech)
+		if mechanism == nil {
+			continue
+		}
+		path, err := m.mtus.get(ctx, remoteIP(mechanism))
+		if err != nil {
+			return nil, err
+		}
+		mtu := path.tunnel
+		if mechanism.MTU() == 0 || mechanism.MTU() > mtu {
+			mechanism.SetMTU(mtu)
 		}
 	}
 	conn, err := next.Client(ctx).Request(ctx, request, opts...)
 	if err != nil {
 		return nil, err
 	}
+	if mechanism := ipsec.ToMechanism(conn.GetMechanism()); mechanism != nil {
+		path, err := m.mtus.get(ctx, remoteIP(mechanism))
+		if err != nil {
+			return nil, err
+		}
+		mtu := path.tunnel
+		mtupath.Store(ctx, metadata.IsClient(m), mtupath.UplinkHop, path.uplink)
+		mtupath.Store(ctx, metadata.IsClient(m), ipsec.MECHANISM, mtu)
+	}
 	return conn, nil
 }
 
@@ -72,20 +81,11 @@ func (m *mtuClient) Close(ctx context.Context, conn *networkservice.Connection,
 	return next.Client(ctx).Close(ctx, conn, opts...)
 }
 
-func (m *mtuClient) init(ctx context.Context) error {
-	if atomic.LoadUint32(&m.inited) > 0 {
-		return nil
-	}
-	m.initMutex.Lock()
-	defer m.initMutex.Unlock()
-	if atomic.LoadUint32(&m.inited) > 0 {
-		return nil
-	}
-
-	var err error
-	m.mtu, err = getMTU(ctx, m.vppConn, m.tunnelIP)
-	if err == nil {
-		atomic.StoreUint32(&m.inited, 1)
+// remoteIP returns the IP of the remote end of the tunnel, or the local one of the same family if the remote one is
+// not known yet
+func remoteIP(mechanism *ipsec.Mechanism) net.IP {
+	if ip := mechanism.DstIP(); ip != nil {
+		return ip
 	}
-	return err
+	return mechanism.SrcIP()
 }
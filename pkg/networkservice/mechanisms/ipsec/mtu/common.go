@@ -18,56 +18,70 @@ package mtu
 
 import (
 	"context"
-	"io"
 	"net"
+	"sync"
 
 	"git.fd.io/govpp.git/api"
-	interfaces "github.com/edwarnicke/govpp/binapi/interface"
-	"github.com/edwarnicke/govpp/binapi/ip"
 	"github.com/pkg/errors"
 
-	"github.com/networkservicemesh/sdk-vpp/pkg/tools/types"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/tunnelip"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/uplink"
 )
 
-func getMTU(ctx context.Context, vppConn api.Connection, tunnelIP net.IP) (uint32, error) {
-	client, err := interfaces.NewServiceClient(vppConn).SwInterfaceDump(ctx, &interfaces.SwInterfaceDump{})
-	if err != nil {
-		return 0, errors.Wrapf(err, "error attempting to get interface dump client to determine MTU for tunnelIP %q", tunnelIP)
+// tunnelMTUs caches the MTUs of the tunnels over the uplinks of the tunnel IPs, the IPv4 and IPv6 tunnels differ in
+// both the uplink and the overhead
+type tunnelMTUs struct {
+	vppConn   api.Connection
+	tunnelIPs []net.IP
+
+	mu   sync.Mutex
+	mtus map[string]pathMTU
+}
+
+// pathMTU - the MTU of the tunnel and of the uplink it is carried over
+type pathMTU struct {
+	tunnel uint32
+	uplink uint32
+}
+
+func newTunnelMTUs(vppConn api.Connection, tunnelIPs ...net.IP) *tunnelMTUs {
+	return &tunnelMTUs{
+		vppConn:   vppConn,
+		tunnelIPs: tunnelIPs,
+		mtus:      make(map[string]pathMTU),
 	}
-	defer func() { _ = client.Close() }()
+}
 
-	for {
-		details, err := client.Recv()
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			return 0, errors.Wrapf(err, "error attempting to get interface details to determine MTU for tunnelIP %q", tunnelIP)
-		}
+// get returns the MTU of the tunnel from the tunnel IP of the remoteIP family (of the primary tunnel IP if remoteIP
+// is nil)
+func (t *tunnelMTUs) get(ctx context.Context, remoteIP net.IP) (pathMTU, error) {
+	tunnelIP := tunnelip.Select(remoteIP, t.tunnelIPs...)
 
-		ipAddressClient, err := ip.NewServiceClient(vppConn).IPAddressDump(ctx, &ip.IPAddressDump{
-			SwIfIndex: details.SwIfIndex,
-			IsIPv6:    tunnelIP.To4() == nil,
-		})
-		if err != nil {
-			return 0, errors.Wrapf(err, "error attempting to get ip address for vpp interface %q determine MTU for tunnelIP %q", details.InterfaceName, tunnelIP)
-		}
-		defer func() { _ = ipAddressClient.Close() }()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if mtu, ok := t.mtus[tunnelIP.String()]; ok {
+		return mtu, nil
+	}
+	mtu, err := getMTU(ctx, t.vppConn, tunnelIP)
+	if err != nil {
+		return pathMTU{}, err
+	}
+	t.mtus[tunnelIP.String()] = mtu
+	return mtu, nil
+}
 
-		for {
-			ipAddressDetails, err := ipAddressClient.Recv()
-			if err == io.EOF {
-				break
-			}
-			if err != nil {
-				return 0, errors.Wrapf(err, "error attempting to get interface ip address for %q (swIfIndex: %q) to determine MTU for tunnelIP %q", details.InterfaceName, details.SwIfIndex, tunnelIP)
-			}
-			if types.FromVppAddressWithPrefix(ipAddressDetails.Prefix).IP.Equal(tunnelIP) && details.Mtu[0] != 0 {
-				return (details.Mtu[0] - overhead(tunnelIP.To4() == nil)), nil
-			}
-		}
+func getMTU(ctx context.Context, vppConn api.Connection, tunnelIP net.IP) (pathMTU, error) {
+	u, err := uplink.ByIP(ctx, vppConn, tunnelIP)
+	if err != nil {
+		return pathMTU{}, errors.Wrapf(err, "error attempting to determine MTU for tunnelIP %q", tunnelIP)
+	}
+	if u.MTU == 0 {
+		return pathMTU{}, errors.Errorf("interface IP MTU is zero for interface %q with tunnelIP: %q", u.Name, tunnelIP)
 	}
-	return 0, errors.Errorf("unable to find interface in vpp with tunnelIP: %q or interface IP MTU is zero", tunnelIP)
+	return pathMTU{
+		tunnel: u.MTU - overhead(tunnelIP.To4() == nil),
+		uplink: u.MTU,
+	}, nil
 }
 
 func overhead(isV6 bool) uint32 {
@@ -0,0 +1,112 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipsec
+
+import (
+	"net"
+
+	"github.com/edwarnicke/govpp/binapi/ip_types"
+	"github.com/edwarnicke/govpp/binapi/tunnel_types"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/keybinding"
+)
+
+type ipsecOptions struct {
+	ipv6TunnelIP net.IP
+	asyncCrypto  bool
+	transport    string
+	psks         map[string]string
+	keyBinding   *keybinding.Binder
+	tos          tunnelTOS
+}
+
+// Option is an option pattern for IPSec server/client
+type Option func(o *ipsecOptions)
+
+// WithIPv6TunnelIP sets an additional IPv6 tunnel IP. It is used instead of the tunnelIP passed to the
+// constructor when the remote side of the connection advertises an IPv6 address.
+func WithIPv6TunnelIP(ipv6TunnelIP net.IP) Option {
+	return func(o *ipsecOptions) {
+		o.ipv6TunnelIP = ipv6TunnelIP
+	}
+}
+
+// WithAsyncCrypto prefers the async crypto handoff (e.g. to a QAT accelerator via dpdk_cryptodev) for the SAs of the IPSec
+// tunnels if vpp has an async crypto engine available
+func WithAsyncCrypto() Option {
+	return func(o *ipsecOptions) {
+		o.asyncCrypto = true
+	}
+}
+
+// WithPreSharedKeys sets the pre-shared keys of the non-NSM IKEv2 gateways the client negotiates the SAs with
+// (see GatewayAddressLabel). The keys are indexed by the gateway identity.
+func WithPreSharedKeys(psks map[string]string) Option {
+	return func(o *ipsecOptions) {
+		o.psks = psks
+	}
+}
+
+// WithKeyBinding binds the IKEv2 certificates passed in the mechanism parameters to the SPIFFE identities of the
+// forwarders: the own certificate is signed and the certificate of the remote side is verified with binder
+func WithKeyBinding(binder *keybinding.Binder) Option {
+	return func(o *ipsecOptions) {
+		o.keyBinding = binder
+	}
+}
+
+// WithTransport sets the tunnel interface protected by the IPSec SAs (TransportIPSec, TransportIPIP or TransportGRE).
+// The client advertises the transport in the mechanism parameters, the server follows it.
+// Route-based IPSec over the ipip/gre tunnels simplifies the interop with the third-party gateways.
+func WithTransport(transport string) Option {
+	return func(o *ipsecOptions) {
+		o.transport = transport
+	}
+}
+
+// WithCopyECN copies the ECN bits of the inner header into the outer one on encap, and the CE mark of the outer header
+// back into the inner one on decap, so the ECN based congestion control (e.g. L4S) works across the tunnel.
+// Applies to the ipip/gre transports only: vpp negotiates the SAs of the ipsec interface with the fixed TOS handling.
+func WithCopyECN() Option {
+	return func(o *ipsecOptions) {
+		o.tos.flags |= tunnel_types.TUNNEL_API_ENCAP_DECAP_FLAG_ENCAP_COPY_ECN | tunnel_types.TUNNEL_API_ENCAP_DECAP_FLAG_DECAP_COPY_ECN
+	}
+}
+
+// WithCopyDSCP copies the DSCP of the inner header into the outer one on encap (ipip/gre transports only)
+func WithCopyDSCP() Option {
+	return func(o *ipsecOptions) {
+		o.tos.flags |= tunnel_types.TUNNEL_API_ENCAP_DECAP_FLAG_ENCAP_COPY_DSCP
+	}
+}
+
+// WithDSCP sets the fixed DSCP of the outer header, it is ignored if WithCopyDSCP is set (ipip transport only, vpp
+// has no fixed DSCP for the gre tunnels)
+func WithDSCP(dscp uint8) Option {
+	return func(o *ipsecOptions) {
+		o.tos.dscp = ip_types.IPDscp(dscp)
+	}
+}
+
+// WithInnerFlowHash derives the outer IPv6 flow label from the hash of the inner flow, so the underlay ECMP spreads the
+// flows of the connection across the paths instead of pinning them to one (ipip/gre transports over IPv6 only: the
+// gre/ipip headers carry no ports and the outer IPv4 header has no flow label)
+func WithInnerFlowHash() Option {
+	return func(o *ipsecOptions) {
+		o.tos.flags |= tunnel_types.TUNNEL_API_ENCAP_DECAP_FLAG_ENCAP_INNER_HASH
+	}
+}
@@ -34,20 +34,34 @@ import (
 
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/ipsec/mtu"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/pinhole"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/cryptoengine"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/keybinding"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/tunnelip"
 )
 
 type ipsecServer struct {
-	vppConn  api.Connection
-	tunnelIP net.IP
+	vppConn   api.Connection
+	tunnelIPs []net.IP
+	crypto    *cryptoengine.Selector
+	keys      *keybinding.Binder
+	tos       tunnelTOS
 }
 
 // NewServer - returns a new server for the IPSec remote mechanism
-func NewServer(vppConn api.Connection, tunnelIP net.IP) networkservice.NetworkServiceServer {
+func NewServer(vppConn api.Connection, tunnelIP net.IP, options ...Option) networkservice.NetworkServiceServer {
+	opts := &ipsecOptions{}
+	for _, opt := range options {
+		opt(opts)
+	}
+
 	return chain.NewNetworkServiceServer(
-		mtu.NewServer(vppConn, tunnelIP),
+		mtu.NewServer(vppConn, tunnelIP, opts.ipv6TunnelIP),
 		&ipsecServer{
-			vppConn:  vppConn,
-			tunnelIP: tunnelIP,
+			vppConn:   vppConn,
+			tunnelIPs: []net.IP{tunnelIP, opts.ipv6TunnelIP},
+			crypto:    cryptoengine.NewSelector(vppConn, "IPSec", opts.asyncCrypto, setAsyncMode),
+			keys:      opts.keyBinding,
+			tos:       opts.tos,
 		},
 	)
 }
@@ -56,12 +70,21 @@ func (i *ipsecServer) Request(ctx context.Context, request *networkservice.Netwo
 	if request.GetConnection().GetPayload() != payload.IP {
 		return next.Server(ctx).Request(ctx, request)
 	}
+	i.crypto.Init(ctx)
 	if mechanism := ipsecMech.ToMechanism(request.GetConnection().GetMechanism()); mechanism != nil {
-		mechanism.SetDstIP(i.tunnelIP)
+		if err := i.keys.Verify(request.GetConnection().GetMechanism(), mechanism.SrcPublicKey(), true); err != nil {
+			return nil, err
+		}
+		dstIP := tunnelip.Select(mechanism.SrcIP(), i.tunnelIPs...)
+		// Per connection tunnel IP overrides the default one
+		if ip, ok := tunnelip.Load(ctx, metadata.IsClient(i)); ok {
+			dstIP = ip
+		}
+		mechanism.SetDstIP(dstIP)
 		mechanism.SetDstPort(ikev2DefaultPort)
 
 		// Store extra IPPort entry to allow IKE protocol - https://www.rfc-editor.org/rfc/rfc5996
-		pinhole.StoreExtra(ctx, metadata.IsClient(i), pinhole.NewIPPort(i.tunnelIP.String(), 500))
+		pinhole.StoreExtra(ctx, metadata.IsClient(i), pinhole.NewIPPort(dstIP.String(), 500))
 	}
 
 	postponeCtxFunc := postpone.ContextWithValues(ctx)
@@ -82,7 +105,10 @@ func (i *ipsecServer) Request(ctx context.Context, request *networkservice.Netwo
 		}
 		mechanism.SetDstPublicKey(publicKey)
 
-		err = create(ctx, conn, i.vppConn, rsaKey, metadata.IsClient(i))
+		err = i.keys.Sign(conn.GetMechanism(), publicKey, false)
+		if err == nil {
+			err = create(ctx, conn, i.vppConn, rsaKey, nil, &i.tos, metadata.IsClient(i))
+		}
 		if err != nil {
 			closeCtx, cancelClose := postponeCtxFunc()
 			defer cancelClose()
@@ -93,6 +119,7 @@ func (i *ipsecServer) Request(ctx context.Context, request *networkservice.Netwo
 
 			return nil, err
 		}
+		i.crypto.SetMetric(conn)
 	}
 
 	return conn, nil
@@ -0,0 +1,28 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipsec
+
+import (
+	"github.com/edwarnicke/govpp/binapi/ip_types"
+	"github.com/edwarnicke/govpp/binapi/tunnel_types"
+)
+
+// tunnelTOS is the ECN/DSCP and the flow label handling of the outer header of the ipip/gre transport tunnels
+type tunnelTOS struct {
+	flags tunnel_types.TunnelEncapDecapFlags
+	dscp  ip_types.IPDscp
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipsec
+
+import (
+	"context"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/edwarnicke/govpp/binapi/gre"
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+	"github.com/edwarnicke/govpp/binapi/ipip"
+	"github.com/edwarnicke/govpp/binapi/tunnel_types"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/ipsec"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/types"
+)
+
+const (
+	// TransportParam - IPSec mechanism parameter selecting the tunnel interface protected by the IKEv2 negotiated SAs
+	TransportParam = "transport"
+	// TransportIPSec - ipsec interface (default)
+	TransportIPSec = "ipsec"
+	// TransportIPIP - route-based IPSec over the ipip tunnel
+	TransportIPIP = "ipip"
+	// TransportGRE - route-based IPSec over the L3 gre tunnel
+	TransportGRE = "gre"
+)
+
+// transport returns the transport of the IPSec mechanism
+func transport(mechanism *networkservice.Mechanism) string {
+	if t, ok := mechanism.GetParameters()[TransportParam]; ok && t != "" {
+		return t
+	}
+	return TransportIPSec
+}
+
+// createTunnel creates the tunnel interface of the transport. The tunnel is then protected by the SAs negotiated with
+// the IKEv2 profile (ipsec tunnel protect).
+func createTunnel(ctx context.Context, vppConn api.Connection, conn *networkservice.Connection, tos *tunnelTOS, isClient bool) (interface_types.InterfaceIndex, error) {
+	mechanism := ipsec.ToMechanism(conn.GetMechanism())
+	src, dst := mechanism.SrcIP(), mechanism.DstIP()
+	if !isClient {
+		src, dst = dst, src
+	}
+
+	now := time.Now()
+	switch t := transport(conn.GetMechanism()); t {
+	case TransportIPSec:
+		return createIPSecTunnel(ctx, vppConn)
+	case TransportIPIP:
+		reply, err := ipip.NewServiceClient(vppConn).IpipAddTunnel(ctx, &ipip.IpipAddTunnel{
+			Tunnel: ipip.IpipTunnel{
+				Instance: ^uint32(0),
+				Src:      types.ToVppAddress(src),
+				Dst:      types.ToVppAddress(dst),
+				Mode:     tunnel_types.TUNNEL_API_MODE_P2P,
+				Flags:    tos.flags,
+				Dscp:     tos.dscp,
+			},
+		})
+		if err != nil {
+			return interface_types.InterfaceIndex(^uint32(0)), errors.WithStack(err)
+		}
+		log.FromContext(ctx).
+			WithField("swIfIndex", reply.SwIfIndex).
+			WithField("src", src).
+			WithField("dst", dst).
+			WithField("duration", time.Since(now)).
+			WithField("vppapi", "IpipAddTunnel").Debug("completed")
+		return reply.SwIfIndex, nil
+	case TransportGRE:
+		reply, err := gre.NewServiceClient(vppConn).GreTunnelAddDel(ctx, &gre.GreTunnelAddDel{
+			IsAdd: true,
+			Tunnel: gre.GreTunnel{
+				Type:     gre.GRE_API_TUNNEL_TYPE_L3,
+				Mode:     tunnel_types.TUNNEL_API_MODE_P2P,
+				Instance: ^uint32(0),
+				Src:      types.ToVppAddress(src),
+				Dst:      types.ToVppAddress(dst),
+				Flags:    tos.flags,
+			},
+		})
+		if err != nil {
+			return interface_types.InterfaceIndex(^uint32(0)), errors.WithStack(err)
+		}
+		log.FromContext(ctx).
+			WithField("swIfIndex", reply.SwIfIndex).
+			WithField("src", src).
+			WithField("dst", dst).
+			WithField("duration", time.Since(now)).
+			WithField("vppapi", "GreTunnelAddDel").Debug("completed")
+		return reply.SwIfIndex, nil
+	default:
+		return interface_types.InterfaceIndex(^uint32(0)), errors.Errorf("unsupported IPSec transport: %s", t)
+	}
+}
+
+// delTunnel deletes the tunnel interface of the transport
+func delTunnel(ctx context.Context, vppConn api.Connection, conn *networkservice.Connection, swIfIndex interface_types.InterfaceIndex, isClient bool) error {
+	mechanism := ipsec.ToMechanism(conn.GetMechanism())
+	src, dst := mechanism.SrcIP(), mechanism.DstIP()
+	if !isClient {
+		src, dst = dst, src
+	}
+
+	now := time.Now()
+	switch transport(conn.GetMechanism()) {
+	case TransportIPIP:
+		if _, err := ipip.NewServiceClient(vppConn).IpipDelTunnel(ctx, &ipip.IpipDelTunnel{
+			SwIfIndex: swIfIndex,
+		}); err != nil {
+			return errors.WithStack(err)
+		}
+		log.FromContext(ctx).
+			WithField("swIfIndex", swIfIndex).
+			WithField("duration", time.Since(now)).
+			WithField("vppapi", "IpipDelTunnel").Debug("completed")
+	case TransportGRE:
+		// vpp looks up the gre tunnel to delete by its endpoints
+		if _, err := gre.NewServiceClient(vppConn).GreTunnelAddDel(ctx, &gre.GreTunnelAddDel{
+			IsAdd: false,
+			Tunnel: gre.GreTunnel{
+				Type:      gre.GRE_API_TUNNEL_TYPE_L3,
+				Mode:      tunnel_types.TUNNEL_API_MODE_P2P,
+				Instance:  ^uint32(0),
+				SwIfIndex: swIfIndex,
+				Src:       types.ToVppAddress(src),
+				Dst:       types.ToVppAddress(dst),
+			},
+		}); err != nil {
+			return errors.WithStack(err)
+		}
+		log.FromContext(ctx).
+			WithField("swIfIndex", swIfIndex).
+			WithField("duration", time.Since(now)).
+			WithField("vppapi", "GreTunnelAddDel").Debug("completed")
+	default:
+		return delIPSecTunnel(ctx, vppConn, swIfIndex)
+	}
+	return nil
+}
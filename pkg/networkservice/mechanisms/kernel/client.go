@@ -31,9 +31,9 @@ import (
 )
 
 // NewClient - returns a new Client chain element implementing the kernel mechanism with vpp
-func NewClient(vppConn api.Connection) networkservice.NetworkServiceClient {
+func NewClient(vppConn api.Connection, opts ...kerneltap.Option) networkservice.NetworkServiceClient {
 	if _, err := os.Stat(vnetFilename); err == nil {
-		return kerneltap.NewClient(vppConn)
+		return kerneltap.NewClient(vppConn, opts...)
 	}
 	return kernelvethpair.NewClient(vppConn)
 }
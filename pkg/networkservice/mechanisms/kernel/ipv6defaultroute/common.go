@@ -0,0 +1,222 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package ipv6defaultroute
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	kernellink "github.com/networkservicemesh/sdk-kernel/pkg/kernel"
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/nshandle"
+)
+
+// kernelDefaultMetric - metric of the IPv6 routes added without the metric, e.g. by the routes element
+const kernelDefaultMetric = 1024
+
+func create(ctx context.Context, conn *networkservice.Connection, o *options, isClient bool) error {
+	mechanism := kernel.ToMechanism(conn.GetMechanism())
+	if mechanism == nil || mechanism.GetVLAN() != 0 {
+		return nil
+	}
+	routes := defaultRoutes(conn, isClient)
+	if len(routes) == 0 && !o.suppressRA {
+		del(ctx, isClient)
+		return nil
+	}
+
+	handle, err := kernellink.GetNetlinkHandle(mechanism.GetNetNSURL())
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer handle.Close()
+
+	l, err := handle.LinkByName(mechanism.GetInterfaceName())
+	if err != nil {
+		return errors.Wrapf(err, "unable to find link %s", mechanism.GetInterfaceName())
+	}
+
+	if o.suppressRA {
+		if err := suppressRA(ctx, mechanism.GetNetNSURL(), l.Attrs().Name); err != nil {
+			return err
+		}
+	}
+
+	current := &programmed{
+		netNSURL: mechanism.GetNetNSURL(),
+		routes:   toRoutes(l, o.metric, routes),
+	}
+	// The routes element (re)adds the default route with the kernel default metric on each Request, the route
+	// would replace the default route of the other interface with the same metric, so it is always removed
+	if o.metric != kernelDefaultMetric {
+		for _, route := range toRoutes(l, kernelDefaultMetric, routes) {
+			if err := handle.RouteDel(route); err == nil {
+				log.FromContext(ctx).
+					WithField("route", route.String()).
+					WithField("netlink", "RouteDel").Debug("completed")
+			}
+		}
+	}
+	if prev, ok := load(ctx, isClient); ok {
+		if equal(prev, current) {
+			return nil
+		}
+		del(ctx, isClient)
+	}
+	store(ctx, isClient, current)
+
+	for _, route := range current.routes {
+		now := time.Now()
+		if o.replace {
+			if err := handle.RouteReplace(route); err != nil {
+				return errors.Wrapf(err, "unable to replace route %s", route)
+			}
+			log.FromContext(ctx).
+				WithField("link.Name", l.Attrs().Name).
+				WithField("route", route.String()).
+				WithField("duration", time.Since(now)).
+				WithField("netlink", "RouteReplace").Debug("completed")
+			continue
+		}
+		if err := handle.RouteAdd(route); err != nil && !errors.Is(err, syscall.EEXIST) {
+			return errors.Wrapf(err, "unable to add route %s", route)
+		}
+		log.FromContext(ctx).
+			WithField("link.Name", l.Attrs().Name).
+			WithField("route", route.String()).
+			WithField("duration", time.Since(now)).
+			WithField("netlink", "RouteAdd").Debug("completed")
+	}
+	return nil
+}
+
+// del deletes the default routes. The routes are deleted by the kernel with the interface as well.
+func del(ctx context.Context, isClient bool) {
+	prev, ok := loadAndDelete(ctx, isClient)
+	if !ok {
+		return
+	}
+	handle, err := kernellink.GetNetlinkHandle(prev.netNSURL)
+	if err != nil {
+		log.FromContext(ctx).Errorf("unable to delete the default routes: %v", err)
+		return
+	}
+	defer handle.Close()
+
+	for _, route := range prev.routes {
+		now := time.Now()
+		if err := handle.RouteDel(route); err != nil {
+			log.FromContext(ctx).Debugf("unable to delete route %s: %v", route, err)
+			continue
+		}
+		log.FromContext(ctx).
+			WithField("route", route.String()).
+			WithField("duration", time.Since(now)).
+			WithField("netlink", "RouteDel").Debug("completed")
+	}
+}
+
+// defaultRoutes returns the IPv6 default routes toward the other end of the connection
+func defaultRoutes(conn *networkservice.Connection, isClient bool) []*networkservice.Route {
+	routes := conn.GetContext().GetIpContext().GetDstRoutes()
+	if isClient {
+		routes = conn.GetContext().GetIpContext().GetSrcRoutes()
+	}
+	var rv []*networkservice.Route
+	for _, route := range routes {
+		prefix := route.GetPrefixIPNet()
+		if prefix == nil || prefix.IP.To4() != nil {
+			continue
+		}
+		if ones, _ := prefix.Mask.Size(); ones == 0 {
+			rv = append(rv, route)
+		}
+	}
+	return rv
+}
+
+func toRoutes(l netlink.Link, metric int, routes []*networkservice.Route) []*netlink.Route {
+	var rv []*netlink.Route
+	for _, route := range routes {
+		r := &netlink.Route{
+			LinkIndex: l.Attrs().Index,
+			Scope:     netlink.SCOPE_LINK,
+			Dst:       &net.IPNet{IP: net.IPv6zero, Mask: net.CIDRMask(0, net.IPv6len*8)},
+			Priority:  metric,
+		}
+		if gw := route.GetNextHopIP(); gw != nil {
+			r.Gw = gw
+			r.Scope = netlink.SCOPE_UNIVERSE
+			r.SetFlag(netlink.FLAG_ONLINK)
+		}
+		rv = append(rv, r)
+	}
+	return rv
+}
+
+func equal(a, b *programmed) bool {
+	if a.netNSURL != b.netNSURL || len(a.routes) != len(b.routes) {
+		return false
+	}
+	for i := range a.routes {
+		if a.routes[i].String() != b.routes[i].String() || a.routes[i].Priority != b.routes[i].Priority {
+			return false
+		}
+	}
+	return true
+}
+
+// suppressRA disables accepting the router advertisements on the interface in the network namespace
+func suppressRA(ctx context.Context, netNSURL, ifName string) error {
+	nsHandle, err := nshandle.FromURL(netNSURL)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get net NS handle by URL: %s", netNSURL)
+	}
+	defer func() { _ = nsHandle.Close() }()
+
+	current, err := nshandle.Current()
+	if err != nil {
+		return errors.Wrap(err, "failed to get current net NS")
+	}
+	defer func() { _ = current.Close() }()
+
+	now := time.Now()
+	path := filepath.Join("/proc/sys/net/ipv6/conf", ifName, "accept_ra")
+	if err := nshandle.RunIn(current, nsHandle, func() error {
+		return os.WriteFile(path, []byte("0"), 0o600)
+	}); err != nil {
+		return errors.Wrapf(err, "unable to disable the router advertisements on %s", ifName)
+	}
+	log.FromContext(ctx).
+		WithField("link.Name", ifName).
+		WithField("duration", time.Since(now)).
+		WithField("sysctl", path).Debug("completed")
+	return nil
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package ipv6defaultroute
+
+import (
+	"context"
+
+	"github.com/vishvananda/netlink"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+type key struct{}
+
+// programmed - the default routes programmed in the network namespace
+type programmed struct {
+	netNSURL string
+	routes   []*netlink.Route
+}
+
+func store(ctx context.Context, isClient bool, value *programmed) {
+	metadata.Map(ctx, isClient).Store(key{}, value)
+}
+
+func load(ctx context.Context, isClient bool) (value *programmed, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).Load(key{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(*programmed)
+	return value, ok
+}
+
+func loadAndDelete(ctx context.Context, isClient bool) (value *programmed, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).LoadAndDelete(key{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(*programmed)
+	return value, ok
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package ipv6defaultroute
+
+const (
+	// defaultMetric - lower than the metric of the routes added by the routes element and of the routes learned from
+	// the router advertisements (1024)
+	defaultMetric = 100
+)
+
+type options struct {
+	metric     int
+	replace    bool
+	suppressRA bool
+}
+
+// Option is an option pattern for ipv6defaultroute client/server
+type Option func(o *options)
+
+// WithMetric sets the metric of the IPv6 default route, 100 by default
+func WithMetric(metric int) Option {
+	return func(o *options) {
+		o.metric = metric
+	}
+}
+
+// WithReplace makes the element replace the IPv6 default route with the same metric if any. By default the route is
+// added next to the existing ones.
+func WithReplace() Option {
+	return func(o *options) {
+		o.replace = true
+	}
+}
+
+// WithRASuppression disables accepting the router advertisements on the kernel interface, so the default route
+// programmed by the element can not be overridden by them
+func WithRASuppression() Option {
+	return func(o *options) {
+		o.suppressRA = true
+	}
+}
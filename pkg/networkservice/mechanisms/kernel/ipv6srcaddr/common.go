@@ -0,0 +1,111 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package ipv6srcaddr
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	kernellink "github.com/networkservicemesh/sdk-kernel/pkg/kernel"
+)
+
+// setSrc sets the NSM IPv6 address as the src of the IPv6 routes via the kernel interface in all the tables
+func setSrc(ctx context.Context, conn *networkservice.Connection, isClient bool) error {
+	mechanism := kernel.ToMechanism(conn.GetMechanism())
+	if mechanism == nil || mechanism.GetVLAN() != 0 {
+		return nil
+	}
+	src := srcIP(conn, isClient)
+	if src == nil {
+		return nil
+	}
+
+	handle, err := kernellink.GetNetlinkHandle(mechanism.GetNetNSURL())
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer handle.Close()
+
+	l, err := handle.LinkByName(mechanism.GetInterfaceName())
+	if err != nil {
+		return errors.Wrapf(err, "unable to find link %s", mechanism.GetInterfaceName())
+	}
+
+	routes, err := handle.RouteListFiltered(netlink.FAMILY_V6, &netlink.Route{
+		LinkIndex: l.Attrs().Index,
+		Table:     unix.RT_TABLE_UNSPEC,
+	}, netlink.RT_FILTER_OIF|netlink.RT_FILTER_TABLE)
+	if err != nil {
+		return errors.Wrapf(err, "unable to list the routes of %s", l.Attrs().Name)
+	}
+	for i := range routes {
+		route := &routes[i]
+		if !hinted(route) || route.Src.Equal(src) {
+			continue
+		}
+		route.Src = src
+		now := time.Now()
+		if err := handle.RouteReplace(route); err != nil {
+			return errors.Wrapf(err, "unable to set the src of route %s", route)
+		}
+		log.FromContext(ctx).
+			WithField("link.Name", l.Attrs().Name).
+			WithField("route", route.String()).
+			WithField("duration", time.Since(now)).
+			WithField("netlink", "RouteReplace").Debug("completed")
+	}
+	return nil
+}
+
+// hinted returns true for the routes the source is chosen for: the unicast routes not added by the kernel (the
+// connected and the link local ones) to the global destinations
+func hinted(route *netlink.Route) bool {
+	if route.Type != unix.RTN_UNICAST || route.Table == unix.RT_TABLE_LOCAL ||
+		route.Protocol == netlink.RouteProtocol(unix.RTPROT_KERNEL) {
+		return false
+	}
+	if route.Dst == nil {
+		return true
+	}
+	return !route.Dst.IP.IsLinkLocalUnicast() && !route.Dst.IP.IsMulticast()
+}
+
+// srcIP returns the first IPv6 address of the kernel interface side of the connection
+func srcIP(conn *networkservice.Connection, isClient bool) net.IP {
+	ipNets := conn.GetContext().GetIpContext().GetSrcIPNets()
+	if isClient {
+		ipNets = conn.GetContext().GetIpContext().GetDstIPNets()
+	}
+	for _, ipNet := range ipNets {
+		if ipNet.IP.To4() == nil {
+			return ipNet.IP
+		}
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package ipv6srcaddr provides chain elements setting the NSM IPv6 address of the kernel interface as the preferred
+// source (src hint) of the IPv6 routes via the interface, so the clients having many IPv6 addresses (CNI + NSM) use
+// the NSM source for the NSM destinations. Otherwise the replies may leave with the CNI source and be dropped by
+// rp_filter. The NSM addresses are added with the infinite preferred lifetime, so the route hints are enough.
+package ipv6srcaddr
@@ -0,0 +1,308 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package kernelroutes
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	kernellink "github.com/networkservicemesh/sdk-kernel/pkg/kernel"
+)
+
+func create(ctx context.Context, conn *networkservice.Connection, o *options, isClient bool) error {
+	mechanism := kernel.ToMechanism(conn.GetMechanism())
+	if mechanism == nil {
+		return nil
+	}
+	table, rules, err := settings(conn, o)
+	if err != nil {
+		return err
+	}
+	if table == 0 {
+		del(ctx, isClient)
+		return nil
+	}
+
+	handle, err := kernellink.GetNetlinkHandle(mechanism.GetNetNSURL())
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer handle.Close()
+
+	l, err := handle.LinkByName(mechanism.GetInterfaceName())
+	if err != nil {
+		return errors.Wrapf(err, "unable to find link %s", mechanism.GetInterfaceName())
+	}
+
+	localNets, peerNets, routes := connNets(conn, isClient)
+	current := &programmed{
+		netNSURL: mechanism.GetNetNSURL(),
+		routes:   toRoutes(l, table, peerNets, routes),
+		rules:    toRules(table, rules, localNets),
+	}
+	if prev, ok := load(ctx, isClient); ok {
+		if equal(prev, current) {
+			return nil
+		}
+		del(ctx, isClient)
+	}
+	store(ctx, isClient, current)
+
+	for _, route := range current.routes {
+		now := time.Now()
+		if err := handle.RouteReplace(route); err != nil {
+			return errors.Wrapf(err, "unable to add route %s", route)
+		}
+		log.FromContext(ctx).
+			WithField("link.Name", l.Attrs().Name).
+			WithField("route", route.String()).
+			WithField("duration", time.Since(now)).
+			WithField("netlink", "RouteReplace").Debug("completed")
+	}
+	for _, rule := range current.rules {
+		now := time.Now()
+		if err := handle.RuleAdd(rule); err != nil {
+			return errors.Wrapf(err, "unable to add rule %s", rule)
+		}
+		log.FromContext(ctx).
+			WithField("rule", rule.String()).
+			WithField("duration", time.Since(now)).
+			WithField("netlink", "RuleAdd").Debug("completed")
+	}
+	return nil
+}
+
+// del deletes the rules and the routes. The routes are deleted by the kernel with the interface, the rules are not.
+func del(ctx context.Context, isClient bool) {
+	prev, ok := loadAndDelete(ctx, isClient)
+	if !ok {
+		return
+	}
+	handle, err := kernellink.GetNetlinkHandle(prev.netNSURL)
+	if err != nil {
+		log.FromContext(ctx).Errorf("unable to delete the rules and the routes: %v", err)
+		return
+	}
+	defer handle.Close()
+
+	for _, rule := range prev.rules {
+		now := time.Now()
+		if err := handle.RuleDel(rule); err != nil {
+			log.FromContext(ctx).Errorf("unable to delete rule %s: %v", rule, err)
+			continue
+		}
+		log.FromContext(ctx).
+			WithField("rule", rule.String()).
+			WithField("duration", time.Since(now)).
+			WithField("netlink", "RuleDel").Debug("completed")
+	}
+	for _, route := range prev.routes {
+		now := time.Now()
+		if err := handle.RouteDel(route); err != nil {
+			log.FromContext(ctx).Debugf("unable to delete route %s: %v", route, err)
+			continue
+		}
+		log.FromContext(ctx).
+			WithField("route", route.String()).
+			WithField("duration", time.Since(now)).
+			WithField("netlink", "RouteDel").Debug("completed")
+	}
+}
+
+// settings returns the table and the rules of the conn, the labels override the options
+func settings(conn *networkservice.Connection, o *options) (table int, rules []*Rule, err error) {
+	table, rules = o.table, o.rules
+	labels := conn.GetLabels()
+	if label, ok := labels[TableLabel]; ok {
+		if table, err = strconv.Atoi(label); err != nil {
+			return 0, nil, errors.Wrapf(err, "invalid %s label %q", TableLabel, label)
+		}
+	}
+
+	fromLabel, hasFrom := labels[FromLabel]
+	toLabel, hasTo := labels[ToLabel]
+	fwmarkLabel, hasFwmark := labels[FwmarkLabel]
+	if !hasFrom && !hasTo && !hasFwmark {
+		return table, rules, nil
+	}
+	froms, err := parseNets(FromLabel, fromLabel)
+	if err != nil {
+		return 0, nil, err
+	}
+	tos, err := parseNets(ToLabel, toLabel)
+	if err != nil {
+		return 0, nil, err
+	}
+	var mark, mask uint64
+	if hasFwmark {
+		markStr, maskStr, hasMask := strings.Cut(fwmarkLabel, "/")
+		if mark, err = strconv.ParseUint(markStr, 0, 32); err != nil {
+			return 0, nil, errors.Wrapf(err, "invalid %s label %q", FwmarkLabel, fwmarkLabel)
+		}
+		if hasMask {
+			if mask, err = strconv.ParseUint(maskStr, 0, 32); err != nil {
+				return 0, nil, errors.Wrapf(err, "invalid %s label %q", FwmarkLabel, fwmarkLabel)
+			}
+		}
+	}
+	rules = nil
+	for _, from := range froms {
+		for _, to := range tos {
+			rules = append(rules, &Rule{
+				From: from,
+				To:   to,
+				Mark: uint32(mark),
+				Mask: uint32(mask),
+			})
+		}
+	}
+	return table, rules, nil
+}
+
+// parseNets parses the comma separated prefixes, returns a single nil prefix matching any if the value is empty
+func parseNets(label, value string) ([]*net.IPNet, error) {
+	if value == "" {
+		return []*net.IPNet{nil}, nil
+	}
+	var rv []*net.IPNet
+	for _, s := range strings.Split(value, ",") {
+		_, ipNet, err := net.ParseCIDR(strings.TrimSpace(s))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid %s label %q", label, value)
+		}
+		rv = append(rv, ipNet)
+	}
+	return rv, nil
+}
+
+// connNets returns the addresses of the kernel interface, the addresses of the other end of the connection and the
+// routes toward the other end
+func connNets(conn *networkservice.Connection, isClient bool) (local, peer []*net.IPNet, routes []*networkservice.Route) {
+	ipContext := conn.GetContext().GetIpContext()
+	if isClient {
+		return ipContext.GetDstIPNets(), ipContext.GetSrcIPNets(), ipContext.GetSrcRoutes()
+	}
+	return ipContext.GetSrcIPNets(), ipContext.GetDstIPNets(), ipContext.GetDstRoutes()
+}
+
+func toRoutes(l netlink.Link, table int, peerNets []*net.IPNet, routes []*networkservice.Route) []*netlink.Route {
+	var rv []*netlink.Route
+	for _, peerNet := range peerNets {
+		bits := net.IPv6len * 8
+		if peerNet.IP.To4() != nil {
+			bits = net.IPv4len * 8
+		}
+		rv = append(rv, &netlink.Route{
+			LinkIndex: l.Attrs().Index,
+			Scope:     netlink.SCOPE_LINK,
+			Dst:       &net.IPNet{IP: peerNet.IP, Mask: net.CIDRMask(bits, bits)},
+			Table:     table,
+		})
+	}
+	for _, route := range routes {
+		if route.GetPrefixIPNet() == nil {
+			continue
+		}
+		r := &netlink.Route{
+			LinkIndex: l.Attrs().Index,
+			Scope:     netlink.SCOPE_UNIVERSE,
+			Dst:       route.GetPrefixIPNet(),
+			Gw:        route.GetNextHopIP(),
+			Table:     table,
+		}
+		if r.Gw == nil {
+			r.Scope = netlink.SCOPE_LINK
+		}
+		rv = append(rv, r)
+	}
+	return rv
+}
+
+func toRules(table int, rules []*Rule, localNets []*net.IPNet) []*netlink.Rule {
+	if len(rules) == 0 {
+		// Source based routing from the interface addresses by default
+		for _, localNet := range localNets {
+			bits := net.IPv6len * 8
+			if localNet.IP.To4() != nil {
+				bits = net.IPv4len * 8
+			}
+			rules = append(rules, &Rule{From: &net.IPNet{IP: localNet.IP, Mask: net.CIDRMask(bits, bits)}})
+		}
+	}
+	var rv []*netlink.Rule
+	for _, rule := range rules {
+		r := netlink.NewRule()
+		r.Table = table
+		r.Src = rule.From
+		r.Dst = rule.To
+		r.Family = family(rule)
+		if rule.Mark != 0 {
+			r.Mark = int(rule.Mark)
+			if rule.Mask != 0 {
+				r.Mask = int(rule.Mask)
+			}
+		}
+		if rule.Priority != 0 {
+			r.Priority = rule.Priority
+		}
+		rv = append(rv, r)
+	}
+	return rv
+}
+
+func family(rule *Rule) int {
+	for _, ipNet := range []*net.IPNet{rule.From, rule.To} {
+		if ipNet == nil {
+			continue
+		}
+		if ipNet.IP.To4() == nil {
+			return netlink.FAMILY_V6
+		}
+		return netlink.FAMILY_V4
+	}
+	return netlink.FAMILY_V4
+}
+
+func equal(a, b *programmed) bool {
+	if a.netNSURL != b.netNSURL || len(a.routes) != len(b.routes) || len(a.rules) != len(b.rules) {
+		return false
+	}
+	for i := range a.routes {
+		if a.routes[i].String() != b.routes[i].String() {
+			return false
+		}
+	}
+	for i := range a.rules {
+		if a.rules[i].String() != b.rules[i].String() || a.rules[i].Mark != b.rules[i].Mark || a.rules[i].Mask != b.rules[i].Mask {
+			return false
+		}
+	}
+	return true
+}
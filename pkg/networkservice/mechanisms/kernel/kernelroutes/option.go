@@ -0,0 +1,73 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package kernelroutes
+
+import (
+	"net"
+)
+
+const (
+	// TableLabel - connection label setting the routing table, e.g. "100"
+	TableLabel = "kernel-route-table"
+	// FromLabel - connection label setting the comma separated source prefixes of the rules, e.g. "10.0.0.0/24"
+	FromLabel = "kernel-rule-from"
+	// ToLabel - connection label setting the comma separated destination prefixes of the rules
+	ToLabel = "kernel-rule-to"
+	// FwmarkLabel - connection label setting the fwmark of the rules with the optional mask, e.g. "0x10/0xff"
+	FwmarkLabel = "kernel-rule-fwmark"
+)
+
+// Rule - ip rule looking up the table
+type Rule struct {
+	// From - source prefix matched, any if nil
+	From *net.IPNet
+	// To - destination prefix matched, any if nil
+	To *net.IPNet
+	// Mark - fwmark matched, any if 0
+	Mark uint32
+	// Mask - fwmark mask, exact match if 0
+	Mask uint32
+	// Priority - rule priority, chosen by the kernel if 0
+	Priority int
+}
+
+type options struct {
+	table int
+	rules []*Rule
+}
+
+// Option is an option pattern for kernelroutes client/server
+type Option func(o *options)
+
+// WithTable sets the default routing table. The element does nothing if the table is not set with the option or
+// the TableLabel.
+func WithTable(table int) Option {
+	return func(o *options) {
+		o.table = table
+	}
+}
+
+// WithRules sets the default rules. The rules from the local IPs of the connection are used if not set with the
+// option or the labels.
+func WithRules(rules ...*Rule) Option {
+	return func(o *options) {
+		o.rules = rules
+	}
+}
@@ -36,13 +36,24 @@ import (
 )
 
 type kernelTapClient struct {
-	vppConn api.Connection
+	vppConn    api.Connection
+	persistent bool
+	noIPv6     bool
+	tagPrefix  string
 }
 
 // NewClient - return a new Client chain element implementing the kernel mechanism with vpp using tapv2
-func NewClient(vppConn api.Connection) networkservice.NetworkServiceClient {
+func NewClient(vppConn api.Connection, opts ...Option) networkservice.NetworkServiceClient {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
 	return &kernelTapClient{
-		vppConn: vppConn,
+		vppConn:    vppConn,
+		persistent: o.persistent,
+		noIPv6:     o.noIPv6,
+		tagPrefix:  o.tagPrefix,
 	}
 }
 
@@ -61,7 +72,7 @@ func (k *kernelTapClient) Request(ctx context.Context, request *networkservice.N
 		return nil, err
 	}
 
-	if err := create(ctx, conn, k.vppConn, metadata.IsClient(k)); err != nil {
+	if err := create(ctx, conn, k.vppConn, k.persistent, k.noIPv6, k.tagPrefix, metadata.IsClient(k)); err != nil {
 		closeCtx, cancelClose := postponeCtxFunc()
 		defer cancelClose()
 
@@ -76,7 +87,7 @@ func (k *kernelTapClient) Request(ctx context.Context, request *networkservice.N
 }
 
 func (k *kernelTapClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
-	err := del(ctx, conn, k.vppConn, metadata.IsClient(k))
+	err := del(ctx, conn, k.vppConn, k.persistent, metadata.IsClient(k))
 	if err != nil {
 		log.FromContext(ctx).Error(err)
 	}
@@ -35,11 +35,15 @@ import (
 	kernellink "github.com/networkservicemesh/sdk-kernel/pkg/kernel"
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
 
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/gso"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/tag"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/teardown"
 	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
 	"github.com/networkservicemesh/sdk-vpp/pkg/tools/mechutils"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/mtupath"
 )
 
-func create(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, isClient bool) error {
+func create(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, persistent, noIPv6 bool, tagPrefix string, isClient bool) error {
 	if mechanism := kernel.ToMechanism(conn.GetMechanism()); mechanism != nil {
 		// Construct the netlink handle for the target namespace for this kernel interface
 		handle, err := kernellink.GetNetlinkHandle(mechanism.GetNetNSURL())
@@ -49,12 +53,28 @@ func create(ctx context.Context, conn *networkservice.Connection, vppConn api.Co
 		defer handle.Close()
 
 		if _, ok := ifindex.Load(ctx, isClient); ok {
-			if _, err = handle.LinkByName(mechanism.GetInterfaceName()); err == nil {
+			if l, linkErr := handle.LinkByName(mechanism.GetInterfaceName()); linkErr == nil {
+				mtupath.Store(ctx, isClient, mtupath.KernelHop, uint32(l.Attrs().MTU))
 				return nil
 			}
 		}
 		// Delete the kernel interface if there is one in the target namespace
-		_ = del(ctx, conn, vppConn, isClient)
+		_ = del(ctx, conn, vppConn, persistent, isClient)
+
+		var attach bool
+		if persistent {
+			swIfIndex, ok, adoptErr := adopt(ctx, vppConn, mechanism.GetInterfaceName(), tag.Format(tagPrefix, conn.GetId()))
+			if adoptErr != nil {
+				log.FromContext(ctx).Warnf("unable to adopt the persistent tap: %v", adoptErr)
+			}
+			if ok {
+				ifindex.Store(ctx, isClient, swIfIndex)
+				return nil
+			}
+			// vpp has been restarted, so the kernel interface left is re-attached
+			_, linkErr := handle.LinkByName(mechanism.GetInterfaceName())
+			attach = linkErr == nil
+		}
 
 		nsFilename, err := mechutils.ToNSFilename(mechanism)
 		if err != nil {
@@ -78,6 +98,23 @@ func create(ctx context.Context, conn *networkservice.Connection, vppConn api.Co
 		if conn.GetPayload() == payload.Ethernet {
 			tapCreateV2.TapFlags ^= tapv2.TAP_API_FLAG_TUN
 		}
+		// The kernel side is raised to the MTU of the connection, e.g. to carry the jumbo frames
+		if mtu := conn.GetContext().GetMTU(); mtu != 0 {
+			tapCreateV2.HostMtuSet = true
+			tapCreateV2.HostMtuSize = mtu
+		}
+		if persistent {
+			tapCreateV2.TapFlags |= tapv2.TAP_API_FLAG_PERSIST
+		}
+		if attach {
+			tapCreateV2.TapFlags |= tapv2.TAP_API_FLAG_ATTACH
+		}
+		if gso.IsEnabled(ctx, isClient) {
+			tapCreateV2.TapFlags |= tapv2.TAP_API_FLAG_GSO | tapv2.TAP_API_FLAG_CSUM_OFFLOAD
+		}
+		if gso.IsGROEnabled(ctx, isClient) {
+			tapCreateV2.TapFlags |= tapv2.TAP_API_FLAG_GRO_COALESCE
+		}
 
 		rsp, err := tapv2.NewServiceClient(vppConn).TapCreateV2(ctx, tapCreateV2)
 		if err != nil {
@@ -128,6 +165,12 @@ func create(ctx context.Context, conn *networkservice.Connection, vppConn api.Co
 			WithField("duration", time.Since(now)).
 			WithField("netlink", "LinkSetAlias").Debug("completed")
 
+		if noIPv6 {
+			if err = mechutils.DisableIPv6(ctx, mechanism.GetNetNSURL(), tapCreateV2.HostIfName); err != nil {
+				return err
+			}
+		}
+
 		// Up the link
 		now = time.Now()
 		err = handle.LinkSetUp(l)
@@ -138,28 +181,42 @@ func create(ctx context.Context, conn *networkservice.Connection, vppConn api.Co
 			WithField("link.Name", l.Attrs().Name).
 			WithField("duration", time.Since(now)).
 			WithField("netlink", "LinkSetUp").Debug("completed")
+
+		mtupath.Store(ctx, isClient, mtupath.KernelHop, uint32(l.Attrs().MTU))
 	}
 	return nil
 }
 
-func del(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, isClient bool) error {
+func del(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, persistent, isClient bool) error {
 	if mechanism := kernel.ToMechanism(conn.GetMechanism()); mechanism != nil {
 		swIfIndex, ok := ifindex.LoadAndDelete(ctx, isClient)
 		if !ok {
 			return nil
 		}
-		now := time.Now()
-		_, err := tapv2.NewServiceClient(vppConn).TapDeleteV2(ctx, &tapv2.TapDeleteV2{
-			SwIfIndex: swIfIndex,
+		netNSURL, ifName := mechanism.GetNetNSURL(), mechanism.GetInterfaceName()
+		// The tap is deleted once the l2 cross connects and the ACLs using it are removed
+		return teardown.Do(ctx, teardown.Interfaces, func(ctx context.Context) error {
+			now := time.Now()
+			_, err := tapv2.NewServiceClient(vppConn).TapDeleteV2(ctx, &tapv2.TapDeleteV2{
+				SwIfIndex: swIfIndex,
+			})
+			if err != nil {
+				return errors.Wrapf(err, "unable to delete connection with SwIfIndex %v", swIfIndex)
+			}
+			log.FromContext(ctx).
+				WithField("SwIfIndex", swIfIndex).
+				WithField("duration", time.Since(now)).
+				WithField("vppapi", "TapDeleteV2").Debug("completed")
+			if !persistent {
+				return nil
+			}
+			handle, err := kernellink.GetNetlinkHandle(netNSURL)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			defer handle.Close()
+			return delHostLink(ctx, handle, ifName)
 		})
-		if err != nil {
-			return errors.Wrapf(err, "unable to delete connection with SwIfIndex %v", swIfIndex)
-		}
-		log.FromContext(ctx).
-			WithField("SwIfIndex", swIfIndex).
-			WithField("duration", time.Since(now)).
-			WithField("vppapi", "TapDeleteV2").Debug("completed")
-		return nil
 	}
 	return nil
 }
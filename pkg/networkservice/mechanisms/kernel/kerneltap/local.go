@@ -0,0 +1,87 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package kerneltap
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+)
+
+const (
+	localNamePrefix = "nsm-"
+	// maxIfNameLen - max length of the linux interface name (IFNAMSIZ - 1)
+	maxIfNameLen = 15
+)
+
+// toLocal targets the kernel mechanism without the network namespace at the network namespace of the forwarder
+// and names the tap after the connection if the name is not set. The netNSPath is the file of the forwarder network
+// namespace opened by both the forwarder and vpp, the namespace file of the forwarder process if not set.
+func toLocal(conn *networkservice.Connection, netNSPath string) error {
+	mechanism := kernel.ToMechanism(conn.GetMechanism())
+	if mechanism == nil || mechanism.GetNetNSURL() != "" || mechanism.GetNetNSInode() != "" {
+		return nil
+	}
+	if netNSPath == "" {
+		// vpp runs in the pid namespace of the forwarder, so the namespace file of the forwarder process is usable
+		// by vpp
+		netNSPath = fmt.Sprintf("/proc/%d/ns/net", os.Getpid())
+	}
+	inode, err := localNetNSInode(netNSPath)
+	if err != nil {
+		return err
+	}
+	mechanism.SetNetNSURL((&url.URL{
+		Scheme: kernel.NetNSURLScheme,
+		Path:   netNSPath,
+	}).String())
+	mechanism.SetNetNSInode(strconv.FormatUint(inode, 10))
+	if mechanism.GetInterfaceName() == "" {
+		name := localNamePrefix + conn.GetId()
+		if len(name) > maxIfNameLen {
+			name = name[:maxIfNameLen]
+		}
+		mechanism.SetInterfaceName(name)
+	}
+	return nil
+}
+
+// localNetNSInode returns the inode of the network namespace file at netNSPath, it must be the network namespace of
+// the forwarder
+func localNetNSInode(netNSPath string) (uint64, error) {
+	var self, local unix.Stat_t
+	if err := unix.Stat("/proc/self/ns/net", &self); err != nil {
+		return 0, errors.Wrap(err, "unable to stat the forwarder network namespace")
+	}
+	if err := unix.Stat(netNSPath, &local); err != nil {
+		return 0, errors.Wrapf(err, "unable to stat the local network namespace %s", netNSPath)
+	}
+	if self.Ino != local.Ino || self.Dev != local.Dev {
+		return 0, errors.Errorf("%s is not the network namespace of the forwarder", netNSPath)
+	}
+	return local.Ino, nil
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package kerneltap
+
+type options struct {
+	persistent bool
+	local      bool
+	localNetNS string
+	noIPv6     bool
+	tagPrefix  string
+}
+
+// Option is an option pattern for kerneltap client/server
+type Option func(o *options)
+
+// WithPersistence makes the taps persistent: the kernel interface outlives vpp and is re-attached when vpp is
+// restarted, the vpp tap of the connection left by the previous forwarder (matched by the host interface name and the
+// tag) is re-adopted. The kernel interface and its addresses are kept intact over the forwarder upgrades.
+func WithPersistence() Option {
+	return func(o *options) {
+		o.persistent = true
+	}
+}
+
+// WithLocalNetNS creates the taps of the server side kernel mechanisms without the network namespace in the network
+// namespace of the forwarder, for the services co-located with the forwarder (e.g. DNS, probes). The tap is named
+// after the connection if the mechanism has no interface name, the name is returned in the mechanism.
+func WithLocalNetNS() Option {
+	return func(o *options) {
+		o.local = true
+	}
+}
+
+// WithLocalNetNSPath is WithLocalNetNS with the path of the forwarder network namespace file vpp opens, for vpp not
+// running in the pid namespace of the forwarder (e.g. the vpp outliving the forwarder in its own container): the
+// namespace bind mounted to a path shared with vpp, like /var/run/netns/forwarder.
+func WithLocalNetNSPath(path string) Option {
+	return func(o *options) {
+		o.local = true
+		o.localNetNS = path
+	}
+}
+
+// WithoutIPv6 fully disables IPv6 on the kernel interfaces (no link local address, disable_ipv6 sysctl) before they
+// are set up and the addresses are assigned, for the workloads not allowed to use IPv6. The Requests having IPv6
+// addresses in the IP context fail then.
+func WithoutIPv6() Option {
+	return func(o *options) {
+		o.noIPv6 = true
+	}
+}
+
+// WithTagPrefix sets the prefix of the tags the persistent taps are adopted by, must be the same as tag.WithPrefix of
+// the tag chain elements
+func WithTagPrefix(prefix string) Option {
+	return func(o *options) {
+		o.tagPrefix = prefix
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package kerneltap
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	interfaces "github.com/edwarnicke/govpp/binapi/interface"
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+	"github.com/edwarnicke/govpp/binapi/tapv2"
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// adopt returns the vpp tap with the host interface name and the tag (set by the tag chain element)
+// left by the previous forwarder
+func adopt(ctx context.Context, vppConn api.Connection, hostIfName, tag string) (interface_types.InterfaceIndex, bool, error) {
+	now := time.Now()
+	dc, err := tapv2.NewServiceClient(vppConn).SwInterfaceTapV2Dump(ctx, &tapv2.SwInterfaceTapV2Dump{
+		SwIfIndex: ^interface_types.InterfaceIndex(0),
+	})
+	if err != nil {
+		return 0, false, errors.WithStack(err)
+	}
+	defer func() { _ = dc.Close() }()
+
+	var candidates []interface_types.InterfaceIndex
+	for {
+		details, err := dc.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, false, errors.WithStack(err)
+		}
+		if details.HostIfName == hostIfName {
+			candidates = append(candidates, interface_types.InterfaceIndex(details.SwIfIndex))
+		}
+	}
+	log.FromContext(ctx).
+		WithField("hostIfName", hostIfName).
+		WithField("candidates", len(candidates)).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "SwInterfaceTapV2Dump").Debug("completed")
+
+	// The same host interface name may be used in many network namespaces, so the tag must match too
+	for _, swIfIndex := range candidates {
+		ok, err := hasTag(ctx, vppConn, swIfIndex, tag)
+		if err != nil {
+			return 0, false, err
+		}
+		if ok {
+			log.FromContext(ctx).
+				WithField("swIfIndex", swIfIndex).
+				WithField("hostIfName", hostIfName).
+				Info("adopted the persistent tap")
+			return swIfIndex, true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+func hasTag(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, tag string) (bool, error) {
+	dc, err := interfaces.NewServiceClient(vppConn).SwInterfaceDump(ctx, &interfaces.SwInterfaceDump{
+		SwIfIndex: swIfIndex,
+	})
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	defer func() { _ = dc.Close() }()
+
+	details, err := dc.Recv()
+	if err != nil {
+		return false, errors.Wrapf(err, "error retrieving SwInterfaceDetails for swIfIndex %d", swIfIndex)
+	}
+	return details.Tag == tag, nil
+}
+
+// delHostLink deletes the persistent kernel interface, vpp doesn't delete it with the tap
+func delHostLink(ctx context.Context, handle *netlink.Handle, hostIfName string) error {
+	l, err := handle.LinkByName(hostIfName)
+	if err != nil {
+		// Already deleted
+		return nil
+	}
+	now := time.Now()
+	if err := handle.LinkDel(l); err != nil {
+		return errors.Wrapf(err, "unable to delete the persistent tap %s", hostIfName)
+	}
+	log.FromContext(ctx).
+		WithField("link.Name", hostIfName).
+		WithField("duration", time.Since(now)).
+		WithField("netlink", "LinkDel").Debug("completed")
+	return nil
+}
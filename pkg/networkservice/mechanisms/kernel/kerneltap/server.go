@@ -34,17 +34,38 @@ import (
 )
 
 type kernelTapServer struct {
-	vppConn api.Connection
+	vppConn    api.Connection
+	persistent bool
+	noIPv6     bool
+	local      bool
+	localNetNS string
+	tagPrefix  string
 }
 
 // NewServer - return a new Server chain element implementing the kernel mechanism with vpp using tapv2
-func NewServer(vppConn api.Connection) networkservice.NetworkServiceServer {
+func NewServer(vppConn api.Connection, opts ...Option) networkservice.NetworkServiceServer {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
 	return &kernelTapServer{
-		vppConn: vppConn,
+		vppConn:    vppConn,
+		persistent: o.persistent,
+		noIPv6:     o.noIPv6,
+		tagPrefix:  o.tagPrefix,
+		local:      o.local,
+		localNetNS: o.localNetNS,
 	}
 }
 
 func (k *kernelTapServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	if k.local {
+		if err := toLocal(request.GetConnection(), k.localNetNS); err != nil {
+			return nil, err
+		}
+	}
+
 	postponeCtxFunc := postpone.ContextWithValues(ctx)
 
 	conn, err := next.Server(ctx).Request(ctx, request)
@@ -52,7 +73,7 @@ func (k *kernelTapServer) Request(ctx context.Context, request *networkservice.N
 		return nil, err
 	}
 
-	if err := create(ctx, conn, k.vppConn, metadata.IsClient(k)); err != nil {
+	if err := create(ctx, conn, k.vppConn, k.persistent, k.noIPv6, k.tagPrefix, metadata.IsClient(k)); err != nil {
 		closeCtx, cancelClose := postponeCtxFunc()
 		defer cancelClose()
 
@@ -67,7 +88,7 @@ func (k *kernelTapServer) Request(ctx context.Context, request *networkservice.N
 }
 
 func (k *kernelTapServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
-	err := del(ctx, conn, k.vppConn, metadata.IsClient(k))
+	err := del(ctx, conn, k.vppConn, k.persistent, metadata.IsClient(k))
 	if err != nil {
 		log.FromContext(ctx).Error(err)
 	}
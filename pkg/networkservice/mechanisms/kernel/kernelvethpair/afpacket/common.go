@@ -34,13 +34,15 @@ import (
 
 	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/peer"
 
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/xdp"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/teardown"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/up"
 	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
 	"github.com/networkservicemesh/sdk-vpp/pkg/tools/types"
 )
 
 func create(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, isClient bool) error {
-	if mechanism := kernel.ToMechanism(conn.GetMechanism()); mechanism != nil {
+	if mechanism := kernel.ToMechanism(conn.GetMechanism()); mechanism != nil && !xdp.Load(ctx, isClient) {
 		if _, ok := ifindex.Load(ctx, isClient); ok {
 			return nil
 		}
@@ -80,7 +82,7 @@ func create(ctx context.Context, conn *networkservice.Connection, vppConn api.Co
 }
 
 func del(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, isClient bool) error {
-	if mechanism := kernel.ToMechanism(conn.GetMechanism()); mechanism != nil {
+	if mechanism := kernel.ToMechanism(conn.GetMechanism()); mechanism != nil && !xdp.Load(ctx, isClient) {
 		swIfIndex, ok := ifindex.LoadAndDelete(ctx, isClient)
 		if !ok {
 			return nil
@@ -89,18 +91,22 @@ func del(ctx context.Context, conn *networkservice.Connection, vppConn api.Conne
 		if !ok {
 			return errors.New("peer link not found")
 		}
-		now := time.Now()
-		_, err := af_packet.NewServiceClient(vppConn).AfPacketDelete(ctx, &af_packet.AfPacketDelete{
-			HostIfName: peerLink.Attrs().Name,
+		hostIfName := peerLink.Attrs().Name
+		// The af_packet is deleted once the l2 cross connects and the ACLs using it are removed
+		return teardown.Do(ctx, teardown.Interfaces, func(ctx context.Context) error {
+			now := time.Now()
+			_, err := af_packet.NewServiceClient(vppConn).AfPacketDelete(ctx, &af_packet.AfPacketDelete{
+				HostIfName: hostIfName,
+			})
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			log.FromContext(ctx).
+				WithField("swIfIndex", swIfIndex).
+				WithField("duration", time.Since(now)).
+				WithField("vppapi", "AfPacketDelete").Debug("completed")
+			return nil
 		})
-		if err != nil {
-			return errors.WithStack(err)
-		}
-		log.FromContext(ctx).
-			WithField("swIfIndex", swIfIndex).
-			WithField("duration", time.Since(now)).
-			WithField("vppapi", "AfPacketDelete").Debug("completed")
-		return nil
 	}
 	return nil
 }
@@ -0,0 +1,103 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package afxdp
+
+import (
+	"context"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/edwarnicke/govpp/binapi/af_xdp"
+	interfaces "github.com/edwarnicke/govpp/binapi/interface"
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/peer"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/xdp"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/up"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+)
+
+func create(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, isClient bool) error {
+	if mechanism := kernel.ToMechanism(conn.GetMechanism()); mechanism != nil && xdp.Load(ctx, isClient) {
+		if _, ok := ifindex.Load(ctx, isClient); ok {
+			return nil
+		}
+		peerLink, ok := peer.Load(ctx, isClient)
+		if !ok {
+			return errors.New("peer link not found")
+		}
+		now := time.Now()
+		// The veth has the single queue, the zero copy mode is not supported by veth, so the mode is auto
+		rsp, err := af_xdp.NewServiceClient(vppConn).AfXdpCreate(ctx, &af_xdp.AfXdpCreate{
+			HostIf: peerLink.Attrs().Name,
+			RxqNum: 1,
+			Mode:   af_xdp.AF_XDP_API_MODE_AUTO,
+		})
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		log.FromContext(ctx).
+			WithField("swIfIndex", rsp.SwIfIndex).
+			WithField("hostIf", peerLink.Attrs().Name).
+			WithField("duration", time.Since(now)).
+			WithField("vppapi", "AfXdpCreate").Debug("completed")
+		ifindex.Store(ctx, isClient, rsp.SwIfIndex)
+
+		now = time.Now()
+		if _, err := interfaces.NewServiceClient(vppConn).SwInterfaceSetRxMode(ctx, &interfaces.SwInterfaceSetRxMode{
+			SwIfIndex: rsp.SwIfIndex,
+			Mode:      interface_types.RX_MODE_API_ADAPTIVE,
+		}); err != nil {
+			return errors.WithStack(err)
+		}
+		log.FromContext(ctx).
+			WithField("swIfIndex", rsp.SwIfIndex).
+			WithField("mode", interface_types.RX_MODE_API_ADAPTIVE).
+			WithField("duration", time.Since(now)).
+			WithField("vppapi", "SwInterfaceSetRxMode").Debug("completed")
+		up.Store(ctx, isClient, true)
+	}
+	return nil
+}
+
+func del(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, isClient bool) error {
+	if mechanism := kernel.ToMechanism(conn.GetMechanism()); mechanism != nil && xdp.Load(ctx, isClient) {
+		swIfIndex, ok := ifindex.LoadAndDelete(ctx, isClient)
+		if !ok {
+			return nil
+		}
+		now := time.Now()
+		if _, err := af_xdp.NewServiceClient(vppConn).AfXdpDelete(ctx, &af_xdp.AfXdpDelete{
+			SwIfIndex: swIfIndex,
+		}); err != nil {
+			return errors.WithStack(err)
+		}
+		log.FromContext(ctx).
+			WithField("swIfIndex", swIfIndex).
+			WithField("duration", time.Since(now)).
+			WithField("vppapi", "AfXdpDelete").Debug("completed")
+	}
+	return nil
+}
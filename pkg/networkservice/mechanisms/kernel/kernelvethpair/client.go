@@ -40,15 +40,24 @@ import (
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/kernel/kernelvethpair/ipneighbor"
 )
 
-type kernelVethPairClient struct{}
+type kernelVethPairClient struct {
+	noIPv6 bool
+}
 
 // NewClient - return a new Client chain element implementing the kernel mechanism with vpp using a veth pair
-func NewClient(vppConn api.Connection) networkservice.NetworkServiceClient {
+func NewClient(vppConn api.Connection, opts ...Option) networkservice.NetworkServiceClient {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
 	return chain.NewNetworkServiceClient(
 		ipneighbor.NewClient(vppConn),
 		afpacket.NewClient(vppConn),
 		mtu.NewClient(),
-		&kernelVethPairClient{},
+		&kernelVethPairClient{
+			noIPv6: o.noIPv6,
+		},
 	)
 }
 
@@ -67,7 +76,7 @@ func (k *kernelVethPairClient) Request(ctx context.Context, request *networkserv
 		return nil, err
 	}
 
-	if err := create(ctx, conn, metadata.IsClient(k)); err != nil {
+	if err := create(ctx, conn, k.noIPv6, metadata.IsClient(k)); err != nil {
 		closeCtx, cancelClose := postponeCtxFunc()
 		defer cancelClose()
 
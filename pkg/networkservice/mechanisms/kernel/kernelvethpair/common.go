@@ -42,7 +42,7 @@ import (
 	"github.com/networkservicemesh/sdk-vpp/pkg/tools/mechutils"
 )
 
-func create(ctx context.Context, conn *networkservice.Connection, isClient bool) error {
+func create(ctx context.Context, conn *networkservice.Connection, noIPv6, isClient bool) error {
 	if mechanism := kernel.ToMechanism(conn.GetMechanism()); mechanism != nil {
 		// Construct the netlink handle for the target namespace for this kernel interface
 		handle, err := kernellink.GetNetlinkHandle(mechanism.GetNetNSURL())
@@ -159,6 +159,12 @@ func create(ctx context.Context, conn *networkservice.Connection, isClient bool)
 			WithField("duration", time.Since(now)).
 			WithField("netlink", "LinkSetAlias").Debug("completed")
 
+		if noIPv6 {
+			if err = mechutils.DisableIPv6(ctx, mechanism.GetNetNSURL(), name); err != nil {
+				return err
+			}
+		}
+
 		// Up the link
 		now = time.Now()
 		err = handle.LinkSetUp(l)
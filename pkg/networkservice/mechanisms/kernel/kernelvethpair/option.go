@@ -0,0 +1,36 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package kernelvethpair
+
+type options struct {
+	noIPv6 bool
+}
+
+// Option is an option pattern for kernelvethpair client/server
+type Option func(o *options)
+
+// WithoutIPv6 fully disables IPv6 on the kernel interfaces (no link local address, disable_ipv6 sysctl) before they
+// are set up and the addresses are assigned, for the workloads not allowed to use IPv6. The Requests having IPv6
+// addresses in the IP context fail then.
+func WithoutIPv6() Option {
+	return func(o *options) {
+		o.noIPv6 = true
+	}
+}
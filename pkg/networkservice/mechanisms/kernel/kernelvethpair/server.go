@@ -35,18 +35,29 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
 
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/kernel/kernelvethpair/afpacket"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/kernel/kernelvethpair/afxdp"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/kernel/kernelvethpair/ipneighbor"
 )
 
-type kernelVethPairServer struct{}
+type kernelVethPairServer struct {
+	noIPv6 bool
+}
 
 // NewServer - return a new Server chain element implementing the kernel mechanism with vpp using a veth pair
-func NewServer(vppConn api.Connection) networkservice.NetworkServiceServer {
+func NewServer(vppConn api.Connection, opts ...Option) networkservice.NetworkServiceServer {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
 	return chain.NewNetworkServiceServer(
 		ipneighbor.NewServer(vppConn),
 		afpacket.NewServer(vppConn),
+		afxdp.NewServer(vppConn),
 		mtu.NewServer(),
-		&kernelVethPairServer{},
+		&kernelVethPairServer{
+			noIPv6: o.noIPv6,
+		},
 	)
 }
 
@@ -58,7 +69,7 @@ func (k *kernelVethPairServer) Request(ctx context.Context, request *networkserv
 		return nil, err
 	}
 
-	if err := create(ctx, request.GetConnection(), false); err != nil {
+	if err := create(ctx, request.GetConnection(), k.noIPv6, false); err != nil {
 		closeCtx, cancelClose := postponeCtxFunc()
 		defer cancelClose()
 
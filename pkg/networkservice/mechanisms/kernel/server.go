@@ -20,20 +20,35 @@
 package kernel
 
 import (
+	"context"
 	"os"
 
 	"git.fd.io/govpp.git/api"
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/switchcase"
 
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/kernel/kernelvethpair"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/xdp"
 
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/kernel/kerneltap"
 )
 
-// NewServer return a NetworkServiceServer chain element that correctly handles the kernel Mechanism
-func NewServer(vppConn api.Connection) networkservice.NetworkServiceServer {
+// NewServer return a NetworkServiceServer chain element that correctly handles the kernel Mechanism, the options are
+// applied to the taps. The translated xdp mechanism is always handled with the veth pair.
+func NewServer(vppConn api.Connection, opts ...kerneltap.Option) networkservice.NetworkServiceServer {
 	if _, err := os.Stat(vnetFilename); err == nil {
-		return kerneltap.NewServer(vppConn)
+		return switchcase.NewServer(
+			&switchcase.ServerCase{
+				Condition: func(ctx context.Context, _ *networkservice.Connection) bool {
+					return xdp.Load(ctx, false)
+				},
+				Server: kernelvethpair.NewServer(vppConn),
+			},
+			&switchcase.ServerCase{
+				Condition: switchcase.Default,
+				Server:    kerneltap.NewServer(vppConn, opts...),
+			},
+		)
 	}
 	return kernelvethpair.NewServer(vppConn)
 }
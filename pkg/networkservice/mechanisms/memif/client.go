@@ -45,6 +45,7 @@ type memifClient struct {
 	vppConn     api.Connection
 	changeNetNS bool
 	nsInfo      NetNSInfo
+	sockets     *sharedSockets
 }
 
 // NewClient provides a NetworkServiceClient chain elements that support the memif Mechanism
@@ -60,6 +61,7 @@ func NewClient(chainCtx context.Context, vppConn Connection, options ...Option)
 			vppConn:     vppConn,
 			changeNetNS: opts.changeNetNS,
 			nsInfo:      newNetNSInfo(),
+			sockets:     newSharedSockets(),
 		},
 	)
 }
@@ -89,7 +91,7 @@ func (m *memifClient) Request(ctx context.Context, request *networkservice.Netwo
 		}
 	}
 
-	if err = create(ctx, conn, m.vppConn, metadata.IsClient(m), m.nsInfo.netNS); err != nil {
+	if err = create(ctx, conn, m.vppConn, metadata.IsClient(m), m.nsInfo.netNS, m.sockets, nil); err != nil {
 		closeCtx, cancelClose := postponeCtxFunc()
 		defer cancelClose()
 
@@ -104,7 +106,7 @@ func (m *memifClient) Request(ctx context.Context, request *networkservice.Netwo
 }
 
 func (m *memifClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
-	_ = del(ctx, conn, m.vppConn, metadata.IsClient(m))
+	_ = del(ctx, conn, m.vppConn, metadata.IsClient(m), m.sockets, nil)
 	return next.Client(ctx).Close(ctx, conn, opts...)
 }
 
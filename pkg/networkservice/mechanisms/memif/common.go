@@ -26,9 +26,12 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
+	"strings"
 	"time"
 
 	"git.fd.io/govpp.git/api"
+	"github.com/edwarnicke/govpp/binapi/interface_types"
 	"github.com/edwarnicke/govpp/binapi/memif"
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 	memifMech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/memif"
@@ -38,8 +41,10 @@ import (
 	"github.com/vishvananda/netns"
 	"golang.org/x/sys/unix"
 
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/teardown"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/up"
 	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/memifdir"
 )
 
 // Connection aggregates the api.Connection and api.ChannelProvider interfaces
@@ -108,12 +113,7 @@ func createMemifSocket(ctx context.Context, mechanism *memifMech.Mechanism, vppC
 	return memifSocketAddDel.SocketID, nil
 }
 
-func deleteMemifSocket(ctx context.Context, vppConn api.Connection, isClient bool) error {
-	memifSocketAddDel, ok := load(ctx, isClient)
-	if !ok {
-		return nil
-	}
-
+func deleteMemifSocket(ctx context.Context, vppConn api.Connection, memifSocketAddDel *memif.MemifSocketFilenameAddDelV2) error {
 	memifSocketAddDel.IsAdd = false
 
 	now := time.Now()
@@ -132,7 +132,7 @@ func deleteMemifSocket(ctx context.Context, vppConn api.Connection, isClient boo
 	return nil
 }
 
-func createMemif(ctx context.Context, vppConn api.Connection, socketID uint32, mode memif.MemifMode, isClient bool) error {
+func createMemif(ctx context.Context, vppConn api.Connection, socketID, memifID uint32, mode memif.MemifMode, isClient bool) error {
 	role := memif.MEMIF_ROLE_API_MASTER
 	if isClient {
 		role = memif.MEMIF_ROLE_API_SLAVE
@@ -141,6 +141,7 @@ func createMemif(ctx context.Context, vppConn api.Connection, socketID uint32, m
 	memifCreate := &memif.MemifCreate{
 		Role:     role,
 		SocketID: socketID,
+		ID:       memifID,
 		Mode:     mode,
 	}
 	rsp, err := memif.NewServiceClient(vppConn).MemifCreate(ctx, memifCreate)
@@ -151,6 +152,7 @@ func createMemif(ctx context.Context, vppConn api.Connection, socketID uint32, m
 		WithField("swIfIndex", rsp.SwIfIndex).
 		WithField("Role", memifCreate.Role).
 		WithField("SocketID", memifCreate.SocketID).
+		WithField("ID", memifCreate.ID).
 		WithField("duration", time.Since(now)).
 		WithField("vppapi", "MemifCreate").Debug("completed")
 	ifindex.Store(ctx, isClient, rsp.SwIfIndex)
@@ -161,11 +163,7 @@ func createMemif(ctx context.Context, vppConn api.Connection, socketID uint32, m
 	return nil
 }
 
-func deleteMemif(ctx context.Context, vppConn api.Connection, isClient bool) error {
-	swIfIndex, ok := ifindex.LoadAndDelete(ctx, isClient)
-	if !ok {
-		return nil
-	}
+func deleteMemif(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex) error {
 	now := time.Now()
 	memifDel := &memif.MemifDelete{
 		SwIfIndex: swIfIndex,
@@ -181,50 +179,123 @@ func deleteMemif(ctx context.Context, vppConn api.Connection, isClient bool) err
 	return nil
 }
 
-func create(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, isClient bool, netNS netns.NsHandle) error {
+func create(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, isClient bool, netNS netns.NsHandle, sockets *sharedSockets, dirs *memifdir.Dirs) error {
 	if mechanism := memifMech.ToMechanism(conn.GetMechanism()); mechanism != nil {
+		id, negotiated, err := getID(mechanism)
+		if err != nil {
+			return err
+		}
 		if !isClient {
 			mechanism.SetSocketFilename(socketFile(conn))
+			if sockets != nil {
+				mechanism.SetSocketFilename(sockets.socketFile)
+			} else if dirs != nil {
+				mechanism.SetSocketFilename(dirs.SocketFile(conn.GetId()))
+			}
+		}
+		// The socket directory is created only by the server serving the socket per connection
+		dirs = socketDirs(dirs, isClient, sockets)
+		// The server shares the socket if configured, the client does if the server has negotiated the memif ID
+		shared := sockets != nil && (!isClient || negotiated)
+		socketFilename, err := getVppSocketFilename(mechanism, netNS)
+		if err != nil {
+			return err
 		}
 		// This connection has already been created
 		if _, ok := ifindex.Load(ctx, isClient); ok {
-			socketFilename, err := getVppSocketFilename(mechanism, netNS)
-			if err != nil {
-				return err
+			if info, ok := loadShared(ctx, isClient); ok && shared && info.socketFilename == socketFilename && (!isClient || info.id == id) {
+				setID(mechanism, info.id)
+				return nil
 			}
-			if memifSocketAddDel, ok := load(ctx, isClient); ok && memifSocketAddDel.SocketFilename == socketFilename {
+			if memifSocketAddDel, ok := load(ctx, isClient); ok && !shared && memifSocketAddDel.SocketFilename == socketFilename {
 				return nil
 			}
 		}
-		_ = del(ctx, conn, vppConn, isClient)
+		_ = del(ctx, conn, vppConn, isClient, sockets, dirs)
 
 		mode := memif.MEMIF_MODE_API_IP
 		if conn.GetPayload() == payload.Ethernet {
 			mode = memif.MEMIF_MODE_API_ETHERNET
 		}
-		socketID, err := createMemifSocket(ctx, mechanism, vppConn, isClient, netNS)
-		if err != nil {
-			return err
+		var socketID, memifID uint32
+		if shared {
+			// The server allocates a free memif ID, the client uses the negotiated one
+			if socketID, memifID, err = sockets.acquire(ctx, vppConn, socketFilename, id, isClient); err != nil {
+				return err
+			}
+			storeShared(ctx, isClient, &sharedInfo{
+				socketFilename: socketFilename,
+				id:             memifID,
+			})
+			setID(mechanism, memifID)
+		} else {
+			if dirs != nil {
+				if err = dirs.Create(conn.GetId(), clientUID(mechanism)); err != nil {
+					return err
+				}
+			}
+			if socketID, err = createMemifSocket(ctx, mechanism, vppConn, isClient, netNS); err != nil {
+				return err
+			}
 		}
-		if err := createMemif(ctx, vppConn, socketID, mode, isClient); err != nil {
+		if err = createMemif(ctx, vppConn, socketID, memifID, mode, isClient); err != nil {
 			return err
 		}
+		// vpp creates the socket file with the first memif
+		if dirs != nil && !shared {
+			return dirs.Secure(mechanism.GetSocketFilename(), clientUID(mechanism))
+		}
 	}
 	return nil
 }
 
-func del(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, isClient bool) error {
+func del(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, isClient bool, sockets *sharedSockets, dirs *memifdir.Dirs) error {
 	if mechanism := memifMech.ToMechanism(conn.GetMechanism()); mechanism != nil {
-		if err := deleteMemif(ctx, vppConn, isClient); err != nil {
-			return err
-		}
-		if err := deleteMemifSocket(ctx, vppConn, isClient); err != nil {
-			return err
-		}
+		swIfIndex, ok := ifindex.LoadAndDelete(ctx, isClient)
+		info, shared := loadAndDeleteShared(ctx, isClient)
+		memifSocketAddDel, socketOk := load(ctx, isClient)
+		dirs = socketDirs(dirs, isClient, sockets)
+		connID := conn.GetId()
+		// The memif is deleted once the l2 cross connects and the ACLs using it are removed
+		return teardown.Do(ctx, teardown.Interfaces, func(ctx context.Context) error {
+			if ok {
+				if err := deleteMemif(ctx, vppConn, swIfIndex); err != nil {
+					return err
+				}
+			}
+			if shared {
+				return sockets.release(ctx, vppConn, info)
+			}
+			if socketOk {
+				if err := deleteMemifSocket(ctx, vppConn, memifSocketAddDel); err != nil {
+					return err
+				}
+			}
+			if dirs != nil {
+				return dirs.Delete(connID)
+			}
+			return nil
+		})
 	}
 	return nil
 }
 
+func socketDirs(dirs *memifdir.Dirs, isClient bool, sockets *sharedSockets) *memifdir.Dirs {
+	if isClient || sockets != nil {
+		return nil
+	}
+	return dirs
+}
+
+// clientUID returns the UID from the UIDParam mechanism parameter, -1 if not set
+func clientUID(mechanism *memifMech.Mechanism) int {
+	uid, err := strconv.Atoi(mechanism.GetParameters()[UIDParam])
+	if err != nil || uid < 0 {
+		return -1
+	}
+	return uid
+}
+
 func socketFile(conn *networkservice.Connection) string {
 	return "@" + filepath.Join(os.TempDir(), "memif", conn.GetId(), "memif.socket")
 }
@@ -244,7 +315,8 @@ func getVppSocketFilename(mechanism *memifMech.Mechanism, netNS netns.NsHandle)
 	}
 	defer func() { _ = targetNetNS.Close() }()
 
-	if !targetNetNS.Equal(netNS) {
+	// The socket file in the file system is reached regardless of the net NS
+	if !targetNetNS.Equal(netNS) && strings.HasPrefix(mechanism.GetSocketFilename(), "@") {
 		return "@netns:" + u.Path + mechanism.GetSocketFilename(), nil
 	}
 	return mechanism.GetSocketFilename(), nil
@@ -23,4 +23,8 @@ import (
 const (
 	// MECHANISM string
 	MECHANISM = memif.MECHANISM
+	// IDParam - the memif ID of the connection served over the shared socket file
+	IDParam = "id"
+	// UIDParam - the UID of the client owning the memif socket directory of the connection
+	UIDParam = "uid"
 )
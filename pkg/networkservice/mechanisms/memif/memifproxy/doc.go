@@ -15,5 +15,7 @@
 // limitations under the License.
 
 // Package memifproxy provides a NetworkServiceClient chain element to 'proxy' to the memif control socket
-// This is done in case the vpp instance can't open the memif socketfile
+// This is done in case the vpp instance can't open the memif socketfile.
+// The proxy is used only while the endpoint is local, for the remote endpoint the memif is created by vpp and bridged
+// into the remote mechanism, so the clients can always request memif regardless of the endpoint locality.
 package memifproxy
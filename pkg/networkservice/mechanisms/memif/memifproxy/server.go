@@ -63,9 +63,13 @@ func (m *memifProxyServer) Request(ctx context.Context, request *networkservice.
 		return nil, err
 	}
 
-	// If it is NOT a direct memif case, do nothing.
+	// If it is NOT a direct memif case, the memif is bridged into the remote mechanism by vpp, so the proxy started
+	// while the endpoint was local is not needed any more.
 	info, _ := LoadInfo(ctx)
 	if info.SocketFile == "" {
+		if cancelProxy, ok := loadAndDelete(ctx); ok {
+			cancelProxy()
+		}
 		return conn, nil
 	}
 
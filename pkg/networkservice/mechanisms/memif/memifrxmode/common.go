@@ -28,6 +28,8 @@ import (
 	"github.com/edwarnicke/govpp/binapi/interface_types"
 
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/keymutex"
 )
 
 // Connection aggregates the api.Connection and api.ChannelProvider interfaces
@@ -62,10 +64,13 @@ func setRxMode(ctx context.Context, vppConn Connection, swIfIndex interface_type
 					msg.SwIfIndex == swIfIndex &&
 					msg.Flags&interface_types.IF_STATUS_API_FLAG_LINK_UP != 0 {
 					now := time.Now()
-					_, err = interfaces.NewServiceClient(vppConn).SwInterfaceSetRxMode(ctx, &interfaces.SwInterfaceSetRxMode{
+					// Set after the link is up, so it races with the other elements programming the interface
+					lockCtx, unlock := keymutex.LockInterface(ctx, swIfIndex)
+					_, err = interfaces.NewServiceClient(vppConn).SwInterfaceSetRxMode(lockCtx, &interfaces.SwInterfaceSetRxMode{
 						SwIfIndex: swIfIndex,
 						Mode:      interface_types.RX_MODE_API_ADAPTIVE,
 					})
+					unlock()
 					if err != nil {
 						log.FromContext(ctx).
 							WithField("swIfIndex", swIfIndex).
@@ -26,6 +26,8 @@ import (
 
 type key struct{}
 
+type sharedKey struct{}
+
 func store(ctx context.Context, isClient bool, socket *memif.MemifSocketFilenameAddDelV2) {
 	metadata.Map(ctx, isClient).Store(key{}, socket)
 }
@@ -38,3 +40,25 @@ func load(ctx context.Context, isClient bool) (value *memif.MemifSocketFilenameA
 	value, ok = rawValue.(*memif.MemifSocketFilenameAddDelV2)
 	return value, ok
 }
+
+func storeShared(ctx context.Context, isClient bool, info *sharedInfo) {
+	metadata.Map(ctx, isClient).Store(sharedKey{}, info)
+}
+
+func loadShared(ctx context.Context, isClient bool) (value *sharedInfo, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).Load(sharedKey{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(*sharedInfo)
+	return value, ok
+}
+
+func loadAndDeleteShared(ctx context.Context, isClient bool) (value *sharedInfo, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).LoadAndDelete(sharedKey{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(*sharedInfo)
+	return value, ok
+}
@@ -16,9 +16,15 @@
 
 package memif
 
+import (
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/memifdir"
+)
+
 type memifOptions struct {
 	directMemifEnabled bool
 	changeNetNS        bool
+	sharedSocket       bool
+	dirs               *memifdir.Dirs
 }
 
 // Option is an option for the connect server
@@ -37,3 +43,20 @@ func WithChangeNetNS() Option {
 		o.changeNetNS = true
 	}
 }
+
+// WithSharedSocket makes memif server serve all the connections over one socket file with the distinct memif IDs
+// negotiated with the IDParam mechanism parameter instead of the socket file per connection
+func WithSharedSocket() Option {
+	return func(o *memifOptions) {
+		o.sharedSocket = true
+	}
+}
+
+// WithSocketDirs makes memif server serve the connections over the socket files in the per connection directories
+// owned by the client UID from the UIDParam mechanism parameter instead of the abstract sockets. The shared socket
+// is not affected.
+func WithSocketDirs(dirs *memifdir.Dirs) Option {
+	return func(o *memifOptions) {
+		o.dirs = dirs
+	}
+}
@@ -33,16 +33,21 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
 	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
 
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/memif/memifproxy"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/memif/memifrxmode"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/memifdir"
 )
 
 type memifServer struct {
 	vppConn     api.Connection
 	changeNetNS bool
 	nsInfo      NetNSInfo
+	sockets     *sharedSockets
+	dirs        *memifdir.Dirs
 }
 
 // NewServer provides a NetworkServiceServer chain elements that support the memif Mechanism
@@ -52,6 +57,11 @@ func NewServer(chainCtx context.Context, vppConn Connection, options ...Option)
 		o(opts)
 	}
 
+	var sockets *sharedSockets
+	if opts.sharedSocket {
+		sockets = newSharedSockets()
+	}
+
 	memifProxyServer := null.NewServer()
 	if opts.directMemifEnabled {
 		memifProxyServer = memifproxy.NewServer(chainCtx)
@@ -64,6 +74,8 @@ func NewServer(chainCtx context.Context, vppConn Connection, options ...Option)
 			vppConn:     vppConn,
 			changeNetNS: opts.changeNetNS,
 			nsInfo:      newNetNSInfo(),
+			sockets:     sockets,
+			dirs:        opts.dirs,
 		},
 	)
 }
@@ -80,12 +92,18 @@ func (m *memifServer) Request(ctx context.Context, request *networkservice.Netwo
 		return nil, err
 	}
 
-	// In direct memif case do nothing.
+	// In direct memif case do nothing, but delete the memif bridged into the remote mechanism if the endpoint has
+	// become local
 	if info, ok := memifproxy.LoadInfo(ctx); ok && info.SocketFile != "" {
+		if _, ok := ifindex.Load(ctx, metadata.IsClient(m)); ok {
+			if err := del(ctx, conn, m.vppConn, metadata.IsClient(m), m.sockets, m.dirs); err != nil {
+				log.FromContext(ctx).Errorf("failed to delete the memif bridged into the remote mechanism: %v", err)
+			}
+		}
 		return conn, nil
 	}
 
-	if err = create(ctx, conn, m.vppConn, metadata.IsClient(m), m.nsInfo.netNS); err != nil {
+	if err = create(ctx, conn, m.vppConn, metadata.IsClient(m), m.nsInfo.netNS, m.sockets, m.dirs); err != nil {
 		closeCtx, cancelClose := postponeCtxFunc()
 		defer cancelClose()
 
@@ -100,6 +118,6 @@ func (m *memifServer) Request(ctx context.Context, request *networkservice.Netwo
 }
 
 func (m *memifServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
-	_ = del(ctx, conn, m.vppConn, metadata.IsClient(m))
+	_ = del(ctx, conn, m.vppConn, metadata.IsClient(m), m.sockets, m.dirs)
 	return next.Server(ctx).Close(ctx, conn)
 }
@@ -0,0 +1,202 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package memif
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+	"github.com/edwarnicke/govpp/binapi/memif"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	memifMech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/memif"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/memif/memifproxy"
+)
+
+// memifVPP - vpp with the memif sockets and interfaces
+type memifVPP struct {
+	sockets map[uint32]string
+	memifs  map[interface_types.InterfaceIndex]uint32
+	nextID  uint32
+	mu      sync.Mutex
+}
+
+func newMemifVPP() *memifVPP {
+	return &memifVPP{
+		sockets: make(map[uint32]string),
+		memifs:  make(map[interface_types.InterfaceIndex]uint32),
+	}
+}
+
+func (v *memifVPP) Invoke(_ context.Context, req, reply api.Message) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	switch r := req.(type) {
+	case *memif.MemifSocketFilenameAddDelV2:
+		if !r.IsAdd {
+			delete(v.sockets, r.SocketID)
+			return nil
+		}
+		v.nextID++
+		v.sockets[v.nextID] = r.SocketFilename
+		reply.(*memif.MemifSocketFilenameAddDelV2Reply).SocketID = v.nextID
+	case *memif.MemifCreate:
+		v.nextID++
+		v.memifs[interface_types.InterfaceIndex(v.nextID)] = r.SocketID
+		reply.(*memif.MemifCreateReply).SwIfIndex = interface_types.InterfaceIndex(v.nextID)
+	case *memif.MemifDelete:
+		delete(v.memifs, r.SwIfIndex)
+	default:
+		return errors.Errorf("unexpected %s", req.GetMessageName())
+	}
+	return nil
+}
+
+func (v *memifVPP) NewStream(context.Context, ...api.StreamOption) (api.Stream, error) {
+	return nil, errors.New("streams are not supported")
+}
+
+func (v *memifVPP) counts() (sockets, memifs int) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return len(v.sockets), len(v.memifs)
+}
+
+// localityServer - the rest of the chain connecting to the endpoint: to the local one the memif client stores the
+// endpoint socket for the direct memif, to the remote one it doesn't
+type localityServer struct {
+	local        bool
+	nsURL        string
+	endpointFile string
+}
+
+func (s *localityServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	if info, ok := memifproxy.LoadInfo(ctx); ok && s.local {
+		info.NSURL = s.nsURL
+		info.SocketFile = s.endpointFile
+	}
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *localityServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func Test_MemifServer_SwitchesBetweenRemoteAndLocalEndpoint(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The socket of the local endpoint the direct memif is proxied to
+	endpointFile := "@" + t.TempDir() + "/endpoint.socket"
+	endpoint, err := net.Listen("unixpacket", endpointFile)
+	require.NoError(t, err)
+	defer func() { _ = endpoint.Close() }()
+	go func() {
+		for {
+			conn, acceptErr := endpoint.Accept()
+			if acceptErr != nil {
+				return
+			}
+			_ = conn.Close()
+		}
+	}()
+
+	vppConn := newMemifVPP()
+	nsInfo := newNetNSInfo()
+	nsURL := (&url.URL{Scheme: memifMech.FileScheme, Path: nsInfo.netNSPath}).String()
+	locality := &localityServer{nsURL: nsURL, endpointFile: endpointFile}
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		memifproxy.NewServer(ctx),
+		&memifServer{
+			vppConn: vppConn,
+			nsInfo:  nsInfo,
+		},
+		locality,
+	)
+
+	request := &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:        "id",
+			Mechanism: memifMech.New(""),
+		},
+	}
+
+	// The remote endpoint is reached through the memif bridged by vpp
+	conn, err := server.Request(ctx, request.Clone())
+	require.NoError(t, err)
+	sockets, memifs := vppConn.counts()
+	require.Equal(t, 1, sockets)
+	require.Equal(t, 1, memifs)
+
+	// The local endpoint is reached directly, the bridged memif is deleted
+	locality.local = true
+	request.Connection = conn.Clone()
+	conn, err = server.Request(ctx, request.Clone())
+	require.NoError(t, err)
+	sockets, memifs = vppConn.counts()
+	require.Equal(t, 0, sockets)
+	require.Equal(t, 0, memifs)
+
+	proxyFile := memifMech.ToMechanism(conn.GetMechanism()).GetSocketFilename()
+	proxyConn, err := net.Dial("unixpacket", proxyFile)
+	require.NoError(t, err)
+	_ = proxyConn.Close()
+
+	// The remote endpoint again, the proxy is stopped and the memif is bridged by vpp
+	locality.local = false
+	request.Connection = conn.Clone()
+	conn, err = server.Request(ctx, request.Clone())
+	require.NoError(t, err)
+	sockets, memifs = vppConn.counts()
+	require.Equal(t, 1, sockets)
+	require.Equal(t, 1, memifs)
+
+	require.Eventually(t, func() bool {
+		proxyConn, err := net.Dial("unixpacket", proxyFile)
+		if err != nil {
+			return true
+		}
+		_ = proxyConn.Close()
+		return false
+	}, time.Second, 10*time.Millisecond)
+
+	_, err = server.Close(ctx, conn)
+	require.NoError(t, err)
+	sockets, memifs = vppConn.counts()
+	require.Equal(t, 0, sockets)
+	require.Equal(t, 0, memifs)
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package memif
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/edwarnicke/govpp/binapi/memif"
+	"github.com/google/uuid"
+	memifMech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/memif"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/pkg/errors"
+)
+
+// sharedSocket - the memif socket serving multiple connections with the distinct memif IDs
+type sharedSocket struct {
+	socketID uint32
+	ids      map[uint32]struct{}
+}
+
+// sharedSockets - the shared memif sockets keyed by the vpp socket filename
+type sharedSockets struct {
+	socketFile string
+	sockets    map[string]*sharedSocket
+	mutex      sync.Mutex
+}
+
+// sharedInfo - the shared socket and the memif ID used by the connection
+type sharedInfo struct {
+	socketFilename string
+	id             uint32
+}
+
+func newSharedSockets() *sharedSockets {
+	return &sharedSockets{
+		socketFile: "@" + filepath.Join(os.TempDir(), "memif", uuid.New().String(), "memif.socket"),
+		sockets:    make(map[string]*sharedSocket),
+	}
+}
+
+// acquire returns the vpp socket id of the socketFilename creating the socket if needed and the memif ID for the
+// connection. A free memif ID is allocated if the id is not negotiated yet.
+func (s *sharedSockets) acquire(ctx context.Context, vppConn api.Connection, socketFilename string, id uint32, negotiated bool) (socketID, memifID uint32, err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	socket, ok := s.sockets[socketFilename]
+	if !ok {
+		memifSocketAddDel := &memif.MemifSocketFilenameAddDelV2{
+			IsAdd:          true,
+			SocketID:       ^uint32(0),
+			SocketFilename: socketFilename,
+		}
+
+		now := time.Now()
+
+		reply, err := memif.NewServiceClient(vppConn).MemifSocketFilenameAddDelV2(ctx, memifSocketAddDel)
+		if err != nil {
+			return 0, 0, errors.WithStack(err)
+		}
+
+		log.FromContext(ctx).
+			WithField("SocketID", reply.SocketID).
+			WithField("SocketFilename", memifSocketAddDel.SocketFilename).
+			WithField("IsAdd", memifSocketAddDel.IsAdd).
+			WithField("duration", time.Since(now)).
+			WithField("vppapi", "MemifSocketFilenameAddDel").Debug("completed")
+
+		socket = &sharedSocket{
+			socketID: reply.SocketID,
+			ids:      make(map[uint32]struct{}),
+		}
+		s.sockets[socketFilename] = socket
+	}
+
+	if negotiated {
+		if _, ok := socket.ids[id]; ok {
+			return 0, 0, errors.Errorf("memif ID %d is already used on the socket %s", id, socketFilename)
+		}
+		memifID = id
+	} else {
+		for ; ; memifID++ {
+			if _, ok := socket.ids[memifID]; !ok {
+				break
+			}
+		}
+	}
+	socket.ids[memifID] = struct{}{}
+
+	return socket.socketID, memifID, nil
+}
+
+// release frees the memif ID deleting the socket if it is not used any more
+func (s *sharedSockets) release(ctx context.Context, vppConn api.Connection, info *sharedInfo) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	socket, ok := s.sockets[info.socketFilename]
+	if !ok {
+		return nil
+	}
+	delete(socket.ids, info.id)
+	if len(socket.ids) > 0 {
+		return nil
+	}
+	delete(s.sockets, info.socketFilename)
+
+	memifSocketAddDel := &memif.MemifSocketFilenameAddDelV2{
+		IsAdd:          false,
+		SocketID:       socket.socketID,
+		SocketFilename: info.socketFilename,
+	}
+
+	now := time.Now()
+
+	if _, err := memif.NewServiceClient(vppConn).MemifSocketFilenameAddDelV2(ctx, memifSocketAddDel); err != nil {
+		return errors.WithStack(err)
+	}
+
+	log.FromContext(ctx).
+		WithField("SocketID", memifSocketAddDel.SocketID).
+		WithField("SocketFilename", memifSocketAddDel.SocketFilename).
+		WithField("IsAdd", memifSocketAddDel.IsAdd).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "MemifSocketFilenameAddDel").Debug("completed")
+
+	return nil
+}
+
+// getID returns the memif ID negotiated with the mechanism parameters if any
+func getID(mechanism *memifMech.Mechanism) (id uint32, ok bool, err error) {
+	value, ok := mechanism.GetParameters()[IDParam]
+	if !ok {
+		return 0, false, nil
+	}
+	rv, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, false, errors.Wrapf(err, "invalid memif %s parameter %q", IDParam, value)
+	}
+	return uint32(rv), true, nil
+}
+
+func setID(mechanism *memifMech.Mechanism, id uint32) {
+	mechanism.GetParameters()[IDParam] = strconv.FormatUint(uint64(id), 10)
+}
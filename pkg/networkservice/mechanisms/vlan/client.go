@@ -41,18 +41,27 @@ const (
 )
 
 type vlanClient struct {
-	vppConn     api.Connection
-	deviceNames map[string]string
+	vppConn api.Connection
+	uplinks *Uplinks
 }
 
 // NewClient returns a VLAN client chain element
-func NewClient(vppConn api.Connection, domain2Device map[string]string) networkservice.NetworkServiceClient {
+func NewClient(vppConn api.Connection, domain2Device map[string]string, options ...Option) networkservice.NetworkServiceClient {
+	opts := &vlanOptions{}
+	for _, opt := range options {
+		opt(opts)
+	}
+	if opts.uplinks == nil {
+		opts.uplinks = NewUplinks(domain2Device)
+	}
 	return chain.NewNetworkServiceClient(
-		mtu.NewClient(vppConn, domain2Device),
+		mtu.NewClient(vppConn, func(ctx context.Context) (string, bool) {
+			return LoadDevice(ctx, true)
+		}),
 		l2vtr.NewClient(vppConn),
 		&vlanClient{
-			vppConn:     vppConn,
-			deviceNames: domain2Device,
+			vppConn: vppConn,
+			uplinks: opts.uplinks,
 		},
 	)
 }
@@ -72,7 +81,7 @@ func (v *vlanClient) Request(ctx context.Context, request *networkservice.Networ
 		return nil, err
 	}
 
-	if err := addSubIf(ctx, conn, v.vppConn, v.deviceNames); err != nil {
+	if err := addSubIf(ctx, conn, v.vppConn, v.uplinks); err != nil {
 		closeCtx, cancelClose := postponeCtxFunc()
 		defer cancelClose()
 
@@ -39,16 +39,20 @@ const (
 	afPacketNamePrefix = "host-"
 )
 
-func addSubIf(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, deviceNames map[string]string) error {
+func addSubIf(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, uplinks *Uplinks) error {
 	if mechanism := vlanmech.ToMechanism(conn.GetMechanism()); mechanism != nil {
 		_, ok := ifindex.Load(ctx, true)
 		if ok {
 			return nil
 		}
 		via := conn.GetLabels()[viaLabel]
-		hostIFName, ok := deviceNames[via]
-		if !ok {
-			return errors.Errorf("no interface name for label %s", via)
+		devices, err := uplinks.resolve(via)
+		if err != nil {
+			return err
+		}
+		hostIFName, err := selectDevice(ctx, vppConn, devices)
+		if err != nil {
+			return err
 		}
 		vlanID := mechanism.GetVlanID()
 		hostSwIfIndex, vlanSwIfIndex, err := getHostOrVlanInterface(ctx, vppConn, hostIFName, vlanID)
@@ -74,6 +78,7 @@ func addSubIf(ctx context.Context, conn *networkservice.Connection, vppConn api.
 		}
 		/* Store vlanID used by bridge domain server */
 		Store(ctx, true, vlanID)
+		StoreDevice(ctx, true, hostIFName)
 	}
 	return nil
 }
@@ -141,5 +146,6 @@ func delSubIf(ctx context.Context, conn *networkservice.Connection) {
 		/* Delete sub-interface together with the l2 bridge */
 		ifindex.Delete(ctx, true)
 		Delete(ctx, true)
+		DeleteDevice(ctx, true)
 	}
 }
@@ -45,3 +45,26 @@ func Load(ctx context.Context, isClient bool) (value uint32, ok bool) {
 	value, ok = rawValue.(uint32)
 	return value, ok
 }
+
+type deviceKey struct{}
+
+// StoreDevice sets the parent interface of the vlan sub-interface stored in per Connection.Id metadata.
+func StoreDevice(ctx context.Context, isClient bool, hostIFName string) {
+	metadata.Map(ctx, isClient).Store(deviceKey{}, hostIFName)
+}
+
+// DeleteDevice deletes the parent interface of the vlan sub-interface stored in per Connection.Id metadata
+func DeleteDevice(ctx context.Context, isClient bool) {
+	metadata.Map(ctx, isClient).Delete(deviceKey{})
+}
+
+// LoadDevice returns the parent interface of the vlan sub-interface stored in per Connection.Id metadata.
+// The ok result indicates whether value was found in the per Connection.Id metadata.
+func LoadDevice(ctx context.Context, isClient bool) (value string, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).Load(deviceKey{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(string)
+	return value, ok
+}
@@ -35,16 +35,16 @@ import (
 )
 
 type mtuClient struct {
-	vppConn     api.Connection
-	mtu         mtuMap
-	deviceNames map[string]string
+	vppConn    api.Connection
+	mtu        mtuMap
+	loadDevice func(ctx context.Context) (string, bool)
 }
 
-// NewClient - returns client chain element to manage vlan MTU
-func NewClient(vppConn api.Connection, deviceNames map[string]string) networkservice.NetworkServiceClient {
+// NewClient - returns client chain element to manage vlan MTU of the parent interface returned by loadDevice
+func NewClient(vppConn api.Connection, loadDevice func(ctx context.Context) (string, bool)) networkservice.NetworkServiceClient {
 	return &mtuClient{
-		vppConn:     vppConn,
-		deviceNames: deviceNames,
+		vppConn:    vppConn,
+		loadDevice: loadDevice,
 	}
 }
 
@@ -60,8 +60,7 @@ func (m *mtuClient) Request(ctx context.Context, request *networkservice.Network
 		return conn, nil
 	}
 	if mechanism := vlan.ToMechanism(conn.GetMechanism()); mechanism != nil {
-		via := conn.GetLabels()[viaLabel]
-		hostIFName, ok := m.deviceNames[via]
+		hostIFName, ok := m.loadDevice(ctx)
 		if !ok {
 			return nil, errors.New("can not find device name for via label")
 		}
@@ -17,9 +17,6 @@
 package mtu
 
 const (
-	// In remote vlan mechanism this label used to identify the host interface
-	viaLabel = "via"
-
 	// vpp constants
 	afPacketNamePrefix = "host-"
 	l3MtuIndex         = 0
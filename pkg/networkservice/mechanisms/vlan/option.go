@@ -0,0 +1,32 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vlan
+
+type vlanOptions struct {
+	uplinks *Uplinks
+}
+
+// Option is an option pattern for NewClient
+type Option func(o *vlanOptions)
+
+// WithUplinks sets the parent interfaces the via label is resolved against instead of domain2Device, so they can be
+// changed at runtime and selected by the labels
+func WithUplinks(uplinks *Uplinks) Option {
+	return func(o *vlanOptions) {
+		o.uplinks = uplinks
+	}
+}
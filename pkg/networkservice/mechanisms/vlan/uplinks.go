@@ -0,0 +1,176 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vlan
+
+import (
+	"context"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	interfaces "github.com/edwarnicke/govpp/binapi/interface"
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// Uplinks - the parent interfaces of the vlan sub-interfaces the via label of the connection is resolved against.
+// The via label is either the name the parent interfaces are set for or the selector "key=value[,key=value]"
+// matching the labels of the parent interfaces. Of several parent interfaces the first one with the link up is used.
+// Uplinks are safe for the concurrent use, so the parent interfaces can be changed at runtime.
+type Uplinks struct {
+	mu      sync.RWMutex
+	devices map[string][]string
+	labels  map[string]map[string]string
+}
+
+// NewUplinks returns the Uplinks with the parent interface per via label of domain2Device
+func NewUplinks(domain2Device map[string]string) *Uplinks {
+	u := &Uplinks{
+		devices: make(map[string][]string),
+		labels:  make(map[string]map[string]string),
+	}
+	for via, device := range domain2Device {
+		u.devices[via] = []string{device}
+	}
+	return u
+}
+
+// Set sets the parent interfaces for the via label
+func (u *Uplinks) Set(via string, devices ...string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.devices[via] = devices
+}
+
+// Delete deletes the parent interfaces of the via label
+func (u *Uplinks) Delete(via string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	delete(u.devices, via)
+}
+
+// SetLabels sets the labels of the parent interface the via selectors are matched against, nil labels delete them
+func (u *Uplinks) SetLabels(device string, labels map[string]string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if labels == nil {
+		delete(u.labels, device)
+		return
+	}
+	u.labels[device] = labels
+}
+
+// resolve returns the parent interfaces of the via label or matching the via selector
+func (u *Uplinks) resolve(via string) ([]string, error) {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	if devices, ok := u.devices[via]; ok {
+		return devices, nil
+	}
+	if !strings.Contains(via, "=") {
+		return nil, errors.Errorf("no interface name for label %s", via)
+	}
+	selector := make(map[string]string)
+	for _, pair := range strings.Split(via, ",") {
+		kv := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+		if len(kv) != 2 {
+			return nil, errors.Errorf("invalid via selector %s", via)
+		}
+		selector[kv[0]] = kv[1]
+	}
+	var rv []string
+	for device, labels := range u.labels {
+		if matches(labels, selector) {
+			rv = append(rv, device)
+		}
+	}
+	if len(rv) == 0 {
+		return nil, errors.Errorf("no interface matches the via selector %s", via)
+	}
+	// The same parent interface is selected for the same link states
+	sort.Strings(rv)
+	return rv, nil
+}
+
+func matches(labels, selector map[string]string) bool {
+	for k, v := range selector {
+		if labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// selectDevice returns the first of the parent interfaces with the link up, the first one present in vpp if there is
+// no such interface
+func selectDevice(ctx context.Context, vppConn api.Connection, devices []string) (string, error) {
+	if len(devices) == 1 {
+		return devices[0], nil
+	}
+	var present string
+	for _, device := range devices {
+		ok, up, err := linkState(ctx, vppConn, device)
+		if err != nil {
+			return "", err
+		}
+		if up {
+			return device, nil
+		}
+		if ok && present == "" {
+			present = device
+		}
+	}
+	if present == "" {
+		return "", errors.Errorf("none of the interfaces %v found", devices)
+	}
+	log.FromContext(ctx).Warnf("none of the interfaces %v has the link up, using %s", devices, present)
+	return present, nil
+}
+
+func linkState(ctx context.Context, vppConn api.Connection, hostIFName string) (ok, up bool, err error) {
+	now := time.Now()
+	client, err := interfaces.NewServiceClient(vppConn).SwInterfaceDump(ctx, &interfaces.SwInterfaceDump{
+		NameFilterValid: true,
+		NameFilter:      hostIFName,
+	})
+	if err != nil {
+		return false, false, errors.Wrapf(err, "error attempting to get interface dump client for %q", hostIFName)
+	}
+	log.FromContext(ctx).
+		WithField("duration", time.Since(now)).
+		WithField("HostInterfaceName", hostIFName).
+		WithField("vppapi", "SwInterfaceDump").Debug("completed")
+	for {
+		details, err := client.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return false, false, errors.Wrapf(err, "error attempting to get interface details for %q", hostIFName)
+		}
+		if hostIFName == details.InterfaceName || afPacketNamePrefix+hostIFName == details.InterfaceName {
+			ok = true
+			up = up || details.Flags&interface_types.IF_STATUS_API_FLAG_LINK_UP != 0
+		}
+	}
+	return ok, up, nil
+}
@@ -31,6 +31,7 @@ import (
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	vxlanMech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vxlan"
 	"github.com/networkservicemesh/api/pkg/api/networkservice/payload"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/mechanisms/vxlan/vni"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
@@ -39,12 +40,19 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
 
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/vxlan/mtu"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/tunnelip"
 )
 
 type vxlanClient struct {
 	vppConn api.Connection
 }
 
+// srcIPClient - fixes up the SrcIP set by vni.NewClient to match the family of the remote side or to the
+// per connection tunnel IP
+type srcIPClient struct {
+	tunnelIPs []net.IP
+}
+
 // NewClient - returns a new client for the vxlan remote mechanism
 func NewClient(vppConn api.Connection, tunnelIP net.IP, options ...Option) networkservice.NetworkServiceClient {
 	opts := &vxlanOptions{
@@ -58,8 +66,11 @@ func NewClient(vppConn api.Connection, tunnelIP net.IP, options ...Option) netwo
 		&vxlanClient{
 			vppConn: vppConn,
 		},
-		mtu.NewClient(vppConn, tunnelIP),
+		mtu.NewClient(vppConn, tunnelIP, opts.ipv6TunnelIP),
 		vni.NewClient(tunnelIP, vni.WithTunnelPort(opts.vxlanPort)),
+		&srcIPClient{
+			tunnelIPs: []net.IP{tunnelIP, opts.ipv6TunnelIP},
+		},
 	)
 }
 
@@ -107,3 +118,30 @@ func (v *vxlanClient) Close(ctx context.Context, conn *networkservice.Connection
 
 	return next.Client(ctx).Close(ctx, conn, opts...)
 }
+
+func (s *srcIPClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	var srcIP net.IP
+	if mechanism := vxlanMech.ToMechanism(request.GetConnection().GetMechanism()); mechanism != nil && mechanism.DstIP() != nil {
+		srcIP = tunnelip.Select(mechanism.DstIP(), s.tunnelIPs...)
+	}
+	ip, override := tunnelip.Load(ctx, metadata.IsClient(s))
+	if override {
+		srcIP = ip
+	}
+	for _, m := range request.GetMechanismPreferences() {
+		if mech := vxlanMech.ToMechanism(m); mech != nil {
+			if srcIP != nil {
+				mech.SetSrcIP(srcIP)
+			}
+			// The remote side falls back to the other underlay IP if the preferred one is not reachable
+			if !override {
+				tunnelip.SetCandidates(m, append([]net.IP{mech.SrcIP()}, s.tunnelIPs...)...)
+			}
+		}
+	}
+	return next.Client(ctx).Request(ctx, request, opts...)
+}
+
+func (s *srcIPClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
@@ -23,6 +23,7 @@ import (
 
 	"git.fd.io/govpp.git/api"
 	"git.fd.io/govpp.git/binapi/vpe"
+	"github.com/edwarnicke/govpp/binapi/interface_types"
 	"github.com/edwarnicke/govpp/binapi/vxlan"
 	"github.com/pkg/errors"
 
@@ -30,22 +31,15 @@ import (
 	vxlanMech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vxlan"
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
 
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/teardown"
 	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
 	"github.com/networkservicemesh/sdk-vpp/pkg/tools/types"
 )
 
 func addDel(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, isAdd, isClient bool) error {
 	if mechanism := vxlanMech.ToMechanism(conn.GetMechanism()); mechanism != nil {
-		port := mechanism.DstPort()
-		if isClient {
-			port = mechanism.SrcPort()
-		}
-		_, ok := ifindex.Load(ctx, isClient)
-		if isAdd && ok {
-			return nil
-		}
-		if !isAdd && !ok {
-			return nil
+		if !isAdd {
+			return del(ctx, mechanism, vppConn, isClient)
 		}
 		if mechanism.SrcIP() == nil {
 			return errors.Errorf("no vxlan SrcIP not provided")
@@ -54,6 +48,23 @@ func addDel(ctx context.Context, conn *networkservice.Connection, vppConn api.Co
 			return errors.Errorf("no vxlan DstIP not provided")
 		}
 
+		vxlanAddDelTunnel := toTunnel(mechanism, isClient)
+		if _, ok := ifindex.Load(ctx, isClient); ok {
+			prev, ok := load(ctx, isClient)
+			if !ok || sameTunnel(prev, vxlanAddDelTunnel) {
+				return nil
+			}
+			// The remote side has changed (e.g. the connection was healed to another forwarder), so only the tunnel is
+			// rebuilt. Elements using the swIfIndex reprogram it on the same Request.
+			log.FromContext(ctx).
+				WithField("prevDstAddress", prev.DstAddress).
+				WithField("DstAddress", vxlanAddDelTunnel.DstAddress).
+				Info("vxlan tunnel endpoint changed, rebuilding the tunnel")
+			if err := del(ctx, mechanism, vppConn, isClient); err != nil {
+				return err
+			}
+		}
+
 		now := time.Now()
 
 		addNextNode := &vpe.AddNodeNext{
@@ -80,41 +91,79 @@ func addDel(ctx context.Context, conn *networkservice.Connection, vppConn api.Co
 			WithField("duration", time.Since(now)).
 			WithField("vppapi", "AddNodeNext").Debug("completed")
 
-		now = time.Now()
-		vxlanAddDelTunnel := &vxlan.VxlanAddDelTunnelV2{
-			IsAdd:          isAdd,
-			Instance:       ^uint32(0),
-			SrcAddress:     types.ToVppAddress(mechanism.SrcIP()),
-			DstAddress:     types.ToVppAddress(mechanism.DstIP()),
-			DecapNextIndex: addNextNodeRsp.NextIndex,
-			Vni:            mechanism.VNI(),
-			SrcPort:        port,
-			DstPort:        port,
-		}
-		if !isClient {
-			vxlanAddDelTunnel.SrcAddress = types.ToVppAddress(mechanism.DstIP())
-			vxlanAddDelTunnel.DstAddress = types.ToVppAddress(mechanism.SrcIP())
-		}
-		rsp, err := vxlan.NewServiceClient(vppConn).VxlanAddDelTunnelV2(ctx, vxlanAddDelTunnel)
+		vxlanAddDelTunnel.DecapNextIndex = addNextNodeRsp.NextIndex
+		swIfIndex, err := addDelTunnel(ctx, vppConn, vxlanAddDelTunnel)
 		if err != nil {
-			return errors.WithStack(err)
-		}
-		log.FromContext(ctx).
-			WithField("isAdd", isAdd).
-			WithField("swIfIndex", rsp.SwIfIndex).
-			WithField("SrcAddress", vxlanAddDelTunnel.SrcAddress).
-			WithField("DstAddress", vxlanAddDelTunnel.DstAddress).
-			WithField("Vni", vxlanAddDelTunnel.Vni).
-			WithField("duration", time.Since(now)).
-			WithField("vppapi", "VxlanAddDelTunnel").Debug("completed")
-		if isAdd {
-			ifindex.Store(ctx, isClient, rsp.SwIfIndex)
-		} else {
-			ifindex.Delete(ctx, isClient)
+			return err
 		}
+		ifindex.Store(ctx, isClient, swIfIndex)
+		store(ctx, isClient, vxlanAddDelTunnel)
+		return nil
 	}
 
 	log.FromContext(ctx).WithField("vxlan", "addDel").Debugf("not vxlan mechanism")
 
 	return nil
 }
+
+func del(ctx context.Context, mechanism *vxlanMech.Mechanism, vppConn api.Connection, isClient bool) error {
+	if _, ok := ifindex.Load(ctx, isClient); !ok {
+		return nil
+	}
+	vxlanAddDelTunnel, ok := loadAndDelete(ctx, isClient)
+	if !ok {
+		vxlanAddDelTunnel = toTunnel(mechanism, isClient)
+	}
+	vxlanAddDelTunnel.IsAdd = false
+	ifindex.Delete(ctx, isClient)
+	return teardown.Do(ctx, teardown.Interfaces, func(ctx context.Context) error {
+		_, err := addDelTunnel(ctx, vppConn, vxlanAddDelTunnel)
+		return err
+	})
+}
+
+func toTunnel(mechanism *vxlanMech.Mechanism, isClient bool) *vxlan.VxlanAddDelTunnelV2 {
+	port := mechanism.DstPort()
+	if isClient {
+		port = mechanism.SrcPort()
+	}
+	vxlanAddDelTunnel := &vxlan.VxlanAddDelTunnelV2{
+		IsAdd:      true,
+		Instance:   ^uint32(0),
+		SrcAddress: types.ToVppAddress(mechanism.SrcIP()),
+		DstAddress: types.ToVppAddress(mechanism.DstIP()),
+		Vni:        mechanism.VNI(),
+		SrcPort:    port,
+		DstPort:    port,
+	}
+	if !isClient {
+		vxlanAddDelTunnel.SrcAddress = types.ToVppAddress(mechanism.DstIP())
+		vxlanAddDelTunnel.DstAddress = types.ToVppAddress(mechanism.SrcIP())
+	}
+	return vxlanAddDelTunnel
+}
+
+func sameTunnel(a, b *vxlan.VxlanAddDelTunnelV2) bool {
+	return a.SrcAddress == b.SrcAddress &&
+		a.DstAddress == b.DstAddress &&
+		a.Vni == b.Vni &&
+		a.SrcPort == b.SrcPort &&
+		a.DstPort == b.DstPort
+}
+
+func addDelTunnel(ctx context.Context, vppConn api.Connection, vxlanAddDelTunnel *vxlan.VxlanAddDelTunnelV2) (interface_types.InterfaceIndex, error) {
+	now := time.Now()
+	rsp, err := vxlan.NewServiceClient(vppConn).VxlanAddDelTunnelV2(ctx, vxlanAddDelTunnel)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("isAdd", vxlanAddDelTunnel.IsAdd).
+		WithField("swIfIndex", rsp.SwIfIndex).
+		WithField("SrcAddress", vxlanAddDelTunnel.SrcAddress).
+		WithField("DstAddress", vxlanAddDelTunnel.DstAddress).
+		WithField("Vni", vxlanAddDelTunnel.Vni).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "VxlanAddDelTunnel").Debug("completed")
+	return rsp.SwIfIndex, nil
+}
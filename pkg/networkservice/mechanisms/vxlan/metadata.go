@@ -0,0 +1,80 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vxlan
+
+import (
+	"context"
+
+	"github.com/edwarnicke/govpp/binapi/vxlan"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+type tunnelKey struct{}
+
+// store sets the vxlan tunnel programmed in vpp, stored in per Connection.Id metadata
+func store(ctx context.Context, isClient bool, tunnel *vxlan.VxlanAddDelTunnelV2) {
+	metadata.Map(ctx, isClient).Store(tunnelKey{}, tunnel)
+}
+
+// load returns the vxlan tunnel programmed in vpp, stored in per Connection.Id metadata
+func load(ctx context.Context, isClient bool) (value *vxlan.VxlanAddDelTunnelV2, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).Load(tunnelKey{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(*vxlan.VxlanAddDelTunnelV2)
+	return value, ok
+}
+
+// loadAndDelete deletes the vxlan tunnel programmed in vpp, stored in per Connection.Id metadata,
+// returning the previous value if any. The loaded result reports whether the key was present.
+func loadAndDelete(ctx context.Context, isClient bool) (value *vxlan.VxlanAddDelTunnelV2, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).LoadAndDelete(tunnelKey{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(*vxlan.VxlanAddDelTunnelV2)
+	return value, ok
+}
+
+type vniKey struct{}
+
+// storeVNI sets the VNI reserved for the server side connection, stored in per Connection.Id metadata
+func storeVNI(ctx context.Context, isClient bool, reserved *reservedVNI) {
+	metadata.Map(ctx, isClient).Store(vniKey{}, reserved)
+}
+
+// loadVNI returns the VNI reserved for the server side connection, stored in per Connection.Id metadata
+func loadVNI(ctx context.Context, isClient bool) (value *reservedVNI, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).Load(vniKey{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(*reservedVNI)
+	return value, ok
+}
+
+// loadAndDeleteVNI deletes the VNI reserved for the server side connection, stored in per Connection.Id metadata
+func loadAndDeleteVNI(ctx context.Context, isClient bool) (value *reservedVNI, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).LoadAndDelete(vniKey{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(*reservedVNI)
+	return value, ok
+}
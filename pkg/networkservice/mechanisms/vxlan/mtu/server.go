@@ -19,8 +19,6 @@ package mtu
 import (
 	"context"
 	"net"
-	"sync"
-	"sync/atomic"
 
 	"git.fd.io/govpp.git/api"
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -29,37 +27,36 @@ import (
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vxlan"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/mtupath"
 )
 
 type mtuServer struct {
-	vppConn  api.Connection
-	tunnelIP net.IP
-	mtu      uint32
-
-	inited    uint32
-	initMutex sync.Mutex
+	mtus *tunnelMTUs
 }
 
-// NewServer - server chain element to manage vxlan MTU
-func NewServer(vppConn api.Connection, tunnelIP net.IP) networkservice.NetworkServiceServer {
+// NewServer - server chain element to manage vxlan MTU. The MTU is computed for the tunnel IP of the family of
+// the remote side, pass the optional IPv6 tunnel IP to compute it for the IPv6 tunnels.
+func NewServer(vppConn api.Connection, tunnelIP net.IP, ipv6TunnelIP ...net.IP) networkservice.NetworkServiceServer {
 	return &mtuServer{
-		vppConn:  vppConn,
-		tunnelIP: tunnelIP,
+		mtus: newTunnelMTUs(vppConn, append([]net.IP{tunnelIP}, ipv6TunnelIP...)...),
 	}
 }
 
 func (m *mtuServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
-	if err := m.init(ctx); err != nil {
-		return nil, err
-	}
 	if mechanism := vxlan.ToMechanism(request.GetConnection().GetMechanism()); mechanism != nil {
-		if err := m.init(ctx); err != nil {
+		path, err := m.mtus.get(ctx, mechanism.SrcIP())
+		if err != nil {
 			return nil, err
 		}
+		mtu := path.tunnel
 		// If the clients MTU is zero or larger than the mtu for the local end of the tunnel, use the the mtu from the local end of the tunnel
-		if mechanism.MTU() > m.mtu || mechanism.MTU() == 0 {
-			mechanism.SetMTU(m.mtu)
+		if mechanism.MTU() > mtu || mechanism.MTU() == 0 {
+			mechanism.SetMTU(mtu)
 		}
+		mtupath.Store(ctx, metadata.IsClient(m), mtupath.UplinkHop, path.uplink)
+		mtupath.Store(ctx, metadata.IsClient(m), vxlan.MECHANISM, mtu)
 		// If the ConnectionContext's MTU is zero or larger than the MTU for the tunnel, set the ConnectionContexts MTU to the MTU for the tunnel
 		if request.GetConnection().GetContext().GetMTU() > mechanism.MTU() || request.GetConnection().GetContext().GetMTU() == 0 {
 			if request.GetConnection() == nil {
@@ -77,21 +74,3 @@ func (m *mtuServer) Request(ctx context.Context, request *networkservice.Network
 func (m *mtuServer) Close(ctx context.Context, conn *networkservice.Connection) (*emptypb.Empty, error) {
 	return next.Server(ctx).Close(ctx, conn)
 }
-
-func (m *mtuServer) init(ctx context.Context) error {
-	if atomic.LoadUint32(&m.inited) > 0 {
-		return nil
-	}
-	m.initMutex.Lock()
-	defer m.initMutex.Unlock()
-	if atomic.LoadUint32(&m.inited) > 0 {
-		return nil
-	}
-
-	var err error
-	m.mtu, err = getMTU(ctx, m.vppConn, m.tunnelIP)
-	if err == nil {
-		atomic.StoreUint32(&m.inited, 1)
-	}
-	return err
-}
@@ -18,10 +18,17 @@
 
 package vxlan
 
+import (
+	"net"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/allocator"
+)
+
 // Option is an option pattern for vxlan server/client
 type Option func(o *vxlanOptions)
 
-// WithPort sets vxlan udp port
+// WithPort sets vxlan udp port. It is the destination port of the tunnel and the port vpp decapsulates on, the
+// source port of the encapsulated packets is derived by vpp from the inner flow hash to give the underlay ECMP entropy.
 func WithPort(port uint16) Option {
 	return func(o *vxlanOptions) {
 		if port != 0 {
@@ -30,6 +37,27 @@ func WithPort(port uint16) Option {
 	}
 }
 
+// WithIPv6TunnelIP sets an additional IPv6 tunnel IP. It is used instead of the tunnelIP passed to the
+// constructor when the remote side of the connection advertises an IPv6 address.
+func WithIPv6TunnelIP(ipv6TunnelIP net.IP) Option {
+	return func(o *vxlanOptions) {
+		o.ipv6TunnelIP = ipv6TunnelIP
+	}
+}
+
+// WithSharedVNIs allocates the VNIs of the server side connections reserving them for the owner in backend, so the
+// forwarders of the node sharing the tunnel IP don't use the same VNI for the same remote side. The reservations left
+// by the previous run of the owner should be released with backend.ReleaseAll on startup.
+func WithSharedVNIs(backend allocator.Backend, owner string) Option {
+	return func(o *vxlanOptions) {
+		o.vniBackend = backend
+		o.vniOwner = owner
+	}
+}
+
 type vxlanOptions struct {
-	vxlanPort uint16
+	vxlanPort    uint16
+	ipv6TunnelIP net.IP
+	vniBackend   allocator.Backend
+	vniOwner     string
 }
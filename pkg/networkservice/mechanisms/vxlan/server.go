@@ -29,6 +29,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	vxlanMech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vxlan"
 	"github.com/networkservicemesh/api/pkg/api/networkservice/payload"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/mechanisms/vxlan/vni"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
@@ -37,10 +38,12 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
 
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/vxlan/mtu"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/tunnelip"
 )
 
 type vxlanServer struct {
-	vppConn api.Connection
+	vppConn   api.Connection
+	tunnelIPs []net.IP
 }
 
 // NewServer - returns a new server for the vxlan remote mechanism
@@ -53,10 +56,17 @@ func NewServer(vppConn api.Connection, tunnelIP net.IP, options ...Option) netwo
 	}
 
 	return chain.NewNetworkServiceServer(
+		&vniAllocateServer{
+			tunnelIP: tunnelIP,
+			backend:  opts.vniBackend,
+			owner:    opts.vniOwner,
+			inUse:    make(map[reservedVNI]struct{}),
+		},
 		vni.NewServer(tunnelIP, vni.WithTunnelPort(opts.vxlanPort)),
-		mtu.NewServer(vppConn, tunnelIP),
+		mtu.NewServer(vppConn, tunnelIP, opts.ipv6TunnelIP),
 		&vxlanServer{
-			vppConn: vppConn,
+			vppConn:   vppConn,
+			tunnelIPs: []net.IP{tunnelIP, opts.ipv6TunnelIP},
 		},
 	)
 }
@@ -66,6 +76,30 @@ func (v *vxlanServer) Request(ctx context.Context, request *networkservice.Netwo
 		return next.Server(ctx).Request(ctx, request)
 	}
 
+	// vni.NewServer always sets the primary tunnelIP, fix it up to match the family of the remote side
+	if mechanism := vxlanMech.ToMechanism(request.GetConnection().GetMechanism()); mechanism != nil && mechanism.SrcIP() != nil {
+		mechanism.SetDstIP(tunnelip.Select(mechanism.SrcIP(), v.tunnelIPs...))
+		// The first reachable of the underlay IPs advertised by the remote side is used
+		if remote, local, ok := tunnelip.Choose(ctx, v.vppConn, tunnelip.Candidates(request.GetConnection().GetMechanism()), v.tunnelIPs...); ok {
+			if !remote.Equal(mechanism.SrcIP()) {
+				log.FromContext(ctx).
+					WithField("srcIP", mechanism.SrcIP()).
+					WithField("candidate", remote).
+					Info("vxlan underlay falls back to the reachable candidate")
+			}
+			// Recorded for the requesting side to see which of its underlay IPs is in use
+			tunnelip.SetChosen(request.GetConnection().GetMechanism(), remote)
+			mechanism.SetSrcIP(remote)
+			mechanism.SetDstIP(local)
+		} else {
+			tunnelip.SetChosen(request.GetConnection().GetMechanism(), nil)
+		}
+		// Per connection tunnel IP overrides the default one
+		if ip, ok := tunnelip.Load(ctx, metadata.IsClient(v)); ok {
+			mechanism.SetDstIP(ip)
+		}
+	}
+
 	postponeCtxFunc := postpone.ContextWithValues(ctx)
 
 	conn, err := next.Server(ctx).Request(ctx, request)
@@ -73,7 +107,7 @@ func (v *vxlanServer) Request(ctx context.Context, request *networkservice.Netwo
 		return nil, err
 	}
 
-	if err := addDel(ctx, conn, v.vppConn, true, metadata.IsClient(v)); err != nil {
+	if err = addDel(ctx, conn, v.vppConn, true, metadata.IsClient(v)); err != nil {
 		closeCtx, cancelClose := postponeCtxFunc()
 		defer cancelClose()
 
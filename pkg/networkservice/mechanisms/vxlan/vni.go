@@ -0,0 +1,152 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vxlan
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	vxlanMech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vxlan"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/allocator"
+)
+
+// reservedVNI - VNI of the server side connection reserved in the allocator.Backend
+type reservedVNI struct {
+	pool string
+	vni  uint32
+}
+
+// vniPool returns the pool of the VNIs in the allocator.Backend, the VNIs are unique per remote side
+func vniPool(mechanism *vxlanMech.Mechanism) string {
+	return "vxlan-vni/" + mechanism.SrcIP().String()
+}
+
+// vniAllocateServer - reserves the VNI of the server side connection in the allocator.Backend shared with the other
+// forwarders of the node. It sets the VNI before vni.NewServer would pick a random one knowing only the VNIs of this
+// forwarder.
+type vniAllocateServer struct {
+	tunnelIP net.IP
+	backend  allocator.Backend
+	owner    string
+	// inUse - VNIs reserved by the connections of this forwarder, the backend allows reserving them again for the
+	// same owner
+	inUse map[reservedVNI]struct{}
+	mu    sync.Mutex
+}
+
+func (v *vniAllocateServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	mechanism := vxlanMech.ToMechanism(request.GetConnection().GetMechanism())
+	if v.backend == nil || mechanism == nil || mechanism.SrcIP() == nil {
+		return next.Server(ctx).Request(ctx, request)
+	}
+
+	isClient := metadata.IsClient(v)
+	prev, loaded := loadVNI(ctx, isClient)
+	reserved := &reservedVNI{
+		pool: vniPool(mechanism),
+		vni:  mechanism.VNI(),
+	}
+	// The VNI is reserved again if it is new or changed by the heal
+	isNew := !loaded || *prev != *reserved
+	if isNew {
+		vni, err := v.reserve(ctx, mechanism, reserved.pool)
+		if err != nil {
+			return nil, err
+		}
+		reserved.vni = vni
+		mechanism.SetVNI(vni)
+	}
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		if isNew {
+			v.release(ctx, reserved)
+		}
+		return nil, err
+	}
+
+	if isNew {
+		if loaded {
+			v.release(ctx, prev)
+		}
+		storeVNI(ctx, isClient, reserved)
+	}
+	return conn, nil
+}
+
+func (v *vniAllocateServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	if reserved, ok := loadAndDeleteVNI(ctx, metadata.IsClient(v)); ok {
+		v.release(ctx, reserved)
+	}
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+// reserve reserves the VNI requested by the mechanism, or a random one if it is not set or is used by another
+// connection
+func (v *vniAllocateServer) reserve(ctx context.Context, mechanism *vxlanMech.Mechanism, pool string) (uint32, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if vni := mechanism.VNI(); vni != 0 && !v.isInUse(pool, vni) {
+		ok, err := v.backend.Reserve(ctx, pool, vni, v.owner)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			v.inUse[reservedVNI{pool: pool, vni: vni}] = struct{}{}
+			return vni, nil
+		}
+	}
+	// The parity of the random VNI depends on the tunnel IP, which vni.NewServer sets as well
+	mechanism.SetDstIP(v.tunnelIP)
+	vni, err := allocator.ReserveAny(ctx, v.backend, pool, v.owner, func() (uint32, error) {
+		for {
+			vni, err := mechanism.GenerateRandomVNI()
+			if err != nil || !v.isInUse(pool, vni) {
+				return vni, err
+			}
+		}
+	})
+	if err != nil {
+		return 0, err
+	}
+	v.inUse[reservedVNI{pool: pool, vni: vni}] = struct{}{}
+	return vni, nil
+}
+
+func (v *vniAllocateServer) isInUse(pool string, vni uint32) bool {
+	_, ok := v.inUse[reservedVNI{pool: pool, vni: vni}]
+	return ok
+}
+
+func (v *vniAllocateServer) release(ctx context.Context, reserved *reservedVNI) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	delete(v.inUse, *reserved)
+	if err := v.backend.Release(ctx, reserved.pool, reserved.vni, v.owner); err != nil {
+		log.FromContext(ctx).WithField("vxlan", "server").Errorf("error while releasing vni %d: %v", reserved.vni, err)
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package vxlan
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	vxlanMech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vxlan"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/allocator"
+)
+
+func vniRequest(id string, vni uint32) *networkservice.NetworkServiceRequest {
+	mechanism := &networkservice.Mechanism{Type: vxlanMech.MECHANISM}
+	vxlanMech.ToMechanism(mechanism).SetSrcIP(net.ParseIP("10.0.0.1")).SetVNI(vni)
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:        id,
+			Mechanism: mechanism,
+		},
+	}
+}
+
+func Test_VNIAllocateServer(t *testing.T) {
+	ctx := context.Background()
+	backend := allocator.NewFileBackend(filepath.Join(t.TempDir(), "reservations.json"))
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		&vniAllocateServer{
+			tunnelIP: net.ParseIP("10.0.0.2"),
+			backend:  backend,
+			owner:    "forwarder",
+			inUse:    make(map[reservedVNI]struct{}),
+		},
+	)
+	isReservedByOther := func(vni uint32) bool {
+		ok, err := backend.Reserve(ctx, "vxlan-vni/10.0.0.1", vni, "other")
+		require.NoError(t, err)
+		if ok {
+			require.NoError(t, backend.Release(ctx, "vxlan-vni/10.0.0.1", vni, "other"))
+		}
+		return !ok
+	}
+
+	conn1, err := server.Request(ctx, vniRequest("1", 0))
+	require.NoError(t, err)
+	vni1 := vxlanMech.ToMechanism(conn1.GetMechanism()).VNI()
+	require.NotZero(t, vni1)
+	require.True(t, isReservedByOther(vni1))
+
+	// The VNI used by another connection of the forwarder is not reused
+	conn2, err := server.Request(ctx, vniRequest("2", vni1))
+	require.NoError(t, err)
+	vni2 := vxlanMech.ToMechanism(conn2.GetMechanism()).VNI()
+	require.NotZero(t, vni2)
+	require.NotEqual(t, vni1, vni2)
+
+	// The VNI reserved by another forwarder is not used
+	ok, err := backend.Reserve(ctx, "vxlan-vni/10.0.0.1", vni1+2, "other")
+	require.NoError(t, err)
+	require.True(t, ok)
+	conn3, err := server.Request(ctx, vniRequest("3", vni1+2))
+	require.NoError(t, err)
+	vni3 := vxlanMech.ToMechanism(conn3.GetMechanism()).VNI()
+	require.NotEqual(t, vni1+2, vni3)
+
+	// The VNI changed on heal is reserved and the previous one is released
+	request := vniRequest("1", vni1+4)
+	conn1, err = server.Request(ctx, request)
+	require.NoError(t, err)
+	require.Equal(t, vni1+4, vxlanMech.ToMechanism(conn1.GetMechanism()).VNI())
+	require.False(t, isReservedByOther(vni1))
+	require.True(t, isReservedByOther(vni1+4))
+
+	_, err = server.Close(ctx, conn1)
+	require.NoError(t, err)
+	require.False(t, isReservedByOther(vni1+4))
+	require.True(t, isReservedByOther(vni2))
+}
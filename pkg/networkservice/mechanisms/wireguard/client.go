@@ -39,22 +39,36 @@ import (
 
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/wireguard/mtu"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/wireguard/peer"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/cryptoengine"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/keybinding"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/tunnelip"
 )
 
 type wireguardClient struct {
-	vppConn  api.Connection
-	tunnelIP net.IP
+	vppConn   api.Connection
+	tunnelIPs []net.IP
+	crypto    *cryptoengine.Selector
+	ports     PortAllocator
+	keys      *keybinding.Binder
 }
 
 // NewClient - returns a new client for the wireguard remote mechanism
-func NewClient(vppConn api.Connection, tunnelIP net.IP) networkservice.NetworkServiceClient {
+func NewClient(vppConn api.Connection, tunnelIP net.IP, options ...Option) networkservice.NetworkServiceClient {
+	opts := &wireguardOptions{}
+	for _, opt := range options {
+		opt(opts)
+	}
+
 	return chain.NewNetworkServiceClient(
-		peer.NewClient(vppConn),
+		peer.NewClient(vppConn, peer.WithAllowedIPs(opts.allowedIPs...)),
 		&wireguardClient{
-			vppConn:  vppConn,
-			tunnelIP: tunnelIP,
+			vppConn:   vppConn,
+			tunnelIPs: []net.IP{tunnelIP, opts.ipv6TunnelIP},
+			crypto:    cryptoengine.NewSelector(vppConn, "wireguard", opts.asyncCrypto, setAsyncMode),
+			ports:     opts.portAllocator,
+			keys:      opts.keyBinding,
 		},
-		mtu.NewClient(vppConn, tunnelIP),
+		mtu.NewClient(vppConn, tunnelIP, opts.ipv6TunnelIP),
 	)
 }
 
@@ -62,14 +76,27 @@ func (w *wireguardClient) Request(ctx context.Context, request *networkservice.N
 	if request.GetConnection().GetPayload() != payload.IP {
 		return next.Client(ctx).Request(ctx, request, opts...)
 	}
+	w.crypto.Init(ctx)
 
 	privateKey, _ := wgtypes.GeneratePrivateKey()
 	publicKey := privateKey.PublicKey().String()
+	srcIP := tunnelip.Select(nil, w.tunnelIPs...)
 	// If we already have a key we can reuse it
 	// else create new key and store it after successful interface creation
 	if mechanism := wireguardMech.ToMechanism(request.GetConnection().GetMechanism()); mechanism != nil {
 		// If there is a key in mechanism then we can use it
 		publicKey = mechanism.SrcPublicKey()
+		// Use the same IP family the remote side has already advertised
+		srcIP = tunnelip.Select(mechanism.DstIP(), w.tunnelIPs...)
+	}
+	// Per connection tunnel IP overrides the default one
+	ip, override := tunnelip.Load(ctx, metadata.IsClient(w))
+	if override {
+		srcIP = ip
+	}
+	port, err := listenPort(ctx, w.ports, metadata.IsClient(w))
+	if err != nil {
+		return nil, err
 	}
 	mechanism := &networkservice.Mechanism{
 		Cls:        cls.REMOTE,
@@ -78,8 +105,18 @@ func (w *wireguardClient) Request(ctx context.Context, request *networkservice.N
 	}
 	wireguardMech.ToMechanism(mechanism).
 		SetSrcPublicKey(publicKey).
-		SetSrcIP(w.tunnelIP).
-		SetSrcPort(wireguardDefaultPort)
+		SetSrcIP(srcIP).
+		SetSrcPort(port)
+	// The remote side falls back to the other underlay IP if the preferred one is not reachable
+	if !override {
+		tunnelip.SetCandidates(mechanism, append([]net.IP{srcIP}, w.tunnelIPs...)...)
+	}
+	if err = w.keys.Sign(mechanism, publicKey, true); err != nil {
+		if _, ok := load(ctx, metadata.IsClient(w)); !ok {
+			releasePort(ctx, w.ports, metadata.IsClient(w))
+		}
+		return nil, err
+	}
 
 	request.MechanismPreferences = append(request.MechanismPreferences, mechanism)
 
@@ -87,10 +124,19 @@ func (w *wireguardClient) Request(ctx context.Context, request *networkservice.N
 
 	conn, err := next.Client(ctx).Request(ctx, request, opts...)
 	if err != nil {
+		if _, ok := load(ctx, metadata.IsClient(w)); !ok {
+			releasePort(ctx, w.ports, metadata.IsClient(w))
+		}
 		return nil, err
 	}
 
-	if _, err = createInterface(ctx, conn, w.vppConn, privateKey, metadata.IsClient(w)); err != nil {
+	if mechanism := wireguardMech.ToMechanism(conn.GetMechanism()); mechanism != nil {
+		err = w.keys.Verify(conn.GetMechanism(), mechanism.DstPublicKey(), false)
+	}
+	if err == nil {
+		_, err = createInterface(ctx, conn, w.vppConn, privateKey, metadata.IsClient(w))
+	}
+	if err != nil {
 		closeCtx, cancelClose := postponeCtxFunc()
 		defer cancelClose()
 
@@ -100,6 +146,9 @@ func (w *wireguardClient) Request(ctx context.Context, request *networkservice.N
 
 		return nil, err
 	}
+	if wireguardMech.ToMechanism(conn.GetMechanism()) != nil {
+		w.crypto.SetMetric(conn)
+	}
 
 	return conn, nil
 }
@@ -109,5 +158,6 @@ func (w *wireguardClient) Close(ctx context.Context, conn *networkservice.Connec
 		return next.Client(ctx).Close(ctx, conn, opts...)
 	}
 	_ = delInterface(ctx, conn, w.vppConn, metadata.IsClient(w))
+	releasePort(ctx, w.ports, metadata.IsClient(w))
 	return next.Client(ctx).Close(ctx, conn, opts...)
 }
@@ -31,6 +31,7 @@ import (
 	wireguardMech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/wireguard"
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
 
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/teardown"
 	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
 	"github.com/networkservicemesh/sdk-vpp/pkg/tools/types"
 )
@@ -42,19 +43,27 @@ func createInterface(ctx context.Context, conn *networkservice.Connection, vppCo
 			return pubKeyStr, nil
 		}
 
+		srcIP := mechanism.SrcIP()
+		if !isClient {
+			srcIP = mechanism.DstIP()
+		}
+		// vpp would take the zero IPv6 address for the missing source IP
+		if srcIP == nil {
+			return "", errors.New("wireguard interface source IP is not set")
+		}
+
 		now := time.Now()
 		wgIfCreate := &wireguard.WireguardInterfaceCreate{
 			Interface: wireguard.WireguardInterface{
 				UserInstance: ^uint32(0),
 				PrivateKey:   privateKey[:],
 				Port:         mechanism.SrcPort(),
-				SrcIP:        types.ToVppAddress(mechanism.SrcIP()),
+				SrcIP:        types.ToVppAddress(srcIP),
 			},
 			GenerateKey: false,
 		}
 		if !isClient {
 			wgIfCreate.Interface.Port = mechanism.DstPort()
-			wgIfCreate.Interface.SrcIP = types.ToVppAddress(mechanism.DstIP())
 		}
 
 		rspIf, err := wireguard.NewServiceClient(vppConn).WireguardInterfaceCreate(ctx, wgIfCreate)
@@ -86,19 +95,22 @@ func delInterface(ctx context.Context, conn *networkservice.Connection, vppConn
 		if !ok {
 			return nil
 		}
-		now := time.Now()
-		wgIfDel := &wireguard.WireguardInterfaceDelete{
-			SwIfIndex: swIfIndex,
-		}
+		return teardown.Do(ctx, teardown.Interfaces, func(ctx context.Context) error {
+			now := time.Now()
+			wgIfDel := &wireguard.WireguardInterfaceDelete{
+				SwIfIndex: swIfIndex,
+			}
 
-		_, err := wireguard.NewServiceClient(vppConn).WireguardInterfaceDelete(ctx, wgIfDel)
-		if err != nil {
-			return errors.WithStack(err)
-		}
-		log.FromContext(ctx).
-			WithField("swIfIndex", wgIfDel.SwIfIndex).
-			WithField("duration", time.Since(now)).
-			WithField("vppapi", "WireguardInterfaceDelete").Debug("completed")
+			_, err := wireguard.NewServiceClient(vppConn).WireguardInterfaceDelete(ctx, wgIfDel)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			log.FromContext(ctx).
+				WithField("swIfIndex", wgIfDel.SwIfIndex).
+				WithField("duration", time.Since(now)).
+				WithField("vppapi", "WireguardInterfaceDelete").Debug("completed")
+			return nil
+		})
 	}
 	return nil
 }
@@ -0,0 +1,223 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mesh
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/edwarnicke/govpp/binapi/fib_types"
+	interfaces "github.com/edwarnicke/govpp/binapi/interface"
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+	"github.com/edwarnicke/govpp/binapi/ip"
+	"github.com/edwarnicke/govpp/binapi/ip_types"
+	"github.com/edwarnicke/govpp/binapi/wireguard"
+	"github.com/pkg/errors"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/types"
+)
+
+const (
+	persistentKeepalive = 10
+	// maxAllowedIPs - vpp limits the number of the wireguard peer allowed IPs with u8
+	maxAllowedIPs = 255
+)
+
+func (m *Mesh) createInterface(ctx context.Context) error {
+	if m.created {
+		return nil
+	}
+	now := time.Now()
+	rsp, err := wireguard.NewServiceClient(m.vppConn).WireguardInterfaceCreate(ctx, &wireguard.WireguardInterfaceCreate{
+		Interface: wireguard.WireguardInterface{
+			UserInstance: ^uint32(0),
+			PrivateKey:   m.privateKey[:],
+			Port:         m.port,
+			SrcIP:        types.ToVppAddress(m.tunnelIP),
+		},
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", rsp.SwIfIndex).
+		WithField("port", m.port).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "WireguardInterfaceCreate").Debug("completed")
+	m.swIfIndex, m.created = rsp.SwIfIndex, true
+
+	client := interfaces.NewServiceClient(m.vppConn)
+	for _, isIPv6 := range []bool{false, true} {
+		if _, err := client.SwInterfaceSetTable(ctx, &interfaces.SwInterfaceSetTable{
+			SwIfIndex: m.swIfIndex,
+			IsIPv6:    isIPv6,
+			VrfID:     m.tableID,
+		}); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	for _, address := range m.addresses {
+		if _, err := client.SwInterfaceAddDelAddress(ctx, &interfaces.SwInterfaceAddDelAddress{
+			SwIfIndex: m.swIfIndex,
+			IsAdd:     true,
+			Prefix:    types.ToVppAddressWithPrefix(address),
+		}); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	if _, err := client.SwInterfaceSetFlags(ctx, &interfaces.SwInterfaceSetFlags{
+		SwIfIndex: m.swIfIndex,
+		Flags:     interface_types.IF_STATUS_API_FLAG_ADMIN_UP,
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", m.swIfIndex).
+		WithField("tableID", m.tableID).
+		WithField("duration", time.Since(now)).
+		Info("wireguard mesh interface created")
+	return nil
+}
+
+func (m *Mesh) deleteInterface(ctx context.Context) error {
+	if !m.created {
+		return nil
+	}
+	now := time.Now()
+	if _, err := wireguard.NewServiceClient(m.vppConn).WireguardInterfaceDelete(ctx, &wireguard.WireguardInterfaceDelete{
+		SwIfIndex: m.swIfIndex,
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", m.swIfIndex).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "WireguardInterfaceDelete").Debug("completed")
+	m.created = false
+	return nil
+}
+
+func (m *Mesh) addPeer(ctx context.Context, member *Member) (*peer, error) {
+	if len(member.AllowedIPs) > maxAllowedIPs {
+		return nil, errors.Errorf("too many allowed IPs of the wireguard mesh member %s: %d, max: %d",
+			member.PublicKey, len(member.AllowedIPs), maxAllowedIPs)
+	}
+	pubKey, err := wgtypes.ParseKey(member.PublicKey)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	var allowedIPs []ip_types.Prefix
+	for _, ipNet := range member.AllowedIPs {
+		allowedIPs = append(allowedIPs, types.ToVppPrefix(ipNet))
+	}
+
+	now := time.Now()
+	rsp, err := wireguard.NewServiceClient(m.vppConn).WireguardPeerAdd(ctx, &wireguard.WireguardPeerAdd{
+		Peer: wireguard.WireguardPeer{
+			PublicKey:           pubKey[:],
+			Port:                member.Port,
+			PersistentKeepalive: persistentKeepalive,
+			TableID:             m.tableID,
+			Endpoint:            types.ToVppAddress(member.Endpoint),
+			SwIfIndex:           m.swIfIndex,
+			NAllowedIps:         uint8(len(allowedIPs)),
+			AllowedIps:          allowedIPs,
+		},
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("peerIndex", rsp.PeerIndex).
+		WithField("member", member.PublicKey).
+		WithField("endpoint", member.Endpoint).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "WireguardPeerAdd").Debug("completed")
+
+	p := &peer{member: &Member{PublicKey: member.PublicKey, Endpoint: member.Endpoint, Port: member.Port}, index: rsp.PeerIndex}
+	for _, ipNet := range member.AllowedIPs {
+		if err := m.routeAddDel(ctx, ipNet, true); err != nil {
+			return p, err
+		}
+		p.member.AllowedIPs = append(p.member.AllowedIPs, ipNet)
+	}
+	return p, nil
+}
+
+func (m *Mesh) removePeer(ctx context.Context, p *peer) error {
+	for len(p.member.AllowedIPs) > 0 {
+		if err := m.routeAddDel(ctx, p.member.AllowedIPs[0], false); err != nil {
+			return err
+		}
+		p.member.AllowedIPs = p.member.AllowedIPs[1:]
+	}
+
+	now := time.Now()
+	if _, err := wireguard.NewServiceClient(m.vppConn).WireguardPeerRemove(ctx, &wireguard.WireguardPeerRemove{
+		PeerIndex: p.index,
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("peerIndex", p.index).
+		WithField("member", p.member.PublicKey).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "WireguardPeerRemove").Debug("completed")
+	return nil
+}
+
+// routeAddDel routes the allowed IP to the mesh interface, vpp picks the peer by the next hop within its allowed IPs
+func (m *Mesh) routeAddDel(ctx context.Context, ipNet *net.IPNet, isAdd bool) error {
+	isIPv6 := ipNet.IP.To4() == nil
+	nh := types.ToVppAddress(ipNet.IP.Mask(ipNet.Mask))
+	route := ip.IPRoute{
+		TableID: m.tableID,
+		Prefix:  types.ToVppPrefix(ipNet),
+		NPaths:  1,
+		Paths: []fib_types.FibPath{
+			{
+				SwIfIndex: uint32(m.swIfIndex),
+				Weight:    1,
+				Type:      fib_types.FIB_API_PATH_TYPE_NORMAL,
+				Flags:     fib_types.FIB_API_PATH_FLAG_NONE,
+				Proto:     types.IsV6toFibProto(isIPv6),
+				Nh: fib_types.FibPathNh{
+					Address: nh.Un,
+				},
+			},
+		},
+	}
+	now := time.Now()
+	if _, err := ip.NewServiceClient(m.vppConn).IPRouteAddDel(ctx, &ip.IPRouteAddDel{
+		IsAdd: isAdd,
+		Route: route,
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", m.swIfIndex).
+		WithField("prefix", ipNet).
+		WithField("tableID", m.tableID).
+		WithField("isAdd", isAdd).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "IPRouteAddDel").Debug("completed")
+	return nil
+}
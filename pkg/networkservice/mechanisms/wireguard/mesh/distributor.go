@@ -0,0 +1,90 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mesh
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+)
+
+type distributorServer struct {
+	mu      sync.Mutex
+	members map[string]*Member
+}
+
+// NewDistributorServer returns a server chain element of the vL3 endpoint collecting the member entries published by
+// the mesh clients and returning the entries of all the members in the connections. The allowed IPs of the member are
+// the source addresses of its connections.
+func NewDistributorServer() networkservice.NetworkServiceServer {
+	return &distributorServer{
+		members: make(map[string]*Member),
+	}
+}
+
+func (d *distributorServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	ms, err := members(request.GetConnection())
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if len(ms) == 1 {
+		ms[0].AllowedIPs = hostPrefixes(conn.GetContext().GetIpContext().GetSrcIPNets())
+		d.members[conn.GetId()] = ms[0]
+	} else {
+		delete(d.members, conn.GetId())
+	}
+	var all []*Member
+	for _, member := range d.members {
+		all = append(all, member)
+	}
+	setMembers(conn, merge(all...)...)
+	return conn, nil
+}
+
+func (d *distributorServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	d.mu.Lock()
+	delete(d.members, conn.GetId())
+	d.mu.Unlock()
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+// hostPrefixes returns the host prefixes of the addresses, the prefix of the address is the whole vL3 network
+func hostPrefixes(ipNets []*net.IPNet) []*net.IPNet {
+	var rv []*net.IPNet
+	for _, ipNet := range ipNets {
+		bits := net.IPv6len * 8
+		if ipNet.IP.To4() != nil {
+			bits = net.IPv4len * 8
+		}
+		rv = append(rv, &net.IPNet{IP: ipNet.IP, Mask: net.CIDRMask(bits, bits)})
+	}
+	return rv
+}
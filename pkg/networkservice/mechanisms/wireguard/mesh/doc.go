@@ -0,0 +1,28 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mesh provides the full mesh wireguard for the vL3 style networks: the forwarders share one wireguard
+// interface per node and program one peer per remote node with the allowed IPs aggregated from all the connections to
+// it, so the number of the tunnels is O(1) per node rather than per connection.
+//
+// The public keys and the endpoints of the forwarders are distributed over the connection ExtraContext: the mesh
+// client publishes the member entry of its node in the Request, the distributor server of the vL3 endpoint fills in
+// the allowed IPs (the addresses of the client) and returns the entries of all the members of the mesh. The members
+// joined later are learned on the next refresh.
+//
+// The mesh interface routes the allowed IPs of the peers in its table, steering the traffic into the table is up to
+// the routing of the vL3 network.
+package mesh
@@ -0,0 +1,152 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mesh
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+const (
+	// MemberKeyPrefix is the prefix of the ExtraContext keys of the member entries, followed by the public key
+	MemberKeyPrefix = "wireguard-mesh/"
+)
+
+// Member is the entry of a forwarder of the mesh
+type Member struct {
+	PublicKey  string
+	Endpoint   net.IP
+	Port       uint16
+	AllowedIPs []*net.IPNet
+}
+
+func (m *Member) String() string {
+	var allowedIPs []string
+	for _, ipNet := range m.AllowedIPs {
+		allowedIPs = append(allowedIPs, ipNet.String())
+	}
+	return fmt.Sprintf("%s %d %s", m.Endpoint, m.Port, strings.Join(allowedIPs, ","))
+}
+
+func parseMember(key, value string) (*Member, error) {
+	fields := strings.Fields(value)
+	if len(fields) < 2 {
+		return nil, errors.Errorf("invalid wireguard mesh member %s: %q", key, value)
+	}
+	m := &Member{
+		PublicKey: strings.TrimPrefix(key, MemberKeyPrefix),
+		Endpoint:  net.ParseIP(fields[0]),
+	}
+	if m.Endpoint == nil {
+		return nil, errors.Errorf("invalid wireguard mesh member %s endpoint: %q", key, fields[0])
+	}
+	port, err := strconv.ParseUint(fields[1], 10, 16)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid wireguard mesh member %s port", key)
+	}
+	m.Port = uint16(port)
+	if len(fields) > 2 {
+		for _, s := range strings.Split(fields[2], ",") {
+			_, ipNet, err := net.ParseCIDR(s)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid wireguard mesh member %s allowed IP", key)
+			}
+			m.AllowedIPs = append(m.AllowedIPs, ipNet)
+		}
+	}
+	return m, nil
+}
+
+// members returns the member entries of the connection sorted by the public key
+func members(conn *networkservice.Connection) ([]*Member, error) {
+	var rv []*Member
+	for key, value := range conn.GetContext().GetExtraContext() {
+		if !strings.HasPrefix(key, MemberKeyPrefix) {
+			continue
+		}
+		m, err := parseMember(key, value)
+		if err != nil {
+			return nil, err
+		}
+		rv = append(rv, m)
+	}
+	sort.Slice(rv, func(i, j int) bool { return rv[i].PublicKey < rv[j].PublicKey })
+	return rv, nil
+}
+
+// setMembers replaces the member entries of the connection
+func setMembers(conn *networkservice.Connection, ms ...*Member) {
+	if conn.GetContext() == nil {
+		conn.Context = &networkservice.ConnectionContext{}
+	}
+	if conn.GetContext().GetExtraContext() == nil {
+		conn.GetContext().ExtraContext = make(map[string]string)
+	}
+	for key := range conn.GetContext().GetExtraContext() {
+		if strings.HasPrefix(key, MemberKeyPrefix) {
+			delete(conn.GetContext().GetExtraContext(), key)
+		}
+	}
+	for _, m := range ms {
+		conn.GetContext().GetExtraContext()[MemberKeyPrefix+m.PublicKey] = m.String()
+	}
+}
+
+// merge aggregates the members by the public key, the allowed IPs are joined
+func merge(ms ...*Member) []*Member {
+	byKey := make(map[string]*Member)
+	var rv []*Member
+	for _, m := range ms {
+		merged, ok := byKey[m.PublicKey]
+		if !ok {
+			merged = &Member{PublicKey: m.PublicKey}
+			byKey[m.PublicKey] = merged
+			rv = append(rv, merged)
+		}
+		merged.Endpoint, merged.Port = m.Endpoint, m.Port
+		for _, ipNet := range m.AllowedIPs {
+			if !containsIPNet(merged.AllowedIPs, ipNet) {
+				merged.AllowedIPs = append(merged.AllowedIPs, ipNet)
+			}
+		}
+	}
+	for _, m := range rv {
+		sort.Slice(m.AllowedIPs, func(i, j int) bool { return m.AllowedIPs[i].String() < m.AllowedIPs[j].String() })
+	}
+	sort.Slice(rv, func(i, j int) bool { return rv[i].PublicKey < rv[j].PublicKey })
+	return rv
+}
+
+func containsIPNet(ipNets []*net.IPNet, ipNet *net.IPNet) bool {
+	for _, n := range ipNets {
+		if n.String() == ipNet.String() {
+			return true
+		}
+	}
+	return false
+}
+
+func equalMembers(a, b *Member) bool {
+	return a.String() == b.String()
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mesh
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+	"github.com/pkg/errors"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+)
+
+// Mesh is the wireguard interface of the node shared by the mesh connections and the peers of the remote nodes
+type Mesh struct {
+	vppConn    api.Connection
+	tunnelIP   net.IP
+	privateKey wgtypes.Key
+	port       uint16
+	tableID    uint32
+	addresses  []*net.IPNet
+
+	mu        sync.Mutex
+	swIfIndex interface_types.InterfaceIndex
+	created   bool
+	conns     map[string][]*Member
+	peers     map[string]*peer
+}
+
+// peer - wireguard peer programmed in vpp for a remote node
+type peer struct {
+	member *Member
+	index  uint32
+}
+
+// NewMesh returns the Mesh with the interface bound to the tunnelIP. The interface is created with the first peer and
+// deleted with the last one.
+func NewMesh(vppConn api.Connection, tunnelIP net.IP, opts ...Option) (*Mesh, error) {
+	o := &options{
+		port: defaultPort,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.privateKey == nil {
+		privateKey, err := wgtypes.GeneratePrivateKey()
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		o.privateKey = &privateKey
+	}
+
+	return &Mesh{
+		vppConn:    vppConn,
+		tunnelIP:   tunnelIP,
+		privateKey: *o.privateKey,
+		port:       o.port,
+		tableID:    o.tableID,
+		addresses:  o.addresses,
+		conns:      make(map[string][]*Member),
+		peers:      make(map[string]*peer),
+	}, nil
+}
+
+// PublicKey returns the public key of the node
+func (m *Mesh) PublicKey() string {
+	return m.privateKey.PublicKey().String()
+}
+
+// self returns the member entry of the node, the allowed IPs are filled by the distributor
+func (m *Mesh) self() *Member {
+	return &Member{
+		PublicKey: m.PublicKey(),
+		Endpoint:  m.tunnelIP,
+		Port:      m.port,
+	}
+}
+
+// update sets the remote members seen by the connection and reprograms the peers changed
+func (m *Mesh) update(ctx context.Context, connID string, members []*Member) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var remote []*Member
+	for _, member := range members {
+		if member.PublicKey != m.PublicKey() {
+			remote = append(remote, member)
+		}
+	}
+	if len(remote) == 0 {
+		delete(m.conns, connID)
+	} else {
+		m.conns[connID] = remote
+	}
+	return m.sync(ctx)
+}
+
+// sync programs the peers of the members aggregated from all the connections
+func (m *Mesh) sync(ctx context.Context) error {
+	var all []*Member
+	for _, members := range m.conns {
+		all = append(all, members...)
+	}
+	desired := make(map[string]*Member)
+	for _, member := range merge(all...) {
+		desired[member.PublicKey] = member
+	}
+
+	for key, p := range m.peers {
+		if member, ok := desired[key]; ok && equalMembers(member, p.member) {
+			continue
+		}
+		if err := m.removePeer(ctx, p); err != nil {
+			return err
+		}
+		delete(m.peers, key)
+	}
+	if len(desired) == 0 {
+		return m.deleteInterface(ctx)
+	}
+	if err := m.createInterface(ctx); err != nil {
+		return err
+	}
+	for key, member := range desired {
+		if _, ok := m.peers[key]; ok {
+			continue
+		}
+		p, err := m.addPeer(ctx, member)
+		if p != nil {
+			m.peers[key] = p
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mesh
+
+import (
+	"net"
+
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+)
+
+const (
+	defaultPort = 51820
+)
+
+type options struct {
+	privateKey *wgtypes.Key
+	port       uint16
+	tableID    uint32
+	addresses  []*net.IPNet
+}
+
+// Option is an option pattern for Mesh
+type Option func(o *options)
+
+// WithPrivateKey sets the private key of the mesh interface, a new one is generated by default
+func WithPrivateKey(key wgtypes.Key) Option {
+	return func(o *options) {
+		o.privateKey = &key
+	}
+}
+
+// WithPort sets the listen port of the mesh interface, 51820 by default
+func WithPort(port uint16) Option {
+	return func(o *options) {
+		o.port = port
+	}
+}
+
+// WithTableID sets the vpp table of the mesh interface and of the routes to the allowed IPs of the peers, 0 by default.
+// The table must exist in vpp.
+func WithTableID(tableID uint32) Option {
+	return func(o *options) {
+		o.tableID = tableID
+	}
+}
+
+// WithAddresses sets the addresses of the mesh interface, vpp accepts the decrypted packets of the IP families the
+// interface has the addresses of
+func WithAddresses(ipNets ...*net.IPNet) Option {
+	return func(o *options) {
+		o.addresses = append(o.addresses, ipNets...)
+	}
+}
@@ -50,3 +50,32 @@ func load(ctx context.Context, isClient bool) (value string, ok bool) {
 	value, ok = rawValue.(string)
 	return value, ok
 }
+
+type portKey struct{}
+
+// storePort sets the listen port allocated for the wireguard interface, stored in per Connection.Id metadata.
+func storePort(ctx context.Context, isClient bool, port uint16) {
+	metadata.Map(ctx, isClient).Store(portKey{}, port)
+}
+
+// loadPort returns the listen port allocated for the wireguard interface, stored in per Connection.Id metadata.
+// The ok result reports whether the key was present.
+func loadPort(ctx context.Context, isClient bool) (value uint16, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).Load(portKey{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(uint16)
+	return value, ok
+}
+
+// loadAndDeletePort deletes the listen port allocated for the wireguard interface, stored in per Connection.Id metadata,
+// returning the previous value if any. The loaded result reports whether the key was present.
+func loadAndDeletePort(ctx context.Context, isClient bool) (value uint16, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).LoadAndDelete(portKey{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(uint16)
+	return value, ok
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wireguard
+
+import (
+	"net"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/allocator"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/keybinding"
+)
+
+type wireguardOptions struct {
+	ipv6TunnelIP  net.IP
+	asyncCrypto   bool
+	allowedIPs    []*net.IPNet
+	portAllocator PortAllocator
+	keyBinding    *keybinding.Binder
+}
+
+// Option is an option pattern for wireguard server/client
+type Option func(o *wireguardOptions)
+
+// WithIPv6TunnelIP sets an additional IPv6 tunnel IP. It is used instead of the tunnelIP passed to the
+// constructor when the remote side of the connection advertises an IPv6 address.
+func WithIPv6TunnelIP(ipv6TunnelIP net.IP) Option {
+	return func(o *wireguardOptions) {
+		o.ipv6TunnelIP = ipv6TunnelIP
+	}
+}
+
+// WithAsyncCrypto prefers the async crypto handoff (e.g. to a QAT accelerator via dpdk_cryptodev) for the wireguard
+// tunnels if vpp has an async crypto engine available
+func WithAsyncCrypto() Option {
+	return func(o *wireguardOptions) {
+		o.asyncCrypto = true
+	}
+}
+
+// WithPortAllocator sets the allocator of the wireguard interfaces listen ports. By default all the wireguard
+// interfaces listen on the 51820 port. The pinhole chain element opens the allocated port as it is taken from
+// the mechanism parameters.
+// Pass the same option to the client and the server to share the allocator between them.
+func WithPortAllocator(allocator PortAllocator) Option {
+	return func(o *wireguardOptions) {
+		o.portAllocator = allocator
+	}
+}
+
+// WithPortRange sets the allocator of the wireguard interfaces listen ports allocating the ports from the [from, to]
+// range. It allows several forwarders sharing the host network namespace to use disjoint port ranges.
+func WithPortRange(from, to uint16) Option {
+	return WithPortAllocator(NewPortRange(from, to))
+}
+
+// WithSharedPortRange sets the allocator of the wireguard interfaces listen ports allocating the ports from the
+// [from, to] range shared with the other forwarders of the node. The ports are reserved for the owner in backend.
+func WithSharedPortRange(backend allocator.Backend, owner string, from, to uint16) Option {
+	return WithPortAllocator(NewSharedPortRange(backend, owner, from, to))
+}
+
+// WithKeyBinding binds the wireguard public keys to the SPIFFE identities of the forwarders: the own key is signed
+// and the key of the remote side is verified with binder
+func WithKeyBinding(binder *keybinding.Binder) Option {
+	return func(o *wireguardOptions) {
+		o.keyBinding = binder
+	}
+}
+
+// WithAllowedIPs widens the allowed IPs of the wireguard peers with ipNets. By default the allowed IPs are limited
+// to the addresses and routes of the remote side from the connection ipcontext.
+// Use 0.0.0.0/0 and ::/0 to allow any address.
+func WithAllowedIPs(ipNets ...*net.IPNet) Option {
+	return func(o *wireguardOptions) {
+		o.allowedIPs = append(o.allowedIPs, ipNets...)
+	}
+}
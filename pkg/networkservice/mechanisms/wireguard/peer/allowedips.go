@@ -0,0 +1,78 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package peer
+
+import (
+	"net"
+
+	"github.com/edwarnicke/govpp/binapi/ip_types"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/types"
+)
+
+// maxAllowedIPs - vpp limits the number of the wireguard peer allowed IPs with u8
+const maxAllowedIPs = 255
+
+// allowedIPs returns the prefixes the remote side of the connection is allowed to send from and receive to:
+// the remote side addresses and the prefixes routed to the remote side, plus the extra prefixes
+func allowedIPs(conn *networkservice.Connection, isClient bool, extra []*net.IPNet) []*net.IPNet {
+	ipContext := conn.GetContext().GetIpContext()
+	ipNets, routes := ipContext.GetSrcIPNets(), ipContext.GetDstRoutes()
+	if isClient {
+		ipNets, routes = ipContext.GetDstIPNets(), ipContext.GetSrcRoutes()
+	}
+	for _, route := range routes {
+		if prefix := route.GetPrefixIPNet(); prefix != nil {
+			ipNets = append(ipNets, prefix)
+		}
+	}
+	ipNets = append(ipNets, extra...)
+
+	var rv []*net.IPNet
+	seen := make(map[string]struct{})
+	for _, ipNet := range ipNets {
+		ipNet = &net.IPNet{IP: ipNet.IP.Mask(ipNet.Mask), Mask: ipNet.Mask}
+		if _, ok := seen[ipNet.String()]; ok {
+			continue
+		}
+		seen[ipNet.String()] = struct{}{}
+		rv = append(rv, ipNet)
+	}
+	return rv
+}
+
+func toPrefixes(ipNets []*net.IPNet) []ip_types.Prefix {
+	var rv []ip_types.Prefix
+	for _, ipNet := range ipNets {
+		rv = append(rv, types.ToVppPrefix(ipNet))
+	}
+	return rv
+}
+
+func equalIPNets(a, b []*net.IPNet) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].String() != b[i].String() {
+			return false
+		}
+	}
+	return true
+}
@@ -18,6 +18,7 @@ package peer
 
 import (
 	"context"
+	"net"
 
 	"git.fd.io/govpp.git/api"
 	"github.com/golang/protobuf/ptypes/empty"
@@ -31,13 +32,20 @@ import (
 )
 
 type wireguardPeerClient struct {
-	vppConn api.Connection
+	vppConn    api.Connection
+	allowedIPs []*net.IPNet
 }
 
 // NewClient - creates peer for the wireguard remote mechanism
-func NewClient(vppConn api.Connection) networkservice.NetworkServiceClient {
+func NewClient(vppConn api.Connection, opts ...Option) networkservice.NetworkServiceClient {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
 	return &wireguardPeerClient{
-		vppConn: vppConn,
+		vppConn:    vppConn,
+		allowedIPs: o.allowedIPs,
 	}
 }
 
@@ -49,7 +57,7 @@ func (w *wireguardPeerClient) Request(ctx context.Context, request *networkservi
 		return nil, err
 	}
 
-	if err = createPeer(ctx, conn, w.vppConn, metadata.IsClient(w)); err != nil {
+	if err = createPeer(ctx, conn, w.vppConn, w.allowedIPs, metadata.IsClient(w)); err != nil {
 		closeCtx, cancelClose := postponeCtxFunc()
 		defer cancelClose()
 
@@ -20,13 +20,13 @@ package peer
 
 import (
 	"context"
+	"net"
 	"time"
 
 	"git.fd.io/govpp.git/api"
 	"github.com/pkg/errors"
 	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
 
-	"github.com/edwarnicke/govpp/binapi/ip_types"
 	"github.com/edwarnicke/govpp/binapi/wireguard"
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
@@ -34,6 +34,7 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
 
 	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/tunnelip"
 	"github.com/networkservicemesh/sdk-vpp/pkg/tools/types"
 )
 
@@ -44,11 +45,41 @@ func getKey(mech *wireguardMech.Mechanism, isClient bool) string {
 	return mech.SrcPublicKey()
 }
 
-func createPeer(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, isClient bool) error {
+func createPeer(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, extraAllowedIPs []*net.IPNet, isClient bool) error {
 	if mechanism := wireguardMech.ToMechanism(conn.GetMechanism()); mechanism != nil {
 		pubKeyStr := getKey(mechanism, isClient)
-		_, ok := Load(ctx, isClient, pubKeyStr)
-		if ok {
+		endpoint, port, srcIP := mechanism.DstIP(), mechanism.DstPort(), mechanism.SrcIP()
+		if !isClient {
+			endpoint, port, srcIP = mechanism.SrcIP(), mechanism.SrcPort(), mechanism.DstIP()
+		}
+		// vpp sends to the endpoint from the source IP of the wireguard interface, so they must be of the same family
+		if endpoint == nil {
+			return errors.New("wireguard peer endpoint is not set")
+		}
+		if tunnelip.IsIPv6(endpoint) != tunnelip.IsIPv6(srcIP) {
+			return errors.Errorf("wireguard peer endpoint %s and the interface source IP %s are of different IP families", endpoint, srcIP)
+		}
+		allowed := allowedIPs(conn, isClient, extraAllowedIPs)
+		if len(allowed) > maxAllowedIPs {
+			return errors.Errorf("too many wireguard peer allowed IPs: %d, max: %d", len(allowed), maxAllowedIPs)
+		}
+		if prev, ok := loadPeer(ctx, isClient); ok {
+			if prev.pubKey == pubKeyStr && prev.endpoint.Equal(endpoint) && prev.port == port && equalIPNets(prev.allowedIPs, allowed) {
+				return nil
+			}
+			// The remote side or the ipcontext has changed (e.g. the connection was healed to another forwarder),
+			// so only the peer is replaced keeping the wireguard interface
+			log.FromContext(ctx).
+				WithField("prevEndpoint", prev.endpoint).
+				WithField("endpoint", endpoint).
+				WithField("allowedIPs", allowed).
+				Info("wireguard peer changed, replacing the peer")
+			if err := removePeer(ctx, vppConn, prev.index); err != nil {
+				return err
+			}
+			Delete(ctx, isClient, prev.pubKey)
+			deletePeer(ctx, isClient)
+		} else if _, ok := Load(ctx, isClient, pubKeyStr); ok {
 			return nil
 		}
 		ifIdx, ok := ifindex.Load(ctx, isClient)
@@ -67,18 +98,11 @@ func createPeer(ctx context.Context, conn *networkservice.Connection, vppConn ap
 			PersistentKeepalive: 10,
 		}
 
-		peer.AllowedIps = []ip_types.Prefix{
-			{Address: ip_types.Address{Af: ip_types.ADDRESS_IP4}}, // IPv4 - 0.0.0.0/0
-			{Address: ip_types.Address{Af: ip_types.ADDRESS_IP6}}} // IPv6 - ::/0
+		peer.AllowedIps = toPrefixes(allowed)
 		peer.NAllowedIps = uint8(len(peer.AllowedIps))
 
-		if !isClient {
-			peer.Port = mechanism.SrcPort()
-			peer.Endpoint = types.ToVppAddress(mechanism.SrcIP())
-		} else {
-			peer.Port = mechanism.DstPort()
-			peer.Endpoint = types.ToVppAddress(mechanism.DstIP())
-		}
+		peer.Port = port
+		peer.Endpoint = types.ToVppAddress(endpoint)
 
 		wgPeerCreate := &wireguard.WireguardPeerAdd{
 			Peer: peer,
@@ -89,30 +113,45 @@ func createPeer(ctx context.Context, conn *networkservice.Connection, vppConn ap
 		}
 		log.FromContext(ctx).
 			WithField("PeerIndex", rspPeer.PeerIndex).
+			WithField("allowedIPs", allowed).
 			WithField("duration", time.Since(now)).
 			WithField("vppapi", "WireguardPeerAdd").Debug("completed")
 		Store(ctx, isClient, pubKeyStr, rspPeer.PeerIndex)
+		storePeer(ctx, isClient, &peerInfo{
+			pubKey:     pubKeyStr,
+			endpoint:   endpoint,
+			port:       port,
+			allowedIPs: allowed,
+			index:      rspPeer.PeerIndex,
+		})
 	}
 	return nil
 }
 
 func delPeer(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, isClient bool) error {
 	if mechanism := wireguardMech.ToMechanism(conn.GetMechanism()); mechanism != nil {
+		deletePeer(ctx, isClient)
 		peerIdx, ok := LoadAndDelete(ctx, isClient, getKey(mechanism, isClient))
 		if !ok {
 			return nil
 		}
-		now := time.Now()
-		wgPeerRem := &wireguard.WireguardPeerRemove{
-			PeerIndex: peerIdx,
-		}
-		_, err := wireguard.NewServiceClient(vppConn).WireguardPeerRemove(ctx, wgPeerRem)
-		if err != nil {
-			return errors.WithStack(err)
-		}
-		log.FromContext(ctx).
-			WithField("duration", time.Since(now)).
-			WithField("vppapi", "WireguardPeerRemove").Debug("completed")
+		return removePeer(ctx, vppConn, peerIdx)
+	}
+	return nil
+}
+
+func removePeer(ctx context.Context, vppConn api.Connection, peerIdx uint32) error {
+	now := time.Now()
+	wgPeerRem := &wireguard.WireguardPeerRemove{
+		PeerIndex: peerIdx,
+	}
+	_, err := wireguard.NewServiceClient(vppConn).WireguardPeerRemove(ctx, wgPeerRem)
+	if err != nil {
+		return errors.WithStack(err)
 	}
+	log.FromContext(ctx).
+		WithField("PeerIndex", peerIdx).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "WireguardPeerRemove").Debug("completed")
 	return nil
 }
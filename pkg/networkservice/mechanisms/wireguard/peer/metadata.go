@@ -18,6 +18,7 @@ package peer
 
 import (
 	"context"
+	"net"
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
 )
@@ -70,3 +71,31 @@ func LoadAndDelete(ctx context.Context, isClient bool, key string) (value uint32
 	value, ok = rawValue.(uint32)
 	return value, ok
 }
+
+type peerKey struct{}
+
+// peerInfo - wireguard peer programmed in vpp for the connection
+type peerInfo struct {
+	pubKey     string
+	endpoint   net.IP
+	port       uint16
+	allowedIPs []*net.IPNet
+	index      uint32
+}
+
+func storePeer(ctx context.Context, isClient bool, peer *peerInfo) {
+	metadata.Map(ctx, isClient).Store(peerKey{}, peer)
+}
+
+func loadPeer(ctx context.Context, isClient bool) (value *peerInfo, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).Load(peerKey{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(*peerInfo)
+	return value, ok
+}
+
+func deletePeer(ctx context.Context, isClient bool) {
+	metadata.Map(ctx, isClient).Delete(peerKey{})
+}
@@ -0,0 +1,36 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package peer
+
+import (
+	"net"
+)
+
+type options struct {
+	allowedIPs []*net.IPNet
+}
+
+// Option is an option pattern for wireguard peer server/client
+type Option func(o *options)
+
+// WithAllowedIPs widens the allowed IPs of the wireguard peers derived from the connection ipcontext with ipNets.
+// Use 0.0.0.0/0 and ::/0 to allow any address.
+func WithAllowedIPs(ipNets ...*net.IPNet) Option {
+	return func(o *options) {
+		o.allowedIPs = append(o.allowedIPs, ipNets...)
+	}
+}
@@ -18,6 +18,7 @@ package peer
 
 import (
 	"context"
+	"net"
 
 	"git.fd.io/govpp.git/api"
 	"github.com/golang/protobuf/ptypes/empty"
@@ -30,13 +31,20 @@ import (
 )
 
 type wireguardPeerServer struct {
-	vppConn api.Connection
+	vppConn    api.Connection
+	allowedIPs []*net.IPNet
 }
 
 // NewServer - creates peer for the wireguard remote mechanism
-func NewServer(vppConn api.Connection) networkservice.NetworkServiceServer {
+func NewServer(vppConn api.Connection, opts ...Option) networkservice.NetworkServiceServer {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
 	return &wireguardPeerServer{
-		vppConn: vppConn,
+		vppConn:    vppConn,
+		allowedIPs: o.allowedIPs,
 	}
 }
 
@@ -48,7 +56,7 @@ func (w *wireguardPeerServer) Request(ctx context.Context, request *networkservi
 		return nil, err
 	}
 
-	if err = createPeer(ctx, conn, w.vppConn, metadata.IsClient(w)); err != nil {
+	if err = createPeer(ctx, conn, w.vppConn, w.allowedIPs, metadata.IsClient(w)); err != nil {
 		closeCtx, cancelClose := postponeCtxFunc()
 		defer cancelClose()
 
@@ -0,0 +1,133 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wireguard
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/allocator"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/vpperrors"
+)
+
+// PortAllocator - allocator of the wireguard interfaces listen ports
+type PortAllocator interface {
+	// Allocate returns the free port
+	Allocate() (uint16, error)
+	// Release returns the port back to the allocator
+	Release(port uint16)
+}
+
+type portRange struct {
+	from, to  uint16
+	next      uint16
+	allocated map[uint16]struct{}
+	mut       sync.Mutex
+}
+
+// NewPortRange returns the PortAllocator allocating the ports from the [from, to] range
+func NewPortRange(from, to uint16) PortAllocator {
+	if from > to {
+		from, to = to, from
+	}
+	return &portRange{
+		from:      from,
+		to:        to,
+		next:      from,
+		allocated: make(map[uint16]struct{}),
+	}
+}
+
+func (p *portRange) Allocate() (uint16, error) {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+
+	size := int(p.to) - int(p.from) + 1
+	for i := 0; i < size; i++ {
+		port := p.next
+		if p.next == p.to {
+			p.next = p.from
+		} else {
+			p.next++
+		}
+		if _, ok := p.allocated[port]; !ok {
+			p.allocated[port] = struct{}{}
+			return port, nil
+		}
+	}
+	return 0, errors.Wrapf(vpperrors.ErrResourceExhausted, "no free wireguard port in range %d-%d", p.from, p.to)
+}
+
+func (p *portRange) Release(port uint16) {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+
+	delete(p.allocated, port)
+}
+
+// portPool - pool of the wireguard ports in the allocator.Backend
+const portPool = "wireguard-port"
+
+type sharedPortRange struct {
+	allocator *allocator.Allocator
+}
+
+// NewSharedPortRange returns the PortAllocator allocating the ports from the [from, to] range shared with the other
+// forwarders of the node, the ports are reserved for the owner in backend
+func NewSharedPortRange(backend allocator.Backend, owner string, from, to uint16) PortAllocator {
+	return &sharedPortRange{
+		allocator: allocator.New(backend, portPool, owner, uint32(from), uint32(to)),
+	}
+}
+
+func (p *sharedPortRange) Allocate() (uint16, error) {
+	port, err := p.allocator.Allocate(context.Background())
+	return uint16(port), err
+}
+
+func (p *sharedPortRange) Release(port uint16) {
+	_ = p.allocator.Release(context.Background(), uint32(port))
+}
+
+// listenPort returns the listen port of the connection wireguard interface, allocating it if needed.
+// wireguardDefaultPort is used if there is no allocator.
+func listenPort(ctx context.Context, allocator PortAllocator, isClient bool) (uint16, error) {
+	if allocator == nil {
+		return wireguardDefaultPort, nil
+	}
+	if port, ok := loadPort(ctx, isClient); ok {
+		return port, nil
+	}
+	port, err := allocator.Allocate()
+	if err != nil {
+		return 0, err
+	}
+	storePort(ctx, isClient, port)
+	return port, nil
+}
+
+// releasePort releases the listen port of the connection wireguard interface
+func releasePort(ctx context.Context, allocator PortAllocator, isClient bool) {
+	if allocator == nil {
+		return
+	}
+	if port, ok := loadAndDeletePort(ctx, isClient); ok {
+		allocator.Release(port)
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wireguard_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/wireguard"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/allocator"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/vpperrors"
+)
+
+func Test_PortRange(t *testing.T) {
+	ports := wireguard.NewPortRange(51822, 51820)
+
+	var allocated []uint16
+	for i := 0; i < 3; i++ {
+		port, err := ports.Allocate()
+		require.NoError(t, err)
+		allocated = append(allocated, port)
+	}
+	require.Equal(t, []uint16{51820, 51821, 51822}, allocated)
+
+	_, err := ports.Allocate()
+	require.True(t, errors.Is(err, vpperrors.ErrResourceExhausted))
+
+	// The released port is allocated again
+	ports.Release(51821)
+	port, err := ports.Allocate()
+	require.NoError(t, err)
+	require.Equal(t, uint16(51821), port)
+}
+
+func Test_SharedPortRange(t *testing.T) {
+	backend := allocator.NewFileBackend(filepath.Join(t.TempDir(), "reservations.json"))
+	ports1 := wireguard.NewSharedPortRange(backend, "forwarder-1", 51820, 51821)
+	ports2 := wireguard.NewSharedPortRange(backend, "forwarder-2", 51820, 51821)
+
+	port1, err := ports1.Allocate()
+	require.NoError(t, err)
+	port2, err := ports2.Allocate()
+	require.NoError(t, err)
+	require.NotEqual(t, port1, port2)
+
+	_, err = ports1.Allocate()
+	require.True(t, errors.Is(err, vpperrors.ErrResourceExhausted))
+
+	// The port released by one forwarder is available to the other
+	ports2.Release(port2)
+	port, err := ports1.Allocate()
+	require.NoError(t, err)
+	require.Equal(t, port2, port)
+
+	ok, err := backend.Reserve(context.Background(), "wireguard-port", uint32(port), "forwarder-2")
+	require.NoError(t, err)
+	require.False(t, ok)
+}
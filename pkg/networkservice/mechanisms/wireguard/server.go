@@ -33,25 +33,40 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
 	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
 
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/wireguard/mtu"
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/wireguard/peer"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/cryptoengine"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/keybinding"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/tunnelip"
 )
 
 type wireguardServer struct {
-	vppConn  api.Connection
-	tunnelIP net.IP
+	vppConn   api.Connection
+	tunnelIPs []net.IP
+	crypto    *cryptoengine.Selector
+	ports     PortAllocator
+	keys      *keybinding.Binder
 }
 
 // NewServer - returns a new server for the wireguard remote mechanism
-func NewServer(vppConn api.Connection, tunnelIP net.IP) networkservice.NetworkServiceServer {
+func NewServer(vppConn api.Connection, tunnelIP net.IP, options ...Option) networkservice.NetworkServiceServer {
+	opts := &wireguardOptions{}
+	for _, opt := range options {
+		opt(opts)
+	}
+
 	return chain.NewNetworkServiceServer(
-		peer.NewServer(vppConn),
-		mtu.NewServer(vppConn, tunnelIP),
+		peer.NewServer(vppConn, peer.WithAllowedIPs(opts.allowedIPs...)),
+		mtu.NewServer(vppConn, tunnelIP, opts.ipv6TunnelIP),
 		&wireguardServer{
-			vppConn:  vppConn,
-			tunnelIP: tunnelIP,
+			vppConn:   vppConn,
+			tunnelIPs: []net.IP{tunnelIP, opts.ipv6TunnelIP},
+			crypto:    cryptoengine.NewSelector(vppConn, "wireguard", opts.asyncCrypto, setAsyncMode),
+			ports:     opts.portAllocator,
+			keys:      opts.keyBinding,
 		},
 	)
 }
@@ -60,15 +75,46 @@ func (w *wireguardServer) Request(ctx context.Context, request *networkservice.N
 	if request.GetConnection().GetPayload() != payload.IP {
 		return next.Server(ctx).Request(ctx, request)
 	}
+	w.crypto.Init(ctx)
 	if mechanism := wireguardMech.ToMechanism(request.GetConnection().GetMechanism()); mechanism != nil {
-		mechanism.SetDstIP(w.tunnelIP)
-		mechanism.SetDstPort(wireguardDefaultPort)
+		if err := w.keys.Verify(request.GetConnection().GetMechanism(), mechanism.SrcPublicKey(), true); err != nil {
+			return nil, err
+		}
+		dstIP := tunnelip.Select(mechanism.SrcIP(), w.tunnelIPs...)
+		// The first reachable of the underlay IPs advertised by the remote side is used
+		if remote, local, ok := tunnelip.Choose(ctx, w.vppConn, tunnelip.Candidates(request.GetConnection().GetMechanism()), w.tunnelIPs...); ok {
+			if !remote.Equal(mechanism.SrcIP()) {
+				log.FromContext(ctx).
+					WithField("srcIP", mechanism.SrcIP()).
+					WithField("candidate", remote).
+					Info("wireguard underlay falls back to the reachable candidate")
+			}
+			// Recorded for the requesting side to see which of its underlay IPs is in use
+			tunnelip.SetChosen(request.GetConnection().GetMechanism(), remote)
+			mechanism.SetSrcIP(remote)
+			dstIP = local
+		} else {
+			tunnelip.SetChosen(request.GetConnection().GetMechanism(), nil)
+		}
+		// Per connection tunnel IP overrides the default one
+		if ip, ok := tunnelip.Load(ctx, metadata.IsClient(w)); ok {
+			dstIP = ip
+		}
+		port, err := listenPort(ctx, w.ports, metadata.IsClient(w))
+		if err != nil {
+			return nil, err
+		}
+		mechanism.SetDstIP(dstIP)
+		mechanism.SetDstPort(port)
 	}
 
 	postponeCtxFunc := postpone.ContextWithValues(ctx)
 
 	conn, err := next.Server(ctx).Request(ctx, request)
 	if err != nil {
+		if _, ok := load(ctx, metadata.IsClient(w)); !ok {
+			releasePort(ctx, w.ports, metadata.IsClient(w))
+		}
 		return nil, err
 	}
 
@@ -86,6 +132,17 @@ func (w *wireguardServer) Request(ctx context.Context, request *networkservice.N
 			return nil, err
 		}
 		mechanism.SetDstPublicKey(pubKey)
+		if err := w.keys.Sign(conn.GetMechanism(), pubKey, false); err != nil {
+			closeCtx, cancelClose := postponeCtxFunc()
+			defer cancelClose()
+
+			if _, closeErr := w.Close(closeCtx, conn); closeErr != nil {
+				err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+			}
+
+			return nil, err
+		}
+		w.crypto.SetMetric(conn)
 	}
 
 	return conn, nil
@@ -96,5 +153,6 @@ func (w *wireguardServer) Close(ctx context.Context, conn *networkservice.Connec
 		return next.Server(ctx).Close(ctx, conn)
 	}
 	_ = delInterface(ctx, conn, w.vppConn, metadata.IsClient(w))
+	releasePort(ctx, w.ports, metadata.IsClient(w))
 	return next.Server(ctx).Close(ctx, conn)
 }
@@ -0,0 +1,21 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package xdp provides the XDP local mechanism: the kernel interface in the client netns is the veth the vpp side of
+// which is read and written by vpp over AF_XDP instead of AF_PACKET or tap, for the clients needing the kernel sockets
+// and the higher packet rate than the tap provides.
+// The mechanism has the kernel mechanism parameters, it is handled as the kernel mechanism within the forwarder.
+package xdp
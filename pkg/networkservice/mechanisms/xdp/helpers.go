@@ -0,0 +1,45 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xdp
+
+import (
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+)
+
+// New returns *networkservice.Mechanism of type xdp using the given netnsURL (file:///proc/${pid}/ns/net)
+func New(netnsURL string) *networkservice.Mechanism {
+	return &networkservice.Mechanism{
+		Cls:  cls.LOCAL,
+		Type: MECHANISM,
+		Parameters: map[string]string{
+			kernel.NetNSURL: netnsURL,
+		},
+	}
+}
+
+// ToMechanism converts unified mechanism to the kernel mechanism helper, the parameters are the same.
+// If Mechanism m is *not* of type xdp.MECHANISM, it returns nil
+func ToMechanism(m *networkservice.Mechanism) *kernel.Mechanism {
+	if m.GetType() == MECHANISM {
+		return &kernel.Mechanism{
+			Mechanism: m,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xdp
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+type xdpServer struct{}
+
+// NewServer returns a server chain element translating the xdp mechanism to the kernel mechanism for the rest of the
+// chain and back. The xdp mechanism is used if the client prefers it to the kernel mechanism, the kernel mechanism
+// elements then attach the veth to vpp over AF_XDP, see Load.
+// It must precede the kernel mechanism elements and connectioncontextkernel in the chain.
+func NewServer() networkservice.NetworkServiceServer {
+	return &xdpServer{}
+}
+
+func (x *xdpServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	if !preferred(request) {
+		del(ctx, metadata.IsClient(x))
+		return next.Server(ctx).Request(ctx, request)
+	}
+	store(ctx, metadata.IsClient(x))
+
+	request = request.Clone()
+	if mechanism := request.GetConnection().GetMechanism(); mechanism.GetType() == MECHANISM {
+		mechanism.Type = kernel.MECHANISM
+	}
+	var preferences []*networkservice.Mechanism
+	for _, mechanism := range request.GetMechanismPreferences() {
+		switch mechanism.GetType() {
+		case kernel.MECHANISM:
+			// The kernel mechanism preferences would be handled the same way as the xdp ones
+			continue
+		case MECHANISM:
+			mechanism.Type = kernel.MECHANISM
+		}
+		preferences = append(preferences, mechanism)
+	}
+	request.MechanismPreferences = preferences
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if conn.GetMechanism().GetType() != kernel.MECHANISM {
+		del(ctx, metadata.IsClient(x))
+		return conn, nil
+	}
+	conn.GetMechanism().Type = MECHANISM
+	return conn, nil
+}
+
+func (x *xdpServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	if conn.GetMechanism().GetType() == MECHANISM {
+		conn = proto.Clone(conn).(*networkservice.Connection)
+		conn.GetMechanism().Type = kernel.MECHANISM
+	}
+	rv, err := next.Server(ctx).Close(ctx, conn)
+	del(ctx, metadata.IsClient(x))
+	return rv, err
+}
+
+// preferred returns true if the connection already uses the xdp mechanism or the client prefers the xdp mechanism to
+// the kernel one
+func preferred(request *networkservice.NetworkServiceRequest) bool {
+	if mechanism := request.GetConnection().GetMechanism(); mechanism != nil {
+		return mechanism.GetType() == MECHANISM
+	}
+	for _, mechanism := range request.GetMechanismPreferences() {
+		switch mechanism.GetType() {
+		case MECHANISM:
+			return true
+		case kernel.MECHANISM:
+			return false
+		}
+	}
+	return false
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multicast
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/edwarnicke/govpp/binapi/fib_types"
+	"github.com/edwarnicke/govpp/binapi/igmp"
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+	"github.com/edwarnicke/govpp/binapi/ip"
+	"github.com/edwarnicke/govpp/binapi/ip_types"
+	"github.com/edwarnicke/govpp/binapi/mfib_types"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/vrf"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/types"
+)
+
+// igmpModeRouter - the interface sends the IGMP queries and listens to the reports of the receivers behind it
+const igmpModeRouter = 0
+
+func create(ctx context.Context, vppConn api.Connection, groups []*net.IPNet, isClient bool) error {
+	swIfIndex, ok := ifindex.Load(ctx, isClient)
+	if !ok {
+		return nil
+	}
+	if prev, ok := load(ctx, isClient); ok {
+		if prev.swIfIndex == swIfIndex {
+			return nil
+		}
+		if err := del(ctx, vppConn, isClient); err != nil {
+			return err
+		}
+	}
+
+	if err := igmpEnableDisable(ctx, vppConn, swIfIndex, true); err != nil {
+		return err
+	}
+	m := &member{swIfIndex: swIfIndex}
+	store(ctx, isClient, m)
+	for _, group := range groups {
+		isIPv6 := group.IP.To4() == nil
+		tableID, _ := vrf.Load(ctx, isClient, isIPv6)
+		route := &mroute{tableID: tableID, group: group}
+		if err := mrouteAddDelPath(ctx, vppConn, route, swIfIndex, true); err != nil {
+			return err
+		}
+		m.routes = append(m.routes, route)
+	}
+	return nil
+}
+
+func del(ctx context.Context, vppConn api.Connection, isClient bool) error {
+	m, ok := loadAndDelete(ctx, isClient)
+	if !ok {
+		return nil
+	}
+	for _, route := range m.routes {
+		if err := mrouteAddDelPath(ctx, vppConn, route, m.swIfIndex, false); err != nil {
+			return err
+		}
+	}
+	return igmpEnableDisable(ctx, vppConn, m.swIfIndex, false)
+}
+
+func igmpEnableDisable(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, enable bool) error {
+	now := time.Now()
+	if _, err := igmp.NewServiceClient(vppConn).IgmpEnableDisable(ctx, &igmp.IgmpEnableDisable{
+		Enable:    enable,
+		Mode:      igmpModeRouter,
+		SwIfIndex: swIfIndex,
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", swIfIndex).
+		WithField("enable", enable).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "IgmpEnableDisable").Debug("completed")
+	return nil
+}
+
+// mrouteAddDelPath adds (removes) the interface to (from) the (*, group) multicast route. The interface both accepts
+// the multicast traffic from the sources behind it and forwards it to the receivers behind it.
+// vpp removes the route with the last path.
+func mrouteAddDelPath(ctx context.Context, vppConn api.Connection, route *mroute, swIfIndex interface_types.InterfaceIndex, isAdd bool) error {
+	now := time.Now()
+	groupAddr := types.ToVppAddress(route.group.IP)
+	groupLen, _ := route.group.Mask.Size()
+	if _, err := ip.NewServiceClient(vppConn).IPMrouteAddDel(ctx, &ip.IPMrouteAddDel{
+		IsAdd:       isAdd,
+		IsMultipath: true,
+		Route: ip.IPMroute{
+			TableID: route.tableID,
+			Prefix: ip_types.Mprefix{
+				Af:               groupAddr.Af,
+				GrpAddressLength: uint16(groupLen),
+				GrpAddress:       groupAddr.Un,
+			},
+			NPaths: 1,
+			Paths: []mfib_types.MfibPath{
+				{
+					ItfFlags: mfib_types.MFIB_API_ITF_FLAG_ACCEPT | mfib_types.MFIB_API_ITF_FLAG_FORWARD,
+					Path: fib_types.FibPath{
+						SwIfIndex: uint32(swIfIndex),
+						TableID:   route.tableID,
+						Proto:     types.IsV6toFibProto(route.group.IP.To4() == nil),
+					},
+				},
+			},
+		},
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", swIfIndex).
+		WithField("group", route.group.String()).
+		WithField("tableID", route.tableID).
+		WithField("isAdd", isAdd).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "IPMrouteAddDel").Debug("completed")
+	return nil
+}
@@ -0,0 +1,23 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package multicast provides chain elements enabling IGMP on the connection interfaces and adding them to the
+// multicast routes (mroutes) of the connection vrf, so the multicast sources behind one connection reach the receivers
+// on the other connections of the same NetworkService.
+//
+// The elements should be used together with the vrf elements, so each NetworkService has its own multicast routes.
+// MLD is enabled by vpp on the IPv6 enabled interfaces.
+package multicast
@@ -0,0 +1,61 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multicast
+
+import (
+	"context"
+	"net"
+
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+type key struct{}
+
+// member - the interface added to the multicast routes
+type member struct {
+	swIfIndex interface_types.InterfaceIndex
+	routes    []*mroute
+}
+
+type mroute struct {
+	tableID uint32
+	group   *net.IPNet
+}
+
+func store(ctx context.Context, isClient bool, value *member) {
+	metadata.Map(ctx, isClient).Store(key{}, value)
+}
+
+func load(ctx context.Context, isClient bool) (value *member, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).Load(key{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(*member)
+	return value, ok
+}
+
+func loadAndDelete(ctx context.Context, isClient bool) (value *member, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).LoadAndDelete(key{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(*member)
+	return value, ok
+}
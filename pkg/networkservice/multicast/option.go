@@ -0,0 +1,42 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multicast
+
+import (
+	"net"
+)
+
+type options struct {
+	groups []*net.IPNet
+}
+
+// Option is an option pattern for multicast client/server
+type Option func(o *options)
+
+// WithGroups sets the multicast group prefixes forwarded between the connections. Default: 224.0.0.0/4 and ff0e::/16
+// (global scope)
+func WithGroups(groups ...*net.IPNet) Option {
+	return func(o *options) {
+		o.groups = groups
+	}
+}
+
+func defaultGroups() []*net.IPNet {
+	_, ipv4Groups, _ := net.ParseCIDR("224.0.0.0/4")
+	_, ipv6Groups, _ := net.ParseCIDR("ff0e::/16")
+	return []*net.IPNet{ipv4Groups, ipv6Groups}
+}
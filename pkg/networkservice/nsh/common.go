@@ -0,0 +1,241 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsh
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+	"github.com/edwarnicke/govpp/binapi/nsh"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vxlan"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/payload"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+)
+
+const (
+	// ServicePathLabel - connection label carrying the NSH service path identifier (24 bits)
+	ServicePathLabel = "nsh-spi"
+	// ServiceIndexLabel - connection label carrying the NSH service index (8 bits)
+	ServiceIndexLabel = "nsh-si"
+)
+
+// nsh actions - vpp/src/plugins/nsh/nsh.h
+const (
+	nshActionPush = 1
+	nshActionPop  = 2
+)
+
+// nsh next nodes - foreach_nsh_node_next in vpp/src/plugins/nsh/nsh.h
+const (
+	nshNodeNextEncapVxlan4   = 4
+	nshNodeNextEncapVxlan6   = 5
+	nshNodeNextDecapEthInput = 6
+	nshNodeNextEncapEthernet = 8
+	nshNodeNextDecapIP4Input = 9
+	nshNodeNextDecapIP6Input = 10
+)
+
+// nsh next protocols - RFC 8300
+const (
+	nshNextProtocolIPv4     = 1
+	nshNextProtocolIPv6     = 2
+	nshNextProtocolEthernet = 3
+)
+
+const (
+	nshMdType1       = 1
+	nshMdType1Length = 6 // in 4-byte words
+	nshDefaultTTL    = 63
+)
+
+// servicePath returns the service path identifier and service index from the connection labels
+func servicePath(conn *networkservice.Connection) (spi uint32, si uint8, ok bool, err error) {
+	labels := conn.GetLabels()
+	spiLabel, spiOk := labels[ServicePathLabel]
+	siLabel, siOk := labels[ServiceIndexLabel]
+	if !spiOk || !siOk {
+		return 0, 0, false, nil
+	}
+	spi64, err := strconv.ParseUint(spiLabel, 10, 24)
+	if err != nil {
+		return 0, 0, false, errors.Wrapf(err, "invalid %s label %q", ServicePathLabel, spiLabel)
+	}
+	si64, err := strconv.ParseUint(siLabel, 10, 8)
+	if err != nil {
+		return 0, 0, false, errors.Wrapf(err, "invalid %s label %q", ServiceIndexLabel, siLabel)
+	}
+	return uint32(spi64), uint8(si64), true, nil
+}
+
+func toNspNsi(spi uint32, si uint8) uint32 {
+	return spi<<8 | uint32(si)
+}
+
+func nextProtocol(conn *networkservice.Connection, isIPv6 bool) uint8 {
+	switch {
+	case conn.GetPayload() == payload.Ethernet:
+		return nshNextProtocolEthernet
+	case isIPv6:
+		return nshNextProtocolIPv6
+	default:
+		return nshNextProtocolIPv4
+	}
+}
+
+func isIPv6(conn *networkservice.Connection) bool {
+	for _, addr := range conn.GetContext().GetIpContext().GetSrcIpAddrs() {
+		if ip, _, err := net.ParseCIDR(addr); err == nil {
+			return ip.To4() == nil
+		}
+	}
+	return false
+}
+
+// push adds the NSH entry for the decremented service index and maps the service path to the output interface
+// of the connection
+func push(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, isClient bool) error {
+	return addDel(ctx, conn, vppConn, isClient, nshActionPush)
+}
+
+// pop maps the service path received on the interface of the connection to the decap node
+func pop(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, isClient bool) error {
+	return addDel(ctx, conn, vppConn, isClient, nshActionPop)
+}
+
+func addDel(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, isClient bool, action uint32) error {
+	if _, ok := load(ctx, isClient); ok {
+		return nil
+	}
+	spi, si, ok, err := servicePath(conn)
+	if err != nil || !ok {
+		return err
+	}
+	swIfIndex, ok := ifindex.Load(ctx, isClient)
+	if !ok {
+		return nil
+	}
+	if action == nshActionPush && si == 0 {
+		return errors.Errorf("service index of the service path %d is exhausted", spi)
+	}
+
+	v6 := isIPv6(conn)
+	nspNsi := toNspNsi(spi, si)
+	entry := &nsh.NshAddDelEntry{
+		IsAdd:        true,
+		NspNsi:       nspNsi,
+		MdType:       nshMdType1,
+		TTL:          nshDefaultTTL,
+		Length:       nshMdType1Length,
+		NextProtocol: nextProtocol(conn, v6),
+	}
+	nshMap := &nsh.NshAddDelMap{
+		IsAdd:        true,
+		NspNsi:       nspNsi,
+		MappedNspNsi: nspNsi,
+		NshAction:    action,
+		SwIfIndex:    ^interface_types.InterfaceIndex(0),
+		RxSwIfIndex:  ^interface_types.InterfaceIndex(0),
+	}
+	switch action {
+	case nshActionPush:
+		entry.NspNsi = toNspNsi(spi, si-1)
+		nshMap.MappedNspNsi = entry.NspNsi
+		nshMap.SwIfIndex = swIfIndex
+		nshMap.NextNode = encapNode(conn, v6)
+	case nshActionPop:
+		nshMap.RxSwIfIndex = swIfIndex
+		nshMap.NextNode = decapNode(conn, v6)
+	}
+
+	if err := shared.add(ctx, vppConn, entry, nshMap); err != nil {
+		return err
+	}
+	store(ctx, isClient, &programmed{entry: entry, nshMap: nshMap})
+	return nil
+}
+
+func del(ctx context.Context, vppConn api.Connection, isClient bool) {
+	p, ok := loadAndDelete(ctx, isClient)
+	if !ok {
+		return
+	}
+	shared.del(ctx, vppConn, p.entry, p.nshMap)
+}
+
+func encapNode(conn *networkservice.Connection, v6 bool) uint32 {
+	if vxlan.ToMechanism(conn.GetMechanism()) == nil {
+		return nshNodeNextEncapEthernet
+	}
+	if v6 {
+		return nshNodeNextEncapVxlan6
+	}
+	return nshNodeNextEncapVxlan4
+}
+
+func decapNode(conn *networkservice.Connection, v6 bool) uint32 {
+	switch {
+	case conn.GetPayload() == payload.Ethernet:
+		return nshNodeNextDecapEthInput
+	case v6:
+		return nshNodeNextDecapIP6Input
+	default:
+		return nshNodeNextDecapIP4Input
+	}
+}
+
+func addDelEntry(ctx context.Context, vppConn api.Connection, entry *nsh.NshAddDelEntry) error {
+	now := time.Now()
+	rsp, err := nsh.NewServiceClient(vppConn).NshAddDelEntry(ctx, entry)
+	if err != nil {
+		return errors.Wrapf(err, "vpp error setting nsh entry %d (isAdd %t)", entry.NspNsi, entry.IsAdd)
+	}
+	log.FromContext(ctx).
+		WithField("nspNsi", entry.NspNsi).
+		WithField("isAdd", entry.IsAdd).
+		WithField("entryIndex", rsp.EntryIndex).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "NshAddDelEntry").Debug("completed")
+	return nil
+}
+
+func addDelMap(ctx context.Context, vppConn api.Connection, nshMap *nsh.NshAddDelMap) error {
+	now := time.Now()
+	rsp, err := nsh.NewServiceClient(vppConn).NshAddDelMap(ctx, nshMap)
+	if err != nil {
+		return errors.Wrapf(err, "vpp error setting nsh map %d (isAdd %t)", nshMap.NspNsi, nshMap.IsAdd)
+	}
+	log.FromContext(ctx).
+		WithField("nspNsi", nshMap.NspNsi).
+		WithField("mappedNspNsi", nshMap.MappedNspNsi).
+		WithField("nshAction", nshMap.NshAction).
+		WithField("swIfIndex", nshMap.SwIfIndex).
+		WithField("rxSwIfIndex", nshMap.RxSwIfIndex).
+		WithField("isAdd", nshMap.IsAdd).
+		WithField("mapIndex", rsp.MapIndex).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "NshAddDelMap").Debug("completed")
+	return nil
+}
@@ -0,0 +1,27 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package nsh provides chain elements for participating in SFC (service function chaining) paths using the vpp
+// nsh plugin.
+//
+// The service path identifier and service index are taken from the connection labels (see ServicePathLabel and
+// ServiceIndexLabel). The server element pops the NSH header from the packets of the service path received on its
+// interface, the client element pushes the NSH header with the decremented service index to the packets sent to
+// the next service function.
+//
+// vpp keys the nsh entries and maps by the service path and index only, so they are shared by the connections of
+// the same service path: they are programmed by the first connection and removed with the last one.
+package nsh
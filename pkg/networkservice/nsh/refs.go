@@ -0,0 +1,135 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsh
+
+import (
+	"context"
+	"sync"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/edwarnicke/govpp/binapi/nsh"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// refs - nsh entries and maps are keyed by the nsp/nsi only in vpp, so the connections of the same service path
+// share them. refs counts the connections using each of them and programs vpp on the first use and the last release.
+type refs struct {
+	mu      sync.Mutex
+	entries map[uint32]*entryRef
+	maps    map[uint32]*mapRef
+}
+
+type entryRef struct {
+	entry *nsh.NshAddDelEntry
+	count int
+}
+
+type mapRef struct {
+	nshMap *nsh.NshAddDelMap
+	count  int
+}
+
+var shared = &refs{
+	entries: make(map[uint32]*entryRef),
+	maps:    make(map[uint32]*mapRef),
+}
+
+func (r *refs) add(ctx context.Context, vppConn api.Connection, entry *nsh.NshAddDelEntry, nshMap *nsh.NshAddDelMap) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.addEntry(ctx, vppConn, entry); err != nil {
+		return err
+	}
+	if err := r.addMap(ctx, vppConn, nshMap); err != nil {
+		r.delEntry(ctx, vppConn, entry)
+		return err
+	}
+	return nil
+}
+
+func (r *refs) del(ctx context.Context, vppConn api.Connection, entry *nsh.NshAddDelEntry, nshMap *nsh.NshAddDelMap) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.delMap(ctx, vppConn, nshMap)
+	r.delEntry(ctx, vppConn, entry)
+}
+
+func (r *refs) addEntry(ctx context.Context, vppConn api.Connection, entry *nsh.NshAddDelEntry) error {
+	if ref, ok := r.entries[entry.NspNsi]; ok {
+		if ref.entry.NextProtocol != entry.NextProtocol || ref.entry.MdType != entry.MdType {
+			return errors.Errorf("nsh entry %d is already used with the next protocol %d", entry.NspNsi, ref.entry.NextProtocol)
+		}
+		ref.count++
+		return nil
+	}
+	if err := addDelEntry(ctx, vppConn, entry); err != nil {
+		return err
+	}
+	r.entries[entry.NspNsi] = &entryRef{entry: entry, count: 1}
+	return nil
+}
+
+func (r *refs) delEntry(ctx context.Context, vppConn api.Connection, entry *nsh.NshAddDelEntry) {
+	ref, ok := r.entries[entry.NspNsi]
+	if !ok {
+		return
+	}
+	if ref.count--; ref.count > 0 {
+		return
+	}
+	delete(r.entries, entry.NspNsi)
+	req := *ref.entry
+	req.IsAdd = false
+	if err := addDelEntry(ctx, vppConn, &req); err != nil {
+		log.FromContext(ctx).Errorf("unable to delete nsh entry: %v", err)
+	}
+}
+
+func (r *refs) addMap(ctx context.Context, vppConn api.Connection, nshMap *nsh.NshAddDelMap) error {
+	if ref, ok := r.maps[nshMap.NspNsi]; ok {
+		if *ref.nshMap != *nshMap {
+			return errors.Errorf("nsh service path %d is already mapped to another interface", nshMap.NspNsi)
+		}
+		ref.count++
+		return nil
+	}
+	if err := addDelMap(ctx, vppConn, nshMap); err != nil {
+		return err
+	}
+	r.maps[nshMap.NspNsi] = &mapRef{nshMap: nshMap, count: 1}
+	return nil
+}
+
+func (r *refs) delMap(ctx context.Context, vppConn api.Connection, nshMap *nsh.NshAddDelMap) {
+	ref, ok := r.maps[nshMap.NspNsi]
+	if !ok {
+		return
+	}
+	if ref.count--; ref.count > 0 {
+		return
+	}
+	delete(r.maps, nshMap.NspNsi)
+	req := *ref.nshMap
+	req.IsAdd = false
+	if err := addDelMap(ctx, vppConn, &req); err != nil {
+		log.FromContext(ctx).Errorf("unable to delete nsh map: %v", err)
+	}
+}
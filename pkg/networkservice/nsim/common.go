@@ -0,0 +1,141 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsim
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+	nsimapi "github.com/edwarnicke/govpp/binapi/nsim"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+)
+
+// configFromLabels returns the nsim configuration requested by the connection labels, nil if there are no labels
+func configFromLabels(labels map[string]string, o *options) (*nsimapi.NsimConfigure2, error) {
+	delayLabel, hasDelay := labels[DelayLabel]
+	lossLabel, hasLoss := labels[LossLabel]
+	reorderLabel, hasReorder := labels[ReorderLabel]
+	if !hasDelay && !hasLoss && !hasReorder {
+		return nil, nil
+	}
+
+	config := &nsimapi.NsimConfigure2{
+		AveragePacketSize:        o.averagePacketSize,
+		BandwidthInBitsPerSecond: o.bandwidth,
+	}
+	if hasDelay {
+		delay, err := time.ParseDuration(delayLabel)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid %s label %q", DelayLabel, delayLabel)
+		}
+		config.DelayInUsec = uint32(delay / time.Microsecond)
+	}
+	var err error
+	if hasLoss {
+		if config.PacketsPerDrop, err = packetsPer(LossLabel, lossLabel); err != nil {
+			return nil, err
+		}
+	}
+	if hasReorder {
+		if config.PacketsPerReorder, err = packetsPer(ReorderLabel, reorderLabel); err != nil {
+			return nil, err
+		}
+	}
+	return config, nil
+}
+
+// packetsPer converts the percent to "every N-th packet"
+func packetsPer(label, value string) (uint32, error) {
+	percent, err := strconv.ParseFloat(value, 64)
+	if err != nil || percent < 0 || percent > 100 {
+		return 0, errors.Errorf("invalid %s label %q, expected percent", label, value)
+	}
+	if percent == 0 {
+		return 0, nil
+	}
+	return uint32(math.Round(100 / percent)), nil
+}
+
+func enable(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, o *options, isClient bool) error {
+	config, err := configFromLabels(conn.GetLabels(), o)
+	if err != nil {
+		return err
+	}
+	if config == nil {
+		disable(ctx, vppConn, isClient)
+		return nil
+	}
+	swIfIndex, ok := ifindex.Load(ctx, isClient)
+	if !ok {
+		return nil
+	}
+
+	now := time.Now()
+	if _, err := nsimapi.NewServiceClient(vppConn).NsimConfigure2(ctx, config); err != nil {
+		return errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("delayInUsec", config.DelayInUsec).
+		WithField("packetsPerDrop", config.PacketsPerDrop).
+		WithField("packetsPerReorder", config.PacketsPerReorder).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "NsimConfigure2").Debug("completed")
+
+	if enabled, ok := load(ctx, isClient); ok && enabled == swIfIndex {
+		return nil
+	}
+	if err := enableDisable(ctx, vppConn, swIfIndex, true); err != nil {
+		return err
+	}
+	store(ctx, isClient, swIfIndex)
+	return nil
+}
+
+func disable(ctx context.Context, vppConn api.Connection, isClient bool) {
+	swIfIndex, ok := loadAndDelete(ctx, isClient)
+	if !ok {
+		return
+	}
+	if err := enableDisable(ctx, vppConn, swIfIndex, false); err != nil {
+		log.FromContext(ctx).Errorf("unable to disable nsim: %v", err)
+	}
+}
+
+func enableDisable(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, isEnable bool) error {
+	now := time.Now()
+	if _, err := nsimapi.NewServiceClient(vppConn).NsimOutputFeatureEnableDisable(ctx, &nsimapi.NsimOutputFeatureEnableDisable{
+		EnableDisable: isEnable,
+		SwIfIndex:     swIfIndex,
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", swIfIndex).
+		WithField("isEnable", isEnable).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "NsimOutputFeatureEnableDisable").Debug("completed")
+	return nil
+}
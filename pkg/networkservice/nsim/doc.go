@@ -0,0 +1,23 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package nsim provides chain elements using the vpp network delay simulator (nsim plugin) to inject the delay, the
+// loss and the reordering on the connection interfaces, driven by the connection labels. Intended for the chaos and
+// resilience testing only.
+//
+// The nsim configuration is global in vpp, so the connections with the impairment labels share the configuration
+// applied last. nsim has no jitter, the reordering can be used to emulate it.
+package nsim
@@ -0,0 +1,62 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsim
+
+const (
+	// DelayLabel - connection label setting the delay, e.g. "50ms"
+	DelayLabel = "nsim-delay"
+	// LossLabel - connection label setting the loss in percent, e.g. "0.5"
+	LossLabel = "nsim-loss"
+	// ReorderLabel - connection label setting the reordered packets in percent, e.g. "1"
+	ReorderLabel = "nsim-reorder"
+
+	defaultBandwidth         = 10_000_000_000
+	defaultAveragePacketSize = 1500
+)
+
+type options struct {
+	bandwidth         uint64
+	averagePacketSize uint32
+}
+
+// Option is an option pattern for nsim client/server
+type Option func(o *options)
+
+// WithBandwidth sets the simulated bandwidth in bits per second. Default: 10Gbps
+func WithBandwidth(bandwidth uint64) Option {
+	return func(o *options) {
+		o.bandwidth = bandwidth
+	}
+}
+
+// WithAveragePacketSize sets the average packet size the nsim buffers are sized for. Default: 1500
+func WithAveragePacketSize(averagePacketSize uint32) Option {
+	return func(o *options) {
+		o.averagePacketSize = averagePacketSize
+	}
+}
+
+func newOptions(opts ...Option) *options {
+	o := &options{
+		bandwidth:         defaultBandwidth,
+		averagePacketSize: defaultAveragePacketSize,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
@@ -18,7 +18,6 @@ package pinhole
 
 import (
 	"context"
-	"fmt"
 	"sync"
 
 	"git.fd.io/govpp.git/api"
@@ -65,29 +64,18 @@ func (v *pinholeClient) Request(ctx context.Context, request *networkservice.Net
 		return nil, err
 	}
 
-	keys := []*IPPort{
+	if err := v.ipPortMap.open(ctx, v.vppConn, v.mutex, remoteFromMechanism(conn.GetMechanism(), metadata.IsClient(v)),
 		fromMechanism(conn.GetMechanism(), metadata.IsClient(v)),
 		fromContext(ctx, metadata.IsClient(v)),
-	}
-	for _, key := range keys {
-		if key == nil {
-			continue
-		}
-		if _, ok := v.ipPortMap.LoadOrStore(*key, struct{}{}); !ok {
-			v.mutex.Lock()
-			if err := create(ctx, v.vppConn, key.IP(), key.Port(), fmt.Sprintf("%s port %d", aclTag, key.port)); err != nil {
-				closeCtx, cancelClose := postponeCtxFunc()
-				defer cancelClose()
-
-				if _, closeErr := v.Close(closeCtx, conn, opts...); closeErr != nil {
-					err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
-				}
+	); err != nil {
+		closeCtx, cancelClose := postponeCtxFunc()
+		defer cancelClose()
 
-				v.mutex.Unlock()
-				return nil, err
-			}
-			v.mutex.Unlock()
+		if _, closeErr := v.Close(closeCtx, conn, opts...); closeErr != nil {
+			err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
 		}
+
+		return nil, err
 	}
 
 	return conn, nil
@@ -18,80 +18,108 @@ package pinhole
 
 import (
 	"context"
+	"fmt"
 	"net"
+	"sync"
 	"time"
 
 	"git.fd.io/govpp.git/api"
 	"github.com/edwarnicke/govpp/binapi/acl"
 	"github.com/edwarnicke/govpp/binapi/acl_types"
-	interfaces "github.com/edwarnicke/govpp/binapi/interface"
 	"github.com/edwarnicke/govpp/binapi/interface_types"
-	"github.com/edwarnicke/govpp/binapi/ip"
 	"github.com/edwarnicke/govpp/binapi/ip_types"
 	"github.com/pkg/errors"
 
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
 
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/aclmanager"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/keymutex"
 	"github.com/networkservicemesh/sdk-vpp/pkg/tools/types"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/uplink"
 )
 
 const (
 	aclTag = "nsm-pinhole"
 )
 
-func create(ctx context.Context, vppConn api.Connection, tunnelIP net.IP, port uint16, tag string) error {
-	if tunnelIP == nil || port == 0 {
-		return nil
+// open opens the holes for the keys on the uplinks the remote is reached via, if not yet opened
+func (m *ipPortMap) open(ctx context.Context, vppConn api.Connection, mutex *sync.Mutex, remote net.IP, keys ...*IPPort) error {
+	for _, key := range keys {
+		if key == nil || key.IP() == nil || key.Port() == 0 {
+			continue
+		}
+		swIfIndexes, err := uplinks(ctx, vppConn, key.IP(), remote)
+		if err != nil {
+			return err
+		}
+		for _, swIfIndex := range swIfIndexes {
+			uplinkKey := *key
+			uplinkKey.swIfIndex = swIfIndex
+			if _, ok := m.LoadOrStore(uplinkKey, struct{}{}); ok {
+				continue
+			}
+			mutex.Lock()
+			err = create(ctx, vppConn, swIfIndex, key.IP(), key.Port(), fmt.Sprintf("%s port %d", aclTag, key.port))
+			mutex.Unlock()
+			if err != nil {
+				m.Delete(uplinkKey)
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// uplinks returns the interfaces the packets to the remote are sent via, so a forwarder with several uplinks opens
+// the holes on the right ones. Falls back to the interface having the tunnel IP if vpp has no route to the remote.
+func uplinks(ctx context.Context, vppConn api.Connection, tunnelIP, remote net.IP) ([]interface_types.InterfaceIndex, error) {
+	if remote != nil {
+		swIfIndexes, err := uplink.RouteInterfaces(ctx, vppConn, remote)
+		if err == nil && len(swIfIndexes) > 0 {
+			return swIfIndexes, nil
+		}
+		log.FromContext(ctx).
+			WithField("remote", remote).
+			WithField("tunnelIP", tunnelIP).
+			Debugf("no uplink found by route lookup, using the uplink of the tunnel IP: %v", err)
 	}
-	swIfIndex, err := tunnelIPSwIfIndex(ctx, vppConn, tunnelIP)
+	u, err := uplink.ByIP(ctx, vppConn, tunnelIP)
 	if err != nil {
-		return errors.WithStack(err)
+		return nil, errors.WithStack(err)
 	}
+	return []interface_types.InterfaceIndex{u.SwIfIndex}, nil
+}
+
+func create(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, tunnelIP net.IP, port uint16, tag string) error {
+	// The ACLs of the uplink are not changed by the other elements between the dump and the set
+	ctx, unlock := keymutex.LockInterface(ctx, swIfIndex)
+	defer unlock()
 
 	ingressACLs, egressACLs, err := interfacesACLDetails(ctx, vppConn, swIfIndex)
 	if err != nil {
 		return errors.WithStack(err)
 	}
 
-	interfaceACLList := &acl.ACLInterfaceSetACLList{
-		SwIfIndex: swIfIndex,
-	}
-
-	interfaceACLList.Acls, err = addToACLToACLListIfNeeded(ctx, vppConn, tunnelIP, port, tag, false, ingressACLs)
+	ingressACLIndeces, err := addACLIfNeeded(ctx, vppConn, tunnelIP, port, tag, false, ingressACLs)
 	if err != nil {
 		return errors.WithStack(err)
 	}
-	interfaceACLList.NInput = uint8(len(interfaceACLList.Acls))
-
-	egressACLIndeces, err := addToACLToACLListIfNeeded(ctx, vppConn, tunnelIP, port, tag, true, egressACLs)
+	egressACLIndeces, err := addACLIfNeeded(ctx, vppConn, tunnelIP, port, tag, true, egressACLs)
 	if err != nil {
 		return errors.WithStack(err)
 	}
-	interfaceACLList.Acls = append(interfaceACLList.Acls, egressACLIndeces...)
-	interfaceACLList.Count = uint8(len(interfaceACLList.Acls))
-
-	if interfaceACLList.Count == uint8(len(ingressACLs)+len(egressACLs)) {
+	if len(ingressACLIndeces)+len(egressACLIndeces) == 0 {
 		return nil
 	}
-	now := time.Now()
-	_, err = acl.NewServiceClient(vppConn).ACLInterfaceSetACLList(ctx, interfaceACLList)
-	if err != nil {
-		return errors.WithStack(err)
-	}
-	log.FromContext(ctx).
-		WithField("swIfIndex", interfaceACLList.SwIfIndex).
-		WithField("acls", interfaceACLList.Acls).
-		WithField("NInput", interfaceACLList.NInput).
-		WithField("duration", time.Since(now)).
-		WithField("vppapi", "ACLInterfaceSetACLList").Debug("completed")
-	return nil
+	// The holes are matched before the other ACLs of the uplink
+	return aclmanager.Set(ctx, vppConn, swIfIndex, tag, aclmanager.PinholePriority, ingressACLIndeces, egressACLIndeces)
 }
 
-func addToACLToACLListIfNeeded(ctx context.Context, vppConn api.Connection, tunnelIP net.IP, port uint16, tag string, egress bool, aclDetails []*acl.ACLDetails) ([]uint32, error) {
+// addACLIfNeeded returns the index of the new ACL opening the hole if the interface has ACLs and none of them has the tag
+func addACLIfNeeded(ctx context.Context, vppConn api.Connection, tunnelIP net.IP, port uint16, tag string, egress bool, aclDetails []*acl.ACLDetails) ([]uint32, error) {
 	var foundACL *acl.ACLDetails
 	var ACLIndeces []uint32
 	for _, aclDetail := range aclDetails {
-		ACLIndeces = append(ACLIndeces, aclDetail.ACLIndex)
 		if aclDetail.Tag == tag {
 			foundACL = aclDetail
 		}
@@ -107,7 +135,7 @@ func addToACLToACLListIfNeeded(ctx context.Context, vppConn api.Connection, tunn
 			WithField("aclIndex", rsp.ACLIndex).
 			WithField("duration", time.Since(now)).
 			WithField("vppapi", "ACLAddReplace").Debug("completed")
-		ACLIndeces = append([]uint32{rsp.ACLIndex}, ACLIndeces...)
+		ACLIndeces = append(ACLIndeces, rsp.ACLIndex)
 	}
 	return ACLIndeces, nil
 }
@@ -174,43 +202,6 @@ func interfaceACLIndeces(ctx context.Context, vppConn api.Connection, swIfIndex
 	return ingressACLIndeces, egressACLIndeces, nil
 }
 
-func tunnelIPSwIfIndex(ctx context.Context, vppConn api.Connection, tunnelIP net.IP) (interface_types.InterfaceIndex, error) {
-	now := time.Now()
-	swIfDumpClient, swIfDumpErr := interfaces.NewServiceClient(vppConn).SwInterfaceDump(ctx, &interfaces.SwInterfaceDump{})
-	if swIfDumpErr != nil {
-		return 0, errors.WithStack(swIfDumpErr)
-	}
-	defer func() { _ = swIfDumpClient.Close() }()
-	swIfDetails, swIfDumpErr := swIfDumpClient.Recv()
-	for swIfDumpErr == nil {
-		ipDumpClient, err := ip.NewServiceClient(vppConn).IPAddressDump(ctx, &ip.IPAddressDump{
-			SwIfIndex: swIfDetails.SwIfIndex,
-			IsIPv6:    tunnelIP.To4() == nil,
-		})
-		if err != nil {
-			return 0, errors.WithStack(err)
-		}
-		defer func() { _ = ipDumpClient.Close() }()
-		ipDetails, ipDumpErr := ipDumpClient.Recv()
-		for ipDumpErr == nil {
-			if types.FromVppAddressWithPrefix(ipDetails.Prefix).IP.Equal(tunnelIP) {
-				log.FromContext(ctx).
-					WithField("swIfIndex", swIfDetails.SwIfIndex).
-					WithField("duration", time.Since(now)).
-					WithField("vppapi", "SwInterfaceDump").Debugf("found interface with ip %s", tunnelIP)
-				return swIfDetails.SwIfIndex, nil
-			}
-			ipDetails, ipDumpErr = ipDumpClient.Recv()
-		}
-		swIfDetails, swIfDumpErr = swIfDumpClient.Recv()
-	}
-	log.FromContext(ctx).
-		WithField("swIfIndex", swIfDetails.SwIfIndex).
-		WithField("duration", time.Since(now)).
-		WithField("vppapi", "SwInterfaceDump").Debugf("did not find interface with ip %s", tunnelIP)
-	return 0, errors.Errorf("unable to find tunnelIP (%s) on any vpp interface", tunnelIP)
-}
-
 func createACLAddReplace(tunnelIP net.IP, port uint16, tag string, egress bool) *acl.ACLAddReplace {
 	defaultNet := &net.IPNet{
 		IP:   net.IPv4zero,
@@ -15,4 +15,7 @@
 // limitations under the License.
 
 // Package pinhole provides networkservice.NetworkService{Client,Server} chain elements for ensuring remote mechanism packets get through any ACLs
+//
+// The ACLs are opened on the uplinks vpp routes the tunnel destination via, looked up in the vpp FIB, so a forwarder
+// with several uplinks opens the holes on the right interfaces.
 package pinhole
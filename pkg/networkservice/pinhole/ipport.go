@@ -21,6 +21,8 @@ import (
 	"net"
 	"strconv"
 
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
 )
@@ -29,6 +31,8 @@ import (
 type IPPort struct {
 	ip   string
 	port uint16
+	// swIfIndex - uplink the ACL rule is applied on
+	swIfIndex interface_types.InterfaceIndex
 }
 
 // NewIPPort returns *IPPort entry
@@ -64,6 +68,15 @@ func fromMechanism(mechanism *networkservice.Mechanism, isClient bool) *IPPort {
 	return NewIPPort(ipStr, uint16(port))
 }
 
+// remoteFromMechanism returns the tunnel destination, the IP of the remote side of the mechanism
+func remoteFromMechanism(mechanism *networkservice.Mechanism, isClient bool) net.IP {
+	ipKey := common.SrcIP
+	if isClient {
+		ipKey = common.DstIP
+	}
+	return net.ParseIP(mechanism.GetParameters()[ipKey])
+}
+
 func fromContext(ctx context.Context, isClient bool) *IPPort {
 	v, ok := LoadExtra(ctx, isClient)
 	if !ok {
@@ -0,0 +1,180 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package promisc
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/edwarnicke/govpp/binapi/ethernet_types"
+	interfaces "github.com/edwarnicke/govpp/binapi/interface"
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+)
+
+// settings returns the promiscuous mode and the MAC allow-list of the conn, the labels override the defaults
+func (o *options) settings(conn *networkservice.Connection) (promisc bool, macs []ethernet_types.MacAddress, err error) {
+	promisc, macs = o.promisc, o.macs
+	if label, ok := conn.GetLabels()[PromiscLabel]; ok {
+		if promisc, err = strconv.ParseBool(label); err != nil {
+			return false, nil, errors.Wrapf(err, "invalid %s label %q", PromiscLabel, label)
+		}
+	}
+	if label, ok := conn.GetLabels()[MACsLabel]; ok {
+		macs = nil
+		for _, s := range strings.Split(label, ",") {
+			if s = strings.TrimSpace(s); s == "" {
+				continue
+			}
+			mac, err := ethernet_types.ParseMacAddress(s)
+			if err != nil {
+				return false, nil, errors.Wrapf(err, "invalid %s label %q", MACsLabel, label)
+			}
+			macs = append(macs, mac)
+		}
+	}
+	return promisc, macs, nil
+}
+
+func create(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, o *options, isClient bool) error {
+	if conn.GetMechanism().GetCls() != cls.LOCAL {
+		return nil
+	}
+	swIfIndex, ok := ifindex.Load(ctx, isClient)
+	if !ok {
+		return nil
+	}
+	promisc, macs, err := o.settings(conn)
+	if err != nil {
+		return err
+	}
+
+	prev, ok := load(ctx, isClient)
+	if ok && prev.swIfIndex != swIfIndex {
+		if err = undo(ctx, vppConn, prev); err != nil {
+			return err
+		}
+		ok = false
+	}
+	if !ok {
+		prev = &applied{swIfIndex: swIfIndex}
+		store(ctx, isClient, prev)
+	}
+
+	if prev.promisc != promisc {
+		if err = setPromisc(ctx, vppConn, swIfIndex, promisc); err != nil {
+			return err
+		}
+		prev.promisc = promisc
+	}
+
+	wanted := make(map[ethernet_types.MacAddress]struct{}, len(macs))
+	for _, mac := range macs {
+		wanted[mac] = struct{}{}
+	}
+	var kept []ethernet_types.MacAddress
+	for i, mac := range prev.macs {
+		if _, ok := wanted[mac]; ok {
+			delete(wanted, mac)
+			kept = append(kept, mac)
+			continue
+		}
+		if err = addDelMAC(ctx, vppConn, swIfIndex, mac, false); err != nil {
+			prev.macs = append(kept, prev.macs[i:]...)
+			return err
+		}
+	}
+	prev.macs = kept
+	for _, mac := range macs {
+		if _, ok := wanted[mac]; !ok {
+			continue
+		}
+		delete(wanted, mac)
+		if err = addDelMAC(ctx, vppConn, swIfIndex, mac, true); err != nil {
+			return err
+		}
+		prev.macs = append(prev.macs, mac)
+	}
+	return nil
+}
+
+func del(ctx context.Context, vppConn api.Connection, isClient bool) error {
+	if prev, ok := loadAndDelete(ctx, isClient); ok {
+		return undo(ctx, vppConn, prev)
+	}
+	return nil
+}
+
+// undo removes the MAC addresses and disables the promiscuous mode set on the interface
+func undo(ctx context.Context, vppConn api.Connection, a *applied) error {
+	for _, mac := range a.macs {
+		if err := addDelMAC(ctx, vppConn, a.swIfIndex, mac, false); err != nil {
+			return err
+		}
+	}
+	if a.promisc {
+		return setPromisc(ctx, vppConn, a.swIfIndex, false)
+	}
+	return nil
+}
+
+func setPromisc(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, promisc bool) error {
+	now := time.Now()
+	if _, err := interfaces.NewServiceClient(vppConn).SwInterfaceSetPromisc(ctx, &interfaces.SwInterfaceSetPromisc{
+		SwIfIndex: swIfIndex,
+		PromiscOn: promisc,
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", swIfIndex).
+		WithField("promisc", promisc).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "SwInterfaceSetPromisc").Debug("completed")
+	return nil
+}
+
+func addDelMAC(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, mac ethernet_types.MacAddress, isAdd bool) error {
+	var add uint8
+	if isAdd {
+		add = 1
+	}
+	now := time.Now()
+	if _, err := interfaces.NewServiceClient(vppConn).SwInterfaceAddDelMacAddress(ctx, &interfaces.SwInterfaceAddDelMacAddress{
+		SwIfIndex: uint32(swIfIndex),
+		Addr:      mac,
+		IsAdd:     add,
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", swIfIndex).
+		WithField("mac", mac.String()).
+		WithField("isAdd", isAdd).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "SwInterfaceAddDelMacAddress").Debug("completed")
+	return nil
+}
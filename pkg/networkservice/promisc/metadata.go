@@ -0,0 +1,57 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package promisc
+
+import (
+	"context"
+
+	"github.com/edwarnicke/govpp/binapi/ethernet_types"
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+type key struct{}
+
+// applied - the promiscuous mode and the MAC addresses set on the interface
+type applied struct {
+	swIfIndex interface_types.InterfaceIndex
+	promisc   bool
+	macs      []ethernet_types.MacAddress
+}
+
+func store(ctx context.Context, isClient bool, value *applied) {
+	metadata.Map(ctx, isClient).Store(key{}, value)
+}
+
+func load(ctx context.Context, isClient bool) (value *applied, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).Load(key{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(*applied)
+	return value, ok
+}
+
+func loadAndDelete(ctx context.Context, isClient bool) (value *applied, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).LoadAndDelete(key{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(*applied)
+	return value, ok
+}
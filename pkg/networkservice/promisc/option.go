@@ -0,0 +1,51 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package promisc
+
+import (
+	"github.com/edwarnicke/govpp/binapi/ethernet_types"
+)
+
+const (
+	// PromiscLabel - connection label overriding the promiscuous mode of the connection interface ("true" or "false")
+	PromiscLabel = "promisc"
+	// MACsLabel - connection label overriding the MAC allow-list of the connection interface, the comma separated MAC
+	// addresses
+	MACsLabel = "allowed-macs"
+)
+
+type options struct {
+	promisc bool
+	macs    []ethernet_types.MacAddress
+}
+
+// Option is an option pattern for promisc client/server
+type Option func(o *options)
+
+// WithPromiscuous enables the promiscuous mode on the connection interfaces by default
+func WithPromiscuous() Option {
+	return func(o *options) {
+		o.promisc = true
+	}
+}
+
+// WithAllowedMACs sets the default MAC allow-list of the connection interfaces
+func WithAllowedMACs(macs ...ethernet_types.MacAddress) Option {
+	return func(o *options) {
+		o.macs = append(o.macs, macs...)
+	}
+}
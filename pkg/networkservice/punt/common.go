@@ -0,0 +1,94 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package punt
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	puntapi "github.com/edwarnicke/govpp/binapi/punt"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// puntHeaderVersion - version of the header vpp prepends to the packets delivered to the client socket
+const puntHeaderVersion = 1
+
+func apply(ctx context.Context, vppConn api.Connection, rules []puntapi.Punt, socket string) error {
+	for i := range rules {
+		if socket != "" {
+			if err := register(ctx, vppConn, &rules[i], socket); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := setPunt(ctx, vppConn, &rules[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func setPunt(ctx context.Context, vppConn api.Connection, rule *puntapi.Punt) error {
+	now := time.Now()
+	if _, err := puntapi.NewServiceClient(vppConn).SetPunt(ctx, &puntapi.SetPunt{
+		IsAdd: true,
+		Punt:  *rule,
+	}); err != nil {
+		return errors.Wrapf(err, "vpp error setting punt %s", ruleString(rule))
+	}
+	log.FromContext(ctx).
+		WithField("punt", ruleString(rule)).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "SetPunt").Debug("completed")
+	return nil
+}
+
+func register(ctx context.Context, vppConn api.Connection, rule *puntapi.Punt, socket string) error {
+	now := time.Now()
+	reply, err := puntapi.NewServiceClient(vppConn).PuntSocketRegister(ctx, &puntapi.PuntSocketRegister{
+		HeaderVersion: puntHeaderVersion,
+		Punt:          *rule,
+		Pathname:      socket,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "vpp error registering punt socket %s for %s", socket, ruleString(rule))
+	}
+	log.FromContext(ctx).
+		WithField("punt", ruleString(rule)).
+		WithField("socket", socket).
+		WithField("vppSocket", reply.Pathname).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "PuntSocketRegister").Debug("completed")
+	return nil
+}
+
+func ruleString(rule *puntapi.Punt) string {
+	switch rule.Type {
+	case puntapi.PUNT_API_TYPE_L4:
+		l4 := rule.Punt.GetL4()
+		return l4.Af.String() + " " + l4.Protocol.String() + " port " + strconv.Itoa(int(l4.Port))
+	case puntapi.PUNT_API_TYPE_IP_PROTO:
+		ipProto := rule.Punt.GetIPProto()
+		return ipProto.Af.String() + " " + ipProto.Protocol.String()
+	default:
+		return rule.Type.String()
+	}
+}
@@ -0,0 +1,23 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package punt provides a chain element configuring the vpp punt rules, so the selected control plane protocols
+// (DHCP, IGMP, BFD, custom UDP ports) arriving on the connection interfaces are delivered out of vpp to the forwarder
+// host or to a client unix socket.
+//
+// The punt rules are global in vpp, so they are configured once per forwarder. Delivering to a client socket requires
+// the punt socket to be enabled in the vpp startup config: punt { socket /path/to/punt.sock }
+package punt
@@ -0,0 +1,105 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package punt
+
+import (
+	"github.com/edwarnicke/govpp/binapi/ip_types"
+	puntapi "github.com/edwarnicke/govpp/binapi/punt"
+)
+
+const (
+	dhcpServerPort  = 67
+	dhcpClientPort  = 68
+	dhcp6ClientPort = 546
+	dhcp6ServerPort = 547
+	bfdPort         = 3784
+	bfdEchoPort     = 3785
+)
+
+type options struct {
+	rules  []puntapi.Punt
+	socket string
+}
+
+// Option is an option pattern for punt server
+type Option func(o *options)
+
+// WithDHCP punts DHCP (UDP 67, 68) and DHCPv6 (UDP 546, 547)
+func WithDHCP() Option {
+	return func(o *options) {
+		o.rules = append(o.rules,
+			udp(ip_types.ADDRESS_IP4, dhcpServerPort),
+			udp(ip_types.ADDRESS_IP4, dhcpClientPort),
+			udp(ip_types.ADDRESS_IP6, dhcp6ClientPort),
+			udp(ip_types.ADDRESS_IP6, dhcp6ServerPort),
+		)
+	}
+}
+
+// WithIGMP punts IGMP
+func WithIGMP() Option {
+	return func(o *options) {
+		o.rules = append(o.rules, ipProto(ip_types.ADDRESS_IP4, ip_types.IP_API_PROTO_IGMP))
+	}
+}
+
+// WithBFD punts BFD control (UDP 3784) and echo (UDP 3785) packets
+func WithBFD() Option {
+	return func(o *options) {
+		for _, af := range []ip_types.AddressFamily{ip_types.ADDRESS_IP4, ip_types.ADDRESS_IP6} {
+			o.rules = append(o.rules, udp(af, bfdPort), udp(af, bfdEchoPort))
+		}
+	}
+}
+
+// WithUDPPorts punts the IPv4 and IPv6 UDP packets destined to the ports
+func WithUDPPorts(ports ...uint16) Option {
+	return func(o *options) {
+		for _, port := range ports {
+			o.rules = append(o.rules, udp(ip_types.ADDRESS_IP4, port), udp(ip_types.ADDRESS_IP6, port))
+		}
+	}
+}
+
+// WithSocket sets the client unix socket the punted packets are delivered to. The packets are delivered to the
+// forwarder host if not set (default).
+func WithSocket(path string) Option {
+	return func(o *options) {
+		o.socket = path
+	}
+}
+
+func udp(af ip_types.AddressFamily, port uint16) puntapi.Punt {
+	return puntapi.Punt{
+		Type: puntapi.PUNT_API_TYPE_L4,
+		Punt: puntapi.PuntUnionL4(puntapi.PuntL4{
+			Af:       af,
+			Protocol: ip_types.IP_API_PROTO_UDP,
+			Port:     port,
+		}),
+	}
+}
+
+func ipProto(af ip_types.AddressFamily, proto ip_types.IPProto) puntapi.Punt {
+	return puntapi.Punt{
+		Type: puntapi.PUNT_API_TYPE_IP_PROTO,
+		Punt: puntapi.PuntUnionIPProto(puntapi.PuntIPProto{
+			Af:       af,
+			Protocol: proto,
+		}),
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package punt
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"git.fd.io/govpp.git/api"
+	puntapi "github.com/edwarnicke/govpp/binapi/punt"
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+)
+
+type puntServer struct {
+	vppConn api.Connection
+	rules   []puntapi.Punt
+	socket  string
+
+	inited    uint32
+	initMutex sync.Mutex
+}
+
+// NewServer - returns a new server chain element configuring the punt rules once per forwarder, before the first
+// Request goes further
+func NewServer(vppConn api.Connection, opts ...Option) networkservice.NetworkServiceServer {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return &puntServer{
+		vppConn: vppConn,
+		rules:   o.rules,
+		socket:  o.socket,
+	}
+}
+
+func (p *puntServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	if err := p.init(ctx); err != nil {
+		return nil, err
+	}
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (p *puntServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func (p *puntServer) init(ctx context.Context) error {
+	if atomic.LoadUint32(&p.inited) > 0 {
+		return nil
+	}
+	p.initMutex.Lock()
+	defer p.initMutex.Unlock()
+	if atomic.LoadUint32(&p.inited) > 0 {
+		return nil
+	}
+
+	err := apply(ctx, p.vppConn, p.rules, p.socket)
+	if err == nil {
+		atomic.StoreUint32(&p.inited, 1)
+	}
+	return err
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quiesce
+
+import (
+	"context"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	interfaces "github.com/edwarnicke/govpp/binapi/interface"
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+)
+
+// quiesce sets the interfaces of the sides admin-down and waits for the drain interval if any interface was set down
+func quiesce(ctx context.Context, vppConn api.Connection, drainInterval time.Duration, sides ...bool) {
+	var down bool
+	for _, isClient := range sides {
+		swIfIndex, ok := ifindex.Load(ctx, isClient)
+		if !ok {
+			continue
+		}
+		// The interface is deleted anyway, so the failure is not the reason to fail Close
+		if err := adminDown(ctx, vppConn, swIfIndex); err != nil {
+			log.FromContext(ctx).WithField("swIfIndex", swIfIndex).Warnf("failed to set the interface down: %v", err)
+			continue
+		}
+		down = true
+	}
+	if !down || drainInterval <= 0 {
+		return
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(drainInterval):
+	}
+}
+
+func adminDown(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex) error {
+	now := time.Now()
+	if _, err := interfaces.NewServiceClient(vppConn).SwInterfaceSetFlags(ctx, &interfaces.SwInterfaceSetFlags{
+		SwIfIndex: swIfIndex,
+		Flags:     0,
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", swIfIndex).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "SwInterfaceSetFlags").Debug("completed")
+	return nil
+}
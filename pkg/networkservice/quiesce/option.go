@@ -0,0 +1,45 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quiesce
+
+import "time"
+
+const defaultDrainInterval = 100 * time.Millisecond
+
+type options struct {
+	drainInterval time.Duration
+}
+
+// Option is an option pattern for NewClient, NewServer
+type Option func(o *options)
+
+// WithDrainInterval sets the time to wait after the interfaces are set admin-down (default 100ms)
+func WithDrainInterval(drainInterval time.Duration) Option {
+	return func(o *options) {
+		o.drainInterval = drainInterval
+	}
+}
+
+func newOptions(opts ...Option) *options {
+	o := &options{
+		drainInterval: defaultDrainInterval,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
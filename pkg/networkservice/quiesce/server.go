@@ -0,0 +1,52 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quiesce
+
+import (
+	"context"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+)
+
+type quiesceServer struct {
+	vppConn       api.Connection
+	drainInterval time.Duration
+}
+
+// NewServer returns a server chain element setting the interfaces of both the server and the client sides admin-down
+// on Close before the rest of the chain deletes them
+func NewServer(vppConn api.Connection, opts ...Option) networkservice.NetworkServiceServer {
+	o := newOptions(opts...)
+	return &quiesceServer{
+		vppConn:       vppConn,
+		drainInterval: o.drainInterval,
+	}
+}
+
+func (q *quiesceServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (q *quiesceServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	quiesce(ctx, q.vppConn, q.drainInterval, false, true)
+	return next.Server(ctx).Close(ctx, conn)
+}
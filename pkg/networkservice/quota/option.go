@@ -0,0 +1,55 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quota
+
+const (
+	// BandwidthLabel - connection label setting the bandwidth in bits per second the connection requests,
+	// counted against the MaxBandwidth quota
+	BandwidthLabel = "bandwidth"
+)
+
+// Quota - the limits of the resources used by all the connections of a NetworkService, 0 means unlimited
+type Quota struct {
+	// MaxConnections - the max number of the connections
+	MaxConnections int
+	// MaxBandwidth - the max sum of the bandwidth in bits per second the connections request with BandwidthLabel
+	MaxBandwidth uint64
+	// MaxRoutes - the max number of the routes in the ip context of the connections
+	MaxRoutes int
+}
+
+type options struct {
+	quotas       map[string]*Quota
+	defaultQuota *Quota
+}
+
+// Option is an option pattern for quota server
+type Option func(o *options)
+
+// WithQuota sets the quota of the networkService
+func WithQuota(networkService string, quota *Quota) Option {
+	return func(o *options) {
+		o.quotas[networkService] = quota
+	}
+}
+
+// WithDefaultQuota sets the quota of the NetworkServices not set with WithQuota. Default: no quota
+func WithDefaultQuota(quota *Quota) Option {
+	return func(o *options) {
+		o.defaultQuota = quota
+	}
+}
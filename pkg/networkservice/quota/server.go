@@ -0,0 +1,189 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quota
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+)
+
+// connUsage - the usage of the connection counted against the quota of its NetworkService
+type connUsage struct {
+	networkService string
+	usage          usage
+}
+
+type quotaServer struct {
+	opts    *options
+	metrics *metrics
+	conns   map[string]*connUsage
+	totals  map[string]usage
+	mutex   sync.Mutex
+}
+
+// NewServer returns a Server chain element rejecting the Requests exceeding the quota of their NetworkService with
+// vpperrors.ErrResourceExhausted. The connections, the bandwidth requested with BandwidthLabel and the routes are
+// counted.
+func NewServer(ctx context.Context, opts ...Option) networkservice.NetworkServiceServer {
+	o := &options{
+		quotas: make(map[string]*Quota),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &quotaServer{
+		opts:    o,
+		metrics: newMetrics(ctx),
+		conns:   make(map[string]*connUsage),
+		totals:  make(map[string]usage),
+	}
+}
+
+func (q *quotaServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	networkService := request.GetConnection().GetNetworkService()
+	quota := q.quota(networkService)
+	if quota == nil {
+		return next.Server(ctx).Request(ctx, request)
+	}
+
+	bandwidth, err := requestedBandwidth(request.GetConnection())
+	if err != nil {
+		return nil, err
+	}
+
+	// The routes are known only after the Request, so they are checked after it
+	connID := request.GetConnection().GetId()
+	prev, err := q.set(ctx, connID, networkService, quota, &usage{
+		connections: 1,
+		bandwidth:   bandwidth,
+		routes:      q.routes(connID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		_, _ = q.set(ctx, connID, networkService, nil, prev)
+		return nil, err
+	}
+
+	routes := len(conn.GetContext().GetIpContext().GetSrcRoutes()) + len(conn.GetContext().GetIpContext().GetDstRoutes())
+	if _, err := q.set(ctx, connID, networkService, quota, &usage{
+		connections: 1,
+		bandwidth:   bandwidth,
+		routes:      routes,
+	}); err != nil {
+		closeCtx, cancelClose := postponeCtxFunc()
+		defer cancelClose()
+
+		if _, closeErr := q.Close(closeCtx, conn); closeErr != nil {
+			err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+		}
+
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (q *quotaServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	_, _ = q.set(ctx, conn.GetId(), conn.GetNetworkService(), nil, nil)
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func (q *quotaServer) quota(networkService string) *Quota {
+	if quota, ok := q.opts.quotas[networkService]; ok {
+		return quota
+	}
+	return q.opts.defaultQuota
+}
+
+// routes returns the routes of the connection counted so far
+func (q *quotaServer) routes(connID string) int {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if c, ok := q.conns[connID]; ok {
+		return c.usage.routes
+	}
+	return 0
+}
+
+// set sets the usage of the connection, nil deletes it. Returns the previous usage of the connection or an error if the
+// quota is not nil and is exceeded.
+func (q *quotaServer) set(ctx context.Context, connID, networkService string, quota *Quota, u *usage) (*usage, error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	var prev *usage
+	var prevUsage usage
+	if c, ok := q.conns[connID]; ok {
+		prev, prevUsage = &c.usage, c.usage
+		networkService = c.networkService
+	}
+	var nextUsage usage
+	if u != nil {
+		nextUsage = *u
+	}
+
+	total := q.totals[networkService]
+	nextTotal := total.sub(prevUsage).add(nextUsage)
+	if quota != nil {
+		if err := nextTotal.check(networkService, quota, total); err != nil {
+			return nil, err
+		}
+	}
+
+	if u == nil {
+		delete(q.conns, connID)
+	} else {
+		q.conns[connID] = &connUsage{
+			networkService: networkService,
+			usage:          nextUsage,
+		}
+	}
+	if nextTotal == (usage{}) {
+		delete(q.totals, networkService)
+	} else {
+		q.totals[networkService] = nextTotal
+	}
+	q.metrics.add(ctx, networkService, total, nextTotal)
+	return prev, nil
+}
+
+func requestedBandwidth(conn *networkservice.Connection) (uint64, error) {
+	label, ok := conn.GetLabels()[BandwidthLabel]
+	if !ok {
+		return 0, nil
+	}
+	bandwidth, err := strconv.ParseUint(label, 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid %s label %q", BandwidthLabel, label)
+	}
+	return bandwidth, nil
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quota
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/vpperrors"
+)
+
+const (
+	connectionsMetric = "quota_connections"
+	bandwidthMetric   = "quota_bandwidth"
+	routesMetric      = "quota_routes"
+)
+
+// usage - the resources used by a connection or by all the connections of a NetworkService
+type usage struct {
+	connections int
+	bandwidth   uint64
+	routes      int
+}
+
+func (u usage) add(other usage) usage {
+	return usage{
+		connections: u.connections + other.connections,
+		bandwidth:   u.bandwidth + other.bandwidth,
+		routes:      u.routes + other.routes,
+	}
+}
+
+func (u usage) sub(other usage) usage {
+	return usage{
+		connections: u.connections - other.connections,
+		bandwidth:   u.bandwidth - other.bandwidth,
+		routes:      u.routes - other.routes,
+	}
+}
+
+// check returns an error if u exceeds the quota in the resources grown from prev
+func (u usage) check(networkService string, quota *Quota, prev usage) error {
+	switch {
+	case quota.MaxConnections > 0 && u.connections > quota.MaxConnections && u.connections > prev.connections:
+		return errors.Wrapf(vpperrors.ErrResourceExhausted, "network service %s has reached the connections quota %d", networkService, quota.MaxConnections)
+	case quota.MaxBandwidth > 0 && u.bandwidth > quota.MaxBandwidth && u.bandwidth > prev.bandwidth:
+		return errors.Wrapf(vpperrors.ErrResourceExhausted, "network service %s has reached the bandwidth quota %d", networkService, quota.MaxBandwidth)
+	case quota.MaxRoutes > 0 && u.routes > quota.MaxRoutes && u.routes > prev.routes:
+		return errors.Wrapf(vpperrors.ErrResourceExhausted, "network service %s has reached the routes quota %d", networkService, quota.MaxRoutes)
+	}
+	return nil
+}
+
+// metrics - the quota usage per NetworkService
+type metrics struct {
+	connections syncint64.UpDownCounter
+	bandwidth   syncint64.UpDownCounter
+	routes      syncint64.UpDownCounter
+}
+
+func newMetrics(ctx context.Context) *metrics {
+	rv := new(metrics)
+	var err error
+	if rv.connections, err = global.Meter("").SyncInt64().UpDownCounter(connectionsMetric); err != nil {
+		log.FromContext(ctx).Warnf("failed to create %s counter: %v", connectionsMetric, err)
+	}
+	if rv.bandwidth, err = global.Meter("").SyncInt64().UpDownCounter(bandwidthMetric); err != nil {
+		log.FromContext(ctx).Warnf("failed to create %s counter: %v", bandwidthMetric, err)
+	}
+	if rv.routes, err = global.Meter("").SyncInt64().UpDownCounter(routesMetric); err != nil {
+		log.FromContext(ctx).Warnf("failed to create %s counter: %v", routesMetric, err)
+	}
+	return rv
+}
+
+// add adds the usage change of the networkService
+func (m *metrics) add(ctx context.Context, networkService string, prev, next usage) {
+	attr := attribute.String("network_service", networkService)
+	if m.connections != nil && next.connections != prev.connections {
+		m.connections.Add(ctx, int64(next.connections-prev.connections), attr)
+	}
+	if m.bandwidth != nil && next.bandwidth != prev.bandwidth {
+		m.bandwidth.Add(ctx, int64(next.bandwidth)-int64(prev.bandwidth), attr)
+	}
+	if m.routes != nil && next.routes != prev.routes {
+		m.routes.Add(ctx, int64(next.routes-prev.routes), attr)
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rawvpp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"text/template"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// Call - templated binapi call
+type Call struct {
+	request  reflect.Type
+	reply    reflect.Type
+	template *template.Template
+}
+
+// NewCall - returns a new Call of the request message rendered from the JSON template, e.g.
+//
+//	NewCall(&ip.IPRouteAddDel{}, &ip.IPRouteAddDelReply{},
+//		`{"is_add": true, "route": {"prefix": ..., "paths": [{"sw_if_index": {{.SwIfIndex}}}]}}`)
+func NewCall(request, reply api.Message, tmpl string) (*Call, error) {
+	t, err := template.New(request.GetMessageName()).Parse(tmpl)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid template of %s", request.GetMessageName())
+	}
+	return &Call{
+		request:  reflect.TypeOf(request).Elem(),
+		reply:    reflect.TypeOf(reply).Elem(),
+		template: t,
+	}, nil
+}
+
+// Step - the call made on Request and the call undoing it on Close
+type Step struct {
+	// Create - call made on Request
+	Create *Call
+	// Delete - optional call made on Close, the Create reply is available in the template as .Reply
+	Delete *Call
+}
+
+// Data - the data available in the templates
+type Data struct {
+	Conn      *networkservice.Connection
+	Mechanism *networkservice.Mechanism
+	SwIfIndex interface_types.InterfaceIndex
+	IsClient  bool
+	// Reply - the reply of the Create call, set for the Delete call only
+	Reply api.Message
+}
+
+func (c *Call) invoke(ctx context.Context, vppConn api.Connection, data *Data) (api.Message, error) {
+	buf := new(bytes.Buffer)
+	if err := c.template.Execute(buf, data); err != nil {
+		return nil, errors.Wrapf(err, "unable to render %s", c.template.Name())
+	}
+	request := reflect.New(c.request).Interface().(api.Message)
+	if err := json.Unmarshal(buf.Bytes(), request); err != nil {
+		return nil, errors.Wrapf(err, "unable to unmarshal %s from %s", c.template.Name(), buf.String())
+	}
+	reply := reflect.New(c.reply).Interface().(api.Message)
+
+	now := time.Now()
+	if err := vppConn.Invoke(ctx, request, reply); err != nil {
+		return nil, errors.Wrapf(err, "vpp error calling %s", c.template.Name())
+	}
+	if retval := reflect.ValueOf(reply).Elem().FieldByName("Retval"); retval.IsValid() && retval.Kind() == reflect.Int32 {
+		if err := api.RetvalToVPPApiError(int32(retval.Int())); err != nil {
+			return nil, errors.Wrapf(err, "vpp error calling %s", c.template.Name())
+		}
+	}
+	log.FromContext(ctx).
+		WithField("request", buf.String()).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", request.GetMessageName()).Debug("completed")
+	return reply, nil
+}
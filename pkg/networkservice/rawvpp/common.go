@@ -0,0 +1,71 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rawvpp
+
+import (
+	"context"
+
+	"git.fd.io/govpp.git/api"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+)
+
+// create makes the Create calls once per connection
+func create(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, steps []*Step, isClient bool) error {
+	if _, ok := load(ctx, isClient); ok {
+		return nil
+	}
+	swIfIndex, _ := ifindex.Load(ctx, isClient)
+
+	var calls []*done
+	for _, step := range steps {
+		data := &Data{
+			Conn:      conn,
+			Mechanism: conn.GetMechanism(),
+			SwIfIndex: swIfIndex,
+			IsClient:  isClient,
+		}
+		reply, err := step.Create.invoke(ctx, vppConn, data)
+		if err != nil {
+			store(ctx, isClient, calls)
+			return err
+		}
+		data.Reply = reply
+		calls = append(calls, &done{step: step, data: data})
+	}
+	store(ctx, isClient, calls)
+	return nil
+}
+
+// del makes the Delete calls in the reverse order
+func del(ctx context.Context, vppConn api.Connection, isClient bool) {
+	calls, ok := loadAndDelete(ctx, isClient)
+	if !ok {
+		return
+	}
+	for i := len(calls) - 1; i >= 0; i-- {
+		if calls[i].step.Delete == nil {
+			continue
+		}
+		if _, err := calls[i].step.Delete.invoke(ctx, vppConn, calls[i].data); err != nil {
+			log.FromContext(ctx).Errorf("unable to delete: %v", err)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rawvpp provides chain elements executing the user supplied templated binapi calls on Request and the
+// corresponding deletes on Close. It is an escape hatch for the vpp features sdk-vpp doesn't wrap yet.
+//
+// The templates render the JSON of the request messages using text/template, with the fields filled from the
+// connection, the mechanism and the interface of the connection.
+package rawvpp
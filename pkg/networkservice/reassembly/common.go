@@ -0,0 +1,139 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reassembly
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	interfaces "github.com/edwarnicke/govpp/binapi/interface"
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+	"github.com/edwarnicke/govpp/binapi/ip"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+)
+
+var reassTypes = map[string]ip.IPReassType{
+	Full:    ip.IP_REASS_TYPE_FULL,
+	Virtual: ip.IP_REASS_TYPE_SHALLOW_VIRTUAL,
+}
+
+// settings returns the reassembly type and the fragment MTU of the conn, the labels override the defaults
+func (o *options) settings(conn *networkservice.Connection) (reassembly string, fragmentMTU uint32, err error) {
+	reassembly, fragmentMTU = o.reassembly, o.fragmentMTU
+	if label, ok := conn.GetLabels()[ReassemblyLabel]; ok {
+		reassembly = label
+	}
+	if _, ok := reassTypes[reassembly]; !ok && reassembly != None && reassembly != "" {
+		return "", 0, errors.Errorf("unknown reassembly type %q, expected one of: %s, %s, %s", reassembly, Full, Virtual, None)
+	}
+	if label, ok := conn.GetLabels()[FragmentMTULabel]; ok {
+		mtu, err := strconv.ParseUint(label, 10, 32)
+		if err != nil {
+			return "", 0, errors.Wrapf(err, "invalid %s label %q", FragmentMTULabel, label)
+		}
+		fragmentMTU = uint32(mtu)
+	}
+	return reassembly, fragmentMTU, nil
+}
+
+func create(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, o *options, isClient bool) error {
+	if conn.GetMechanism().GetCls() != cls.REMOTE {
+		return nil
+	}
+	swIfIndex, ok := ifindex.Load(ctx, isClient)
+	if !ok {
+		return nil
+	}
+	reassembly, fragmentMTU, err := o.settings(conn)
+	if err != nil {
+		return err
+	}
+
+	if prev, ok := load(ctx, isClient); ok {
+		if *prev == (enabled{swIfIndex: swIfIndex, reassembly: reassembly}) {
+			return setFragmentMTU(ctx, vppConn, swIfIndex, fragmentMTU)
+		}
+		if err = enableDisable(ctx, vppConn, prev.swIfIndex, prev.reassembly, false); err != nil {
+			return err
+		}
+	}
+	store(ctx, isClient, &enabled{swIfIndex: swIfIndex, reassembly: reassembly})
+	if err = enableDisable(ctx, vppConn, swIfIndex, reassembly, true); err != nil {
+		return err
+	}
+	return setFragmentMTU(ctx, vppConn, swIfIndex, fragmentMTU)
+}
+
+func del(ctx context.Context, vppConn api.Connection, isClient bool) error {
+	if prev, ok := loadAndDelete(ctx, isClient); ok {
+		return enableDisable(ctx, vppConn, prev.swIfIndex, prev.reassembly, false)
+	}
+	return nil
+}
+
+func enableDisable(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, reassembly string, isEnable bool) error {
+	reassType, ok := reassTypes[reassembly]
+	if !ok {
+		return nil
+	}
+	now := time.Now()
+	if _, err := ip.NewServiceClient(vppConn).IPReassemblyEnableDisable(ctx, &ip.IPReassemblyEnableDisable{
+		SwIfIndex: swIfIndex,
+		EnableIP4: isEnable,
+		EnableIP6: isEnable,
+		Type:      reassType,
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", swIfIndex).
+		WithField("type", reassType).
+		WithField("isEnable", isEnable).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "IPReassemblyEnableDisable").Debug("completed")
+	return nil
+}
+
+// setFragmentMTU sets the IPv4 and IPv6 MTU of the interface, the oversized packets are fragmented to the MTU.
+// The L3 and MPLS MTUs are left untouched.
+func setFragmentMTU(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, mtu uint32) error {
+	if mtu == 0 {
+		return nil
+	}
+	now := time.Now()
+	setMTU := &interfaces.SwInterfaceSetMtu{
+		SwIfIndex: swIfIndex,
+		Mtu:       []uint32{0, mtu, mtu, 0},
+	}
+	if _, err := interfaces.NewServiceClient(vppConn).SwInterfaceSetMtu(ctx, setMTU); err != nil {
+		return errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", swIfIndex).
+		WithField("MTU", setMTU.Mtu).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "SwInterfaceSetMtu").Debug("completed")
+	return nil
+}
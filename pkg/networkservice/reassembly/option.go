@@ -0,0 +1,56 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reassembly
+
+const (
+	// Full - the fragments are reassembled into the full packets before being forwarded
+	Full = "full"
+	// Virtual - the fragments are forwarded as they are, only the L4 header of the first fragment is made available to
+	// the other fragments (e.g. for the ACLs and NAT)
+	Virtual = "virtual"
+	// None - the reassembly is disabled
+	None = "none"
+
+	// ReassemblyLabel - connection label overriding the reassembly type (full, virtual or none) of the connection
+	ReassemblyLabel = "reassembly"
+	// FragmentMTULabel - connection label overriding the IP MTU the oversized packets of the connection are
+	// fragmented to
+	FragmentMTULabel = "fragment-mtu"
+)
+
+type options struct {
+	reassembly  string
+	fragmentMTU uint32
+}
+
+// Option is an option pattern for reassembly client/server
+type Option func(o *options)
+
+// WithReassembly sets the default reassembly type: Full, Virtual or None (default)
+func WithReassembly(reassembly string) Option {
+	return func(o *options) {
+		o.reassembly = reassembly
+	}
+}
+
+// WithFragmentMTU sets the default IP MTU the oversized packets are fragmented to. The MTU of the interface is not
+// changed if 0 (default).
+func WithFragmentMTU(mtu uint32) Option {
+	return func(o *options) {
+		o.fragmentMTU = mtu
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+type reconcileClient struct {
+	*reconciler
+}
+
+// NewClient - returns a new client chain element periodically running the checks stored in metadata by the next
+// elements, for each connection till it is closed.
+// The corrections made and the failed checks are counted by the reconcile_corrections and reconcile_failures metrics.
+func NewClient(chainCtx context.Context, opts ...Option) networkservice.NetworkServiceClient {
+	return &reconcileClient{
+		reconciler: newReconciler(chainCtx, opts...),
+	}
+}
+
+func (r *reconcileClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	c, loaded := r.lock(ctx, request.GetConnection().GetId(), metadata.IsClient(r))
+
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	r.update(ctx, c, loaded, metadata.IsClient(r), err)
+
+	return conn, err
+}
+
+func (r *reconcileClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	r.stop(ctx, metadata.IsClient(r))
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
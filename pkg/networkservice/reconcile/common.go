@@ -0,0 +1,144 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const (
+	correctionsMetric = "reconcile_corrections"
+	failuresMetric    = "reconcile_failures"
+)
+
+// reconciler runs the checks of the connections
+type reconciler struct {
+	chainCtx    context.Context
+	interval    time.Duration
+	corrections syncint64.Counter
+	failures    syncint64.Counter
+}
+
+func newReconciler(chainCtx context.Context, opts ...Option) *reconciler {
+	o := &options{
+		interval: defaultInterval,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	rv := &reconciler{
+		chainCtx: chainCtx,
+		interval: o.interval,
+	}
+	var err error
+	if rv.corrections, err = global.Meter("").SyncInt64().Counter(correctionsMetric); err != nil {
+		log.FromContext(chainCtx).Warnf("failed to create %s counter: %v", correctionsMetric, err)
+	}
+	if rv.failures, err = global.Meter("").SyncInt64().Counter(failuresMetric); err != nil {
+		log.FromContext(chainCtx).Warnf("failed to create %s counter: %v", failuresMetric, err)
+	}
+	return rv
+}
+
+// connection - the checks of the connection.
+// The mutex serializes the checks with the Request/Close of the connection.
+type connection struct {
+	sync.Mutex
+	id     string
+	checks map[string]Check
+	cancel context.CancelFunc
+}
+
+type connectionKey struct{}
+
+// lock returns the locked connection of the ctx, starting its checks on the first Request.
+// The loaded result is false on the first Request.
+func (r *reconciler) lock(ctx context.Context, id string, isClient bool) (c *connection, loaded bool) {
+	rawValue, loaded := metadata.Map(ctx, isClient).LoadOrStore(connectionKey{}, &connection{id: id})
+	c = rawValue.(*connection)
+	c.Lock()
+	if !loaded {
+		var cancelCtx context.Context
+		cancelCtx, c.cancel = context.WithCancel(r.chainCtx)
+		go r.run(log.WithLog(cancelCtx, log.FromContext(ctx)), c)
+	}
+	return c, loaded
+}
+
+// update updates the checks of the connection from the per Connection.Id metadata and unlocks it.
+// The checks are stopped if the first Request has failed.
+func (r *reconciler) update(ctx context.Context, c *connection, loaded, isClient bool, err error) {
+	c.checks = load(ctx, isClient)
+	c.Unlock()
+	if err != nil && !loaded {
+		r.stop(ctx, isClient)
+	}
+}
+
+// stop stops the checks of the connection
+func (r *reconciler) stop(ctx context.Context, isClient bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).LoadAndDelete(connectionKey{})
+	if !ok {
+		return
+	}
+	c := rawValue.(*connection)
+	c.Lock()
+	defer c.Unlock()
+	c.cancel()
+}
+
+func (r *reconciler) run(ctx context.Context, c *connection) {
+	logger := log.FromContext(ctx).WithField("reconcile", c.id)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(r.interval):
+		}
+		c.Lock()
+		if ctx.Err() != nil {
+			c.Unlock()
+			return
+		}
+		for name, check := range c.checks {
+			corrections, err := check(ctx)
+			if corrections > 0 {
+				logger.WithField("check", name).Warnf("vpp state drifted, corrections made: %d", corrections)
+				if r.corrections != nil {
+					r.corrections.Add(ctx, int64(corrections), attribute.String("check", name))
+				}
+			}
+			if err != nil {
+				logger.WithField("check", name).Errorf("check failed: %v", err)
+				if r.failures != nil {
+					r.failures.Add(ctx, 1, attribute.String("check", name))
+				}
+			}
+		}
+		c.Unlock()
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+// Check - checks the state programmed in vpp for the connection and repairs the drift.
+// Returns the number of the corrections made.
+// Check is run outside of the Request/Close, so it must not use the per Connection.Id metadata.
+type Check func(ctx context.Context) (corrections int, err error)
+
+type checksKey struct{}
+
+// Store sets the named check of the connection, stored in per Connection.Id metadata.
+// Storing the check with the same name again replaces it.
+func Store(ctx context.Context, isClient bool, name string, check Check) {
+	checks, _ := metadata.Map(ctx, isClient).LoadOrStore(checksKey{}, make(map[string]Check))
+	checks.(map[string]Check)[name] = check
+}
+
+// Delete deletes the named check of the connection, stored in per Connection.Id metadata
+func Delete(ctx context.Context, isClient bool, name string) {
+	if checks, ok := metadata.Map(ctx, isClient).Load(checksKey{}); ok {
+		delete(checks.(map[string]Check), name)
+	}
+}
+
+// load returns a copy of the checks of the connection, stored in per Connection.Id metadata
+func load(ctx context.Context, isClient bool) map[string]Check {
+	rv := make(map[string]Check)
+	if checks, ok := metadata.Map(ctx, isClient).Load(checksKey{}); ok {
+		for name, check := range checks.(map[string]Check) {
+			rv[name] = check
+		}
+	}
+	return rv
+}
@@ -23,9 +23,7 @@ package stats
 
 import (
 	"context"
-	"sync"
 
-	"git.fd.io/govpp.git/core"
 	"github.com/golang/protobuf/ptypes/empty"
 	"google.golang.org/grpc"
 
@@ -35,11 +33,8 @@ import (
 )
 
 type statsClient struct {
-	chainCtx  context.Context
-	statsConn *core.StatsConnection
-	statsSock string
-	once      sync.Once
-	initErr   error
+	statsConn *Conn
+	perWorker bool
 }
 
 // NewClient provides a NetworkServiceClient chain elements that retrieves vpp interface metrics.
@@ -49,14 +44,18 @@ func NewClient(ctx context.Context, options ...Option) networkservice.NetworkSer
 		opt(opts)
 	}
 
+	statsConn := opts.conn
+	if statsConn == nil {
+		statsConn = NewConn(ctx, options...)
+	}
 	return &statsClient{
-		chainCtx:  ctx,
-		statsSock: opts.socket,
+		statsConn: statsConn,
+		perWorker: opts.perWorker,
 	}
 }
 
 func (s *statsClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
-	initErr := s.init()
+	statsConn, initErr := s.statsConn.Get()
 	if initErr != nil {
 		log.FromContext(ctx).Errorf("%v", initErr)
 	}
@@ -66,23 +65,26 @@ func (s *statsClient) Request(ctx context.Context, request *networkservice.Netwo
 		return conn, err
 	}
 
-	retrieveMetrics(ctx, s.statsConn, conn.Path.PathSegments[conn.Path.Index], true)
+	retrieveMetrics(ctx, statsConn, conn.Path.PathSegments[conn.Path.Index], true)
+	if s.perWorker {
+		retrieveWorkerMetrics(ctx, s.statsConn, conn.Path.PathSegments[conn.Path.Index], true)
+	}
 	return conn, nil
 }
 
 func (s *statsClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
 	rv, err := next.Client(ctx).Close(ctx, conn, opts...)
-	if err != nil || s.initErr != nil {
+	if err != nil {
 		return rv, err
 	}
+	statsConn, initErr := s.statsConn.Get()
+	if initErr != nil {
+		return rv, nil
+	}
 
-	retrieveMetrics(ctx, s.statsConn, conn.Path.PathSegments[conn.Path.Index], true)
+	retrieveMetrics(ctx, statsConn, conn.Path.PathSegments[conn.Path.Index], true)
+	if s.perWorker {
+		retrieveWorkerMetrics(ctx, s.statsConn, conn.Path.PathSegments[conn.Path.Index], true)
+	}
 	return &empty.Empty{}, nil
 }
-
-func (s *statsClient) init() error {
-	s.once.Do(func() {
-		s.statsConn, s.initErr = initFunc(s.chainCtx, s.statsSock)
-	})
-	return s.initErr
-}
@@ -23,16 +23,15 @@ package stats
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 
 	"git.fd.io/govpp.git/adapter"
-	"git.fd.io/govpp.git/adapter/statsclient"
 	"git.fd.io/govpp.git/api"
 	"git.fd.io/govpp.git/core"
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
-	"github.com/pkg/errors"
 
 	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
 )
@@ -73,17 +72,64 @@ func retrieveMetrics(ctx context.Context, statsConn *core.StatsConnection, segme
 	}
 }
 
-func initFunc(chainCtx context.Context, statsSocket string) (*core.StatsConnection, error) {
-	if statsSocket == "" {
-		statsSocket = adapter.DefaultStatsSocket
+const (
+	rxCounter    = "/if/rx"
+	txCounter    = "/if/tx"
+	dropsCounter = "/if/drops"
+)
+
+// Save the per worker vpp interface metrics in pathSegment, the workers having no traffic on the interface are skipped
+func retrieveWorkerMetrics(ctx context.Context, statsConn *Conn, segment *networkservice.PathSegment, isClient bool) {
+	swIfIndex, ok := ifindex.Load(ctx, isClient)
+	if !ok {
+		return
 	}
-	statsConn, err := core.ConnectStats(statsclient.NewStatsClient(statsSocket))
+	entries, err := statsConn.Dump(rxCounter, txCounter, dropsCounter)
 	if err != nil {
-		return nil, errors.WithStack(err)
+		log.FromContext(ctx).Errorf("getting per worker interface stats failed: %v", err)
+		return
+	}
+
+	addName := "server_"
+	if isClient {
+		addName = "client_"
+	}
+	metrics := make(map[string]string)
+	for idx := range entries {
+		name := string(entries[idx].Name)
+		switch data := entries[idx].Data.(type) {
+		case adapter.CombinedCounterStat:
+			if name != rxCounter && name != txCounter {
+				continue
+			}
+			direction := name[len("/if/"):]
+			for worker, counters := range data {
+				if int(swIfIndex) >= len(counters) || counters[swIfIndex].Packets() == 0 {
+					continue
+				}
+				prefix := fmt.Sprintf("%sworker%d_%s_", addName, worker, direction)
+				metrics[prefix+"bytes"] = strconv.FormatUint(counters[swIfIndex].Bytes(), 10)
+				metrics[prefix+"packets"] = strconv.FormatUint(counters[swIfIndex].Packets(), 10)
+			}
+		case adapter.SimpleCounterStat:
+			if name != dropsCounter {
+				continue
+			}
+			for worker, counters := range data {
+				if int(swIfIndex) >= len(counters) || counters[swIfIndex] == 0 {
+					continue
+				}
+				metrics[fmt.Sprintf("%sworker%d_drops", addName, worker)] = strconv.FormatUint(uint64(counters[swIfIndex]), 10)
+			}
+		}
+	}
+	if len(metrics) == 0 {
+		return
+	}
+	if segment.Metrics == nil {
+		segment.Metrics = make(map[string]string)
+	}
+	for k, v := range metrics {
+		segment.Metrics[k] = v
 	}
-	go func() {
-		<-chainCtx.Done()
-		statsConn.Disconnect()
-	}()
-	return statsConn, nil
 }
@@ -0,0 +1,112 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package stats
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"git.fd.io/govpp.git/adapter"
+	"git.fd.io/govpp.git/adapter/statsclient"
+	"git.fd.io/govpp.git/core"
+	"github.com/pkg/errors"
+)
+
+const defaultRetryInterval = time.Second
+
+// Conn - connection to the vpp stats socket shared between the stats client and server.
+// The socket is connected lazily on the first use, a failed attempt (e.g. vpp is not ready yet during the startup) is
+// retried on the use after the retry interval.
+type Conn struct {
+	chainCtx      context.Context
+	socket        string
+	retryInterval time.Duration
+
+	statsConn   *core.StatsConnection
+	statsAPI    adapter.StatsAPI
+	lastErr     error
+	lastAttempt time.Time
+	mut         sync.Mutex
+}
+
+// NewConn returns a new Conn to the stats socket set by the options (the default one if not set), disconnected when
+// chainCtx is done
+func NewConn(chainCtx context.Context, options ...Option) *Conn {
+	opts := &statsOptions{
+		retryInterval: defaultRetryInterval,
+	}
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	socket := opts.socket
+	if socket == "" {
+		socket = adapter.DefaultStatsSocket
+	}
+	return &Conn{
+		chainCtx:      chainCtx,
+		socket:        socket,
+		retryInterval: opts.retryInterval,
+	}
+}
+
+// Get returns the stats connection, connecting to the stats socket if not connected yet
+func (c *Conn) Get() (*core.StatsConnection, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	if c.statsConn != nil {
+		return c.statsConn, nil
+	}
+	if c.chainCtx.Err() != nil {
+		return nil, errors.WithStack(c.chainCtx.Err())
+	}
+	if c.lastErr != nil && time.Since(c.lastAttempt) < c.retryInterval {
+		return nil, c.lastErr
+	}
+
+	c.lastAttempt = time.Now()
+	statsAPI := statsclient.NewStatsClient(c.socket)
+	statsConn, err := core.ConnectStats(statsAPI)
+	if err != nil {
+		c.lastErr = errors.Wrapf(err, "failed to connect to the stats socket %s", c.socket)
+		return nil, c.lastErr
+	}
+	c.statsConn, c.statsAPI, c.lastErr = statsConn, statsAPI, nil
+	go func() {
+		<-c.chainCtx.Done()
+		statsConn.Disconnect()
+	}()
+	return statsConn, nil
+}
+
+// Dump returns the raw stats entries matching the patterns, e.g. the per worker counters aggregated by the stats
+// connection
+func (c *Conn) Dump(patterns ...string) ([]adapter.StatEntry, error) {
+	if _, err := c.Get(); err != nil {
+		return nil, err
+	}
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	entries, err := c.statsAPI.DumpStats(patterns...)
+	return entries, errors.WithStack(err)
+}
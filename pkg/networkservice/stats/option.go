@@ -14,10 +14,20 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
+//go:build linux
+// +build linux
+
 package stats
 
+import (
+	"time"
+)
+
 type statsOptions struct {
-	socket string
+	socket        string
+	retryInterval time.Duration
+	conn          *Conn
+	perWorker     bool
 }
 
 // Option is an option pattern for stats server/client
@@ -29,3 +39,25 @@ func WithSocket(socket string) Option {
 		o.socket = socket
 	}
 }
+
+// WithRetryInterval sets the interval the failed connection to the stats socket is retried after
+func WithRetryInterval(retryInterval time.Duration) Option {
+	return func(o *statsOptions) {
+		o.retryInterval = retryInterval
+	}
+}
+
+// WithConn sets the stats socket connection shared between the stats client and server
+func WithConn(conn *Conn) Option {
+	return func(o *statsOptions) {
+		o.conn = conn
+	}
+}
+
+// WithPerWorkerCounters adds the per worker (per thread) rx/tx counters of the connection interfaces to the metrics,
+// the aggregated counters hide a single saturated worker
+func WithPerWorkerCounters() Option {
+	return func(o *statsOptions) {
+		o.perWorker = true
+	}
+}
@@ -23,9 +23,7 @@ package stats
 
 import (
 	"context"
-	"sync"
 
-	"git.fd.io/govpp.git/core"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
@@ -33,11 +31,8 @@ import (
 )
 
 type statsServer struct {
-	chainCtx  context.Context
-	statsConn *core.StatsConnection
-	statsSock string
-	once      sync.Once
-	initErr   error
+	statsConn *Conn
+	perWorker bool
 }
 
 // NewServer provides a NetworkServiceServer chain elements that retrieves vpp interface metrics.
@@ -47,14 +42,18 @@ func NewServer(ctx context.Context, options ...Option) networkservice.NetworkSer
 		opt(opts)
 	}
 
+	statsConn := opts.conn
+	if statsConn == nil {
+		statsConn = NewConn(ctx, options...)
+	}
 	return &statsServer{
-		chainCtx:  ctx,
-		statsSock: opts.socket,
+		statsConn: statsConn,
+		perWorker: opts.perWorker,
 	}
 }
 
 func (s *statsServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
-	initErr := s.init()
+	statsConn, initErr := s.statsConn.Get()
 	if initErr != nil {
 		log.FromContext(ctx).Errorf("%v", initErr)
 	}
@@ -64,23 +63,26 @@ func (s *statsServer) Request(ctx context.Context, request *networkservice.Netwo
 		return conn, err
 	}
 
-	retrieveMetrics(ctx, s.statsConn, conn.Path.PathSegments[conn.Path.Index], false)
+	retrieveMetrics(ctx, statsConn, conn.Path.PathSegments[conn.Path.Index], false)
+	if s.perWorker {
+		retrieveWorkerMetrics(ctx, s.statsConn, conn.Path.PathSegments[conn.Path.Index], false)
+	}
 	return conn, nil
 }
 
 func (s *statsServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
 	rv, err := next.Server(ctx).Close(ctx, conn)
-	if err != nil || s.initErr != nil {
+	if err != nil {
 		return rv, err
 	}
+	statsConn, initErr := s.statsConn.Get()
+	if initErr != nil {
+		return rv, nil
+	}
 
-	retrieveMetrics(ctx, s.statsConn, conn.Path.PathSegments[conn.Path.Index], false)
+	retrieveMetrics(ctx, statsConn, conn.Path.PathSegments[conn.Path.Index], false)
+	if s.perWorker {
+		retrieveWorkerMetrics(ctx, s.statsConn, conn.Path.PathSegments[conn.Path.Index], false)
+	}
 	return &empty.Empty{}, nil
 }
-
-func (s *statsServer) init() error {
-	s.once.Do(func() {
-		s.statsConn, s.initErr = initFunc(s.chainCtx, s.statsSock)
-	})
-	return s.initErr
-}
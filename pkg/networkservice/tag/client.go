@@ -32,13 +32,20 @@ import (
 type tagClient struct {
 	ctx     context.Context
 	vppConn api.Connection
+	prefix  string
 }
 
 // NewClient returns a Client chain element that applies a 'tag' to the vpp interface created
-func NewClient(ctx context.Context, vppConn api.Connection) networkservice.NetworkServiceClient {
+func NewClient(ctx context.Context, vppConn api.Connection, opts ...Option) networkservice.NetworkServiceClient {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
 	return &tagClient{
 		ctx:     ctx,
 		vppConn: vppConn,
+		prefix:  o.prefix,
 	}
 }
 
@@ -50,7 +57,7 @@ func (t *tagClient) Request(ctx context.Context, request *networkservice.Network
 		return nil, err
 	}
 
-	if err := create(ctx, conn, t.vppConn, true); err != nil {
+	if err := create(ctx, conn, t.vppConn, t.prefix, true); err != nil {
 		closeCtx, cancelClose := postponeCtxFunc()
 		defer cancelClose()
 
@@ -26,26 +26,35 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
 	"github.com/pkg/errors"
 
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/external"
 	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
 )
 
-func create(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, isClient bool) error {
+func create(ctx context.Context, conn *networkservice.Connection, vppConn api.Connection, prefix string, isClient bool) error {
 	swIfIndex, ok := ifindex.Load(ctx, isClient)
 	if !ok {
 		return nil
 	}
+	// The external interfaces are identified by the tags set by the operator
+	if external.ToMechanism(conn.GetMechanism()) != nil {
+		return nil
+	}
+	tag := Format(prefix, conn.GetId())
+	if err := validate(tag); err != nil {
+		return err
+	}
 
 	now := time.Now()
 	if _, err := interfaces.NewServiceClient(vppConn).SwInterfaceTagAddDel(ctx, &interfaces.SwInterfaceTagAddDel{
 		IsAdd:     true,
 		SwIfIndex: swIfIndex,
-		Tag:       conn.GetId(),
+		Tag:       tag,
 	}); err != nil {
 		return errors.WithStack(err)
 	}
 	log.FromContext(ctx).
 		WithField("swIfIndex", swIfIndex).
-		WithField("tag", conn.GetId()).
+		WithField("tag", tag).
 		WithField("duration", time.Since(now)).
 		WithField("vppapi", "SwInterfaceTagAddDel").Debug("completed")
 	return nil
@@ -0,0 +1,34 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tag
+
+type options struct {
+	prefix string
+}
+
+// Option is an option pattern for tag client/server
+type Option func(o *options)
+
+// WithPrefix sets the prefix of the tags, the tag is the prefix followed by the connection ID. The prefix specific to
+// the forwarder instance marks the vpp objects it owns, so the many consumers sharing one vpp daemon can tell and
+// garbage collect only their own objects (see Parse).
+// vpp limits the tags to 63 bytes, so the prefix shouldn't be longer than 27 bytes for the UUID connection IDs.
+func WithPrefix(prefix string) Option {
+	return func(o *options) {
+		o.prefix = prefix
+	}
+}
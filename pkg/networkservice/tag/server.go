@@ -31,13 +31,20 @@ import (
 type tagServer struct {
 	ctx     context.Context
 	vppConn api.Connection
+	prefix  string
 }
 
 // NewServer returns a Serve chain element that applies a 'tag' to the vpp interface for the connection
-func NewServer(ctx context.Context, vppConn api.Connection) networkservice.NetworkServiceServer {
+func NewServer(ctx context.Context, vppConn api.Connection, opts ...Option) networkservice.NetworkServiceServer {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
 	return &tagServer{
 		ctx:     ctx,
 		vppConn: vppConn,
+		prefix:  o.prefix,
 	}
 }
 
@@ -49,7 +56,7 @@ func (t *tagServer) Request(ctx context.Context, request *networkservice.Network
 		return nil, err
 	}
 
-	if err := create(ctx, conn, t.vppConn, false); err != nil {
+	if err := create(ctx, conn, t.vppConn, t.prefix, false); err != nil {
 		closeCtx, cancelClose := postponeCtxFunc()
 		defer cancelClose()
 
@@ -0,0 +1,50 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tag
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// maxTagLen is the max length of the vpp tags (string[64] including the terminating zero)
+	maxTagLen = 63
+)
+
+// Format returns the tag of the vpp objects of the connection
+func Format(prefix, connID string) string {
+	return prefix + connID
+}
+
+// Parse returns the connection ID of the tag and true if the tag has the prefix, i.e. the object is owned by the
+// forwarder using the prefix
+func Parse(prefix, tag string) (connID string, ok bool) {
+	if tag == "" || !strings.HasPrefix(tag, prefix) {
+		return "", false
+	}
+	connID = strings.TrimPrefix(tag, prefix)
+	return connID, connID != ""
+}
+
+func validate(tag string) error {
+	if len(tag) > maxTagLen {
+		return errors.Errorf("tag %s is longer than %d bytes", tag, maxTagLen)
+	}
+	return nil
+}
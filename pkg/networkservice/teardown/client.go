@@ -0,0 +1,60 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package teardown
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+)
+
+type teardownClient struct {
+	opts *options
+}
+
+// NewClient returns a client chain element running the teardown steps deferred by the rest of the chain in the phase
+// order on Close
+func NewClient(opts ...Option) networkservice.NetworkServiceClient {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &teardownClient{
+		opts: o,
+	}
+}
+
+func (t *teardownClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	// The steps run immediately on the Request failures get the step timeout too
+	return next.Client(ctx).Request(withPolicy(ctx, t.opts), request, opts...)
+}
+
+func (t *teardownClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	ctx, cancel := closeContext(ctx, t.opts)
+	defer cancel()
+
+	ctx, run := withCoordinator(ctx)
+	rv, err := next.Client(ctx).Close(ctx, conn, opts...)
+	if runErr := run(); err == nil {
+		err = runErr
+	}
+	return rv, err
+}
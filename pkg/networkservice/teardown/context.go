@@ -0,0 +1,74 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package teardown
+
+import (
+	"context"
+	"time"
+)
+
+type policyKey struct{}
+
+// detachedContext has the values of the parent context but neither its deadline nor its cancellation
+type detachedContext struct {
+	context.Context
+}
+
+func (detachedContext) Deadline() (deadline time.Time, ok bool) {
+	return time.Time{}, false
+}
+
+func (detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (detachedContext) Err() error {
+	return nil
+}
+
+// withPolicy stores the options in ctx to be found by Do and Context, the options already stored by the server
+// element are kept unless the client one sets its own
+func withPolicy(ctx context.Context, o *options) context.Context {
+	if *o == (options{}) {
+		if _, ok := ctx.Value(policyKey{}).(*options); ok {
+			return ctx
+		}
+	}
+	return context.WithValue(ctx, policyKey{}, o)
+}
+
+// closeContext returns the context for the Close of the rest of the chain with the teardown timeout instead of the
+// deadline of ctx, or ctx if the timeout is not set
+func closeContext(ctx context.Context, o *options) (context.Context, context.CancelFunc) {
+	ctx = withPolicy(ctx, o)
+	if o.timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(detachedContext{Context: ctx}, o.timeout)
+}
+
+// Context returns the context for the vpp calls of a teardown step with the values of ctx and the step timeout set
+// by the teardown element instead of the deadline of ctx, so the step is not cut short by the expired deadline of the
+// Request or by the previous steps. It returns ctx if the step timeout is not set. The elements running their vpp
+// calls on Close outside of Do use it the same way Do does.
+func Context(ctx context.Context) (context.Context, context.CancelFunc) {
+	o, ok := ctx.Value(policyKey{}).(*options)
+	if !ok || o.stepTimeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(detachedContext{Context: ctx}, o.stepTimeout)
+}
@@ -0,0 +1,25 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package teardown provides chain elements coordinating the teardown order on Close across the chain elements.
+// The elements defer their vpp configuration removal with Do to the phase it belongs to, the phases are run in order
+// after the rest of the chain is closed: the routes before the ACLs, the ACLs before the l2 cross connects and the
+// bridge domains, those before the interfaces and the tunnels, the interfaces before the vrf tables and the tunnels
+// before their underlay. The pinholes are shared by the tunnels and are never removed on Close. Without the teardown
+// element in the chain Do runs the removal immediately, in the element-local order. The teardown element options give
+// the Close and each of its steps their own deadlines instead of the incoming ones, so the vpp configuration is not
+// leaked when the Request has failed on a timeout.
+package teardown
@@ -0,0 +1,43 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package teardown
+
+import "time"
+
+type options struct {
+	timeout     time.Duration
+	stepTimeout time.Duration
+}
+
+// Option is an option pattern for NewServer, NewClient
+type Option func(o *options)
+
+// WithTimeout sets the deadline of the Close of the rest of the chain instead of the deadline of the incoming Close
+// context, so the teardown is not cut short by an already expired deadline (0 keeps the incoming one, default)
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// WithStepTimeout sets the deadline of each teardown step (the vpp calls of the step) independent of the Close and
+// the Request contexts, also for the steps run immediately on the Request failures (0 disables it, default)
+func WithStepTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.stepTimeout = timeout
+	}
+}
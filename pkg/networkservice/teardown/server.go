@@ -0,0 +1,59 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package teardown
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+)
+
+type teardownServer struct {
+	opts *options
+}
+
+// NewServer returns a server chain element running the teardown steps deferred by the rest of the chain (including
+// the client chain closed from it) in the phase order on Close
+func NewServer(opts ...Option) networkservice.NetworkServiceServer {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &teardownServer{
+		opts: o,
+	}
+}
+
+func (t *teardownServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	// The steps run immediately on the Request failures get the step timeout too
+	return next.Server(ctx).Request(withPolicy(ctx, t.opts), request)
+}
+
+func (t *teardownServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	ctx, cancel := closeContext(ctx, t.opts)
+	defer cancel()
+
+	ctx, run := withCoordinator(ctx)
+	rv, err := next.Server(ctx).Close(ctx, conn)
+	if runErr := run(); err == nil {
+		err = runErr
+	}
+	return rv, err
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package teardown
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// Phase - the teardown phase
+type Phase int
+
+const (
+	// Routes - the routes and the neighbors via the interfaces
+	Routes Phase = iota
+	// ACLs - the ACLs applied to the interfaces
+	ACLs
+	// L2 - the l2 cross connects and the bridge domains of the interfaces
+	L2
+	// Interfaces - the interfaces, including the tunnels
+	Interfaces
+	// Tables - the vrf tables the interfaces were bound to
+	Tables
+	// Underlay - the underlay of the tunnels: the underlay addresses
+	Underlay
+	// Verify - the checks that the connection configuration is actually removed
+	Verify
+
+	phases
+)
+
+var phaseNames = [phases]string{"routes", "acls", "l2", "interfaces", "tables", "underlay", "verify"}
+
+func (p Phase) String() string {
+	if p < 0 || p >= phases {
+		return "unknown"
+	}
+	return phaseNames[p]
+}
+
+type coordinator struct {
+	steps [phases][]func(ctx context.Context) error
+	mutex sync.Mutex
+}
+
+type key struct{}
+
+// Do defers f to the phase of the teardown coordinator of ctx, or runs it immediately if there is no coordinator.
+// The error is returned only if f is run immediately, the errors of the deferred steps are returned by the teardown
+// element's Close. f is run with the step timeout of the teardown element (see Context).
+func Do(ctx context.Context, phase Phase, f func(ctx context.Context) error) error {
+	step := func(ctx context.Context) error {
+		stepCtx, cancel := Context(ctx)
+		defer cancel()
+		return f(stepCtx)
+	}
+	c, ok := ctx.Value(key{}).(*coordinator)
+	if !ok || phase < 0 || phase >= phases {
+		return step(ctx)
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.steps[phase] = append(c.steps[phase], step)
+	return nil
+}
+
+// withCoordinator returns ctx with the coordinator and the function running its steps. If ctx already has the
+// coordinator (e.g. the client chain closed from the server one), the steps are left to it.
+func withCoordinator(ctx context.Context) (context.Context, func() error) {
+	if _, ok := ctx.Value(key{}).(*coordinator); ok {
+		return ctx, func() error { return nil }
+	}
+	c := new(coordinator)
+	return context.WithValue(ctx, key{}, c), func() error {
+		return c.run(ctx)
+	}
+}
+
+// run runs the steps phase by phase, all the steps are run even if some fail
+func (c *coordinator) run(ctx context.Context) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var err error
+	for phase := Phase(0); phase < phases; phase++ {
+		for _, step := range c.steps[phase] {
+			stepErr := step(ctx)
+			if stepErr == nil {
+				continue
+			}
+			log.FromContext(ctx).WithField("phase", phase).Errorf("teardown step failed: %v", stepErr)
+			if err == nil {
+				err = errors.Wrapf(stepErr, "teardown %s", phase)
+			}
+		}
+		c.steps[phase] = nil
+	}
+	return err
+}
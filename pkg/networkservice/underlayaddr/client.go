@@ -0,0 +1,80 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package underlayaddr
+
+import (
+	"context"
+	"net"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+)
+
+type underlayAddrClient struct {
+	vppConn   api.Connection
+	tunnelIPs []net.IP
+	pool      *Pool
+	tenantFn  TenantFunc
+}
+
+// NewClient creates a NetworkServiceClient chain element allocating a per tenant tunnel source address from pool
+// on the uplink having tunnelIP. If pool is nil the chain element does nothing
+func NewClient(vppConn api.Connection, tunnelIP net.IP, pool *Pool, opts ...Option) networkservice.NetworkServiceClient {
+	o := &options{
+		tenantFn: defaultTenant,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return &underlayAddrClient{
+		vppConn:   vppConn,
+		tunnelIPs: []net.IP{tunnelIP, o.ipv6TunnelIP},
+		pool:      pool,
+		tenantFn:  o.tenantFn,
+	}
+}
+
+func (u *underlayAddrClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	allocated, err := allocate(ctx, u.vppConn, u.tunnelIPs, u.pool, u.tenantFn(request.GetConnection()),
+		request.GetConnection().GetMechanism(), metadata.IsClient(u))
+	if err != nil {
+		return nil, err
+	}
+
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil && allocated {
+		closeCtx, cancelClose := postponeCtxFunc()
+		defer cancelClose()
+		free(closeCtx, u.vppConn, u.pool, metadata.IsClient(u))
+	}
+	return conn, err
+}
+
+func (u *underlayAddrClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	rv, err := next.Client(ctx).Close(ctx, conn, opts...)
+	free(ctx, u.vppConn, u.pool, metadata.IsClient(u))
+	return rv, err
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package underlayaddr
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	interfaces "github.com/edwarnicke/govpp/binapi/interface"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/teardown"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/tunnelip"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/types"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/uplink"
+)
+
+// allocate - allocates the address of the IP family of the remote side for the connection (of the family of the
+// primary tunnel IP while the remote side is not known yet). The allocated result reports whether the address has
+// been allocated by this call.
+func allocate(ctx context.Context, vppConn api.Connection, tunnelIPs []net.IP, pool *Pool, tenant string, mechanism *networkservice.Mechanism, isClient bool) (allocated bool, err error) {
+	if pool == nil {
+		return false, nil
+	}
+	remoteIP := tunnelip.RemoteIP(mechanism, isClient)
+	tunnelIP := tunnelip.Select(remoteIP, tunnelIPs...)
+	isIPv6 := tunnelip.IsIPv6(tunnelIP)
+	if remoteIP != nil {
+		isIPv6 = tunnelip.IsIPv6(remoteIP)
+	}
+	if a, ok := load(ctx, isClient); ok {
+		if a.isIPv6 == isIPv6 {
+			return false, nil
+		}
+		// The remote side has changed the IP family (e.g. on heal), the address of its family replaces the old one
+		free(ctx, vppConn, pool, isClient)
+	}
+	addr, err := pool.acquire(tenant, isIPv6, func(addr *net.IPNet) error {
+		return addDel(ctx, vppConn, tunnelIP, addr, true)
+	})
+	if err != nil {
+		return false, err
+	}
+	store(ctx, isClient, &allocation{
+		tenant:   tenant,
+		isIPv6:   isIPv6,
+		tunnelIP: tunnelIP,
+	})
+	tunnelip.Store(ctx, isClient, addr.IP)
+	return true, nil
+}
+
+func free(ctx context.Context, vppConn api.Connection, pool *Pool, isClient bool) {
+	a, ok := loadAndDelete(ctx, isClient)
+	if !ok || pool == nil {
+		return
+	}
+	tunnelip.Delete(ctx, isClient)
+	// The address is released once the tunnels using it are deleted
+	_ = teardown.Do(ctx, teardown.Underlay, func(ctx context.Context) error {
+		if err := pool.release(a.tenant, a.isIPv6, func(addr *net.IPNet) error {
+			return addDel(ctx, vppConn, a.tunnelIP, addr, false)
+		}); err != nil {
+			log.FromContext(ctx).Errorf("unable to delete underlay address: %v", err)
+		}
+		return nil
+	})
+}
+
+func addDel(ctx context.Context, vppConn api.Connection, tunnelIP net.IP, addr *net.IPNet, isAdd bool) error {
+	u, err := uplink.ByIP(ctx, vppConn, tunnelIP)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	if _, err := interfaces.NewServiceClient(vppConn).SwInterfaceAddDelAddress(ctx, &interfaces.SwInterfaceAddDelAddress{
+		SwIfIndex: u.SwIfIndex,
+		IsAdd:     isAdd,
+		Prefix:    types.ToVppAddressWithPrefix(addr),
+	}); err != nil {
+		return errors.Wrapf(err, "failed to set underlay address %s on uplink %q, isAdd: %t", addr, u.Name, isAdd)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", u.SwIfIndex).
+		WithField("prefix", addr).
+		WithField("isAdd", isAdd).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "SwInterfaceAddDelAddress").Debug("completed")
+	return nil
+}
@@ -0,0 +1,26 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package underlayaddr provides chain elements allocating additional (secondary) addresses on the vpp uplink
+// from a provided pool and using them as tunnel source addresses.
+//
+// Connections of the same tenant (by default - the same NetworkService) share the address, connections of
+// different tenants get different addresses. The address is of the IP family of the remote side of the tunnel, so
+// the tenant gets an address per family. The address is removed from the uplink when the last connection of the
+// tenant is closed.
+//
+// Chain elements must be placed before the remote mechanisms in the chain.
+package underlayaddr
@@ -0,0 +1,54 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package underlayaddr
+
+import (
+	"net"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+// TenantFunc - returns the tenant of the connection
+type TenantFunc func(conn *networkservice.Connection) string
+
+type options struct {
+	tenantFn     TenantFunc
+	ipv6TunnelIP net.IP
+}
+
+// Option is an option pattern for underlayaddr client/server
+type Option func(o *options)
+
+// WithTenantFunc - sets the function determining the tenant of the connection. By default the tenant
+// is the NetworkService of the connection.
+func WithTenantFunc(tenantFn TenantFunc) Option {
+	return func(o *options) {
+		o.tenantFn = tenantFn
+	}
+}
+
+// WithIPv6TunnelIP - sets the IPv6 tunnel IP, the IPv6 underlay addresses are set on its uplink for the connections
+// having an IPv6 remote side
+func WithIPv6TunnelIP(ipv6TunnelIP net.IP) Option {
+	return func(o *options) {
+		o.ipv6TunnelIP = ipv6TunnelIP
+	}
+}
+
+func defaultTenant(conn *networkservice.Connection) string {
+	return conn.GetNetworkService()
+}
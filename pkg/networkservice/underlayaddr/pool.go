@@ -0,0 +1,151 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package underlayaddr
+
+import (
+	"net"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/vpperrors"
+)
+
+// poolKey - the tenant address of the IP family, the tenant gets an address per family of its tunnels
+type poolKey struct {
+	tenant string
+	isIPv6 bool
+}
+
+// poolEntry - the address of the tenant. The ready channel is closed once the address is programmed (or deleted after
+// the last release), so the concurrent callers don't use the address before it is set on the uplink.
+type poolEntry struct {
+	addr      *net.IPNet
+	count     uint32
+	ready     chan struct{}
+	releasing bool
+	err       error
+}
+
+// Pool - pool of addresses to be allocated on the uplink. It may be shared between client and server.
+type Pool struct {
+	free    []*net.IPNet
+	entries map[poolKey]*poolEntry
+	mut     sync.Mutex
+}
+
+// NewPool creates a pool of addresses. Every addr is allocated as is, with its prefix length.
+func NewPool(addrs ...*net.IPNet) *Pool {
+	return &Pool{
+		free:    addrs,
+		entries: make(map[poolKey]*poolEntry),
+	}
+}
+
+// acquire - returns the address of the tenant of the IP family, allocating a free one if needed. The add is called to
+// program the allocated address, the other callers wait for it and get its error. If the add fails, the address is
+// returned to the free list.
+func (p *Pool) acquire(tenant string, isIPv6 bool, add func(addr *net.IPNet) error) (*net.IPNet, error) {
+	key := poolKey{tenant: tenant, isIPv6: isIPv6}
+	for {
+		p.mut.Lock()
+		if entry, ok := p.entries[key]; ok {
+			ready := entry.ready
+			if entry.releasing {
+				// The address is being deleted by the last release, the next attempt allocates it again
+				p.mut.Unlock()
+				<-ready
+				continue
+			}
+			entry.count++
+			p.mut.Unlock()
+			<-ready
+			if entry.err != nil {
+				return nil, entry.err
+			}
+			return entry.addr, nil
+		}
+		entry := p.allocate(key)
+		p.mut.Unlock()
+		if entry == nil {
+			return nil, errors.Wrapf(vpperrors.ErrResourceExhausted, "no free underlay addresses left for tenant %q, isIPv6: %t", tenant, isIPv6)
+		}
+
+		err := add(entry.addr)
+
+		p.mut.Lock()
+		if err != nil {
+			entry.err = err
+			delete(p.entries, key)
+			p.free = append(p.free, entry.addr)
+		}
+		close(entry.ready)
+		p.mut.Unlock()
+		if err != nil {
+			return nil, err
+		}
+		return entry.addr, nil
+	}
+}
+
+// allocate - moves the free address of the IP family to the new entry of the key, p.mut must be held
+func (p *Pool) allocate(key poolKey) *poolEntry {
+	for i, addr := range p.free {
+		if (addr.IP.To4() == nil) != key.isIPv6 {
+			continue
+		}
+		p.free = append(p.free[:i:i], p.free[i+1:]...)
+		entry := &poolEntry{
+			addr:  addr,
+			count: 1,
+			ready: make(chan struct{}),
+		}
+		p.entries[key] = entry
+		return entry
+	}
+	return nil
+}
+
+// release - releases the address of the tenant of the IP family. The del is called to delete the address released by
+// the last caller, the address is returned to the free list after it.
+func (p *Pool) release(tenant string, isIPv6 bool, del func(addr *net.IPNet) error) error {
+	key := poolKey{tenant: tenant, isIPv6: isIPv6}
+
+	p.mut.Lock()
+	entry, ok := p.entries[key]
+	if !ok || entry.releasing {
+		p.mut.Unlock()
+		return nil
+	}
+	entry.count--
+	if entry.count > 0 {
+		p.mut.Unlock()
+		return nil
+	}
+	entry.releasing = true
+	entry.ready = make(chan struct{})
+	p.mut.Unlock()
+
+	err := del(entry.addr)
+
+	p.mut.Lock()
+	delete(p.entries, key)
+	p.free = append(p.free, entry.addr)
+	close(entry.ready)
+	p.mut.Unlock()
+	return err
+}
@@ -0,0 +1,176 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package underlayaddr
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/vpperrors"
+)
+
+func ipNet(s string) *net.IPNet {
+	ip, ipNet, _ := net.ParseCIDR(s)
+	ipNet.IP = ip
+	return ipNet
+}
+
+// programmed records the add and del calls of the pool
+type programmed struct {
+	added   []string
+	deleted []string
+}
+
+func (p *programmed) add(addr *net.IPNet) error {
+	p.added = append(p.added, addr.String())
+	return nil
+}
+
+func (p *programmed) del(addr *net.IPNet) error {
+	p.deleted = append(p.deleted, addr.String())
+	return nil
+}
+
+func Test_Pool_AddressPerTenantAndFamily(t *testing.T) {
+	pool := NewPool(ipNet("10.0.0.1/24"), ipNet("fd00::1/64"), ipNet("10.0.0.2/24"))
+	vpp := new(programmed)
+
+	addr, err := pool.acquire("tenant-1", false, vpp.add)
+	require.NoError(t, err)
+	require.Equal(t, "10.0.0.1/24", addr.String())
+
+	// The tenant shares its address of the family between the connections, it is programmed once
+	addr, err = pool.acquire("tenant-1", false, vpp.add)
+	require.NoError(t, err)
+	require.Equal(t, "10.0.0.1/24", addr.String())
+	require.Equal(t, []string{"10.0.0.1/24"}, vpp.added)
+
+	addr, err = pool.acquire("tenant-1", true, vpp.add)
+	require.NoError(t, err)
+	require.Equal(t, "fd00::1/64", addr.String())
+
+	addr, err = pool.acquire("tenant-2", false, vpp.add)
+	require.NoError(t, err)
+	require.Equal(t, "10.0.0.2/24", addr.String())
+	require.Equal(t, []string{"10.0.0.1/24", "fd00::1/64", "10.0.0.2/24"}, vpp.added)
+
+	_, err = pool.acquire("tenant-3", false, vpp.add)
+	require.True(t, errors.Is(err, vpperrors.ErrResourceExhausted))
+}
+
+func Test_Pool_ReleasesAddressOfLastConnection(t *testing.T) {
+	pool := NewPool(ipNet("10.0.0.1/24"))
+	vpp := new(programmed)
+
+	_, err := pool.acquire("tenant-1", false, vpp.add)
+	require.NoError(t, err)
+	_, err = pool.acquire("tenant-1", false, vpp.add)
+	require.NoError(t, err)
+
+	require.NoError(t, pool.release("tenant-1", false, vpp.del))
+	require.Empty(t, vpp.deleted)
+	_, err = pool.acquire("tenant-2", false, vpp.add)
+	require.Error(t, err)
+
+	require.NoError(t, pool.release("tenant-1", false, vpp.del))
+	require.Equal(t, []string{"10.0.0.1/24"}, vpp.deleted)
+
+	addr, err := pool.acquire("tenant-2", false, vpp.add)
+	require.NoError(t, err)
+	require.Equal(t, "10.0.0.1/24", addr.String())
+
+	// Releasing the unknown tenant is a no-op
+	require.NoError(t, pool.release("tenant-3", false, vpp.del))
+	require.Equal(t, []string{"10.0.0.1/24"}, vpp.deleted)
+}
+
+func Test_Pool_WaitsForAddressBeingProgrammed(t *testing.T) {
+	pool := NewPool(ipNet("10.0.0.1/24"))
+
+	adding := make(chan struct{})
+	addErr := make(chan error)
+	firstErr := make(chan error)
+	go func() {
+		_, err := pool.acquire("tenant-1", false, func(*net.IPNet) error {
+			close(adding)
+			return <-addErr
+		})
+		firstErr <- err
+	}()
+	<-adding
+
+	secondErr := make(chan error)
+	go func() {
+		_, err := pool.acquire("tenant-1", false, func(*net.IPNet) error {
+			return errors.New("the address is programmed twice")
+		})
+		secondErr <- err
+	}()
+	select {
+	case err := <-secondErr:
+		require.FailNow(t, "the address is used before it is programmed", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// The failed add is reported to both callers and the address is returned to the free list
+	addErr <- errors.New("add failed")
+	require.EqualError(t, <-firstErr, "add failed")
+	require.EqualError(t, <-secondErr, "add failed")
+
+	addr, err := pool.acquire("tenant-2", false, new(programmed).add)
+	require.NoError(t, err)
+	require.Equal(t, "10.0.0.1/24", addr.String())
+}
+
+func Test_Pool_WaitsForAddressBeingDeleted(t *testing.T) {
+	pool := NewPool(ipNet("10.0.0.1/24"))
+	vpp := new(programmed)
+
+	_, err := pool.acquire("tenant-1", false, vpp.add)
+	require.NoError(t, err)
+
+	deleting := make(chan struct{})
+	delDone := make(chan struct{})
+	go func() {
+		_ = pool.release("tenant-1", false, func(*net.IPNet) error {
+			close(deleting)
+			<-delDone
+			return nil
+		})
+	}()
+	<-deleting
+
+	acquired := make(chan error)
+	go func() {
+		_, err := pool.acquire("tenant-1", false, vpp.add)
+		acquired <- err
+	}()
+	select {
+	case <-acquired:
+		require.FailNow(t, "the address is acquired while it is being deleted")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// The address is programmed again after the delete
+	close(delDone)
+	require.NoError(t, <-acquired)
+	require.Equal(t, []string{"10.0.0.1/24", "10.0.0.1/24"}, vpp.added)
+}
@@ -27,6 +27,8 @@ import (
 	"google.golang.org/grpc"
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/ipsec"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/wireguard"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
@@ -41,6 +43,8 @@ type upClient struct {
 	ctx         context.Context
 	vppConn     Connection
 	loadIfIndex ifIndexFunc
+	policies    map[string]Policy
+	initFuncs   []InitFunc
 
 	inited    uint32
 	initMutex sync.Mutex
@@ -50,19 +54,22 @@ type upClient struct {
 func NewClient(ctx context.Context, vppConn Connection, opts ...Option) networkservice.NetworkServiceClient {
 	o := &options{
 		loadIfIndex: ifindex.Load,
+		initFuncs:   []InitFunc{EnableInterfaceEvents},
 	}
 	for _, opt := range opts {
 		opt(o)
 	}
 
 	return chain.NewNetworkServiceClient(
-		peerup.NewClient(ctx, vppConn),
+		peerup.NewClient(ctx, vppConn, peerup.WithTimeout(o.policies[wireguard.MECHANISM].Timeout)),
 		&upClient{
 			ctx:         ctx,
 			vppConn:     vppConn,
 			loadIfIndex: o.loadIfIndex,
+			policies:    o.policies,
+			initFuncs:   o.initFuncs,
 		},
-		ipsecup.NewClient(ctx, vppConn),
+		ipsecup.NewClient(ctx, vppConn, ipsecup.WithTimeout(o.policies[ipsec.MECHANISM].Timeout)),
 	)
 }
 
@@ -78,7 +85,7 @@ func (u *upClient) Request(ctx context.Context, request *networkservice.NetworkS
 		return nil, err
 	}
 
-	if err := up(ctx, u.vppConn, u.loadIfIndex, metadata.IsClient(u)); err != nil {
+	if err := up(ctx, u.vppConn, u.loadIfIndex, u.policies[conn.GetMechanism().GetType()], metadata.IsClient(u)); err != nil {
 		closeCtx, cancelClose := postponeCtxFunc()
 		defer cancelClose()
 
@@ -106,9 +113,11 @@ func (u *upClient) init(ctx context.Context) error {
 		return nil
 	}
 
-	err := initFunc(ctx, u.vppConn)
-	if err == nil {
-		atomic.StoreUint32(&u.inited, 1)
+	for _, initFunc := range u.initFuncs {
+		if err := initFunc(ctx, u.vppConn); err != nil {
+			return err
+		}
 	}
-	return err
+	atomic.StoreUint32(&u.inited, 1)
+	return nil
 }
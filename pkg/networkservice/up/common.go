@@ -26,6 +26,9 @@ import (
 	"github.com/edwarnicke/govpp/binapi/interface_types"
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
 	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/dryrun"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/keymutex"
 )
 
 // Connection - simply combines tha api.Connection and api.ChannelProvider interfaces
@@ -34,7 +37,7 @@ type Connection interface {
 	api.ChannelProvider
 }
 
-func up(ctx context.Context, vppConn Connection, loadIfIndex ifIndexFunc, isClient bool) error {
+func up(ctx context.Context, vppConn Connection, loadIfIndex ifIndexFunc, policy Policy, isClient bool) error {
 	swIfIndex, ok := loadIfIndex(ctx, isClient)
 	if !ok {
 		return nil
@@ -46,11 +49,15 @@ func up(ctx context.Context, vppConn Connection, loadIfIndex ifIndexFunc, isClie
 	}
 	defer apiChannel.Close()
 
+	// The lock is not held while waiting for the link, the peer may take the whole Request to bring it up
 	now := time.Now()
-	if _, err := interfaces.NewServiceClient(vppConn).SwInterfaceSetFlags(ctx, &interfaces.SwInterfaceSetFlags{
+	lockCtx, unlock := keymutex.LockInterface(ctx, swIfIndex)
+	_, err = interfaces.NewServiceClient(vppConn).SwInterfaceSetFlags(lockCtx, &interfaces.SwInterfaceSetFlags{
 		SwIfIndex: swIfIndex,
 		Flags:     interface_types.IF_STATUS_API_FLAG_ADMIN_UP,
-	}); err != nil {
+	})
+	unlock()
+	if err != nil {
 		return errors.WithStack(err)
 	}
 	log.FromContext(ctx).
@@ -58,15 +65,25 @@ func up(ctx context.Context, vppConn Connection, loadIfIndex ifIndexFunc, isClie
 		WithField("duration", time.Since(now)).
 		WithField("vppapi", "SwInterfaceSetFlags").Debug("completed")
 
-	if waitTillUp, ok := Load(ctx, isClient); ok && waitTillUp {
-		if err := waitForUpLinkUp(ctx, vppConn, apiChannel, swIfIndex); err != nil {
-			return err
-		}
+	if waitTillUp, _ := Load(ctx, isClient); !waitTillUp && !policy.Wait {
+		return nil
+	}
+	waitCtx := ctx
+	if policy.Timeout > 0 {
+		var cancelWait context.CancelFunc
+		waitCtx, cancelWait = context.WithTimeout(ctx, policy.Timeout)
+		defer cancelWait()
+	}
+	if err := waitForUpLinkUp(waitCtx, vppConn, apiChannel, swIfIndex); err != nil {
+		return errors.Wrapf(err, "interface %d is not up", swIfIndex)
 	}
 	return nil
 }
 
 func waitForUpLinkUp(ctx context.Context, vppConn api.Connection, apiChannel api.Channel, swIfIndex interface_types.InterfaceIndex) error {
+	if dryrun.IsDryRun(apiChannel) {
+		return nil
+	}
 	notifCh := make(chan api.Message, 256)
 	subscription, err := apiChannel.SubscribeNotification(notifCh, &interfaces.SwInterfaceEvent{})
 	if err != nil {
@@ -118,7 +135,9 @@ func waitForUpLinkUp(ctx context.Context, vppConn api.Connection, apiChannel api
 	}
 }
 
-func initFunc(ctx context.Context, vppConn api.Connection) error {
+// EnableInterfaceEvents enables the vpp interface events the elements wait for the link-up with, it is the default
+// init function of the elements
+func EnableInterfaceEvents(ctx context.Context, vppConn api.Connection) error {
 	now := time.Now()
 	_, err := interfaces.NewServiceClient(vppConn).WantInterfaceEvents(ctx, &interfaces.WantInterfaceEvents{
 		EnableDisable: 1,
@@ -126,7 +145,8 @@ func initFunc(ctx context.Context, vppConn api.Connection) error {
 	})
 	// If we've already registered, then we are done here.  api.INVALID_REGISTRATION  is returned when we attempt to
 	// register for the second time.
-	if vppAPIError, ok := err.(api.VPPApiError); ok && vppAPIError == api.INVALID_REGISTRATION {
+	var vppAPIError api.VPPApiError
+	if errors.As(err, &vppAPIError) && vppAPIError == api.INVALID_REGISTRATION {
 		return nil
 	}
 	if err != nil {
@@ -19,6 +19,7 @@ package ipsecup
 
 import (
 	"context"
+	"time"
 
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
@@ -35,13 +36,20 @@ import (
 type ipsecUpClient struct {
 	ctx     context.Context
 	vppConn Connection
+	timeout time.Duration
 }
 
 // NewClient provides a NetworkServiceClient chain element that waits the 'up' of the IPSec interface
-func NewClient(ctx context.Context, vppConn Connection) networkservice.NetworkServiceClient {
+func NewClient(ctx context.Context, vppConn Connection, opts ...Option) networkservice.NetworkServiceClient {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
 	return &ipsecUpClient{
 		ctx:     ctx,
 		vppConn: vppConn,
+		timeout: o.timeout,
 	}
 }
 
@@ -54,7 +62,10 @@ func (u *ipsecUpClient) Request(ctx context.Context, request *networkservice.Net
 	}
 
 	if mechanism := ipsec.ToMechanism(conn.GetMechanism()); mechanism != nil {
-		if err := waitForUpLinkUp(ctx, u.vppConn, metadata.IsClient(u)); err != nil {
+		waitCtx, cancelWait := u.waitContext(ctx)
+		err := waitForUpLinkUp(waitCtx, u.vppConn, metadata.IsClient(u))
+		cancelWait()
+		if err != nil {
 			closeCtx, cancelClose := postponeCtxFunc()
 			defer cancelClose()
 
@@ -69,6 +80,13 @@ func (u *ipsecUpClient) Request(ctx context.Context, request *networkservice.Net
 	return conn, nil
 }
 
+func (u *ipsecUpClient) waitContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if u.timeout > 0 {
+		return context.WithTimeout(ctx, u.timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
 func (u *ipsecUpClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
 	return next.Client(ctx).Close(ctx, conn, opts...)
 }
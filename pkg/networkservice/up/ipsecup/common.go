@@ -27,6 +27,7 @@ import (
 
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
 
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/dryrun"
 	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
 )
 
@@ -46,6 +47,9 @@ func waitForUpLinkUp(ctx context.Context, vppConn Connection, isClient bool) err
 		return errors.WithStack(err)
 	}
 	defer apiChannel.Close()
+	if dryrun.IsDryRun(apiChannel) {
+		return nil
+	}
 	notifCh := make(chan api.Message, 256)
 	subscription, err := apiChannel.SubscribeNotification(notifCh, &interfaces.SwInterfaceEvent{})
 	if err != nil {
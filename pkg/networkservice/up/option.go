@@ -18,12 +18,30 @@ package up
 
 import (
 	"context"
+	"time"
 
+	"git.fd.io/govpp.git/api"
 	"github.com/edwarnicke/govpp/binapi/interface_types"
 )
 
 type options struct {
 	loadIfIndex ifIndexFunc
+	policies    map[string]Policy
+	initFuncs   []InitFunc
+}
+
+// InitFunc - one time vpp setup done by the element before handling the first Request
+type InitFunc func(ctx context.Context, vppConn api.Connection) error
+
+// Policy - the way the chain element waits for the interface of the mechanism type to be up:
+//   - kernel, memif, vxlan, ... - for the link-up (for memif the link is up when the memif is connected)
+//   - wireguard - for the handshake with the peer (client only)
+//   - ipsec - for the link-up, the ipsec interface is up when the SAs are installed (client only)
+type Policy struct {
+	// Wait - wait for the link-up even if the mechanism chain element hasn't asked for it (e.g. the kernel taps)
+	Wait bool
+	// Timeout - max time to wait, 0 - till the Request context is done
+	Timeout time.Duration
 }
 
 // Option is an option pattern for upClient/Server
@@ -38,3 +56,28 @@ func WithLoadSwIfIndex(f ifIndexFunc) Option {
 		o.loadIfIndex = f
 	}
 }
+
+// WithPolicy - sets the wait policy for the interfaces of the mechanism type
+func WithPolicy(mechanismType string, policy Policy) Option {
+	return func(o *options) {
+		if o.policies == nil {
+			o.policies = make(map[string]Policy)
+		}
+		o.policies[mechanismType] = policy
+	}
+}
+
+// WithInitFunc - replaces the init functions of the element, EnableInterfaceEvents has to be kept for the waits for the
+// link-up
+func WithInitFunc(initFuncs ...InitFunc) Option {
+	return func(o *options) {
+		o.initFuncs = initFuncs
+	}
+}
+
+// WithAdditionalInitFunc - adds the init functions called after the already set ones (EnableInterfaceEvents by default)
+func WithAdditionalInitFunc(initFuncs ...InitFunc) Option {
+	return func(o *options) {
+		o.initFuncs = append(o.initFuncs, initFuncs...)
+	}
+}
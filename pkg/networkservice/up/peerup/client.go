@@ -14,11 +14,14 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Package peerup provides chain elements to 'up' peer
+// Package peerup provides chain elements to 'up' peer and the API to query and watch the wireguard peer state.
+// The connection is returned to the previous elements only after the first handshake with the peer completes, the
+// time since the handshake is then tracked for monitoring.
 package peerup
 
 import (
 	"context"
+	"time"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/pkg/errors"
@@ -28,19 +31,29 @@ import (
 	wireguardMech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/wireguard"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
 	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/wireguard/peer"
 )
 
 type peerupClient struct {
 	ctx     context.Context
 	vppConn Connection
+	timeout time.Duration
 }
 
 // NewClient provides a NetworkServiceClient chain elements that 'up's the peer
-func NewClient(ctx context.Context, vppConn Connection) networkservice.NetworkServiceClient {
+func NewClient(ctx context.Context, vppConn Connection, opts ...Option) networkservice.NetworkServiceClient {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
 	return &peerupClient{
 		ctx:     ctx,
 		vppConn: vppConn,
+		timeout: o.timeout,
 	}
 }
 
@@ -53,7 +66,10 @@ func (u *peerupClient) Request(ctx context.Context, request *networkservice.Netw
 	}
 
 	if mechanism := wireguardMech.ToMechanism(conn.GetMechanism()); mechanism != nil {
-		if err := waitForPeerUp(ctx, u.vppConn, mechanism.DstPublicKey(), metadata.IsClient(u)); err != nil {
+		waitCtx, cancelWait := u.waitContext(ctx)
+		err := waitForPeerUp(waitCtx, u.vppConn, mechanism.DstPublicKey(), metadata.IsClient(u))
+		cancelWait()
+		if err != nil {
 			closeCtx, cancelClose := postponeCtxFunc()
 			defer cancelClose()
 
@@ -63,11 +79,49 @@ func (u *peerupClient) Request(ctx context.Context, request *networkservice.Netw
 
 			return nil, err
 		}
+		u.watch(ctx, mechanism.DstPublicKey())
 	}
 
 	return conn, nil
 }
 
+// watch starts watching the handshakes of the connection peer (if not yet watched)
+func (u *peerupClient) watch(ctx context.Context, pubKey string) {
+	peerIndex, ok := peer.Load(ctx, metadata.IsClient(u), pubKey)
+	if !ok {
+		return
+	}
+	if w, ok := loadAndDeleteWatch(ctx, metadata.IsClient(u)); ok {
+		if w.peerIndex == peerIndex {
+			storeWatch(ctx, metadata.IsClient(u), w)
+			return
+		}
+		// The peer has been replaced
+		w.cancel()
+	}
+
+	watchCtx, cancel := context.WithCancel(u.ctx)
+	if err := watchHandshakes(watchCtx, u.vppConn, peerIndex); err != nil {
+		cancel()
+		log.FromContext(ctx).WithField("peerIndex", peerIndex).Warnf("failed to watch the peer handshakes: %v", err)
+		return
+	}
+	storeWatch(ctx, metadata.IsClient(u), &watch{
+		peerIndex: peerIndex,
+		cancel:    cancel,
+	})
+}
+
+func (u *peerupClient) waitContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if u.timeout > 0 {
+		return context.WithTimeout(ctx, u.timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
 func (u *peerupClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	if w, ok := loadAndDeleteWatch(ctx, metadata.IsClient(u)); ok {
+		w.cancel()
+	}
 	return next.Client(ctx).Close(ctx, conn, opts...)
 }
@@ -29,6 +29,7 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
 
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/wireguard/peer"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/dryrun"
 )
 
 // Connection - simply combines tha api.Connection and api.ChannelProvider interfaces
@@ -47,6 +48,9 @@ func waitForPeerUp(ctx context.Context, vppConn Connection, pubKey string, isCli
 	if err != nil {
 		return errors.WithStack(err)
 	}
+	if dryrun.IsDryRun(apiChannel) {
+		return nil
+	}
 
 	notifCh := make(chan api.Message, 256)
 	subscription, err := apiChannel.SubscribeNotification(notifCh, &wireguard.WireguardPeerEvent{})
@@ -55,31 +59,14 @@ func waitForPeerUp(ctx context.Context, vppConn Connection, pubKey string, isCli
 	}
 	defer func() { _ = subscription.Unsubscribe() }()
 
-	now := time.Now()
-
-	dp, err := wireguard.NewServiceClient(vppConn).WireguardPeersDump(ctx, &wireguard.WireguardPeersDump{
-		PeerIndex: peerIndex,
-	})
-	if err != nil {
-		return errors.WithStack(err)
-	}
-	defer func() { _ = dp.Close() }()
-
-	details, err := dp.Recv()
+	state, err := Query(ctx, vppConn, peerIndex)
 	if err != nil {
-		return errors.Wrapf(err, "error retrieving WireguardPeersDetails")
+		return err
 	}
-	log.FromContext(ctx).
-		WithField("peerIndex", peerIndex).
-		WithField("details.Flags", details.Peer.Flags).
-		WithField("duration", time.Since(now)).
-		WithField("vppapi", "WireguardPeersDump").Debug("completed")
-
-	isEstablished := details.Peer.Flags & wireguard.WIREGUARD_PEER_ESTABLISHED
-	if isEstablished != 0 {
+	if state.Established {
 		return nil
 	}
-	now = time.Now()
+	now := time.Now()
 	for {
 		select {
 		case <-ctx.Done():
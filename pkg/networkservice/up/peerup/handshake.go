@@ -0,0 +1,103 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package peerup
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/metric/instrument"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// handshakeAgeMetric - the time since the handshake established the wireguard peer per peer index
+const handshakeAgeMetric = "wireguard_handshake_age_seconds"
+
+var handshakes struct {
+	once        sync.Once
+	mu          sync.Mutex
+	established map[uint32]time.Time
+}
+
+// HandshakeAge returns the time since the handshake established the wireguard peer of a connection 'up'ed by the
+// client. vpp reports only the changes of the peer state, so the rekeying handshakes of the established peer are not
+// seen. The ok result is false if the peer is not established or is not watched.
+func HandshakeAge(peerIndex uint32) (age time.Duration, ok bool) {
+	handshakes.mu.Lock()
+	defer handshakes.mu.Unlock()
+	at, ok := handshakes.established[peerIndex]
+	if !ok {
+		return 0, false
+	}
+	return time.Since(at), true
+}
+
+func initHandshakes(ctx context.Context) {
+	handshakes.once.Do(func() {
+		handshakes.established = make(map[uint32]time.Time)
+		meter := global.Meter("")
+		gauge, err := meter.AsyncFloat64().Gauge(handshakeAgeMetric)
+		if err != nil {
+			log.FromContext(ctx).Warnf("failed to create %s gauge: %v", handshakeAgeMetric, err)
+			return
+		}
+		if err = meter.RegisterCallback([]instrument.Asynchronous{gauge}, func(ctx context.Context) {
+			handshakes.mu.Lock()
+			defer handshakes.mu.Unlock()
+			for peerIndex, at := range handshakes.established {
+				gauge.Observe(ctx, time.Since(at).Seconds(),
+					attribute.String("peer_index", strconv.FormatUint(uint64(peerIndex), 10)))
+			}
+		}); err != nil {
+			log.FromContext(ctx).Warnf("failed to register %s callback: %v", handshakeAgeMetric, err)
+		}
+	})
+}
+
+func setHandshake(peerIndex uint32, at time.Time) {
+	handshakes.mu.Lock()
+	defer handshakes.mu.Unlock()
+	if at.IsZero() {
+		delete(handshakes.established, peerIndex)
+		return
+	}
+	handshakes.established[peerIndex] = at
+}
+
+// watchHandshakes keeps the handshake time of the peer up to date till ctx is done
+func watchHandshakes(ctx context.Context, vppConn Connection, peerIndex uint32) error {
+	initHandshakes(ctx)
+	states, err := Watch(ctx, vppConn, peerIndex)
+	if err != nil {
+		return err
+	}
+	go func() {
+		defer setHandshake(peerIndex, time.Time{})
+		for state := range states {
+			if state.Dead {
+				log.FromContext(ctx).WithField("peerIndex", peerIndex).Warn("wireguard peer is dead")
+			}
+			setHandshake(peerIndex, state.EstablishedAt)
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package peerup
+
+import (
+	"context"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+type watchKey struct{}
+
+// watch - the handshakes watch of the connection peer
+type watch struct {
+	peerIndex uint32
+	cancel    context.CancelFunc
+}
+
+func storeWatch(ctx context.Context, isClient bool, w *watch) {
+	metadata.Map(ctx, isClient).Store(watchKey{}, w)
+}
+
+func loadAndDeleteWatch(ctx context.Context, isClient bool) (w *watch, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).LoadAndDelete(watchKey{})
+	if !ok {
+		return
+	}
+	w, ok = rawValue.(*watch)
+	return w, ok
+}
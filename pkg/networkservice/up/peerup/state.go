@@ -0,0 +1,169 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package peerup
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+	"github.com/edwarnicke/govpp/binapi/wireguard"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	wireguardMech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/wireguard"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/wireguard/peer"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/dryrun"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/types"
+)
+
+// State - state of the wireguard peer as reported by vpp
+type State struct {
+	PeerIndex uint32
+	SwIfIndex interface_types.InterfaceIndex
+	Endpoint  net.IP
+	Port      uint16
+	// Established - the handshake with the peer has been completed
+	Established bool
+	// Dead - the peer has not responded to the handshakes
+	Dead bool
+	// Time - time the state was retrieved from vpp
+	Time time.Time
+	// EstablishedAt - time the peer has been seen established first by the Watch, zero if not established or the
+	// state is returned by the Query
+	EstablishedAt time.Time
+}
+
+// PeerIndex returns the index of the wireguard peer of the conn, stored in per Connection.Id metadata.
+// The ok result is false if conn has no wireguard mechanism or the peer has not been created.
+func PeerIndex(ctx context.Context, conn *networkservice.Connection, isClient bool) (peerIndex uint32, ok bool) {
+	mechanism := wireguardMech.ToMechanism(conn.GetMechanism())
+	if mechanism == nil {
+		return 0, false
+	}
+	// The peer is stored by the public key of the remote side
+	if isClient {
+		return peer.Load(ctx, isClient, mechanism.DstPublicKey())
+	}
+	return peer.Load(ctx, isClient, mechanism.SrcPublicKey())
+}
+
+// Query returns the current state of the wireguard peer
+func Query(ctx context.Context, vppConn api.Connection, peerIndex uint32) (*State, error) {
+	now := time.Now()
+	dp, err := wireguard.NewServiceClient(vppConn).WireguardPeersDump(ctx, &wireguard.WireguardPeersDump{
+		PeerIndex: peerIndex,
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer func() { _ = dp.Close() }()
+
+	details, err := dp.Recv()
+	if err != nil {
+		return nil, errors.Wrapf(err, "error retrieving WireguardPeersDetails")
+	}
+	log.FromContext(ctx).
+		WithField("peerIndex", peerIndex).
+		WithField("details.Flags", details.Peer.Flags).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "WireguardPeersDump").Debug("completed")
+
+	return &State{
+		PeerIndex:   details.Peer.PeerIndex,
+		SwIfIndex:   details.Peer.SwIfIndex,
+		Endpoint:    types.FromVppAddress(details.Peer.Endpoint),
+		Port:        details.Peer.Port,
+		Established: details.Peer.Flags&wireguard.WIREGUARD_PEER_ESTABLISHED != 0,
+		Dead:        details.Peer.Flags&wireguard.WIREGUARD_PEER_STATUS_DEAD != 0,
+		Time:        time.Now(),
+	}, nil
+}
+
+// Watch returns a channel receiving the current state of the wireguard peer and then the state on every peer event
+// reported by vpp. The channel is closed when ctx is done.
+func Watch(ctx context.Context, vppConn Connection, peerIndex uint32) (<-chan *State, error) {
+	apiChannel, err := getAPIChannel(ctx, vppConn, peerIndex)
+	if err != nil {
+		return nil, err
+	}
+	if dryrun.IsDryRun(apiChannel) {
+		// There is no peer state to report, the channel is closed when ctx is done
+		stateCh := make(chan *State)
+		go func() {
+			<-ctx.Done()
+			close(stateCh)
+		}()
+		return stateCh, nil
+	}
+
+	notifCh := make(chan api.Message, 256)
+	subscription, err := apiChannel.SubscribeNotification(notifCh, &wireguard.WireguardPeerEvent{})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	state, err := Query(ctx, vppConn, peerIndex)
+	if err != nil {
+		_ = subscription.Unsubscribe()
+		return nil, err
+	}
+	if state.Established {
+		state.EstablishedAt = state.Time
+	}
+
+	stateCh := make(chan *State, 1)
+	stateCh <- state
+	go func() {
+		defer close(stateCh)
+		defer func() { _ = subscription.Unsubscribe() }()
+
+		establishedAt := state.EstablishedAt
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case rawMsg := <-notifCh:
+				if msg, ok := rawMsg.(*wireguard.WireguardPeerEvent); !ok || msg.PeerIndex != peerIndex {
+					continue
+				}
+				state, err := Query(ctx, vppConn, peerIndex)
+				if err != nil {
+					log.FromContext(ctx).WithField("peerIndex", peerIndex).Warnf("failed to query the peer state: %v", err)
+					continue
+				}
+				switch {
+				case !state.Established:
+					establishedAt = time.Time{}
+				case establishedAt.IsZero():
+					establishedAt = state.Time
+				}
+				state.EstablishedAt = establishedAt
+				select {
+				case <-ctx.Done():
+					return
+				case stateCh <- state:
+				}
+			}
+		}
+	}()
+	return stateCh, nil
+}
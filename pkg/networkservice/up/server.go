@@ -36,6 +36,8 @@ type upServer struct {
 	ctx         context.Context
 	vppConn     Connection
 	loadIfIndex ifIndexFunc
+	policies    map[string]Policy
+	initFuncs   []InitFunc
 
 	inited    uint32
 	initMutex sync.Mutex
@@ -45,6 +47,7 @@ type upServer struct {
 func NewServer(ctx context.Context, vppConn Connection, opts ...Option) networkservice.NetworkServiceServer {
 	o := &options{
 		loadIfIndex: ifindex.Load,
+		initFuncs:   []InitFunc{EnableInterfaceEvents},
 	}
 	for _, opt := range opts {
 		opt(o)
@@ -54,6 +57,8 @@ func NewServer(ctx context.Context, vppConn Connection, opts ...Option) networks
 		ctx:         ctx,
 		vppConn:     vppConn,
 		loadIfIndex: o.loadIfIndex,
+		policies:    o.policies,
+		initFuncs:   o.initFuncs,
 	}
 }
 
@@ -69,14 +74,15 @@ func (u *upServer) Request(ctx context.Context, request *networkservice.NetworkS
 		return nil, err
 	}
 
-	if err := up(ctx, u.vppConn, u.loadIfIndex, true); err != nil {
+	// The mechanism of the client side is not known here, so only the waits asked by the mechanism chain elements are done
+	if err := up(ctx, u.vppConn, u.loadIfIndex, Policy{}, true); err != nil {
 		if closeErr := u.closeOnFailure(postponeCtxFunc, conn); closeErr != nil {
 			err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
 		}
 		return nil, err
 	}
 
-	if err := up(ctx, u.vppConn, u.loadIfIndex, false); err != nil {
+	if err := up(ctx, u.vppConn, u.loadIfIndex, u.policies[conn.GetMechanism().GetType()], false); err != nil {
 		if closeErr := u.closeOnFailure(postponeCtxFunc, conn); closeErr != nil {
 			err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
 		}
@@ -109,9 +115,11 @@ func (u *upServer) init(ctx context.Context) error {
 		return nil
 	}
 
-	err := initFunc(ctx, u.vppConn)
-	if err == nil {
-		atomic.StoreUint32(&u.inited, 1)
+	for _, initFunc := range u.initFuncs {
+		if err := initFunc(ctx, u.vppConn); err != nil {
+			return err
+		}
 	}
-	return err
+	atomic.StoreUint32(&u.inited, 1)
+	return nil
 }
@@ -38,6 +38,7 @@ type vrfClient struct {
 	vppConn api.Connection
 	loadFn  ifindex.LoadInterfaceFn
 	m       *Map
+	leaks   RouteLeaks
 }
 
 // NewClient creates a NetworkServiceClient chain element to create the ip table in vpp
@@ -54,6 +55,7 @@ func NewClient(vppConn api.Connection, opts ...Option) networkservice.NetworkSer
 	return &vrfClient{
 		vppConn: vppConn,
 		m:       o.m,
+		leaks:   o.leaks,
 		loadFn:  o.loadFn,
 	}
 }
@@ -68,7 +70,7 @@ func (v *vrfClient) Request(ctx context.Context, request *networkservice.Network
 			t = v.m.ipv6
 		}
 		if _, ok := Load(ctx, metadata.IsClient(v), isIPv6); !ok {
-			vrfID, err := create(ctx, v.vppConn, networkService, t, isIPv6)
+			vrfID, err := create(ctx, v.vppConn, networkService, t, isIPv6, v.leaks.get(networkService))
 			if err != nil {
 				return nil, err
 			}
@@ -29,10 +29,11 @@ import (
 
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
 
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/teardown"
 	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
 )
 
-func create(ctx context.Context, vppConn api.Connection, networkService string, t *vrfMap, isIPv6 bool) (vtfID uint32, err error) {
+func create(ctx context.Context, vppConn api.Connection, networkService string, t *vrfMap, isIPv6 bool, leak *RouteLeak) (vtfID uint32, err error) {
 	t.mut.Lock()
 	defer t.mut.Unlock()
 
@@ -42,9 +43,15 @@ func create(ctx context.Context, vppConn api.Connection, networkService string,
 		if err != nil {
 			return vrfID, err
 		}
+		if err = addDelLeak(ctx, vppConn, leak, vrfID, isIPv6, true); err != nil {
+			_ = addDelLeak(ctx, vppConn, leak, vrfID, isIPv6, false)
+			_ = delVPP(ctx, vppConn, vrfID, isIPv6)
+			return vrfID, err
+		}
 		info = &vrfInfo{
 			id:       vrfID,
 			attached: make(map[interface_types.InterfaceIndex]struct{}),
+			leak:     leak,
 		}
 		t.entries[networkService] = info
 	}
@@ -111,24 +118,34 @@ func attach(ctx context.Context, vppConn api.Connection, networkService string,
 }
 
 func del(ctx context.Context, vppConn api.Connection, networkService string, t *vrfMap, isIPv6, isClient bool) {
-	if vrfID, ok := Load(ctx, isClient, isIPv6); ok {
+	vrfID, ok := Load(ctx, isClient, isIPv6)
+	if !ok {
+		return
+	}
+	swIfIndex, _ := ifindex.Load(ctx, isClient)
+	// The table is deleted once the interfaces bound to it are deleted
+	_ = teardown.Do(ctx, teardown.Tables, func(ctx context.Context) error {
 		t.mut.Lock()
-		if vrfInfo, ok := t.entries[networkService]; ok {
-			swIfIndex, _ := ifindex.Load(ctx, isClient)
-			delete(vrfInfo.attached, swIfIndex)
-			log.FromContext(ctx).
-				WithField("swIfIndex", swIfIndex).
-				WithField("networkService", networkService).
-				Debugf("swIfIndex deleted from vrfInfo.attached map")
+		defer t.mut.Unlock()
 
-			/* If there are no more clients using the vrf - delete it */
-			if len(vrfInfo.attached) == 1 {
-				delete(t.entries, networkService)
-				_ = delVPP(ctx, vppConn, vrfID, isIPv6)
-			}
+		vrfInfo, ok := t.entries[networkService]
+		if !ok {
+			return nil
 		}
-		t.mut.Unlock()
-	}
+		delete(vrfInfo.attached, swIfIndex)
+		log.FromContext(ctx).
+			WithField("swIfIndex", swIfIndex).
+			WithField("networkService", networkService).
+			Debugf("swIfIndex deleted from vrfInfo.attached map")
+
+		/* If there are no more clients using the vrf - delete it */
+		if len(vrfInfo.attached) == 1 {
+			delete(t.entries, networkService)
+			_ = addDelLeak(ctx, vppConn, vrfInfo.leak, vrfID, isIPv6, false)
+			_ = delVPP(ctx, vppConn, vrfID, isIPv6)
+		}
+		return nil
+	})
 }
 
 func delVPP(ctx context.Context, vppConn api.Connection, vrfID uint32, isIPv6 bool) error {
@@ -0,0 +1,116 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vrf
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/edwarnicke/govpp/binapi/fib_types"
+	"github.com/edwarnicke/govpp/binapi/ip"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/types"
+)
+
+// AnyNetworkService - the RouteLeaks key of the route leak of the network services having no own entry
+const AnyNetworkService = "*"
+
+// RouteLeak - the prefixes leaked between the VRF of the network service and the shared services VRF
+type RouteLeak struct {
+	// SharedVRFID - the table ID of the shared services VRF, the same for IPv4 and IPv6
+	SharedVRFID uint32
+	// Imports - the prefixes of the shared services (e.g. DNS, NTP) reachable from the VRF of the network service
+	Imports []*net.IPNet
+	// Exports - the prefixes of the network service reachable from the shared services VRF. They must not overlap
+	// with the exports of the other network services.
+	Exports []*net.IPNet
+}
+
+// RouteLeaks - the route leaks by the network service name
+type RouteLeaks map[string]*RouteLeak
+
+func (r RouteLeaks) get(networkService string) *RouteLeak {
+	if leak, ok := r[networkService]; ok {
+		return leak
+	}
+	return r[AnyNetworkService]
+}
+
+// addDelLeak installs or removes the routes of the family leaked between the vrfID and the shared services VRF: the
+// imports are looked up in the shared services VRF and the exports are looked up in the vrfID
+func addDelLeak(ctx context.Context, vppConn api.Connection, leak *RouteLeak, vrfID uint32, isIPv6, isAdd bool) error {
+	if leak == nil {
+		return nil
+	}
+	for _, prefix := range leak.Imports {
+		if (prefix.IP.To4() == nil) != isIPv6 {
+			continue
+		}
+		if err := addDelLeakRoute(ctx, vppConn, prefix, vrfID, leak.SharedVRFID, isAdd); err != nil {
+			return err
+		}
+	}
+	for _, prefix := range leak.Exports {
+		if (prefix.IP.To4() == nil) != isIPv6 {
+			continue
+		}
+		if err := addDelLeakRoute(ctx, vppConn, prefix, leak.SharedVRFID, vrfID, isAdd); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addDelLeakRoute installs the route of the prefix in the tableID looked up in the lookupTableID
+func addDelLeakRoute(ctx context.Context, vppConn api.Connection, prefix *net.IPNet, tableID, lookupTableID uint32, isAdd bool) error {
+	now := time.Now()
+	route := ip.IPRoute{
+		TableID: tableID,
+		Prefix:  types.ToVppPrefix(prefix),
+		NPaths:  1,
+		Paths: []fib_types.FibPath{
+			{
+				// No interface and no next hop - the lookup in the TableID
+				SwIfIndex: ^uint32(0),
+				TableID:   lookupTableID,
+				Weight:    1,
+				Type:      fib_types.FIB_API_PATH_TYPE_NORMAL,
+				Flags:     fib_types.FIB_API_PATH_FLAG_NONE,
+				Proto:     types.IsV6toFibProto(prefix.IP.To4() == nil),
+			},
+		},
+	}
+	if _, err := ip.NewServiceClient(vppConn).IPRouteAddDel(ctx, &ip.IPRouteAddDel{
+		IsAdd: isAdd,
+		Route: route,
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("prefix", prefix).
+		WithField("tableID", tableID).
+		WithField("lookupTableID", lookupTableID).
+		WithField("isAdd", isAdd).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "IPRouteAddDel").Debug("completed")
+	return nil
+}
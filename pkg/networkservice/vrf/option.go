@@ -30,6 +30,9 @@ type vrfInfo struct {
 
 	/* attached - attached interfaces */
 	attached map[interface_types.InterfaceIndex]struct{}
+
+	/* leak - routes leaked between the vrf and the shared services vrf */
+	leak *RouteLeak
 }
 
 type vrfMap struct {
@@ -58,6 +61,7 @@ func NewMap() *Map {
 type options struct {
 	m      *Map
 	loadFn ifindex.LoadInterfaceFn
+	leaks  RouteLeaks
 }
 
 // Option is an option pattern for upClient/Server
@@ -76,3 +80,11 @@ func WithLoadInterface(loadFn ifindex.LoadInterfaceFn) Option {
 		o.loadFn = loadFn
 	}
 }
+
+// WithRouteLeaks installs the routes leaked between the vrf of the network service and the shared services vrf, so the
+// common services (DNS, NTP) are reachable from all the tenant vrfs. The routes exist while the vrf does.
+func WithRouteLeaks(leaks RouteLeaks) Option {
+	return func(o *options) {
+		o.leaks = leaks
+	}
+}
@@ -35,6 +35,7 @@ type vrfServer struct {
 	vppConn api.Connection
 	loadFn  ifindex.LoadInterfaceFn
 	m       *Map
+	leaks   RouteLeaks
 }
 
 // NewServer creates a NetworkServiceServer chain element to create the ip table in vpp
@@ -51,6 +52,7 @@ func NewServer(vppConn api.Connection, opts ...Option) networkservice.NetworkSer
 		vppConn: vppConn,
 		loadFn:  o.loadFn,
 		m:       o.m,
+		leaks:   o.leaks,
 	}
 }
 
@@ -64,7 +66,7 @@ func (v *vrfServer) Request(ctx context.Context, request *networkservice.Network
 			t = v.m.ipv6
 		}
 		if _, ok := Load(ctx, metadata.IsClient(v), isIPv6); !ok {
-			vrfID, err := create(ctx, v.vppConn, networkService, t, isIPv6)
+			vrfID, err := create(ctx, v.vppConn, networkService, t, isIPv6, v.leaks.get(networkService))
 			if err != nil {
 				return nil, err
 			}
@@ -0,0 +1,160 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vrrp
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+	"github.com/edwarnicke/govpp/binapi/ip_types"
+	"github.com/edwarnicke/govpp/binapi/vrrp"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/types"
+)
+
+// vrKey - the virtual router is identified in vpp by the interface, the VR ID and the address family
+type vrKey struct {
+	swIfIndex interface_types.InterfaceIndex
+	vrID      uint8
+	isIPv6    bool
+}
+
+func (k vrKey) flags(o *options) vrrp.VrrpVrFlags {
+	var flags vrrp.VrrpVrFlags
+	if o.preempt {
+		flags |= vrrp.VRRP_API_VR_PREEMPT
+	}
+	if o.accept {
+		flags |= vrrp.VRRP_API_VR_ACCEPT
+	}
+	if len(o.peers) > 0 {
+		flags |= vrrp.VRRP_API_VR_UNICAST
+	}
+	if k.isIPv6 {
+		flags |= vrrp.VRRP_API_VR_IPV6
+	}
+	return flags
+}
+
+func addVR(ctx context.Context, vppConn api.Connection, k vrKey, addrs []net.IP, o *options) error {
+	vr := &vrrp.VrrpVrAddDel{
+		IsAdd:     1,
+		SwIfIndex: k.swIfIndex,
+		VrID:      k.vrID,
+		Priority:  o.priority,
+		Interval:  uint16(o.interval / (10 * time.Millisecond)),
+		Flags:     k.flags(o),
+	}
+	for _, addr := range addrs {
+		vr.Addrs = append(vr.Addrs, types.ToVppAddress(addr))
+	}
+	vr.NAddrs = uint8(len(vr.Addrs))
+
+	now := time.Now()
+	if _, err := vrrp.NewServiceClient(vppConn).VrrpVrAddDel(ctx, vr); err != nil {
+		return errors.Wrapf(err, "failed to add the virtual router %d", k.vrID)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", k.swIfIndex).
+		WithField("vrID", k.vrID).
+		WithField("addrs", addrs).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "VrrpVrAddDel").Debug("completed")
+
+	if peers := peersOf(o, k.isIPv6); len(peers) > 0 {
+		now = time.Now()
+		if _, err := vrrp.NewServiceClient(vppConn).VrrpVrSetPeers(ctx, &vrrp.VrrpVrSetPeers{
+			SwIfIndex: k.swIfIndex,
+			VrID:      k.vrID,
+			IsIPv6:    boolToUint8(k.isIPv6),
+			NAddrs:    uint8(len(peers)),
+			Addrs:     peers,
+		}); err != nil {
+			return errors.Wrapf(err, "failed to set the peers of the virtual router %d", k.vrID)
+		}
+		log.FromContext(ctx).
+			WithField("swIfIndex", k.swIfIndex).
+			WithField("vrID", k.vrID).
+			WithField("duration", time.Since(now)).
+			WithField("vppapi", "VrrpVrSetPeers").Debug("completed")
+	}
+
+	return startStopVR(ctx, vppConn, k, true)
+}
+
+func delVR(ctx context.Context, vppConn api.Connection, k vrKey, o *options) error {
+	if err := startStopVR(ctx, vppConn, k, false); err != nil {
+		return err
+	}
+	now := time.Now()
+	if _, err := vrrp.NewServiceClient(vppConn).VrrpVrAddDel(ctx, &vrrp.VrrpVrAddDel{
+		IsAdd:     0,
+		SwIfIndex: k.swIfIndex,
+		VrID:      k.vrID,
+		Flags:     k.flags(o),
+	}); err != nil {
+		return errors.Wrapf(err, "failed to delete the virtual router %d", k.vrID)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", k.swIfIndex).
+		WithField("vrID", k.vrID).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "VrrpVrAddDel").Debug("completed")
+	return nil
+}
+
+func startStopVR(ctx context.Context, vppConn api.Connection, k vrKey, isStart bool) error {
+	now := time.Now()
+	if _, err := vrrp.NewServiceClient(vppConn).VrrpVrStartStop(ctx, &vrrp.VrrpVrStartStop{
+		SwIfIndex: k.swIfIndex,
+		VrID:      k.vrID,
+		IsIPv6:    boolToUint8(k.isIPv6),
+		IsStart:   boolToUint8(isStart),
+	}); err != nil {
+		return errors.Wrapf(err, "failed to start/stop the virtual router %d", k.vrID)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", k.swIfIndex).
+		WithField("vrID", k.vrID).
+		WithField("isStart", isStart).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "VrrpVrStartStop").Debug("completed")
+	return nil
+}
+
+func peersOf(o *options, isIPv6 bool) []ip_types.Address {
+	var rv []ip_types.Address
+	for _, peer := range o.peers {
+		if (peer.To4() == nil) == isIPv6 {
+			rv = append(rv, types.ToVppAddress(peer))
+		}
+	}
+	return rv
+}
+
+func boolToUint8(b bool) uint8 {
+	if b {
+		return 1
+	}
+	return 0
+}
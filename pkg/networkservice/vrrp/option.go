@@ -0,0 +1,93 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vrrp
+
+import (
+	"net"
+	"time"
+)
+
+const (
+	defaultPriority = 100
+	defaultInterval = time.Second
+)
+
+type virtualRouter struct {
+	vrID  uint8
+	addrs []net.IP
+}
+
+type options struct {
+	routers  map[string]*virtualRouter
+	priority uint8
+	interval time.Duration
+	preempt  bool
+	accept   bool
+	peers    []net.IP
+}
+
+// Option is an option pattern for NewServer
+type Option func(o *options)
+
+// WithVirtualRouter runs the virtual router vrID with the gateway addresses addrs for the connections of the
+// networkService. The IPv4 and IPv6 addresses are served by the separate virtual routers with the same vrID.
+func WithVirtualRouter(networkService string, vrID uint8, addrs ...net.IP) Option {
+	return func(o *options) {
+		o.routers[networkService] = &virtualRouter{
+			vrID:  vrID,
+			addrs: addrs,
+		}
+	}
+}
+
+// WithPriority sets the priority of the virtual routers, the router with the highest priority becomes the master.
+// Default: 100
+func WithPriority(priority uint8) Option {
+	return func(o *options) {
+		o.priority = priority
+	}
+}
+
+// WithInterval sets the interval of the VRRP advertisements, rounded down to the centiseconds. Default: 1s
+func WithInterval(interval time.Duration) Option {
+	return func(o *options) {
+		o.interval = interval
+	}
+}
+
+// WithPreempt makes the router with the higher priority take over the master role from the router with the lower one
+func WithPreempt() Option {
+	return func(o *options) {
+		o.preempt = true
+	}
+}
+
+// WithAccept makes the master accept the packets addressed to the gateway addresses, e.g. ping. The gateway
+// interface must be able to get the addresses assigned, so the unnumbered BVI can't be used.
+func WithAccept() Option {
+	return func(o *options) {
+		o.accept = true
+	}
+}
+
+// WithUnicastPeers sends the VRRP advertisements to the peers instead of the multicast group, e.g. when the bridge
+// domain is stretched over a network not forwarding the multicast
+func WithUnicastPeers(peers ...net.IP) Option {
+	return func(o *options) {
+		o.peers = peers
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vrrp
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/loopback"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/xconnect/l2bridgedomain"
+)
+
+type vrrpServer struct {
+	vppConn api.Connection
+	options *options
+
+	mu      sync.Mutex
+	routers map[vrKey]uint32
+}
+
+// NewServer returns a server chain element running the virtual routers configured with WithVirtualRouter on the
+// gateway interface of the connection: the BVI of the bridge domain the connection is routed over or the loopback of
+// the NetworkService. The virtual router is shared by the connections using the same gateway interface and is
+// deleted with the last of them.
+// It must precede l2bridgedomain.NewServer in the chain: the BVI is known after l2bridgedomain has handled the Request,
+// and the virtual router is deleted before the BVI on Close.
+func NewServer(vppConn api.Connection, opts ...Option) networkservice.NetworkServiceServer {
+	o := &options{
+		routers:  make(map[string]*virtualRouter),
+		priority: defaultPriority,
+		interval: defaultInterval,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &vrrpServer{
+		vppConn: vppConn,
+		options: o,
+		routers: make(map[vrKey]uint32),
+	}
+}
+
+func (v *vrrpServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	router, ok := v.options.routers[conn.GetNetworkService()]
+	if !ok {
+		return conn, nil
+	}
+	swIfIndex, ok := gatewayInterface(ctx, metadata.IsClient(v))
+	if !ok {
+		return conn, nil
+	}
+	if err := v.add(ctx, swIfIndex, router); err != nil {
+		closeCtx, cancelClose := postponeCtxFunc()
+		defer cancelClose()
+
+		if _, closeErr := v.Close(closeCtx, conn); closeErr != nil {
+			err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+		}
+
+		return nil, err
+	}
+	return conn, nil
+}
+
+func (v *vrrpServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	if keys, ok := loadAndDelete(ctx, metadata.IsClient(v)); ok {
+		v.mu.Lock()
+		for _, k := range keys {
+			v.release(ctx, k)
+		}
+		v.mu.Unlock()
+	}
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+// add starts the virtual routers of the gateway interface if not yet started and stores them in the metadata
+func (v *vrrpServer) add(ctx context.Context, swIfIndex interface_types.InterfaceIndex, router *virtualRouter) error {
+	if _, ok := loadAndDelete(ctx, metadata.IsClient(v)); ok {
+		// Refresh: the gateway interface doesn't change for the connection
+		store(ctx, metadata.IsClient(v), keysOf(swIfIndex, router))
+		return nil
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	var keys []vrKey
+	for _, k := range keysOf(swIfIndex, router) {
+		if v.routers[k] == 0 {
+			if err := addVR(ctx, v.vppConn, k, addrsOf(router, k.isIPv6), v.options); err != nil {
+				for _, added := range keys {
+					v.release(ctx, added)
+				}
+				return err
+			}
+		}
+		v.routers[k]++
+		keys = append(keys, k)
+	}
+	store(ctx, metadata.IsClient(v), keys)
+	return nil
+}
+
+// release deletes the virtual router on the last connection using it, v.mu must be held
+func (v *vrrpServer) release(ctx context.Context, k vrKey) {
+	v.routers[k]--
+	if v.routers[k] > 0 {
+		return
+	}
+	delete(v.routers, k)
+	if err := delVR(ctx, v.vppConn, k, v.options); err != nil {
+		log.FromContext(ctx).WithField("vrrp", "server").Errorf("unable to delete the virtual router %d: %v", k.vrID, err)
+	}
+}
+
+func gatewayInterface(ctx context.Context, isClient bool) (interface_types.InterfaceIndex, bool) {
+	if swIfIndex, ok := l2bridgedomain.LoadBVI(ctx, isClient); ok {
+		return swIfIndex, true
+	}
+	return loopback.Load(ctx, isClient)
+}
+
+func keysOf(swIfIndex interface_types.InterfaceIndex, router *virtualRouter) []vrKey {
+	var keys []vrKey
+	for _, isIPv6 := range []bool{false, true} {
+		if len(addrsOf(router, isIPv6)) > 0 {
+			keys = append(keys, vrKey{
+				swIfIndex: swIfIndex,
+				vrID:      router.vrID,
+				isIPv6:    isIPv6,
+			})
+		}
+	}
+	return keys
+}
+
+func addrsOf(router *virtualRouter, isIPv6 bool) []net.IP {
+	var rv []net.IP
+	for _, addr := range router.addrs {
+		if (addr.To4() == nil) == isIPv6 {
+			rv = append(rv, addr)
+		}
+	}
+	return rv
+}
@@ -27,9 +27,9 @@ import (
 )
 
 // NewClient - creates new xconnect client chain element to that correctly handles payload.IP and payload.Ethernet
-func NewClient(vppConn api.Connection) networkservice.NetworkServiceClient {
+func NewClient(vppConn api.Connection, l2Opts ...l2xconnect.Option) networkservice.NetworkServiceClient {
 	return chain.NewNetworkServiceClient(
-		l2xconnect.NewClient(vppConn),
+		l2xconnect.NewClient(vppConn, l2Opts...),
 		l3xconnect.NewClient(vppConn),
 	)
 }
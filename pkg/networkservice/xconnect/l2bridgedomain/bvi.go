@@ -0,0 +1,376 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package l2bridgedomain
+
+import (
+	"context"
+	"io"
+	"net"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/edwarnicke/govpp/binapi/arp"
+	"github.com/edwarnicke/govpp/binapi/fib_types"
+	interfaces "github.com/edwarnicke/govpp/binapi/interface"
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+	"github.com/edwarnicke/govpp/binapi/ip"
+	"github.com/edwarnicke/govpp/binapi/ip6_nd"
+	"github.com/edwarnicke/govpp/binapi/ip_types"
+	"github.com/edwarnicke/govpp/binapi/l2"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/teardown"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/types"
+)
+
+// bviRoute - route programmed for the connection routed over the bridge domain BVI
+type bviRoute struct {
+	prefix  *net.IPNet
+	nextHop net.IP
+	via     interface_types.InterfaceIndex
+}
+
+// addBVI attaches the client interface to the bridge domain, creates the bridge domain BVI (if not yet created) and
+// routes the connection prefixes between the BVI and the server interface.
+// The server interface is a L3 one, so it is not attached to the bridge domain.
+func addBVI(ctx context.Context, vppConn api.Connection, bridges *l2BridgeDomain, conn *networkservice.Connection, vlanID uint32) error {
+	clientIfIndex, ok := ifindex.Load(ctx, true)
+	if !ok {
+		return nil
+	}
+	serverIfIndex, ok := ifindex.Load(ctx, false)
+	if !ok {
+		return nil
+	}
+
+	key := bridgeDomainKey{
+		vlanID:        vlanID,
+		clientIfIndex: clientIfIndex,
+	}
+	l2Bridge, err := loadOrCreateBridgeDomain(ctx, vppConn, bridges, key)
+	if err != nil {
+		return err
+	}
+	if _, ok = l2Bridge.attached[clientIfIndex]; !ok {
+		if err = addDelVppInterfaceBridgeDomain(ctx, vppConn, clientIfIndex, l2Bridge.id, 0, true); err != nil {
+			return err
+		}
+		l2Bridge.attached[clientIfIndex] = struct{}{}
+		bridges.Store(key, l2Bridge)
+	}
+	if l2Bridge.bviIfIndex == 0 {
+		bviIfIndex, err := createBVI(ctx, vppConn, l2Bridge.id, clientIfIndex, vlanID)
+		if err != nil {
+			return err
+		}
+		l2Bridge.bviIfIndex = bviIfIndex
+		bridges.Store(key, l2Bridge)
+	}
+	storeBVI(ctx, false, l2Bridge.bviIfIndex)
+	if _, ok = l2Bridge.routed[serverIfIndex]; ok {
+		return nil
+	}
+	// Mark the server interface as routed first, so the failed Request cleans up the partially added routes on Close
+	l2Bridge.routed[serverIfIndex] = struct{}{}
+	bridges.Store(key, l2Bridge)
+	for _, route := range bviRoutes(conn, l2Bridge.bviIfIndex, serverIfIndex) {
+		if err = addDelVppRoute(ctx, vppConn, route, true); err != nil {
+			return err
+		}
+	}
+	return addDelProxy(ctx, vppConn, l2Bridge.bviIfIndex, conn.GetContext().GetIpContext().GetSrcIPNets(), true)
+}
+
+// delBVI removes the connection routes and deletes the BVI together with the bridge domain on the last connection
+func delBVI(ctx context.Context, vppConn api.Connection, bridges *l2BridgeDomain, conn *networkservice.Connection, vlanID uint32) error {
+	deleteBVI(ctx, false)
+
+	clientIfIndex, ok := ifindex.Load(ctx, true)
+	if !ok {
+		return nil
+	}
+	serverIfIndex, serverOk := ifindex.Load(ctx, false)
+	// The bridge domain is released before the interfaces attached to it are deleted
+	return teardown.Do(ctx, teardown.L2, func(ctx context.Context) error {
+		key := bridgeDomainKey{
+			vlanID:        vlanID,
+			clientIfIndex: clientIfIndex,
+		}
+		l2Bridge, ok := bridges.Load(key)
+		if !ok {
+			return nil
+		}
+		if serverOk {
+			if _, ok = l2Bridge.routed[serverIfIndex]; ok {
+				delete(l2Bridge.routed, serverIfIndex)
+				bridges.Store(key, l2Bridge)
+				for _, route := range bviRoutes(conn, l2Bridge.bviIfIndex, serverIfIndex) {
+					if err := addDelVppRoute(ctx, vppConn, route, false); err != nil {
+						return err
+					}
+				}
+				if err := addDelProxy(ctx, vppConn, l2Bridge.bviIfIndex, conn.GetContext().GetIpContext().GetSrcIPNets(), false); err != nil {
+					return err
+				}
+			}
+		}
+		return releaseBridgeDomain(ctx, vppConn, bridges, key, l2Bridge)
+	})
+}
+
+func createBVI(ctx context.Context, vppConn api.Connection, bridgeID uint32, clientIfIndex interface_types.InterfaceIndex, vlanID uint32) (interface_types.InterfaceIndex, error) {
+	now := time.Now()
+	rsp, err := l2.NewServiceClient(vppConn).BviCreate(ctx, &l2.BviCreate{
+		UserInstance: ^uint32(0),
+	})
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", rsp.SwIfIndex).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "BviCreate").Info("completed")
+
+	now = time.Now()
+	if _, err = l2.NewServiceClient(vppConn).SwInterfaceSetL2Bridge(ctx, &l2.SwInterfaceSetL2Bridge{
+		RxSwIfIndex: rsp.SwIfIndex,
+		BdID:        bridgeID,
+		PortType:    l2.L2_API_PORT_TYPE_BVI,
+		Enable:      true,
+	}); err != nil {
+		return rsp.SwIfIndex, errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", rsp.SwIfIndex).
+		WithField("bridgeID", bridgeID).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "SwInterfaceSetL2Bridge").Info("completed")
+
+	now = time.Now()
+	if _, err = interfaces.NewServiceClient(vppConn).SwInterfaceSetFlags(ctx, &interfaces.SwInterfaceSetFlags{
+		SwIfIndex: rsp.SwIfIndex,
+		Flags:     interface_types.IF_STATUS_API_FLAG_ADMIN_UP,
+	}); err != nil {
+		return rsp.SwIfIndex, errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", rsp.SwIfIndex).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "SwInterfaceSetFlags").Debug("completed")
+
+	now = time.Now()
+	if _, err = arp.NewServiceClient(vppConn).ProxyArpIntfcEnableDisable(ctx, &arp.ProxyArpIntfcEnableDisable{
+		SwIfIndex: rsp.SwIfIndex,
+		Enable:    true,
+	}); err != nil {
+		return rsp.SwIfIndex, errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", rsp.SwIfIndex).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "ProxyArpIntfcEnableDisable").Debug("completed")
+
+	if vlanID == 0 {
+		return rsp.SwIfIndex, nil
+	}
+	// The BVI borrows the address of the vlan parent (uplink) interface as the source of the ARP/ND requests
+	return rsp.SwIfIndex, setUnnumbered(ctx, vppConn, rsp.SwIfIndex, clientIfIndex)
+}
+
+func delVppBVI(ctx context.Context, vppConn api.Connection, bviIfIndex interface_types.InterfaceIndex) error {
+	now := time.Now()
+	if _, err := l2.NewServiceClient(vppConn).BviDelete(ctx, &l2.BviDelete{
+		SwIfIndex: bviIfIndex,
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", bviIfIndex).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "BviDelete").Info("completed")
+	return nil
+}
+
+func setUnnumbered(ctx context.Context, vppConn api.Connection, bviIfIndex, subIfIndex interface_types.InterfaceIndex) error {
+	now := time.Now()
+	client, err := interfaces.NewServiceClient(vppConn).SwInterfaceDump(ctx, &interfaces.SwInterfaceDump{
+		SwIfIndex: subIfIndex,
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", subIfIndex).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "SwInterfaceDump").Debug("completed")
+	var parentIfIndex interface_types.InterfaceIndex
+	for {
+		details, err := client.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		parentIfIndex = interface_types.InterfaceIndex(details.SupSwIfIndex)
+	}
+	if parentIfIndex == 0 || parentIfIndex == subIfIndex {
+		return nil
+	}
+
+	now = time.Now()
+	if _, err := interfaces.NewServiceClient(vppConn).SwInterfaceSetUnnumbered(ctx, &interfaces.SwInterfaceSetUnnumbered{
+		SwIfIndex:           parentIfIndex,
+		UnnumberedSwIfIndex: bviIfIndex,
+		IsAdd:               true,
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", parentIfIndex).
+		WithField("unnumberedSwIfIndex", bviIfIndex).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "SwInterfaceSetUnnumbered").Debug("completed")
+	return nil
+}
+
+// bviRoutes returns the routes to the remote side addresses and routes via the BVI and the routes to the local side
+// addresses via the server interface
+func bviRoutes(conn *networkservice.Connection, bviIfIndex, serverIfIndex interface_types.InterfaceIndex) []*bviRoute {
+	var rv []*bviRoute
+	dstIPNets := conn.GetContext().GetIpContext().GetDstIPNets()
+	for _, ipNet := range dstIPNets {
+		rv = append(rv, &bviRoute{
+			prefix:  hostIPNet(ipNet.IP),
+			nextHop: ipNet.IP,
+			via:     bviIfIndex,
+		})
+	}
+	for _, route := range conn.GetContext().GetIpContext().GetDstRoutes() {
+		prefix := route.GetPrefixIPNet()
+		if prefix == nil {
+			continue
+		}
+		nextHop := route.GetNextHopIP()
+		for _, ipNet := range dstIPNets {
+			if nextHop == nil && (ipNet.IP.To4() == nil) == (prefix.IP.To4() == nil) {
+				nextHop = ipNet.IP
+			}
+		}
+		if nextHop == nil {
+			continue
+		}
+		rv = append(rv, &bviRoute{
+			prefix:  prefix,
+			nextHop: nextHop,
+			via:     bviIfIndex,
+		})
+	}
+	for _, ipNet := range conn.GetContext().GetIpContext().GetSrcIPNets() {
+		rv = append(rv, &bviRoute{
+			prefix: hostIPNet(ipNet.IP),
+			via:    serverIfIndex,
+		})
+	}
+	return rv
+}
+
+func hostIPNet(ip net.IP) *net.IPNet {
+	if ip4 := ip.To4(); ip4 != nil {
+		return &net.IPNet{IP: ip4, Mask: net.CIDRMask(net.IPv4len*8, net.IPv4len*8)}
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(net.IPv6len*8, net.IPv6len*8)}
+}
+
+func addDelVppRoute(ctx context.Context, vppConn api.Connection, route *bviRoute, isAdd bool) error {
+	isIPv6 := route.prefix.IP.To4() == nil
+	path := fib_types.FibPath{
+		SwIfIndex: uint32(route.via),
+		Weight:    1,
+		Type:      fib_types.FIB_API_PATH_TYPE_NORMAL,
+		Flags:     fib_types.FIB_API_PATH_FLAG_NONE,
+		Proto:     types.IsV6toFibProto(isIPv6),
+	}
+	if route.nextHop != nil {
+		path.Nh.Address = types.ToVppAddress(route.nextHop).Un
+	}
+	now := time.Now()
+	if _, err := ip.NewServiceClient(vppConn).IPRouteAddDel(ctx, &ip.IPRouteAddDel{
+		IsAdd: isAdd,
+		Route: ip.IPRoute{
+			Prefix: types.ToVppPrefix(route.prefix),
+			NPaths: 1,
+			Paths:  []fib_types.FibPath{path},
+		},
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", route.via).
+		WithField("prefix", route.prefix).
+		WithField("nextHop", route.nextHop).
+		WithField("isAdd", isAdd).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "IPRouteAddDel").Info("completed")
+	return nil
+}
+
+// addDelProxy makes the BVI answer the ARP/ND requests for the local side addresses
+func addDelProxy(ctx context.Context, vppConn api.Connection, bviIfIndex interface_types.InterfaceIndex, ipNets []*net.IPNet, isAdd bool) error {
+	for _, ipNet := range ipNets {
+		now := time.Now()
+		if ip4 := ipNet.IP.To4(); ip4 != nil {
+			var addr ip_types.IP4Address
+			copy(addr[:], ip4)
+			if _, err := arp.NewServiceClient(vppConn).ProxyArpAddDel(ctx, &arp.ProxyArpAddDel{
+				IsAdd: isAdd,
+				Proxy: arp.ProxyArp{
+					Low: addr,
+					Hi:  addr,
+				},
+			}); err != nil {
+				return errors.WithStack(err)
+			}
+			log.FromContext(ctx).
+				WithField("ip", ip4).
+				WithField("isAdd", isAdd).
+				WithField("duration", time.Since(now)).
+				WithField("vppapi", "ProxyArpAddDel").Debug("completed")
+			continue
+		}
+		var addr ip_types.IP6Address
+		copy(addr[:], ipNet.IP.To16())
+		if _, err := ip6_nd.NewServiceClient(vppConn).IP6ndProxyAddDel(ctx, &ip6_nd.IP6ndProxyAddDel{
+			SwIfIndex: bviIfIndex,
+			IsAdd:     isAdd,
+			IP:        addr,
+		}); err != nil {
+			return errors.WithStack(err)
+		}
+		log.FromContext(ctx).
+			WithField("swIfIndex", bviIfIndex).
+			WithField("ip", ipNet.IP).
+			WithField("isAdd", isAdd).
+			WithField("duration", time.Since(now)).
+			WithField("vppapi", "IP6ndProxyAddDel").Debug("completed")
+	}
+	return nil
+}
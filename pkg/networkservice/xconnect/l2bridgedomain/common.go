@@ -28,6 +28,7 @@ import (
 
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
 
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/teardown"
 	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
 )
 
@@ -37,6 +38,15 @@ type bridgeDomain struct {
 
 	// attached interfaces
 	attached map[interface_types.InterfaceIndex]struct{}
+
+	// BVI routing the IP payload connections to the bridge domain (0 if not created)
+	bviIfIndex interface_types.InterfaceIndex
+
+	// server interfaces routed via the BVI
+	routed map[interface_types.InterfaceIndex]struct{}
+
+	// storm control of the frames received on the server interfaces (nil if not enabled)
+	storm *stormControl
 }
 
 type bridgeDomainKey struct {
@@ -44,7 +54,7 @@ type bridgeDomainKey struct {
 	clientIfIndex interface_types.InterfaceIndex
 }
 
-func addBridgeDomain(ctx context.Context, vppConn api.Connection, bridges *l2BridgeDomain, vlanID uint32) error {
+func addBridgeDomain(ctx context.Context, vppConn api.Connection, bridges *l2BridgeDomain, vlanID uint32, o *options) error {
 	clientIfIndex, ok := ifindex.Load(ctx, true)
 	if !ok {
 		return nil
@@ -59,28 +69,34 @@ func addBridgeDomain(ctx context.Context, vppConn api.Connection, bridges *l2Bri
 		vlanID:        vlanID,
 		clientIfIndex: clientIfIndex,
 	}
-	l2Bridge, ok := bridges.Load(key)
-	if !ok {
-		bridgeID, err := addDelVppBridgeDomain(ctx, vppConn, ^uint32(0), true)
-		if err != nil {
+	l2Bridge, err := loadOrCreateBridgeDomain(ctx, vppConn, bridges, key)
+	if err != nil {
+		return err
+	}
+	if pps := o.stormControlPPS.Load(); pps > 0 && l2Bridge.storm == nil {
+		if l2Bridge.storm, err = createStormControl(ctx, vppConn, l2Bridge.id, pps); err != nil {
 			return err
 		}
-		l2Bridge = &bridgeDomain{
-			id:       bridgeID,
-			attached: make(map[interface_types.InterfaceIndex]struct{}),
-		}
 		bridges.Store(key, l2Bridge)
 	}
 	if _, ok = l2Bridge.attached[serverIfIndex]; !ok {
-		err := addDelVppInterfaceBridgeDomain(ctx, vppConn, serverIfIndex, l2Bridge.id, 1, true)
+		if l2Bridge.storm != nil {
+			if err = l2Bridge.storm.setInterface(ctx, vppConn, serverIfIndex, true); err != nil {
+				return err
+			}
+		}
+		err = addDelVppInterfaceBridgeDomain(ctx, vppConn, serverIfIndex, l2Bridge.id, 1, true)
 		if err != nil {
+			if l2Bridge.storm != nil {
+				_ = l2Bridge.storm.setInterface(ctx, vppConn, serverIfIndex, false)
+			}
 			return err
 		}
 		l2Bridge.attached[serverIfIndex] = struct{}{}
 		bridges.Store(key, l2Bridge)
 	}
 	if _, ok = l2Bridge.attached[clientIfIndex]; !ok {
-		err := addDelVppInterfaceBridgeDomain(ctx, vppConn, clientIfIndex, l2Bridge.id, 0, true)
+		err = addDelVppInterfaceBridgeDomain(ctx, vppConn, clientIfIndex, l2Bridge.id, 0, true)
 		if err != nil {
 			return err
 		}
@@ -91,7 +107,13 @@ func addBridgeDomain(ctx context.Context, vppConn api.Connection, bridges *l2Bri
 }
 
 func delBridgeDomain(ctx context.Context, vppConn api.Connection, bridges *l2BridgeDomain, vlanID uint32) error {
-	if clientIfIndex, ok := ifindex.Load(ctx, true); ok {
+	clientIfIndex, ok := ifindex.Load(ctx, true)
+	if !ok {
+		return nil
+	}
+	serverIfIndex, serverOk := ifindex.Load(ctx, false)
+	// The bridge domain is released before the interfaces attached to it are deleted
+	return teardown.Do(ctx, teardown.L2, func(ctx context.Context) error {
 		key := bridgeDomainKey{
 			vlanID:        vlanID,
 			clientIfIndex: clientIfIndex,
@@ -100,37 +122,78 @@ func delBridgeDomain(ctx context.Context, vppConn api.Connection, bridges *l2Bri
 		if !ok {
 			return nil
 		}
-		if serverIfIndex, okey := ifindex.Load(ctx, false); okey {
+		if serverOk {
 			if _, ok = l2Bridge.attached[serverIfIndex]; ok {
 				err := addDelVppInterfaceBridgeDomain(ctx, vppConn, serverIfIndex, l2Bridge.id, 0, false)
 				if err != nil {
 					return err
 				}
 				delete(l2Bridge.attached, serverIfIndex)
-			}
-		}
-		if len(l2Bridge.attached) == 1 {
-			// last interface -> delete the bridge and the sub-interface also
-			if _, ok = l2Bridge.attached[clientIfIndex]; ok {
-				err := addDelVppInterfaceBridgeDomain(ctx, vppConn, clientIfIndex, l2Bridge.id, 0, false)
-				if err != nil {
-					return err
-				}
-				err = delVppSubIf(ctx, vppConn, vlanID, clientIfIndex)
-				if err != nil {
-					return err
-				}
-				delete(l2Bridge.attached, clientIfIndex)
-				_, err = addDelVppBridgeDomain(ctx, vppConn, l2Bridge.id, false)
-				if err != nil {
-					return err
+				if l2Bridge.storm != nil {
+					if err = l2Bridge.storm.setInterface(ctx, vppConn, serverIfIndex, false); err != nil {
+						return err
+					}
 				}
-				bridges.Delete(key)
 			}
-		} else {
-			bridges.Store(key, l2Bridge)
 		}
+		return releaseBridgeDomain(ctx, vppConn, bridges, key, l2Bridge)
+	})
+}
+
+func loadOrCreateBridgeDomain(ctx context.Context, vppConn api.Connection, bridges *l2BridgeDomain, key bridgeDomainKey) (*bridgeDomain, error) {
+	if l2Bridge, ok := bridges.Load(key); ok {
+		return l2Bridge, nil
+	}
+	bridgeID, err := addDelVppBridgeDomain(ctx, vppConn, ^uint32(0), true)
+	if err != nil {
+		return nil, err
+	}
+	l2Bridge := &bridgeDomain{
+		id:       bridgeID,
+		attached: make(map[interface_types.InterfaceIndex]struct{}),
+		routed:   make(map[interface_types.InterfaceIndex]struct{}),
+	}
+	bridges.Store(key, l2Bridge)
+	return l2Bridge, nil
+}
+
+// releaseBridgeDomain deletes the bridge domain (together with the BVI and the sub-interface) if the client interface
+// is the last interface left in it
+func releaseBridgeDomain(ctx context.Context, vppConn api.Connection, bridges *l2BridgeDomain, key bridgeDomainKey, l2Bridge *bridgeDomain) error {
+	if len(l2Bridge.attached) != 1 || len(l2Bridge.routed) != 0 {
+		bridges.Store(key, l2Bridge)
+		return nil
+	}
+	// last interface -> delete the bridge and the sub-interface also
+	if _, ok := l2Bridge.attached[key.clientIfIndex]; !ok {
+		return nil
+	}
+	if l2Bridge.bviIfIndex != 0 {
+		if err := delVppBVI(ctx, vppConn, l2Bridge.bviIfIndex); err != nil {
+			return err
+		}
+		l2Bridge.bviIfIndex = 0
+	}
+	err := addDelVppInterfaceBridgeDomain(ctx, vppConn, key.clientIfIndex, l2Bridge.id, 0, false)
+	if err != nil {
+		return err
+	}
+	err = delVppSubIf(ctx, vppConn, key.vlanID, key.clientIfIndex)
+	if err != nil {
+		return err
+	}
+	delete(l2Bridge.attached, key.clientIfIndex)
+	if l2Bridge.storm != nil {
+		if err = l2Bridge.storm.del(ctx, vppConn); err != nil {
+			return err
+		}
+		l2Bridge.storm = nil
+	}
+	_, err = addDelVppBridgeDomain(ctx, vppConn, l2Bridge.id, false)
+	if err != nil {
+		return err
 	}
+	bridges.Delete(key)
 	return nil
 }
 
@@ -15,4 +15,6 @@
 // limitations under the License.
 
 // Package l2bridgedomain provides chain elements for creating l2 bridge domain in vpp and adding client and server interfaces (if present)
+// or routing the IP payload server interfaces to it over the bridge domain BVI.
+// The broadcast and multicast frames received from the server interfaces may be rate limited per bridge domain.
 package l2bridgedomain
@@ -0,0 +1,46 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package l2bridgedomain
+
+import (
+	"context"
+
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+type bviKey struct{}
+
+func storeBVI(ctx context.Context, isClient bool, bviIfIndex interface_types.InterfaceIndex) {
+	metadata.Map(ctx, isClient).Store(bviKey{}, bviIfIndex)
+}
+
+func deleteBVI(ctx context.Context, isClient bool) {
+	metadata.Map(ctx, isClient).Delete(bviKey{})
+}
+
+// LoadBVI returns the BVI the IP payload connection is routed over, stored in per Connection.Id metadata.
+// The ok result indicates whether the connection is routed over the BVI instead of being cross connected.
+func LoadBVI(ctx context.Context, isClient bool) (value interface_types.InterfaceIndex, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).Load(bviKey{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(interface_types.InterfaceIndex)
+	return value, ok
+}
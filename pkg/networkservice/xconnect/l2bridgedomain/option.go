@@ -0,0 +1,51 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package l2bridgedomain
+
+import (
+	"context"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/hotreload"
+)
+
+type options struct {
+	chainCtx        context.Context
+	stormControlPPS *hotreload.Value[uint32]
+}
+
+// Option is an option pattern for l2bridgedomain server
+type Option func(o *options)
+
+// WithStormControl limits the broadcast and multicast packets received by the bridge domains from the client networks
+// (the server interfaces) to pps packets per second per bridge domain, so a broadcast storm in a client network doesn't
+// flood the WAN tunnels backing the multipoint L2 service. The unknown unicast is not limited: vpp knows the
+// destination is unknown only after the L2 FIB lookup, after the ingress policers.
+func WithStormControl(pps uint32) Option {
+	return func(o *options) {
+		o.stormControlPPS = hotreload.NewValue(pps)
+	}
+}
+
+// WithStormControlRate sets the storm control rate updated at runtime, see WithStormControl. The new rate replaces the
+// policers of the existing bridge domains until chainCtx is done. The storm control is enabled or disabled only for
+// the new bridge domains.
+func WithStormControlRate(chainCtx context.Context, pps *hotreload.Value[uint32]) Option {
+	return func(o *options) {
+		o.chainCtx = chainCtx
+		o.stormControlPPS = pps
+	}
+}
@@ -31,22 +31,55 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
 
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/vlan"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/xconnect/payloadadapter"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/hotreload"
 )
 
 type l2BridgeDomainServer struct {
 	vppConn api.Connection
 	b       l2BridgeDomain
+	options *options
 }
 
-// NewServer returns a Client chain element that will add client and server vpp interface (if present) to a dridge domain
-func NewServer(vppConn api.Connection) networkservice.NetworkServiceServer {
-	return &l2BridgeDomainServer{
+// NewServer returns a Client chain element that will add client and server vpp interface (if present) to a dridge domain.
+// For the IP payload only the client interface is added, the server interface is routed to the bridge domain over
+// the bridge domain BVI.
+func NewServer(vppConn api.Connection, opts ...Option) networkservice.NetworkServiceServer {
+	o := &options{
+		stormControlPPS: hotreload.NewValue[uint32](0),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	s := &l2BridgeDomainServer{
 		vppConn: vppConn,
+		options: o,
 	}
+	if o.chainCtx != nil {
+		o.stormControlPPS.Watch(o.chainCtx, s.updateStormControl)
+	}
+	return s
+}
+
+// updateStormControl sets the storm control rate of the existing bridge domains
+func (v *l2BridgeDomainServer) updateStormControl(ctx context.Context, pps uint32) {
+	if pps == 0 {
+		log.FromContext(ctx).Warn("the storm control is not disabled for the existing bridge domains")
+		return
+	}
+	v.b.Range(func(_ bridgeDomainKey, l2Bridge *bridgeDomain) bool {
+		if storm := l2Bridge.storm; storm != nil {
+			if err := storm.setRate(ctx, v.vppConn, pps); err != nil {
+				log.FromContext(ctx).WithField("bridgeID", l2Bridge.id).Errorf("unable to update the storm control rate: %v", err)
+			}
+		}
+		return true
+	})
 }
 
 func (v *l2BridgeDomainServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
-	if request.GetConnection().GetPayload() != payload.Ethernet {
+	if request.GetConnection().GetPayload() != payload.Ethernet && request.GetConnection().GetPayload() != payload.IP {
 		return next.Server(ctx).Request(ctx, request)
 	}
 
@@ -62,8 +95,17 @@ func (v *l2BridgeDomainServer) Request(ctx context.Context, request *networkserv
 	if !ok {
 		return conn, nil
 	}
+	if _, ok = payloadadapter.Load(ctx); ok {
+		return conn, nil
+	}
 
-	if err := addBridgeDomain(ctx, v.vppConn, &v.b, vlanID); err != nil {
+	if conn.GetPayload() == payload.IP {
+		// IP payload is routed to the bridge domain over the BVI
+		err = addBVI(ctx, v.vppConn, &v.b, conn, vlanID)
+	} else {
+		err = addBridgeDomain(ctx, v.vppConn, &v.b, vlanID, v.options)
+	}
+	if err != nil {
 		closeCtx, cancelClose := postponeCtxFunc()
 		defer cancelClose()
 
@@ -79,11 +121,21 @@ func (v *l2BridgeDomainServer) Request(ctx context.Context, request *networkserv
 
 func (v *l2BridgeDomainServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
 	vlanID, ok := vlan.Load(ctx, true)
-	if !ok || conn.GetPayload() != payload.Ethernet {
+	if !ok {
 		return next.Server(ctx).Close(ctx, conn)
 	}
-	if err := delBridgeDomain(ctx, v.vppConn, &v.b, vlanID); err != nil {
-		log.FromContext(ctx).WithField("l2BridgeDomain", "server").Error("delBridgeDomain", err)
+	if _, ok = payloadadapter.Load(ctx); ok {
+		return next.Server(ctx).Close(ctx, conn)
+	}
+	switch conn.GetPayload() {
+	case payload.Ethernet:
+		if err := delBridgeDomain(ctx, v.vppConn, &v.b, vlanID); err != nil {
+			log.FromContext(ctx).WithField("l2BridgeDomain", "server").Error("delBridgeDomain", err)
+		}
+	case payload.IP:
+		if err := delBVI(ctx, v.vppConn, &v.b, conn, vlanID); err != nil {
+			log.FromContext(ctx).WithField("l2BridgeDomain", "server").Error("delBVI", err)
+		}
 	}
 	return next.Server(ctx).Close(ctx, conn)
 }
@@ -0,0 +1,240 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package l2bridgedomain
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/edwarnicke/govpp/binapi/classify"
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+	"github.com/edwarnicke/govpp/binapi/policer"
+	"github.com/edwarnicke/govpp/binapi/policer_types"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const (
+	// noTable - ~0 used by the vpp classifier api for 'no table'
+	noTable = ^uint32(0)
+	// classifyVectorSize - size of the classifier mask and match vectors
+	classifyVectorSize = 16
+	// groupBit - I/G bit of the first octet of the destination MAC address set for the broadcast and multicast frames
+	groupBit = 0x01
+)
+
+// stormControl - policer limiting the broadcast and multicast frames of the bridge domain and the L2 classifier table
+// directing them to the policer
+type stormControl struct {
+	name         string
+	policerIndex uint32
+	tableIndex   uint32
+	pps          uint32
+	// generation of the policer, the policer is replaced by a new one when the rate changes
+	generation uint32
+	bridgeID   uint32
+	mu         sync.Mutex
+}
+
+func createStormControl(ctx context.Context, vppConn api.Connection, bridgeID, pps uint32) (*stormControl, error) {
+	s := &stormControl{
+		name:       fmt.Sprintf("nsm-storm-bd%d", bridgeID),
+		tableIndex: noTable,
+		pps:        pps,
+		bridgeID:   bridgeID,
+	}
+	var err error
+	if s.policerIndex, err = addPolicer(ctx, vppConn, s.name, pps); err != nil {
+		return nil, err
+	}
+
+	mask := make([]byte, classifyVectorSize)
+	mask[0] = groupBit
+	now := time.Now()
+	tableReply, err := classify.NewServiceClient(vppConn).ClassifyAddDelTable(ctx, &classify.ClassifyAddDelTable{
+		IsAdd:          true,
+		TableIndex:     noTable,
+		Nbuckets:       2,
+		MemorySize:     1 << 16,
+		MatchNVectors:  1,
+		NextTableIndex: noTable,
+		MissNextIndex:  noTable,
+		MaskLen:        uint32(len(mask)),
+		Mask:           mask,
+	})
+	if err != nil {
+		_ = s.del(ctx, vppConn)
+		return nil, errors.WithStack(err)
+	}
+	s.tableIndex = tableReply.NewTableIndex
+	log.FromContext(ctx).
+		WithField("tableIndex", s.tableIndex).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "ClassifyAddDelTable").Debug("completed")
+
+	if err = s.setSession(ctx, vppConn); err != nil {
+		_ = s.del(ctx, vppConn)
+		return nil, err
+	}
+	return s, nil
+}
+
+// setRate replaces the policer with the one limiting the frames to pps. The classifier session is updated in place to
+// point to the new policer, so the frames are policed without a gap.
+func (s *stormControl) setRate(ctx context.Context, vppConn api.Connection, pps uint32) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.pps == pps || s.tableIndex == noTable {
+		return nil
+	}
+	name := fmt.Sprintf("nsm-storm-bd%d-%d", s.bridgeID, s.generation+1)
+	policerIndex, err := addPolicer(ctx, vppConn, name, pps)
+	if err != nil {
+		return err
+	}
+	prevName, prevIndex := s.name, s.policerIndex
+	s.name, s.policerIndex = name, policerIndex
+	if err = s.setSession(ctx, vppConn); err != nil {
+		s.name, s.policerIndex = prevName, prevIndex
+		_ = delPolicer(ctx, vppConn, name)
+		return err
+	}
+	s.generation++
+	s.pps = pps
+	return delPolicer(ctx, vppConn, prevName)
+}
+
+// setSession adds the classifier session directing the broadcast and multicast frames to the policer, or updates the
+// policer of the existing one
+func (s *stormControl) setSession(ctx context.Context, vppConn api.Connection) error {
+	match := make([]byte, classifyVectorSize)
+	match[0] = groupBit
+	now := time.Now()
+	if _, err := classify.NewServiceClient(vppConn).ClassifyAddDelSession(ctx, &classify.ClassifyAddDelSession{
+		IsAdd:      true,
+		TableIndex: s.tableIndex,
+		// The policer classifier expects the policer index as the hit next index and the precolor as the opaque index
+		HitNextIndex: s.policerIndex,
+		OpaqueIndex:  0,
+		MatchLen:     uint32(len(match)),
+		Match:        match,
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("tableIndex", s.tableIndex).
+		WithField("policerIndex", s.policerIndex).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "ClassifyAddDelSession").Debug("completed")
+	return nil
+}
+
+func addPolicer(ctx context.Context, vppConn api.Connection, name string, pps uint32) (uint32, error) {
+	// 100ms of the rate is allowed in a burst
+	burst := uint64(pps / 10)
+	if burst == 0 {
+		burst = 1
+	}
+
+	now := time.Now()
+	policerReply, err := policer.NewServiceClient(vppConn).PolicerAddDel(ctx, &policer.PolicerAddDel{
+		IsAdd:         true,
+		Name:          name,
+		Cir:           pps,
+		Cb:            burst,
+		RateType:      policer_types.SSE2_QOS_RATE_API_PPS,
+		RoundType:     policer_types.SSE2_QOS_ROUND_API_TO_CLOSEST,
+		Type:          policer_types.SSE2_QOS_POLICER_TYPE_API_1R2C,
+		ConformAction: policer_types.Sse2QosAction{Type: policer_types.SSE2_QOS_ACTION_API_TRANSMIT},
+		ExceedAction:  policer_types.Sse2QosAction{Type: policer_types.SSE2_QOS_ACTION_API_DROP},
+		ViolateAction: policer_types.Sse2QosAction{Type: policer_types.SSE2_QOS_ACTION_API_DROP},
+	})
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("name", name).
+		WithField("policerIndex", policerReply.PolicerIndex).
+		WithField("pps", pps).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "PolicerAddDel").Debug("completed")
+	return policerReply.PolicerIndex, nil
+}
+
+func delPolicer(ctx context.Context, vppConn api.Connection, name string) error {
+	now := time.Now()
+	if _, err := policer.NewServiceClient(vppConn).PolicerAddDel(ctx, &policer.PolicerAddDel{
+		IsAdd: false,
+		Name:  name,
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("name", name).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "PolicerAddDel").Debug("completed")
+	return nil
+}
+
+// setInterface enables/disables the storm control of the frames received on the interface
+func (s *stormControl) setInterface(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, isAdd bool) error {
+	now := time.Now()
+	if _, err := classify.NewServiceClient(vppConn).PolicerClassifySetInterface(ctx, &classify.PolicerClassifySetInterface{
+		SwIfIndex:     swIfIndex,
+		IP4TableIndex: noTable,
+		IP6TableIndex: noTable,
+		L2TableIndex:  s.tableIndex,
+		IsAdd:         isAdd,
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", swIfIndex).
+		WithField("l2TableIndex", s.tableIndex).
+		WithField("isAdd", isAdd).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "PolicerClassifySetInterface").Debug("completed")
+	return nil
+}
+
+func (s *stormControl) del(ctx context.Context, vppConn api.Connection) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.tableIndex != noTable {
+		now := time.Now()
+		if _, err := classify.NewServiceClient(vppConn).ClassifyAddDelTable(ctx, &classify.ClassifyAddDelTable{
+			IsAdd:          false,
+			TableIndex:     s.tableIndex,
+			NextTableIndex: noTable,
+			MissNextIndex:  noTable,
+		}); err != nil {
+			return errors.WithStack(err)
+		}
+		log.FromContext(ctx).
+			WithField("tableIndex", s.tableIndex).
+			WithField("duration", time.Since(now)).
+			WithField("vppapi", "ClassifyAddDelTable").Debug("completed")
+		s.tableIndex = noTable
+	}
+	return delPolicer(ctx, vppConn, s.name)
+}
@@ -31,13 +31,27 @@ import (
 )
 
 type l2XConnectServer struct {
-	vppConn api.Connection
+	vppConn   api.Connection
+	strictMTU bool
+	hubs      *hubs
 }
 
 // NewClient returns a Client chain element that will cross connect a client and server vpp interface (if present)
-func NewClient(vppConn api.Connection) networkservice.NetworkServiceClient {
+func NewClient(vppConn api.Connection, opts ...Option) networkservice.NetworkServiceClient {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	var h *hubs
+	if o.hubMode {
+		h = newHubs()
+	}
+
 	return &l2XConnectServer{
-		vppConn: vppConn,
+		vppConn:   vppConn,
+		strictMTU: o.strictMTU,
+		hubs:      h,
 	}
 }
 
@@ -53,7 +67,7 @@ func (v *l2XConnectServer) Request(ctx context.Context, request *networkservice.
 		return nil, err
 	}
 
-	if err := addDel(ctx, v.vppConn, true); err != nil {
+	if err := v.addDel(ctx, true); err != nil {
 		closeCtx, cancelClose := postponeCtxFunc()
 		defer cancelClose()
 
@@ -71,6 +85,16 @@ func (v *l2XConnectServer) Close(ctx context.Context, conn *networkservice.Conne
 	if conn.GetPayload() != payload.Ethernet {
 		return next.Client(ctx).Close(ctx, conn, opts...)
 	}
-	_ = addDel(ctx, v.vppConn, false)
+	_ = v.addDel(ctx, false)
 	return next.Client(ctx).Close(ctx, conn, opts...)
 }
+
+func (v *l2XConnectServer) addDel(ctx context.Context, isAdd bool) error {
+	if v.hubs == nil {
+		return addDel(ctx, v.vppConn, isAdd, v.strictMTU)
+	}
+	if isAdd {
+		return v.hubs.add(ctx, v.vppConn, v.strictMTU)
+	}
+	return v.hubs.del(ctx, v.vppConn)
+}
@@ -23,16 +23,18 @@ import (
 	"time"
 
 	"git.fd.io/govpp.git/api"
+	"github.com/edwarnicke/govpp/binapi/interface_types"
 	"github.com/edwarnicke/govpp/binapi/l2"
 	"github.com/pkg/errors"
 
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
 
 	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/vlan"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/teardown"
 	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
 )
 
-func addDel(ctx context.Context, vppConn api.Connection, addDel bool) error {
+func addDel(ctx context.Context, vppConn api.Connection, addDel, strictMTU bool) error {
 	clientIfIndex, ok := ifindex.Load(ctx, true)
 	if !ok {
 		return nil
@@ -49,6 +51,19 @@ func addDel(ctx context.Context, vppConn api.Connection, addDel bool) error {
 		return nil
 	}
 
+	if !addDel {
+		// The cross connect is removed before the interfaces are deleted
+		return teardown.Do(ctx, teardown.L2, func(ctx context.Context) error {
+			return setXconnect(ctx, vppConn, clientIfIndex, serverIfIndex, false)
+		})
+	}
+	if err := reconcileMTU(ctx, vppConn, clientIfIndex, serverIfIndex, strictMTU); err != nil {
+		return err
+	}
+	return setXconnect(ctx, vppConn, clientIfIndex, serverIfIndex, true)
+}
+
+func setXconnect(ctx context.Context, vppConn api.Connection, clientIfIndex, serverIfIndex interface_types.InterfaceIndex, addDel bool) error {
 	now := time.Now()
 	if _, err := l2.NewServiceClient(vppConn).SwInterfaceSetL2Xconnect(ctx, &l2.SwInterfaceSetL2Xconnect{
 		RxSwIfIndex: clientIfIndex,
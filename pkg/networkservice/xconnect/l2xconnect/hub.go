@@ -0,0 +1,191 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package l2xconnect
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+	"github.com/edwarnicke/govpp/binapi/l2"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/mechanisms/vlan"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/teardown"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+)
+
+const (
+	hubShg   = 0
+	spokeShg = 1
+)
+
+type hub struct {
+	bdID   uint32
+	spokes map[interface_types.InterfaceIndex]struct{}
+}
+
+// hubs - the client interfaces shared by the connections (the hubs) bridged to the server interfaces of the
+// connections (the spokes). The spokes are in the same split horizon group, so they reach only the hub.
+type hubs struct {
+	mu   sync.Mutex
+	hubs map[interface_types.InterfaceIndex]*hub
+}
+
+func newHubs() *hubs {
+	return &hubs{
+		hubs: make(map[interface_types.InterfaceIndex]*hub),
+	}
+}
+
+func (h *hubs) add(ctx context.Context, vppConn api.Connection, strictMTU bool) error {
+	hubIfIndex, ok := ifindex.Load(ctx, true)
+	if !ok {
+		return nil
+	}
+	spokeIfIndex, ok := ifindex.Load(ctx, false)
+	if !ok {
+		return nil
+	}
+	if vlanID, ok := vlan.Load(ctx, true); ok {
+		log.FromContext(ctx).
+			WithField("VLAN-ID", vlanID).Info("bridge is used instead of xconnect")
+		return nil
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	hb, ok := h.hubs[hubIfIndex]
+	if ok {
+		if _, ok = hb.spokes[spokeIfIndex]; ok {
+			return nil
+		}
+	}
+	if err := reconcileMTU(ctx, vppConn, hubIfIndex, spokeIfIndex, strictMTU); err != nil {
+		return err
+	}
+	if !ok {
+		bdID, err := addDelHubBridgeDomain(ctx, vppConn, ^uint32(0), true)
+		if err != nil {
+			return err
+		}
+		if err = setHubInterface(ctx, vppConn, hubIfIndex, bdID, hubShg, true); err != nil {
+			_, _ = addDelHubBridgeDomain(ctx, vppConn, bdID, false)
+			return err
+		}
+		hb = &hub{
+			bdID:   bdID,
+			spokes: make(map[interface_types.InterfaceIndex]struct{}),
+		}
+		h.hubs[hubIfIndex] = hb
+	}
+	if err := setHubInterface(ctx, vppConn, spokeIfIndex, hb.bdID, spokeShg, true); err != nil {
+		return err
+	}
+	hb.spokes[spokeIfIndex] = struct{}{}
+	return nil
+}
+
+// del removes the spoke from the hub, the bridge domain is deleted with the last spoke
+func (h *hubs) del(ctx context.Context, vppConn api.Connection) error {
+	hubIfIndex, ok := ifindex.Load(ctx, true)
+	if !ok {
+		return nil
+	}
+	spokeIfIndex, ok := ifindex.Load(ctx, false)
+	if !ok {
+		return nil
+	}
+
+	// The spoke is removed from the hub before the interfaces are deleted
+	return teardown.Do(ctx, teardown.L2, func(ctx context.Context) error {
+		return h.release(ctx, vppConn, hubIfIndex, spokeIfIndex)
+	})
+}
+
+func (h *hubs) release(ctx context.Context, vppConn api.Connection, hubIfIndex, spokeIfIndex interface_types.InterfaceIndex) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	hb, ok := h.hubs[hubIfIndex]
+	if !ok {
+		return nil
+	}
+	if _, ok = hb.spokes[spokeIfIndex]; ok {
+		if err := setHubInterface(ctx, vppConn, spokeIfIndex, hb.bdID, spokeShg, false); err != nil {
+			return err
+		}
+		delete(hb.spokes, spokeIfIndex)
+	}
+	if len(hb.spokes) > 0 {
+		return nil
+	}
+	if err := setHubInterface(ctx, vppConn, hubIfIndex, hb.bdID, hubShg, false); err != nil {
+		return err
+	}
+	if _, err := addDelHubBridgeDomain(ctx, vppConn, hb.bdID, false); err != nil {
+		return err
+	}
+	delete(h.hubs, hubIfIndex)
+	return nil
+}
+
+func addDelHubBridgeDomain(ctx context.Context, vppConn api.Connection, bdID uint32, isAdd bool) (uint32, error) {
+	now := time.Now()
+	rsp, err := l2.NewServiceClient(vppConn).BridgeDomainAddDelV2(ctx, &l2.BridgeDomainAddDelV2{
+		IsAdd:   isAdd,
+		BdID:    bdID,
+		Flood:   true,
+		Forward: true,
+		Learn:   true,
+		UuFlood: true,
+	})
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("bridgeID", rsp.BdID).
+		WithField("isAdd", isAdd).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "BridgeDomainAddDelV2").Debug("completed")
+	return rsp.BdID, nil
+}
+
+func setHubInterface(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, bdID uint32, shg uint8, isAdd bool) error {
+	now := time.Now()
+	if _, err := l2.NewServiceClient(vppConn).SwInterfaceSetL2Bridge(ctx, &l2.SwInterfaceSetL2Bridge{
+		RxSwIfIndex: swIfIndex,
+		Enable:      isAdd,
+		BdID:        bdID,
+		Shg:         shg,
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", swIfIndex).
+		WithField("bridgeID", bdID).
+		WithField("isAdd", isAdd).
+		WithField("shg", shg).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "SwInterfaceSetL2Bridge").Debug("completed")
+	return nil
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package l2xconnect
+
+import (
+	"context"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	interfaces "github.com/edwarnicke/govpp/binapi/interface"
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// reconcileMTU makes sure the cross connected interfaces have the same MTU, otherwise the frames larger than the
+// smaller MTU are silently dropped in one direction. The smaller MTU is set on both interfaces or, if strict, an error
+// is returned.
+func reconcileMTU(ctx context.Context, vppConn api.Connection, clientIfIndex, serverIfIndex interface_types.InterfaceIndex, strict bool) error {
+	clientMTU, err := getMTU(ctx, vppConn, clientIfIndex)
+	if err != nil {
+		return err
+	}
+	serverMTU, err := getMTU(ctx, vppConn, serverIfIndex)
+	if err != nil {
+		return err
+	}
+	if clientMTU == serverMTU || clientMTU == 0 || serverMTU == 0 {
+		return nil
+	}
+	if strict {
+		return errors.Errorf("unable to cross connect the client interface %d with MTU %d and the server interface %d with MTU %d",
+			clientIfIndex, clientMTU, serverIfIndex, serverMTU)
+	}
+	swIfIndex, mtu := clientIfIndex, serverMTU
+	if serverMTU > clientMTU {
+		swIfIndex, mtu = serverIfIndex, clientMTU
+	}
+	log.FromContext(ctx).
+		WithField("clientMTU", clientMTU).
+		WithField("serverMTU", serverMTU).
+		Warnf("cross connected interfaces have different MTUs, setting MTU %d on swIfIndex %d", mtu, swIfIndex)
+	return setMTU(ctx, vppConn, swIfIndex, mtu)
+}
+
+func getMTU(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex) (uint32, error) {
+	now := time.Now()
+	client, err := interfaces.NewServiceClient(vppConn).SwInterfaceDump(ctx, &interfaces.SwInterfaceDump{
+		SwIfIndex: swIfIndex,
+	})
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	defer func() { _ = client.Close() }()
+
+	details, err := client.Recv()
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable to get the details of swIfIndex %d", swIfIndex)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", swIfIndex).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "SwInterfaceDump").Debug("completed")
+	if len(details.Mtu) == 0 {
+		return 0, nil
+	}
+	return details.Mtu[0], nil
+}
+
+func setMTU(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, mtu uint32) error {
+	now := time.Now()
+	if _, err := interfaces.NewServiceClient(vppConn).SwInterfaceSetMtu(ctx, &interfaces.SwInterfaceSetMtu{
+		SwIfIndex: swIfIndex,
+		Mtu:       []uint32{mtu, mtu, mtu, mtu},
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", swIfIndex).
+		WithField("MTU", mtu).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "SwInterfaceSetMtu").Debug("completed")
+	return nil
+}
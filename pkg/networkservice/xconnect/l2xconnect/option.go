@@ -0,0 +1,42 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package l2xconnect
+
+type options struct {
+	strictMTU bool
+	hubMode   bool
+}
+
+// Option is an option pattern for l2xconnect client/server
+type Option func(o *options)
+
+// WithStrictMTU fails the Request cross connecting the interfaces with the different MTUs instead of setting the
+// smaller MTU on both of them
+func WithStrictMTU() Option {
+	return func(o *options) {
+		o.strictMTU = true
+	}
+}
+
+// WithHubMode bridges the client interface shared by several connections (the hub) to the server interfaces of the
+// connections (the spokes) instead of the cross connect. The spokes are in the same split horizon group, so they reach
+// only the hub, e.g. to model an access concentrator without a bridge domain per service.
+func WithHubMode() Option {
+	return func(o *options) {
+		o.hubMode = true
+	}
+}
@@ -27,16 +27,32 @@ import (
 	"github.com/networkservicemesh/api/pkg/api/networkservice/payload"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
 	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/xconnect/payloadadapter"
 )
 
 type l2XconnectServer struct {
-	vppConn api.Connection
+	vppConn   api.Connection
+	strictMTU bool
+	hubs      *hubs
 }
 
 // NewServer returns a Server chain element that will cross connect a client and server vpp interface (if present)
-func NewServer(vppConn api.Connection) networkservice.NetworkServiceServer {
+func NewServer(vppConn api.Connection, opts ...Option) networkservice.NetworkServiceServer {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	var h *hubs
+	if o.hubMode {
+		h = newHubs()
+	}
+
 	return &l2XconnectServer{
-		vppConn: vppConn,
+		vppConn:   vppConn,
+		strictMTU: o.strictMTU,
+		hubs:      h,
 	}
 }
 
@@ -52,7 +68,12 @@ func (v *l2XconnectServer) Request(ctx context.Context, request *networkservice.
 		return nil, err
 	}
 
-	if err := addDel(ctx, v.vppConn, true); err != nil {
+	// The interfaces of the converted payload connection are routed instead
+	if _, ok := payloadadapter.Load(ctx); ok {
+		return conn, nil
+	}
+
+	if err := v.addDel(ctx, true); err != nil {
 		closeCtx, cancelClose := postponeCtxFunc()
 		defer cancelClose()
 
@@ -69,10 +90,23 @@ func (v *l2XconnectServer) Close(ctx context.Context, conn *networkservice.Conne
 	if conn.GetPayload() != payload.Ethernet {
 		return next.Server(ctx).Close(ctx, conn)
 	}
-	_ = addDel(ctx, v.vppConn, false)
+	if _, ok := payloadadapter.Load(ctx); ok {
+		return next.Server(ctx).Close(ctx, conn)
+	}
+	_ = v.addDel(ctx, false)
 	rv, err := next.Server(ctx).Close(ctx, conn)
 	if err != nil {
 		return nil, err
 	}
 	return rv, nil
 }
+
+func (v *l2XconnectServer) addDel(ctx context.Context, isAdd bool) error {
+	if v.hubs == nil {
+		return addDel(ctx, v.vppConn, isAdd, v.strictMTU)
+	}
+	if isAdd {
+		return v.hubs.add(ctx, v.vppConn, v.strictMTU)
+	}
+	return v.hubs.del(ctx, v.vppConn)
+}
@@ -27,6 +27,9 @@ import (
 	"github.com/networkservicemesh/api/pkg/api/networkservice/payload"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
 	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/xconnect/l2bridgedomain"
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/xconnect/payloadadapter"
 )
 
 type l3XconnectServer struct {
@@ -52,6 +55,16 @@ func (v *l3XconnectServer) Request(ctx context.Context, request *networkservice.
 		return nil, err
 	}
 
+	// The connection is routed over the bridge domain BVI instead
+	if _, ok := l2bridgedomain.LoadBVI(ctx, false); ok {
+		return conn, nil
+	}
+
+	// The interfaces of the converted payload connection are routed instead
+	if _, ok := payloadadapter.Load(ctx); ok {
+		return conn, nil
+	}
+
 	if err := create(ctx, v.vppConn, conn); err != nil {
 		closeCtx, cancelClose := postponeCtxFunc()
 		defer cancelClose()
@@ -70,6 +83,12 @@ func (v *l3XconnectServer) Close(ctx context.Context, conn *networkservice.Conne
 	if conn.GetPayload() != payload.IP {
 		return next.Server(ctx).Close(ctx, conn)
 	}
+	if _, ok := l2bridgedomain.LoadBVI(ctx, false); ok {
+		return next.Server(ctx).Close(ctx, conn)
+	}
+	if _, ok := payloadadapter.Load(ctx); ok {
+		return next.Server(ctx).Close(ctx, conn)
+	}
 	_ = del(ctx, v.vppConn)
 	rv, err := next.Server(ctx).Close(ctx, conn)
 	if err != nil {
@@ -0,0 +1,93 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package payloadadapter
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+type payloadAdapterClient struct{}
+
+// NewClient returns a client chain element swapping the connection payload with the RemotePayloadLabel label on the
+// client side of the connections converted by the server element. It has to be placed after the
+// mechanismtranslation client element, so the client side mechanisms are selected for the converted payload.
+func NewClient() networkservice.NetworkServiceClient {
+	return new(payloadAdapterClient)
+}
+
+func (c *payloadAdapterClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	value, ok := load(ctx)
+	if !ok {
+		return next.Client(ctx).Request(ctx, request, opts...)
+	}
+
+	clientRequest := request.Clone()
+	swap(clientRequest.GetConnection(), value.clientPayload)
+
+	clientConn, err := next.Client(ctx).Request(ctx, clientRequest, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// The endpoint is reached without the remote hop, so it has to get the connection payload
+	if value.ingress && clientConn.GetMechanism().GetCls() != cls.REMOTE {
+		log.FromContext(ctx).WithField("payloadAdapter", "client").
+			Infof("%s is not a remote mechanism, falling back to the %s payload", clientConn.GetMechanism().GetType(), request.GetConnection().GetPayload())
+		if _, closeErr := next.Client(ctx).Close(ctx, clientConn.Clone(), opts...); closeErr != nil {
+			return nil, errors.Wrap(closeErr, "failed to close the converted connection")
+		}
+		remove(ctx)
+
+		fallbackRequest := request.Clone()
+		fallbackRequest.GetConnection().Mechanism = nil
+		return next.Client(ctx).Request(ctx, fallbackRequest, opts...)
+	}
+
+	conn := clientConn.Clone()
+	swap(conn, value.clientPayload)
+	return conn, nil
+}
+
+func (c *payloadAdapterClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	if value, ok := load(ctx); ok {
+		conn = conn.Clone()
+		swap(conn, value.clientPayload)
+	}
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
+
+// swap swaps the connection payload with the RemotePayloadLabel label, the label value of the converted connection
+// is the payload of the other side of the forwarder
+func swap(conn *networkservice.Connection, clientPayload string) {
+	if conn.GetLabels() == nil {
+		conn.Labels = make(map[string]string)
+	}
+	if conn.GetPayload() == clientPayload {
+		conn.GetLabels()[RemotePayloadLabel], conn.Payload = clientPayload, conn.GetLabels()[RemotePayloadLabel]
+		return
+	}
+	conn.GetLabels()[RemotePayloadLabel], conn.Payload = conn.GetPayload(), clientPayload
+}
@@ -0,0 +1,314 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package payloadadapter
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/edwarnicke/govpp/binapi/arp"
+	"github.com/edwarnicke/govpp/binapi/fib_types"
+	interfaces "github.com/edwarnicke/govpp/binapi/interface"
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+	"github.com/edwarnicke/govpp/binapi/ip"
+	"github.com/edwarnicke/govpp/binapi/ip6_nd"
+	"github.com/edwarnicke/govpp/binapi/ip_types"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/payload"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/types"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/uplink"
+)
+
+// adapterRoute - route programmed between the ETHERNET and the IP payload interfaces
+type adapterRoute struct {
+	prefix  *net.IPNet
+	nextHop net.IP
+	via     interface_types.InterfaceIndex
+}
+
+// sides - the ETHERNET payload interface with the addresses behind it and the IP payload interface with the addresses
+// behind it
+type sides struct {
+	l2IfIndex interface_types.InterfaceIndex
+	l2IPNets  []*net.IPNet
+	l2Routes  []*networkservice.Route
+	l3IfIndex interface_types.InterfaceIndex
+	l3IPNets  []*net.IPNet
+	l3Routes  []*networkservice.Route
+}
+
+func loadSides(ctx context.Context, conn *networkservice.Connection, clientPayload string) (*sides, bool) {
+	clientIfIndex, ok := ifindex.Load(ctx, true)
+	if !ok {
+		return nil, false
+	}
+	serverIfIndex, ok := ifindex.Load(ctx, false)
+	if !ok {
+		return nil, false
+	}
+	ipContext := conn.GetContext().GetIpContext()
+	if clientPayload == payload.IP {
+		// The client of the connection is behind the ETHERNET payload server interface
+		return &sides{
+			l2IfIndex: serverIfIndex,
+			l2IPNets:  ipContext.GetSrcIPNets(),
+			l2Routes:  ipContext.GetSrcRoutes(),
+			l3IfIndex: clientIfIndex,
+			l3IPNets:  ipContext.GetDstIPNets(),
+			l3Routes:  ipContext.GetDstRoutes(),
+		}, true
+	}
+	// The endpoint of the connection is behind the ETHERNET payload client interface
+	return &sides{
+		l2IfIndex: clientIfIndex,
+		l2IPNets:  ipContext.GetDstIPNets(),
+		l2Routes:  ipContext.GetDstRoutes(),
+		l3IfIndex: serverIfIndex,
+		l3IPNets:  ipContext.GetSrcIPNets(),
+		l3Routes:  ipContext.GetSrcRoutes(),
+	}, true
+}
+
+// create enables IP on the ETHERNET payload interface and routes it to the IP payload interface
+func create(ctx context.Context, vppConn api.Connection, conn *networkservice.Connection, clientPayload string) error {
+	s, ok := loadSides(ctx, conn, clientPayload)
+	if !ok {
+		return nil
+	}
+	if err := enableIP(ctx, vppConn, s.l2IfIndex, s.l2IPNets); err != nil {
+		return err
+	}
+	for _, route := range s.routes() {
+		if err := addDelVppRoute(ctx, vppConn, route, true); err != nil {
+			return err
+		}
+	}
+	return addDelProxy(ctx, vppConn, s.l2IfIndex, s.l3IPNets, true)
+}
+
+// del removes the routes and the ARP/ND proxies, the interface settings are removed together with the interfaces
+func del(ctx context.Context, vppConn api.Connection, conn *networkservice.Connection, clientPayload string) error {
+	s, ok := loadSides(ctx, conn, clientPayload)
+	if !ok {
+		return nil
+	}
+	for _, route := range s.routes() {
+		if err := addDelVppRoute(ctx, vppConn, route, false); err != nil {
+			return err
+		}
+	}
+	return addDelProxy(ctx, vppConn, s.l2IfIndex, s.l3IPNets, false)
+}
+
+// routes returns the routes to the addresses behind the ETHERNET payload interface resolved with ARP/ND and the routes
+// to the addresses behind the IP payload interface
+func (s *sides) routes() []*adapterRoute {
+	var rv []*adapterRoute
+	for _, ipNet := range s.l2IPNets {
+		rv = append(rv, &adapterRoute{
+			prefix:  hostIPNet(ipNet.IP),
+			nextHop: ipNet.IP,
+			via:     s.l2IfIndex,
+		})
+	}
+	for _, route := range s.l2Routes {
+		prefix := route.GetPrefixIPNet()
+		if prefix == nil {
+			continue
+		}
+		nextHop := route.GetNextHopIP()
+		for _, ipNet := range s.l2IPNets {
+			if nextHop == nil && (ipNet.IP.To4() == nil) == (prefix.IP.To4() == nil) {
+				nextHop = ipNet.IP
+			}
+		}
+		if nextHop == nil {
+			continue
+		}
+		rv = append(rv, &adapterRoute{
+			prefix:  prefix,
+			nextHop: nextHop,
+			via:     s.l2IfIndex,
+		})
+	}
+	for _, ipNet := range s.l3IPNets {
+		rv = append(rv, &adapterRoute{
+			prefix: hostIPNet(ipNet.IP),
+			via:    s.l3IfIndex,
+		})
+	}
+	for _, route := range s.l3Routes {
+		if prefix := route.GetPrefixIPNet(); prefix != nil {
+			rv = append(rv, &adapterRoute{
+				prefix: prefix,
+				via:    s.l3IfIndex,
+			})
+		}
+	}
+	return rv
+}
+
+func hostIPNet(ipAddr net.IP) *net.IPNet {
+	if ip4 := ipAddr.To4(); ip4 != nil {
+		return &net.IPNet{IP: ip4, Mask: net.CIDRMask(net.IPv4len*8, net.IPv4len*8)}
+	}
+	return &net.IPNet{IP: ipAddr, Mask: net.CIDRMask(net.IPv6len*8, net.IPv6len*8)}
+}
+
+// enableIP makes the ETHERNET payload interface answer the ARP/ND requests and resolve the addresses behind it.
+// The IPv4 ARP requests are sent from the address of the default route uplink borrowed by the interface, the IPv6 ND
+// ones from the link local address.
+func enableIP(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, ipNets []*net.IPNet) error {
+	var hasIPv4, hasIPv6 bool
+	for _, ipNet := range ipNets {
+		hasIPv4 = hasIPv4 || ipNet.IP.To4() != nil
+		hasIPv6 = hasIPv6 || ipNet.IP.To4() == nil
+	}
+
+	now := time.Now()
+	if _, err := arp.NewServiceClient(vppConn).ProxyArpIntfcEnableDisable(ctx, &arp.ProxyArpIntfcEnableDisable{
+		SwIfIndex: swIfIndex,
+		Enable:    true,
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", swIfIndex).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "ProxyArpIntfcEnableDisable").Debug("completed")
+
+	if hasIPv6 {
+		now = time.Now()
+		if _, err := ip.NewServiceClient(vppConn).SwInterfaceIP6EnableDisable(ctx, &ip.SwInterfaceIP6EnableDisable{
+			SwIfIndex: swIfIndex,
+			Enable:    true,
+		}); err != nil {
+			return errors.WithStack(err)
+		}
+		log.FromContext(ctx).
+			WithField("swIfIndex", swIfIndex).
+			WithField("duration", time.Since(now)).
+			WithField("vppapi", "SwInterfaceIP6EnableDisable").Debug("completed")
+	}
+
+	if !hasIPv4 {
+		return nil
+	}
+	up, err := uplink.ByDefaultRoute(ctx, vppConn, false)
+	if err != nil {
+		log.FromContext(ctx).Warnf("no address for the ARP requests of the interface %d: %v", swIfIndex, err)
+		return nil
+	}
+
+	now = time.Now()
+	if _, err := interfaces.NewServiceClient(vppConn).SwInterfaceSetUnnumbered(ctx, &interfaces.SwInterfaceSetUnnumbered{
+		SwIfIndex:           up.SwIfIndex,
+		UnnumberedSwIfIndex: swIfIndex,
+		IsAdd:               true,
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", up.SwIfIndex).
+		WithField("unnumberedSwIfIndex", swIfIndex).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "SwInterfaceSetUnnumbered").Debug("completed")
+	return nil
+}
+
+func addDelVppRoute(ctx context.Context, vppConn api.Connection, route *adapterRoute, isAdd bool) error {
+	isIPv6 := route.prefix.IP.To4() == nil
+	path := fib_types.FibPath{
+		SwIfIndex: uint32(route.via),
+		Weight:    1,
+		Type:      fib_types.FIB_API_PATH_TYPE_NORMAL,
+		Flags:     fib_types.FIB_API_PATH_FLAG_NONE,
+		Proto:     types.IsV6toFibProto(isIPv6),
+	}
+	if route.nextHop != nil {
+		path.Nh.Address = types.ToVppAddress(route.nextHop).Un
+	}
+	now := time.Now()
+	if _, err := ip.NewServiceClient(vppConn).IPRouteAddDel(ctx, &ip.IPRouteAddDel{
+		IsAdd: isAdd,
+		Route: ip.IPRoute{
+			Prefix: types.ToVppPrefix(route.prefix),
+			NPaths: 1,
+			Paths:  []fib_types.FibPath{path},
+		},
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", route.via).
+		WithField("prefix", route.prefix).
+		WithField("nextHop", route.nextHop).
+		WithField("isAdd", isAdd).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "IPRouteAddDel").Info("completed")
+	return nil
+}
+
+// addDelProxy makes the ETHERNET payload interface answer the ARP/ND requests for the addresses behind the IP payload
+// interface
+func addDelProxy(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, ipNets []*net.IPNet, isAdd bool) error {
+	for _, ipNet := range ipNets {
+		now := time.Now()
+		if ip4 := ipNet.IP.To4(); ip4 != nil {
+			var addr ip_types.IP4Address
+			copy(addr[:], ip4)
+			if _, err := arp.NewServiceClient(vppConn).ProxyArpAddDel(ctx, &arp.ProxyArpAddDel{
+				IsAdd: isAdd,
+				Proxy: arp.ProxyArp{
+					Low: addr,
+					Hi:  addr,
+				},
+			}); err != nil {
+				return errors.WithStack(err)
+			}
+			log.FromContext(ctx).
+				WithField("ip", ip4).
+				WithField("isAdd", isAdd).
+				WithField("duration", time.Since(now)).
+				WithField("vppapi", "ProxyArpAddDel").Debug("completed")
+			continue
+		}
+		var addr ip_types.IP6Address
+		copy(addr[:], ipNet.IP.To16())
+		if _, err := ip6_nd.NewServiceClient(vppConn).IP6ndProxyAddDel(ctx, &ip6_nd.IP6ndProxyAddDel{
+			SwIfIndex: swIfIndex,
+			IsAdd:     isAdd,
+			IP:        addr,
+		}); err != nil {
+			return errors.WithStack(err)
+		}
+		log.FromContext(ctx).
+			WithField("swIfIndex", swIfIndex).
+			WithField("ip", ipNet.IP).
+			WithField("isAdd", isAdd).
+			WithField("duration", time.Since(now)).
+			WithField("vppapi", "IP6ndProxyAddDel").Debug("completed")
+	}
+	return nil
+}
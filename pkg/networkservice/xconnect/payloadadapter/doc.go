@@ -0,0 +1,24 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package payloadadapter provides chain elements converting the connection payload between the ETHERNET and the IP
+// payloads on the remote hop between the forwarders. The conversion is requested with the RemotePayloadLabel label.
+//
+// The forwarder of the connection client converts the payload for its client side and the forwarder of the
+// connection endpoint converts it back, so both the client and the endpoint get the payload of the network service.
+// The ETHERNET payload interface is routed to the IP payload one: the ETHERNET interface answers the ARP/ND requests
+// for the addresses behind the IP interface and the addresses behind the ETHERNET interface are resolved with ARP/ND.
+package payloadadapter
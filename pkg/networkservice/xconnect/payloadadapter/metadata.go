@@ -0,0 +1,60 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package payloadadapter
+
+import (
+	"context"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+type key struct{}
+
+// adaptation - payload conversion of the connection
+type adaptation struct {
+	// ingress - the server side is the local one, so the client side is expected to be a remote one
+	ingress       bool
+	clientPayload string
+}
+
+func store(ctx context.Context, value *adaptation) {
+	metadata.Map(ctx, false).Store(key{}, value)
+}
+
+func load(ctx context.Context) (value *adaptation, ok bool) {
+	rawValue, ok := metadata.Map(ctx, false).Load(key{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(*adaptation)
+	return value, ok
+}
+
+func remove(ctx context.Context) {
+	metadata.Map(ctx, false).Delete(key{})
+}
+
+// Load returns the payload of the client side of the connection, stored in per Connection.Id metadata.
+// The ok result indicates whether the payload differs between the server and the client sides, so the interfaces are
+// routed to each other instead of being cross connected.
+func Load(ctx context.Context) (clientPayload string, ok bool) {
+	value, ok := load(ctx)
+	if !ok {
+		return "", false
+	}
+	return value.clientPayload, true
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package payloadadapter
+
+import (
+	"context"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/payload"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+)
+
+type payloadAdapterServer struct {
+	vppConn api.Connection
+}
+
+// NewServer returns a server chain element deciding whether the payload of the connection is converted on its client
+// side and routing the ETHERNET payload interface to the IP payload one (if both are present) for the converted
+// connections. The xconnect elements skip the converted connections.
+func NewServer(vppConn api.Connection) networkservice.NetworkServiceServer {
+	return &payloadAdapterServer{
+		vppConn: vppConn,
+	}
+}
+
+func (s *payloadAdapterServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	if _, ok := load(ctx); !ok {
+		if value := newAdaptation(request); value != nil {
+			store(ctx, value)
+		}
+	}
+
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	// The client side may have fallen back to the connection payload
+	value, ok := load(ctx)
+	if !ok {
+		return conn, nil
+	}
+
+	if err := create(ctx, s.vppConn, conn, value.clientPayload); err != nil {
+		closeCtx, cancelClose := postponeCtxFunc()
+		defer cancelClose()
+
+		if _, closeErr := s.Close(closeCtx, conn); closeErr != nil {
+			err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+		}
+
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (s *payloadAdapterServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	if value, ok := load(ctx); ok {
+		if err := del(ctx, s.vppConn, conn, value.clientPayload); err != nil {
+			log.FromContext(ctx).WithField("payloadAdapter", "server").Error("del", err)
+		}
+	}
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+// newAdaptation returns the payload conversion requested for the connection or nil if there is none
+func newAdaptation(request *networkservice.NetworkServiceRequest) *adaptation {
+	conn := request.GetConnection()
+	remotePayload := conn.GetLabels()[RemotePayloadLabel]
+	if !isConvertible(conn.GetPayload()) || !isConvertible(remotePayload) || remotePayload == conn.GetPayload() {
+		return nil
+	}
+	mechanism := conn.GetMechanism()
+	if mechanism == nil && len(request.GetMechanismPreferences()) > 0 {
+		mechanism = request.GetMechanismPreferences()[0]
+	}
+	return &adaptation{
+		ingress:       mechanism.GetCls() != cls.REMOTE,
+		clientPayload: remotePayload,
+	}
+}
+
+func isConvertible(p string) bool {
+	return p == payload.Ethernet || p == payload.IP
+}
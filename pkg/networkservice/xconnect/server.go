@@ -27,9 +27,9 @@ import (
 )
 
 // NewServer - creates new xconnect server chain element to that correctly handles payload.IP and payload.Ethernet
-func NewServer(vppConn api.Connection) networkservice.NetworkServiceServer {
+func NewServer(vppConn api.Connection, l2Opts ...l2xconnect.Option) networkservice.NetworkServiceServer {
 	return chain.NewNetworkServiceServer(
-		l2xconnect.NewServer(vppConn),
+		l2xconnect.NewServer(vppConn, l2Opts...),
 		l3xconnect.NewServer(vppConn),
 	)
 }
@@ -0,0 +1,23 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package aclmanager composes the ACLs contributed by the independent elements (pinhole, acl, the default deny
+// policies) into the single ACL list of the vpp interface. The contributions are ordered by their priority, so the
+// elements don't overwrite each other's ACL attachments and the first matching rule is deterministic.
+//
+// The ACLs attached to the interface by someone else (e.g. by the operator) are kept after the contributions.
+// vpp allows a single MACIP ACL per interface, so the MACIP ACL of the highest priority contribution is attached.
+package aclmanager
@@ -0,0 +1,334 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aclmanager
+
+import (
+	"context"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/edwarnicke/govpp/binapi/acl"
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/keymutex"
+)
+
+// Priority - priority of the contribution, the ACLs of the lower value are matched first
+type Priority int
+
+const (
+	// PinholePriority - the holes for the tunnel protocols are opened before any other rule
+	PinholePriority Priority = 100
+	// ACLPriority - the ACLs configured for the connections
+	ACLPriority Priority = 200
+	// DefaultDenyPriority - the policies denying everything not permitted by the other contributions
+	DefaultDenyPriority Priority = 1000
+)
+
+type contribution struct {
+	owner    string
+	priority Priority
+	ingress  []uint32
+	egress   []uint32
+	macip    uint32
+}
+
+type interfaceACLs struct {
+	contributions map[string]*contribution
+	macip         map[string]*contribution
+	// applied - the ACLs of the contributions attached to the interface by the last apply
+	applied map[uint32]struct{}
+	// appliedMACIP - the MACIP ACL attached to the interface by the last applyMACIP
+	appliedMACIP *uint32
+}
+
+// Manager - the ACL lists of the vpp interfaces composed of the contributions. The zero Manager is ready to use.
+type Manager struct {
+	mu         sync.Mutex
+	interfaces map[interface_types.InterfaceIndex]*interfaceACLs
+}
+
+// Set sets the ingress and egress ACLs contributed by the owner to the interface and reprograms its ACL list. Setting
+// the contribution of the same owner again replaces it.
+func (m *Manager) Set(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, owner string, priority Priority, ingress, egress []uint32) error {
+	ctx, unlock := keymutex.LockInterface(ctx, swIfIndex)
+	defer unlock()
+
+	acls := m.load(swIfIndex)
+	acls.contributions[owner] = &contribution{
+		owner:    owner,
+		priority: priority,
+		ingress:  append([]uint32(nil), ingress...),
+		egress:   append([]uint32(nil), egress...),
+	}
+	return m.apply(ctx, vppConn, swIfIndex, acls)
+}
+
+// Remove removes the ACLs contributed by the owner from the interface and reprograms its ACL list
+func (m *Manager) Remove(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, owner string) error {
+	ctx, unlock := keymutex.LockInterface(ctx, swIfIndex)
+	defer unlock()
+
+	acls := m.load(swIfIndex)
+	if _, ok := acls.contributions[owner]; !ok {
+		m.release(swIfIndex, acls)
+		return nil
+	}
+	delete(acls.contributions, owner)
+	err := m.apply(ctx, vppConn, swIfIndex, acls)
+	m.release(swIfIndex, acls)
+	return err
+}
+
+// SetMACIP sets the MACIP ACL contributed by the owner to the interface, the MACIP ACL of the highest priority
+// contribution is attached
+func (m *Manager) SetMACIP(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, owner string, priority Priority, aclIndex uint32) error {
+	ctx, unlock := keymutex.LockInterface(ctx, swIfIndex)
+	defer unlock()
+
+	acls := m.load(swIfIndex)
+	acls.macip[owner] = &contribution{
+		owner:    owner,
+		priority: priority,
+		macip:    aclIndex,
+	}
+	return m.applyMACIP(ctx, vppConn, swIfIndex, acls)
+}
+
+// RemoveMACIP removes the MACIP ACL contributed by the owner from the interface, the MACIP ACL of the next contribution
+// is attached instead
+func (m *Manager) RemoveMACIP(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, owner string) error {
+	ctx, unlock := keymutex.LockInterface(ctx, swIfIndex)
+	defer unlock()
+
+	acls := m.load(swIfIndex)
+	if _, ok := acls.macip[owner]; !ok {
+		m.release(swIfIndex, acls)
+		return nil
+	}
+	delete(acls.macip, owner)
+	err := m.applyMACIP(ctx, vppConn, swIfIndex, acls)
+	m.release(swIfIndex, acls)
+	return err
+}
+
+func (m *Manager) load(swIfIndex interface_types.InterfaceIndex) *interfaceACLs {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.interfaces == nil {
+		m.interfaces = make(map[interface_types.InterfaceIndex]*interfaceACLs)
+	}
+	acls, ok := m.interfaces[swIfIndex]
+	if !ok {
+		acls = &interfaceACLs{
+			contributions: make(map[string]*contribution),
+			macip:         make(map[string]*contribution),
+			applied:       make(map[uint32]struct{}),
+		}
+		m.interfaces[swIfIndex] = acls
+	}
+	return acls
+}
+
+// release forgets the interface having no contributions, so the interfaces don't leak
+func (m *Manager) release(swIfIndex interface_types.InterfaceIndex, acls *interfaceACLs) {
+	if len(acls.contributions) > 0 || len(acls.macip) > 0 {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.interfaces, swIfIndex)
+}
+
+// sorted returns the contributions ordered by the priority and then by the owner
+func sorted(contributions map[string]*contribution) []*contribution {
+	rv := make([]*contribution, 0, len(contributions))
+	for _, c := range contributions {
+		rv = append(rv, c)
+	}
+	sort.Slice(rv, func(i, j int) bool {
+		if rv[i].priority != rv[j].priority {
+			return rv[i].priority < rv[j].priority
+		}
+		return rv[i].owner < rv[j].owner
+	})
+	return rv
+}
+
+// apply sets the ACL list of the interface to the ACLs of the contributions followed by the ACLs attached by someone
+// else
+func (m *Manager) apply(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, acls *interfaceACLs) error {
+	ingress, egress, err := dumpInterfaceACLs(ctx, vppConn, swIfIndex)
+	if err != nil {
+		return err
+	}
+
+	var newIngress, newEgress []uint32
+	applied := make(map[uint32]struct{})
+	for _, c := range sorted(acls.contributions) {
+		newIngress = append(newIngress, c.ingress...)
+		newEgress = append(newEgress, c.egress...)
+		for _, index := range append(append([]uint32(nil), c.ingress...), c.egress...) {
+			applied[index] = struct{}{}
+		}
+	}
+	foreign := func(index uint32) bool {
+		_, ok := applied[index]
+		_, wasApplied := acls.applied[index]
+		return !ok && !wasApplied
+	}
+	for _, index := range ingress {
+		if foreign(index) {
+			newIngress = append(newIngress, index)
+		}
+	}
+	for _, index := range egress {
+		if foreign(index) {
+			newEgress = append(newEgress, index)
+		}
+	}
+	if equalIndices(ingress, newIngress) && equalIndices(egress, newEgress) {
+		acls.applied = applied
+		return nil
+	}
+	interfaceACLList := &acl.ACLInterfaceSetACLList{
+		SwIfIndex: swIfIndex,
+		Count:     uint8(len(newIngress) + len(newEgress)),
+		NInput:    uint8(len(newIngress)),
+		Acls:      append(newIngress, newEgress...),
+	}
+	now := time.Now()
+	if _, err := acl.NewServiceClient(vppConn).ACLInterfaceSetACLList(ctx, interfaceACLList); err != nil {
+		return errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", swIfIndex).
+		WithField("acls", interfaceACLList.Acls).
+		WithField("NInput", interfaceACLList.NInput).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "ACLInterfaceSetACLList").Debug("completed")
+	// The ACLs attached by the last successful apply stay ours until vpp accepts the new list
+	acls.applied = applied
+	return nil
+}
+
+// applyMACIP attaches the MACIP ACL of the highest priority contribution to the interface, vpp replaces the attached
+// one
+func (m *Manager) applyMACIP(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, acls *interfaceACLs) error {
+	contributions := sorted(acls.macip)
+	if len(contributions) == 0 && acls.appliedMACIP == nil ||
+		len(contributions) > 0 && acls.appliedMACIP != nil && *acls.appliedMACIP == contributions[0].macip {
+		return nil
+	}
+
+	macipInterface := &acl.MacipACLInterfaceAddDel{
+		IsAdd:     len(contributions) > 0,
+		SwIfIndex: swIfIndex,
+	}
+	if macipInterface.IsAdd {
+		macipInterface.ACLIndex = contributions[0].macip
+	} else {
+		macipInterface.ACLIndex = *acls.appliedMACIP
+	}
+	now := time.Now()
+	if _, err := acl.NewServiceClient(vppConn).MacipACLInterfaceAddDel(ctx, macipInterface); err != nil {
+		return errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", swIfIndex).
+		WithField("isAdd", macipInterface.IsAdd).
+		WithField("aclIndex", macipInterface.ACLIndex).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "MacipACLInterfaceAddDel").Debug("completed")
+
+	acls.appliedMACIP = nil
+	if macipInterface.IsAdd {
+		acls.appliedMACIP = &macipInterface.ACLIndex
+	}
+	return nil
+}
+
+func dumpInterfaceACLs(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex) (ingress, egress []uint32, err error) {
+	now := time.Now()
+	client, err := acl.NewServiceClient(vppConn).ACLInterfaceListDump(ctx, &acl.ACLInterfaceListDump{
+		SwIfIndex: swIfIndex,
+	})
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+	defer func() { _ = client.Close() }()
+	log.FromContext(ctx).
+		WithField("swIfIndex", swIfIndex).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "ACLInterfaceListDump").Debug("completed")
+
+	for {
+		details, err := client.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, errors.WithStack(err)
+		}
+		if details.SwIfIndex == swIfIndex {
+			ingress = append(ingress, details.Acls[:details.NInput]...)
+			egress = append(egress, details.Acls[details.NInput:]...)
+		}
+	}
+	return ingress, egress, nil
+}
+
+func equalIndices(a, b []uint32) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+var defaultManager Manager
+
+// Set sets the ACLs contributed by the owner to the interface in the manager shared by all the elements
+func Set(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, owner string, priority Priority, ingress, egress []uint32) error {
+	return defaultManager.Set(ctx, vppConn, swIfIndex, owner, priority, ingress, egress)
+}
+
+// Remove removes the ACLs contributed by the owner from the interface in the manager shared by all the elements
+func Remove(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, owner string) error {
+	return defaultManager.Remove(ctx, vppConn, swIfIndex, owner)
+}
+
+// SetMACIP sets the MACIP ACL contributed by the owner to the interface in the manager shared by all the elements
+func SetMACIP(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, owner string, priority Priority, aclIndex uint32) error {
+	return defaultManager.SetMACIP(ctx, vppConn, swIfIndex, owner, priority, aclIndex)
+}
+
+// RemoveMACIP removes the MACIP ACL contributed by the owner from the interface in the manager shared by all the
+// elements
+func RemoveMACIP(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, owner string) error {
+	return defaultManager.RemoveMACIP(ctx, vppConn, swIfIndex, owner)
+}
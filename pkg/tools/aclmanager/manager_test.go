@@ -0,0 +1,244 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aclmanager_test
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/edwarnicke/govpp/binapi/acl"
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+	"github.com/edwarnicke/govpp/binapi/memclnt"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/aclmanager"
+)
+
+const swIfIndex interface_types.InterfaceIndex = 1
+
+// aclVPP - vpp keeping the ACL lists and the MACIP ACLs of the interfaces
+type aclVPP struct {
+	lists map[interface_types.InterfaceIndex]acl.ACLInterfaceListDetails
+	macip map[interface_types.InterfaceIndex]uint32
+	sets  int
+	// failSets - the ACLInterfaceSetACLList calls failing before the list is set
+	failSets int
+	mu       sync.Mutex
+}
+
+func newACLVPP() *aclVPP {
+	return &aclVPP{
+		lists: make(map[interface_types.InterfaceIndex]acl.ACLInterfaceListDetails),
+		macip: make(map[interface_types.InterfaceIndex]uint32),
+	}
+}
+
+func (v *aclVPP) Invoke(_ context.Context, req, _ api.Message) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	switch r := req.(type) {
+	case *acl.ACLInterfaceSetACLList:
+		if v.failSets > 0 {
+			v.failSets--
+			return errors.New("vpp is busy")
+		}
+		v.sets++
+		v.lists[r.SwIfIndex] = acl.ACLInterfaceListDetails{
+			SwIfIndex: r.SwIfIndex,
+			Count:     r.Count,
+			NInput:    r.NInput,
+			Acls:      append([]uint32(nil), r.Acls...),
+		}
+	case *acl.MacipACLInterfaceAddDel:
+		if r.IsAdd {
+			v.macip[r.SwIfIndex] = r.ACLIndex
+		} else {
+			delete(v.macip, r.SwIfIndex)
+		}
+	default:
+		return errors.Errorf("unexpected %s", req.GetMessageName())
+	}
+	return nil
+}
+
+func (v *aclVPP) NewStream(ctx context.Context, _ ...api.StreamOption) (api.Stream, error) {
+	return &aclStream{ctx: ctx, vpp: v}, nil
+}
+
+func (v *aclVPP) attach(ingress, egress []uint32) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.lists[swIfIndex] = acl.ACLInterfaceListDetails{
+		SwIfIndex: swIfIndex,
+		Count:     uint8(len(ingress) + len(egress)),
+		NInput:    uint8(len(ingress)),
+		Acls:      append(append([]uint32(nil), ingress...), egress...),
+	}
+}
+
+func (v *aclVPP) acls() (ingress, egress []uint32) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	details := v.lists[swIfIndex]
+	ingress = append(ingress, details.Acls[:details.NInput]...)
+	egress = append(egress, details.Acls[details.NInput:]...)
+	return ingress, egress
+}
+
+type aclStream struct {
+	ctx     context.Context
+	vpp     *aclVPP
+	replies []api.Message
+}
+
+func (s *aclStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *aclStream) SendMsg(msg api.Message) error {
+	switch m := msg.(type) {
+	case *acl.ACLInterfaceListDump:
+		s.vpp.mu.Lock()
+		if details, ok := s.vpp.lists[m.SwIfIndex]; ok {
+			s.replies = append(s.replies, &details)
+		}
+		s.vpp.mu.Unlock()
+	case *memclnt.ControlPing:
+		s.replies = append(s.replies, &memclnt.ControlPingReply{})
+	default:
+		return errors.Errorf("unexpected %s", msg.GetMessageName())
+	}
+	return nil
+}
+
+func (s *aclStream) RecvMsg() (api.Message, error) {
+	if len(s.replies) == 0 {
+		return nil, io.EOF
+	}
+	msg := s.replies[0]
+	s.replies = s.replies[1:]
+	return msg, nil
+}
+
+func (s *aclStream) Close() error {
+	return nil
+}
+
+func Test_Manager_OrdersContributionsByPriority(t *testing.T) {
+	ctx := context.Background()
+	vpp := newACLVPP()
+	m := new(aclmanager.Manager)
+
+	require.NoError(t, m.Set(ctx, vpp, swIfIndex, "acl", aclmanager.ACLPriority, []uint32{10}, []uint32{11}))
+	require.NoError(t, m.Set(ctx, vpp, swIfIndex, "deny", aclmanager.DefaultDenyPriority, []uint32{99}, []uint32{99}))
+	require.NoError(t, m.Set(ctx, vpp, swIfIndex, "pinhole", aclmanager.PinholePriority, []uint32{1}, []uint32{2}))
+
+	ingress, egress := vpp.acls()
+	require.Equal(t, []uint32{1, 10, 99}, ingress)
+	require.Equal(t, []uint32{2, 11, 99}, egress)
+
+	// The contributions of the same priority are ordered by the owner
+	require.NoError(t, m.Set(ctx, vpp, swIfIndex, "a-acl", aclmanager.ACLPriority, []uint32{20}, nil))
+	ingress, egress = vpp.acls()
+	require.Equal(t, []uint32{1, 20, 10, 99}, ingress)
+	require.Equal(t, []uint32{2, 11, 99}, egress)
+}
+
+func Test_Manager_ReplacesContributionOfOwner(t *testing.T) {
+	ctx := context.Background()
+	vpp := newACLVPP()
+	m := new(aclmanager.Manager)
+
+	require.NoError(t, m.Set(ctx, vpp, swIfIndex, "acl", aclmanager.ACLPriority, []uint32{10}, []uint32{11}))
+	require.NoError(t, m.Set(ctx, vpp, swIfIndex, "acl", aclmanager.ACLPriority, []uint32{12}, nil))
+
+	// The ACLs replaced by the owner are not kept as the foreign ones
+	ingress, egress := vpp.acls()
+	require.Equal(t, []uint32{12}, ingress)
+	require.Empty(t, egress)
+
+	// Setting the same ACLs again doesn't reprogram the interface
+	sets := vpp.sets
+	require.NoError(t, m.Set(ctx, vpp, swIfIndex, "acl", aclmanager.ACLPriority, []uint32{12}, nil))
+	require.Equal(t, sets, vpp.sets)
+}
+
+func Test_Manager_KeepsForeignACLs(t *testing.T) {
+	ctx := context.Background()
+	vpp := newACLVPP()
+	vpp.attach([]uint32{42}, []uint32{43})
+	m := new(aclmanager.Manager)
+
+	require.NoError(t, m.Set(ctx, vpp, swIfIndex, "pinhole", aclmanager.PinholePriority, []uint32{1}, []uint32{2}))
+	ingress, egress := vpp.acls()
+	require.Equal(t, []uint32{1, 42}, ingress)
+	require.Equal(t, []uint32{2, 43}, egress)
+
+	// The ACL attached by someone else meanwhile is kept too
+	vpp.attach(append(ingress, 50), egress)
+	require.NoError(t, m.Set(ctx, vpp, swIfIndex, "acl", aclmanager.ACLPriority, []uint32{10}, nil))
+	ingress, egress = vpp.acls()
+	require.Equal(t, []uint32{1, 10, 42, 50}, ingress)
+	require.Equal(t, []uint32{2, 43}, egress)
+
+	require.NoError(t, m.Remove(ctx, vpp, swIfIndex, "pinhole"))
+	require.NoError(t, m.Remove(ctx, vpp, swIfIndex, "acl"))
+	ingress, egress = vpp.acls()
+	require.Equal(t, []uint32{42, 50}, ingress)
+	require.Equal(t, []uint32{43}, egress)
+
+	// Removing the unknown owner is a no-op
+	require.NoError(t, m.Remove(ctx, vpp, swIfIndex, "acl"))
+}
+
+func Test_Manager_FailedSetKeepsOwnACLs(t *testing.T) {
+	ctx := context.Background()
+	vpp := newACLVPP()
+	m := new(aclmanager.Manager)
+
+	require.NoError(t, m.Set(ctx, vpp, swIfIndex, "acl", aclmanager.ACLPriority, []uint32{10}, nil))
+
+	vpp.failSets = 1
+	require.Error(t, m.Set(ctx, vpp, swIfIndex, "acl", aclmanager.ACLPriority, []uint32{12}, nil))
+	ingress, _ := vpp.acls()
+	require.Equal(t, []uint32{10}, ingress)
+
+	// The ACL still attached after the failed set is not taken for a foreign one
+	require.NoError(t, m.Set(ctx, vpp, swIfIndex, "acl", aclmanager.ACLPriority, []uint32{12}, nil))
+	ingress, _ = vpp.acls()
+	require.Equal(t, []uint32{12}, ingress)
+}
+
+func Test_Manager_AttachesHighestPriorityMACIP(t *testing.T) {
+	ctx := context.Background()
+	vpp := newACLVPP()
+	m := new(aclmanager.Manager)
+
+	require.NoError(t, m.SetMACIP(ctx, vpp, swIfIndex, "deny", aclmanager.DefaultDenyPriority, 7))
+	require.Equal(t, uint32(7), vpp.macip[swIfIndex])
+	require.NoError(t, m.SetMACIP(ctx, vpp, swIfIndex, "acl", aclmanager.ACLPriority, 5))
+	require.Equal(t, uint32(5), vpp.macip[swIfIndex])
+
+	require.NoError(t, m.RemoveMACIP(ctx, vpp, swIfIndex, "acl"))
+	require.Equal(t, uint32(7), vpp.macip[swIfIndex])
+	require.NoError(t, m.RemoveMACIP(ctx, vpp, swIfIndex, "deny"))
+	require.NotContains(t, vpp.macip, swIfIndex)
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package allocator
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/vpperrors"
+)
+
+// Backend - storage of the values reserved by the forwarders, shared between them
+type Backend interface {
+	// Reserve reserves the value of the pool for the owner.
+	// Returns false if the value is already reserved by another owner. Reserving the value again by the same owner
+	// succeeds.
+	Reserve(ctx context.Context, pool string, value uint32, owner string) (bool, error)
+	// Release releases the value of the pool, if it is reserved by the owner
+	Release(ctx context.Context, pool string, value uint32, owner string) error
+	// ReleaseAll releases all the values reserved by the owner, e.g. left by the previous run of the forwarder
+	ReleaseAll(ctx context.Context, owner string) error
+}
+
+// Allocator - allocates the values of the pool from the [from, to] range reserving them in the Backend
+type Allocator struct {
+	backend  Backend
+	pool     string
+	owner    string
+	from, to uint32
+	next     uint32
+	// allocated - values allocated by this Allocator, the backend allows reserving them again for the same owner
+	allocated map[uint32]struct{}
+	mut       sync.Mutex
+}
+
+// New returns a new Allocator of the pool values from the [from, to] range reserved for the owner in the backend
+func New(backend Backend, pool, owner string, from, to uint32) *Allocator {
+	if from > to {
+		from, to = to, from
+	}
+	return &Allocator{
+		backend: backend,
+		pool:    pool,
+		owner:   owner,
+		from:    from,
+		to:      to,
+		next:    from,
+
+		allocated: make(map[uint32]struct{}),
+	}
+}
+
+// Allocate returns a value not reserved by anyone else, reserving it for the owner
+func (a *Allocator) Allocate(ctx context.Context) (uint32, error) {
+	a.mut.Lock()
+	defer a.mut.Unlock()
+
+	size := uint64(a.to) - uint64(a.from) + 1
+	for i := uint64(0); i < size; i++ {
+		value := a.next
+		if a.next == a.to {
+			a.next = a.from
+		} else {
+			a.next++
+		}
+		if _, ok := a.allocated[value]; ok {
+			continue
+		}
+		reserved, err := a.backend.Reserve(ctx, a.pool, value, a.owner)
+		if err != nil {
+			return 0, err
+		}
+		if reserved {
+			a.allocated[value] = struct{}{}
+			return value, nil
+		}
+	}
+	return 0, errors.Wrapf(vpperrors.ErrResourceExhausted, "no free value in %s range %d-%d", a.pool, a.from, a.to)
+}
+
+// Release releases the value allocated by Allocate
+func (a *Allocator) Release(ctx context.Context, value uint32) error {
+	a.mut.Lock()
+	defer a.mut.Unlock()
+
+	delete(a.allocated, value)
+	return a.backend.Release(ctx, a.pool, value, a.owner)
+}
+
+// maxReserveAttempts bounds the number of the values tried by ReserveAny
+const maxReserveAttempts = 100
+
+// ReserveAny reserves for the owner the first of the values returned by generate not reserved by anyone else
+func ReserveAny(ctx context.Context, backend Backend, pool, owner string, generate func() (uint32, error)) (uint32, error) {
+	for i := 0; i < maxReserveAttempts; i++ {
+		value, err := generate()
+		if err != nil {
+			return 0, err
+		}
+		reserved, err := backend.Reserve(ctx, pool, value, owner)
+		if err != nil {
+			return 0, err
+		}
+		if reserved {
+			return value, nil
+		}
+	}
+	return 0, errors.Wrapf(vpperrors.ErrResourceExhausted, "no free value in %s after %d attempts", pool, maxReserveAttempts)
+}
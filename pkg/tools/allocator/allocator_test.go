@@ -0,0 +1,131 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package allocator_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/allocator"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/vpperrors"
+)
+
+type memBackend struct {
+	values map[string]map[uint32]string
+	mu     sync.Mutex
+}
+
+func newMemBackend() *memBackend {
+	return &memBackend{values: make(map[string]map[uint32]string)}
+}
+
+func (m *memBackend) Reserve(_ context.Context, pool string, value uint32, owner string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if current, ok := m.values[pool][value]; ok && current != owner {
+		return false, nil
+	}
+	if m.values[pool] == nil {
+		m.values[pool] = make(map[uint32]string)
+	}
+	m.values[pool][value] = owner
+	return true, nil
+}
+
+func (m *memBackend) Release(_ context.Context, pool string, value uint32, owner string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.values[pool][value] == owner {
+		delete(m.values[pool], value)
+	}
+	return nil
+}
+
+func (m *memBackend) ReleaseAll(_ context.Context, owner string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, values := range m.values {
+		for value, current := range values {
+			if current == owner {
+				delete(values, value)
+			}
+		}
+	}
+	return nil
+}
+
+func Test_Allocator_SkipsValuesOfOtherOwners(t *testing.T) {
+	ctx := context.Background()
+	backend := newMemBackend()
+
+	ok, err := backend.Reserve(ctx, "pool", 10, "other")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	a := allocator.New(backend, "pool", "owner", 10, 12)
+	for _, expected := range []uint32{11, 12} {
+		value, allocErr := a.Allocate(ctx)
+		require.NoError(t, allocErr)
+		require.Equal(t, expected, value)
+	}
+
+	_, err = a.Allocate(ctx)
+	require.True(t, errors.Is(err, vpperrors.ErrResourceExhausted))
+
+	require.NoError(t, a.Release(ctx, 11))
+	value, err := a.Allocate(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint32(11), value)
+}
+
+func Test_Allocator_SwapsReversedRange(t *testing.T) {
+	a := allocator.New(newMemBackend(), "pool", "owner", 5, 4)
+
+	value, err := a.Allocate(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint32(4), value)
+}
+
+func Test_ReserveAny(t *testing.T) {
+	ctx := context.Background()
+	backend := newMemBackend()
+
+	ok, err := backend.Reserve(ctx, "pool", 1, "other")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	values := []uint32{1, 1, 3}
+	generate := func() (uint32, error) {
+		value := values[0]
+		values = values[1:]
+		return value, nil
+	}
+	value, err := allocator.ReserveAny(ctx, backend, "pool", "owner", generate)
+	require.NoError(t, err)
+	require.Equal(t, uint32(3), value)
+
+	_, err = allocator.ReserveAny(ctx, backend, "pool", "owner", func() (uint32, error) { return 1, nil })
+	require.True(t, errors.Is(err, vpperrors.ErrResourceExhausted))
+
+	generateErr := errors.New("generate failed")
+	_, err = allocator.ReserveAny(ctx, backend, "pool", "owner", func() (uint32, error) { return 0, generateErr })
+	require.True(t, errors.Is(err, generateErr))
+}
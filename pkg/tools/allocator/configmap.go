@@ -0,0 +1,176 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package allocator
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	serviceAccountDir     = "/var/run/secrets/kubernetes.io/serviceaccount"
+	configMapReservations = "reservations"
+)
+
+// configMapStore - versionedStore keeping the document in a kubernetes configmap, accessed with the REST API of the
+// kubernetes API server. The version is the resourceVersion of the configmap, "" if the configmap doesn't exist.
+type configMapStore struct {
+	client    *http.Client
+	apiServer string
+	tokenFile string
+	namespace string
+	name      string
+}
+
+// NewConfigMapBackend returns a Backend keeping the reservations in the configmap name of namespace. The kubernetes
+// API server is accessed with the service account of the forwarder pod, it needs the get, create and update
+// permissions on the configmap.
+func NewConfigMapBackend(namespace, name string) (Backend, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return nil, errors.New("the forwarder is not running in a kubernetes pod")
+	}
+	ca, err := os.ReadFile(serviceAccountDir + "/ca.crt")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the kubernetes CA")
+	}
+	rootCAs := x509.NewCertPool()
+	if !rootCAs.AppendCertsFromPEM(ca) {
+		return nil, errors.New("invalid kubernetes CA")
+	}
+	return &versionedBackend{
+		store: &configMapStore{
+			client: &http.Client{
+				Transport: &http.Transport{
+					TLSClientConfig: &tls.Config{
+						RootCAs:    rootCAs,
+						MinVersion: tls.VersionTLS12,
+					},
+				},
+			},
+			apiServer: "https://" + net.JoinHostPort(host, port),
+			tokenFile: serviceAccountDir + "/token",
+			namespace: namespace,
+			name:      name,
+		},
+	}, nil
+}
+
+type configMapMeta struct {
+	Name            string `json:"name"`
+	Namespace       string `json:"namespace"`
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+}
+
+type configMap struct {
+	APIVersion string            `json:"apiVersion"`
+	Kind       string            `json:"kind"`
+	Metadata   configMapMeta     `json:"metadata"`
+	Data       map[string]string `json:"data,omitempty"`
+}
+
+func (c *configMapStore) get(ctx context.Context) (data []byte, version string, err error) {
+	cm := &configMap{}
+	status, err := c.do(ctx, http.MethodGet, c.path(c.name), nil, cm)
+	switch {
+	case err != nil:
+		return nil, "", err
+	case status == http.StatusNotFound:
+		return nil, "", nil
+	case status != http.StatusOK:
+		return nil, "", errors.Errorf("failed to get configmap %s/%s: %s", c.namespace, c.name, http.StatusText(status))
+	}
+	return []byte(cm.Data[configMapReservations]), cm.Metadata.ResourceVersion, nil
+}
+
+func (c *configMapStore) put(ctx context.Context, data []byte, version string) (bool, error) {
+	cm := &configMap{
+		APIVersion: "v1",
+		Kind:       "ConfigMap",
+		Metadata: configMapMeta{
+			Name:            c.name,
+			Namespace:       c.namespace,
+			ResourceVersion: version,
+		},
+		Data: map[string]string{
+			configMapReservations: string(data),
+		},
+	}
+	// The configmap is created by the first forwarder and replaced with the resourceVersion precondition then
+	method, path := http.MethodPut, c.path(c.name)
+	if version == "" {
+		method, path = http.MethodPost, c.path("")
+	}
+	status, err := c.do(ctx, method, path, cm, nil)
+	switch {
+	case err != nil:
+		return false, err
+	case status == http.StatusConflict:
+		return false, nil
+	case status != http.StatusOK && status != http.StatusCreated:
+		return false, errors.Errorf("failed to update configmap %s/%s: %s", c.namespace, c.name, http.StatusText(status))
+	}
+	return true, nil
+}
+
+func (c *configMapStore) path(name string) string {
+	return strings.TrimSuffix(c.apiServer+"/api/v1/namespaces/"+c.namespace+"/configmaps/"+name, "/")
+}
+
+// do sends the request and decodes the response into rsp, if the request succeeds
+func (c *configMapStore) do(ctx context.Context, method, path string, req, rsp interface{}) (int, error) {
+	var body io.Reader
+	if req != nil {
+		data, err := json.Marshal(req)
+		if err != nil {
+			return 0, errors.WithStack(err)
+		}
+		body = bytes.NewReader(data)
+	}
+	// The projected service account token is rotated, so it is read on every request
+	token, err := os.ReadFile(c.tokenFile)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to read the service account token")
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, method, path, body)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/json")
+	httpRsp, err := c.client.Do(httpReq)
+	if err != nil {
+		return 0, errors.Wrapf(err, "kubernetes request %s %s failed", method, path)
+	}
+	defer func() { _ = httpRsp.Body.Close() }()
+
+	if rsp == nil || httpRsp.StatusCode != http.StatusOK {
+		return httpRsp.StatusCode, nil
+	}
+	return httpRsp.StatusCode, errors.Wrapf(json.NewDecoder(httpRsp.Body).Decode(rsp), "failed to parse the configmap %s/%s", c.namespace, c.name)
+}
@@ -0,0 +1,25 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package allocator provides the allocation of the values (VNIs, wireguard ports, SPIs) shared between the multiple
+// forwarders running on the same node, so the forwarders don't pick the colliding values.
+// The allocations are kept by the pluggable Backend: a file on the node (NewFileBackend), a kubernetes configmap
+// (NewConfigMapBackend) or an etcd key (NewEtcdBackend). The configmap and etcd backends update the reservations with
+// the optimistic concurrency, so they can be shared by the forwarders of different nodes as well.
+//
+// The owner should be stable across the restarts of the forwarder, so the reservations left by the previous run are
+// released by Backend.ReleaseAll on startup.
+package allocator
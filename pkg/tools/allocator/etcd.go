@@ -0,0 +1,148 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package allocator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// etcdStore - versionedStore keeping the document in an etcd key, accessed with the JSON gRPC gateway of the etcd
+// v3 API. The version is the mod revision of the key, "0" if the key doesn't exist.
+type etcdStore struct {
+	client   *http.Client
+	endpoint string
+	key      string
+}
+
+// NewEtcdBackend returns a Backend keeping the reservations in the key of etcd at endpoint (e.g. http://etcd:2379).
+// If client is nil, http.DefaultClient is used.
+func NewEtcdBackend(client *http.Client, endpoint, key string) Backend {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &versionedBackend{
+		store: &etcdStore{
+			client:   client,
+			endpoint: strings.TrimSuffix(endpoint, "/"),
+			key:      key,
+		},
+	}
+}
+
+type etcdKeyValue struct {
+	Value       []byte `json:"value,omitempty"`
+	ModRevision string `json:"mod_revision,omitempty"`
+}
+
+type etcdRangeRequest struct {
+	Key []byte `json:"key"`
+}
+
+type etcdRangeResponse struct {
+	Kvs []etcdKeyValue `json:"kvs,omitempty"`
+}
+
+type etcdCompare struct {
+	Target      string `json:"target"`
+	Result      string `json:"result"`
+	Key         []byte `json:"key"`
+	ModRevision string `json:"mod_revision"`
+}
+
+type etcdPutRequest struct {
+	Key   []byte `json:"key"`
+	Value []byte `json:"value"`
+}
+
+type etcdRequestOp struct {
+	RequestPut *etcdPutRequest `json:"request_put,omitempty"`
+}
+
+type etcdTxnRequest struct {
+	Compare []etcdCompare   `json:"compare"`
+	Success []etcdRequestOp `json:"success"`
+}
+
+type etcdTxnResponse struct {
+	Succeeded bool `json:"succeeded,omitempty"`
+}
+
+func (e *etcdStore) get(ctx context.Context) (data []byte, version string, err error) {
+	rsp := &etcdRangeResponse{}
+	if err := e.post(ctx, "/v3/kv/range", &etcdRangeRequest{Key: []byte(e.key)}, rsp); err != nil {
+		return nil, "", err
+	}
+	if len(rsp.Kvs) == 0 {
+		return nil, "0", nil
+	}
+	return rsp.Kvs[0].Value, rsp.Kvs[0].ModRevision, nil
+}
+
+func (e *etcdStore) put(ctx context.Context, data []byte, version string) (bool, error) {
+	req := &etcdTxnRequest{
+		Compare: []etcdCompare{{
+			Target:      "MOD",
+			Result:      "EQUAL",
+			Key:         []byte(e.key),
+			ModRevision: version,
+		}},
+		Success: []etcdRequestOp{{
+			RequestPut: &etcdPutRequest{
+				Key:   []byte(e.key),
+				Value: data,
+			},
+		}},
+	}
+	rsp := &etcdTxnResponse{}
+	if err := e.post(ctx, "/v3/kv/txn", req, rsp); err != nil {
+		return false, err
+	}
+	return rsp.Succeeded, nil
+}
+
+func (e *etcdStore) post(ctx context.Context, path string, req, rsp interface{}) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, e.endpoint+path, bytes.NewReader(body))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpRsp, err := e.client.Do(httpReq)
+	if err != nil {
+		return errors.Wrapf(err, "etcd request %s failed", path)
+	}
+	defer func() { _ = httpRsp.Body.Close() }()
+
+	data, err := io.ReadAll(httpRsp.Body)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read etcd response %s", path)
+	}
+	if httpRsp.StatusCode != http.StatusOK {
+		return errors.Errorf("etcd request %s failed: %s: %s", path, httpRsp.Status, data)
+	}
+	return errors.Wrapf(json.Unmarshal(data, rsp), "failed to parse etcd response %s", path)
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package allocator_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/allocator"
+)
+
+// fakeEtcd - the range and txn calls of the etcd JSON gRPC gateway for a single key
+type fakeEtcd struct {
+	value     []byte
+	revision  int64
+	conflicts int
+	mu        sync.Mutex
+}
+
+func (f *fakeEtcd) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var rsp interface{}
+	switch r.URL.Path {
+	case "/v3/kv/range":
+		var kvs []map[string]interface{}
+		if f.revision > 0 {
+			kvs = append(kvs, map[string]interface{}{
+				"value":        f.value,
+				"mod_revision": strconv.FormatInt(f.revision, 10),
+			})
+		}
+		rsp = map[string]interface{}{"kvs": kvs}
+	case "/v3/kv/txn":
+		var req struct {
+			Compare []struct {
+				ModRevision string `json:"mod_revision"`
+			} `json:"compare"`
+			Success []struct {
+				RequestPut struct {
+					Value []byte `json:"value"`
+				} `json:"request_put"`
+			} `json:"success"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		// Another writer updates the key between the range and the txn
+		if f.conflicts > 0 {
+			f.conflicts--
+			f.revision++
+		}
+		succeeded := req.Compare[0].ModRevision == strconv.FormatInt(f.revision, 10)
+		if succeeded {
+			f.value = req.Success[0].RequestPut.Value
+			f.revision++
+		}
+		rsp = map[string]interface{}{"succeeded": succeeded}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(rsp)
+}
+
+func Test_EtcdBackend_RetriesOnConflict(t *testing.T) {
+	ctx := context.Background()
+	etcd := &fakeEtcd{conflicts: 2}
+	server := httptest.NewServer(etcd)
+	defer server.Close()
+
+	first := allocator.NewEtcdBackend(server.Client(), server.URL, "/forwarders/reservations")
+	second := allocator.NewEtcdBackend(server.Client(), server.URL+"/", "/forwarders/reservations")
+
+	ok, err := first.Reserve(ctx, "pool", 1, "first")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Zero(t, etcd.conflicts)
+
+	ok, err = second.Reserve(ctx, "pool", 1, "second")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	require.NoError(t, first.ReleaseAll(ctx, "first"))
+	ok, err = second.Reserve(ctx, "pool", 1, "second")
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+func Test_EtcdBackend_Error(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	_, err := allocator.NewEtcdBackend(server.Client(), server.URL, "key").Reserve(context.Background(), "pool", 1, "owner")
+	require.Error(t, err)
+}
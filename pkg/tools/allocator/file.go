@@ -0,0 +1,100 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package allocator
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+)
+
+// fileBackend - Backend keeping the reservations in a json file, locked with flock(2) on every access
+type fileBackend struct {
+	path string
+}
+
+// NewFileBackend returns a Backend keeping the reservations in the file at path, the file should be shared by all the
+// forwarders of the node (e.g. placed on a hostPath volume)
+func NewFileBackend(path string) Backend {
+	return &fileBackend{
+		path: path,
+	}
+}
+
+func (f *fileBackend) Reserve(_ context.Context, pool string, value uint32, owner string) (reserved bool, err error) {
+	err = f.update(func(r reservations) {
+		reserved = r.reserve(pool, value, owner)
+	})
+	return reserved, err
+}
+
+func (f *fileBackend) Release(_ context.Context, pool string, value uint32, owner string) error {
+	return f.update(func(r reservations) {
+		r.release(pool, value, owner)
+	})
+}
+
+func (f *fileBackend) ReleaseAll(_ context.Context, owner string) error {
+	return f.update(func(r reservations) {
+		r.releaseAll(owner)
+	})
+}
+
+// update runs updateFunc on the reservations read from the locked file and writes them back
+func (f *fileBackend) update(updateFunc func(r reservations)) error {
+	file, err := os.OpenFile(f.path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open %s", f.path)
+	}
+	defer func() { _ = file.Close() }()
+
+	if err = unix.Flock(int(file.Fd()), unix.LOCK_EX); err != nil {
+		return errors.Wrapf(err, "failed to lock %s", f.path)
+	}
+	defer func() { _ = unix.Flock(int(file.Fd()), unix.LOCK_UN) }()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read %s", f.path)
+	}
+	r := make(reservations)
+	if len(data) > 0 {
+		if err = json.Unmarshal(data, &r); err != nil {
+			return errors.Wrapf(err, "failed to parse %s", f.path)
+		}
+	}
+
+	updateFunc(r)
+
+	if data, err = json.Marshal(r); err != nil {
+		return errors.WithStack(err)
+	}
+	if err = file.Truncate(0); err != nil {
+		return errors.Wrapf(err, "failed to truncate %s", f.path)
+	}
+	if _, err = file.WriteAt(data, 0); err != nil {
+		return errors.Wrapf(err, "failed to write %s", f.path)
+	}
+	return errors.Wrapf(file.Sync(), "failed to sync %s", f.path)
+}
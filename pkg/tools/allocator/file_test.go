@@ -0,0 +1,63 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package allocator_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/allocator"
+)
+
+func Test_FileBackend_SharedBetweenOwners(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "reservations.json")
+	first, second := allocator.NewFileBackend(path), allocator.NewFileBackend(path)
+
+	ok, err := first.Reserve(ctx, "pool", 1, "first")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = first.Reserve(ctx, "pool", 1, "first")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = second.Reserve(ctx, "pool", 1, "second")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	ok, err = second.Reserve(ctx, "other-pool", 1, "second")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// Release by another owner is ignored
+	require.NoError(t, second.Release(ctx, "pool", 1, "second"))
+	ok, err = second.Reserve(ctx, "pool", 1, "second")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	require.NoError(t, first.ReleaseAll(ctx, "first"))
+	ok, err = second.Reserve(ctx, "pool", 1, "second")
+	require.NoError(t, err)
+	require.True(t, ok)
+}
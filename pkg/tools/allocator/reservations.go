@@ -0,0 +1,47 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package allocator
+
+// reservations - pool -> value -> owner
+type reservations map[string]map[uint32]string
+
+func (r reservations) reserve(pool string, value uint32, owner string) bool {
+	if current, ok := r[pool][value]; ok && current != owner {
+		return false
+	}
+	if r[pool] == nil {
+		r[pool] = make(map[uint32]string)
+	}
+	r[pool][value] = owner
+	return true
+}
+
+func (r reservations) release(pool string, value uint32, owner string) {
+	if r[pool][value] == owner {
+		delete(r[pool], value)
+	}
+}
+
+func (r reservations) releaseAll(owner string) {
+	for _, values := range r {
+		for value, current := range values {
+			if current == owner {
+				delete(values, value)
+			}
+		}
+	}
+}
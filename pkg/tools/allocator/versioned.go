@@ -0,0 +1,87 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package allocator
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// versionedStore - store of a single document updated with the optimistic concurrency: put succeeds only if the
+// document has not changed since it was read
+type versionedStore interface {
+	// get returns the document and its version, the empty data if the document doesn't exist yet
+	get(ctx context.Context) (data []byte, version string, err error)
+	// put writes the document, if its version is still the given one. Returns false on the version conflict.
+	put(ctx context.Context, data []byte, version string) (bool, error)
+}
+
+// versionedBackend - Backend keeping the reservations in a versionedStore, the update is retried on the conflicts
+// with the other forwarders
+type versionedBackend struct {
+	store versionedStore
+}
+
+func (v *versionedBackend) Reserve(ctx context.Context, pool string, value uint32, owner string) (reserved bool, err error) {
+	err = v.update(ctx, func(r reservations) {
+		reserved = r.reserve(pool, value, owner)
+	})
+	return reserved, err
+}
+
+func (v *versionedBackend) Release(ctx context.Context, pool string, value uint32, owner string) error {
+	return v.update(ctx, func(r reservations) {
+		r.release(pool, value, owner)
+	})
+}
+
+func (v *versionedBackend) ReleaseAll(ctx context.Context, owner string) error {
+	return v.update(ctx, func(r reservations) {
+		r.releaseAll(owner)
+	})
+}
+
+// update runs updateFunc on the reservations read from the store and writes them back, until there is no conflict
+func (v *versionedBackend) update(ctx context.Context, updateFunc func(r reservations)) error {
+	for {
+		data, version, err := v.store.get(ctx)
+		if err != nil {
+			return err
+		}
+		r := make(reservations)
+		if len(data) > 0 {
+			if err = json.Unmarshal(data, &r); err != nil {
+				return errors.Wrap(err, "failed to parse the reservations")
+			}
+		}
+
+		updateFunc(r)
+
+		if data, err = json.Marshal(r); err != nil {
+			return errors.WithStack(err)
+		}
+		ok, err := v.store.put(ctx, data, version)
+		if err != nil || ok {
+			return err
+		}
+		if err = ctx.Err(); err != nil {
+			return errors.Wrap(err, "failed to update the reservations")
+		}
+	}
+}
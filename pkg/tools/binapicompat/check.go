@@ -0,0 +1,133 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binapicompat
+
+import (
+	"context"
+
+	"git.fd.io/govpp.git/api"
+	legacyvpe "git.fd.io/govpp.git/binapi/vpe"
+	"github.com/edwarnicke/govpp/binapi/af_packet"
+	interfaces "github.com/edwarnicke/govpp/binapi/interface"
+	"github.com/edwarnicke/govpp/binapi/ip"
+	"github.com/edwarnicke/govpp/binapi/ip_neighbor"
+	"github.com/edwarnicke/govpp/binapi/l2"
+	"github.com/edwarnicke/govpp/binapi/memif"
+	"github.com/edwarnicke/govpp/binapi/tapv2"
+	"github.com/edwarnicke/govpp/binapi/vlib"
+	"github.com/edwarnicke/govpp/binapi/vpe"
+	"github.com/edwarnicke/govpp/binapi/vxlan"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// Required - the messages the mechanisms and the xconnect of the forwarder send and receive, the forwarder can't work
+// without them. The messages of the optional plugins are checked with vppcaps.
+var Required = []api.Message{
+	// interface
+	&interfaces.SwInterfaceDump{},
+	&interfaces.SwInterfaceDetails{},
+	&interfaces.SwInterfaceSetFlags{},
+	&interfaces.SwInterfaceSetFlagsReply{},
+	&interfaces.SwInterfaceSetRxMode{},
+	&interfaces.SwInterfaceSetRxModeReply{},
+	&interfaces.SwInterfaceSetMtu{},
+	&interfaces.SwInterfaceSetMtuReply{},
+	&interfaces.SwInterfaceAddDelAddress{},
+	&interfaces.SwInterfaceAddDelAddressReply{},
+	&interfaces.SwInterfaceSetTable{},
+	&interfaces.SwInterfaceSetTableReply{},
+	&interfaces.SwInterfaceTagAddDel{},
+	&interfaces.SwInterfaceTagAddDelReply{},
+	&interfaces.WantInterfaceEvents{},
+	&interfaces.WantInterfaceEventsReply{},
+	&interfaces.SwInterfaceEvent{},
+	&interfaces.CreateVlanSubif{},
+	&interfaces.CreateVlanSubifReply{},
+	&interfaces.DeleteSubif{},
+	&interfaces.DeleteSubifReply{},
+	&interfaces.CreateLoopback{},
+	&interfaces.CreateLoopbackReply{},
+	&interfaces.DeleteLoopback{},
+	&interfaces.DeleteLoopbackReply{},
+	// l2
+	&l2.SwInterfaceSetL2Xconnect{},
+	&l2.SwInterfaceSetL2XconnectReply{},
+	&l2.SwInterfaceSetL2Bridge{},
+	&l2.SwInterfaceSetL2BridgeReply{},
+	&l2.BridgeDomainAddDelV2{},
+	&l2.BridgeDomainAddDelV2Reply{},
+	&l2.L2InterfaceVlanTagRewrite{},
+	&l2.L2InterfaceVlanTagRewriteReply{},
+	// ip
+	&ip.IPRouteAddDel{},
+	&ip.IPRouteAddDelReply{},
+	&ip.IPRouteDump{},
+	&ip.IPRouteDetails{},
+	&ip.IPTableAddDel{},
+	&ip.IPTableAddDelReply{},
+	&ip.IPAddressDump{},
+	&ip.IPAddressDetails{},
+	&ip_neighbor.IPNeighborAddDel{},
+	&ip_neighbor.IPNeighborAddDelReply{},
+	// mechanisms
+	&memif.MemifSocketFilenameAddDelV2{},
+	&memif.MemifSocketFilenameAddDelV2Reply{},
+	&memif.MemifCreate{},
+	&memif.MemifCreateReply{},
+	&memif.MemifDelete{},
+	&memif.MemifDeleteReply{},
+	&tapv2.TapCreateV2{},
+	&tapv2.TapCreateV2Reply{},
+	&tapv2.TapDeleteV2{},
+	&tapv2.TapDeleteV2Reply{},
+	&tapv2.SwInterfaceTapV2Dump{},
+	&tapv2.SwInterfaceTapV2Details{},
+	&af_packet.AfPacketCreate{},
+	&af_packet.AfPacketCreateReply{},
+	&af_packet.AfPacketDelete{},
+	&af_packet.AfPacketDeleteReply{},
+	&vxlan.VxlanAddDelTunnelV2{},
+	&vxlan.VxlanAddDelTunnelV2Reply{},
+	&vxlan.VxlanTunnelV2Dump{},
+	&vxlan.VxlanTunnelV2Details{},
+	// vxlan sends it with the bindings of git.fd.io/govpp.git
+	&legacyvpe.AddNodeNext{},
+	&legacyvpe.AddNodeNextReply{},
+	// vpp version and capabilities
+	&vpe.ShowVersion{},
+	&vpe.ShowVersionReply{},
+	&vlib.CliInband{},
+	&vlib.CliInbandReply{},
+}
+
+// Check returns an error listing the messages unknown to the connected vpp or known with a different CRC than the
+// binapi bindings the elements are built with (github.com/edwarnicke/govpp/binapi)
+func Check(ctx context.Context, vppConn api.ChannelProvider, messages ...api.Message) error {
+	apiChannel, err := vppConn.NewAPIChannel()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer apiChannel.Close()
+
+	if err := apiChannel.CheckCompatiblity(messages...); err != nil {
+		return errors.Wrap(err, "vpp is not compatible with the binapi bindings")
+	}
+	log.FromContext(ctx).WithField("messages", len(messages)).Debug("vpp is compatible with the binapi bindings")
+	return nil
+}
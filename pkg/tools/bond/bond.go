@@ -0,0 +1,229 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bond
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/edwarnicke/govpp/binapi/bond"
+	interfaces "github.com/edwarnicke/govpp/binapi/interface"
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/uplink"
+)
+
+// Bond - vpp bond interface
+type Bond struct {
+	SwIfIndex interface_types.InterfaceIndex
+	Name      string
+	Mode      bond.BondMode
+	// Members - names of the member interfaces
+	Members []string
+	// ActiveMembers - the number of the members the traffic is sent over
+	ActiveMembers uint32
+}
+
+// Ensure creates the bond interface with the id (if not yet created), adds the member interfaces missing in it and
+// sets the members and the bond admin up. The members are the interface names, e.g. the dpdk interfaces of the NICs.
+func Ensure(ctx context.Context, vppConn api.Connection, id uint32, members []string, opts ...Option) (*Bond, error) {
+	o := &options{
+		mode: bond.BOND_API_MODE_LACP,
+		lb:   bond.BOND_API_LB_ALGO_L34,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	b, err := Get(ctx, vppConn, id)
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		if b, err = create(ctx, vppConn, id, o); err != nil {
+			return nil, err
+		}
+	}
+	if b.Mode != o.mode {
+		log.FromContext(ctx).Warnf("bond %s is in %s mode instead of %s", b.Name, b.Mode, o.mode)
+	}
+
+	for _, name := range members {
+		if contains(b.Members, name) {
+			continue
+		}
+		member, err := uplink.ByName(ctx, vppConn, name)
+		if err != nil {
+			return nil, err
+		}
+		if err := addMember(ctx, vppConn, b, member.SwIfIndex); err != nil {
+			return nil, errors.Wrapf(err, "failed to add %s to the bond %s", name, b.Name)
+		}
+		if err := setUp(ctx, vppConn, member.SwIfIndex); err != nil {
+			return nil, err
+		}
+		b.Members = append(b.Members, name)
+	}
+	if err := setUp(ctx, vppConn, b.SwIfIndex); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Get returns the bond interface with the id, nil if there is no such bond
+func Get(ctx context.Context, vppConn api.Connection, id uint32) (*Bond, error) {
+	now := time.Now()
+	client, err := bond.NewServiceClient(vppConn).SwBondInterfaceDump(ctx, &bond.SwBondInterfaceDump{
+		SwIfIndex: ^interface_types.InterfaceIndex(0),
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer func() { _ = client.Close() }()
+
+	var rv *Bond
+	for {
+		details, err := client.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if details.ID != id {
+			continue
+		}
+		rv = &Bond{
+			SwIfIndex:     details.SwIfIndex,
+			Name:          details.InterfaceName,
+			Mode:          details.Mode,
+			ActiveMembers: details.ActiveMembers,
+		}
+	}
+	log.FromContext(ctx).
+		WithField("id", id).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "SwBondInterfaceDump").Debug("completed")
+	if rv == nil {
+		return nil, nil
+	}
+	if rv.Members, err = memberNames(ctx, vppConn, rv.SwIfIndex); err != nil {
+		return nil, err
+	}
+	return rv, nil
+}
+
+func create(ctx context.Context, vppConn api.Connection, id uint32, o *options) (*Bond, error) {
+	now := time.Now()
+	rsp, err := bond.NewServiceClient(vppConn).BondCreate2(ctx, &bond.BondCreate2{
+		Mode: o.mode,
+		Lb:   o.lb,
+		ID:   id,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create the bond %d", id)
+	}
+	log.FromContext(ctx).
+		WithField("id", id).
+		WithField("mode", o.mode).
+		WithField("swIfIndex", rsp.SwIfIndex).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "BondCreate2").Info("completed")
+
+	u, err := uplink.BySwIfIndex(ctx, vppConn, rsp.SwIfIndex)
+	if err != nil {
+		return nil, err
+	}
+	return &Bond{
+		SwIfIndex: rsp.SwIfIndex,
+		Name:      u.Name,
+		Mode:      o.mode,
+	}, nil
+}
+
+func memberNames(ctx context.Context, vppConn api.Connection, bondSwIfIndex interface_types.InterfaceIndex) ([]string, error) {
+	now := time.Now()
+	client, err := bond.NewServiceClient(vppConn).SwMemberInterfaceDump(ctx, &bond.SwMemberInterfaceDump{
+		SwIfIndex: bondSwIfIndex,
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer func() { _ = client.Close() }()
+
+	var rv []string
+	for {
+		details, err := client.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		rv = append(rv, details.InterfaceName)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", bondSwIfIndex).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "SwMemberInterfaceDump").Debug("completed")
+	return rv, nil
+}
+
+func addMember(ctx context.Context, vppConn api.Connection, b *Bond, swIfIndex interface_types.InterfaceIndex) error {
+	now := time.Now()
+	if _, err := bond.NewServiceClient(vppConn).BondAddMember(ctx, &bond.BondAddMember{
+		SwIfIndex:     swIfIndex,
+		BondSwIfIndex: b.SwIfIndex,
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", swIfIndex).
+		WithField("bondSwIfIndex", b.SwIfIndex).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "BondAddMember").Info("completed")
+	return nil
+}
+
+func setUp(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex) error {
+	now := time.Now()
+	if _, err := interfaces.NewServiceClient(vppConn).SwInterfaceSetFlags(ctx, &interfaces.SwInterfaceSetFlags{
+		SwIfIndex: swIfIndex,
+		Flags:     interface_types.IF_STATUS_API_FLAG_ADMIN_UP,
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", swIfIndex).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "SwInterfaceSetFlags").Debug("completed")
+	return nil
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
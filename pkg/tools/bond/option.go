@@ -0,0 +1,44 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bond
+
+import (
+	"github.com/edwarnicke/govpp/binapi/bond"
+)
+
+type options struct {
+	mode bond.BondMode
+	lb   bond.BondLbAlgo
+}
+
+// Option is an option pattern for Ensure
+type Option func(o *options)
+
+// WithActiveBackup sets the active-backup mode instead of the LACP (default)
+func WithActiveBackup() Option {
+	return func(o *options) {
+		o.mode = bond.BOND_API_MODE_ACTIVE_BACKUP
+		o.lb = bond.BOND_API_LB_ALGO_AB
+	}
+}
+
+// WithLoadBalance sets the load balancing algorithm of the LACP mode, L34 by default
+func WithLoadBalance(lb bond.BondLbAlgo) Option {
+	return func(o *options) {
+		o.lb = lb
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cryptoengine
+
+import (
+	"bufio"
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/edwarnicke/govpp/binapi/vlib"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const (
+	// MetricKey - PathSegment.Metrics key the selected crypto engine is recorded with
+	MetricKey = "crypto_engine"
+
+	showCryptoEnginesCmd = "show crypto engines"
+)
+
+// asyncEngines - engines processing the crypto operations asynchronously (handed off to a scheduler or an accelerator)
+var asyncEngines = map[string]bool{
+	"sw_scheduler":   true,
+	"dpdk_cryptodev": true,
+}
+
+// hardwareEngines - engines backed by a crypto accelerator (e.g. Intel QAT)
+var hardwareEngines = map[string]bool{
+	"dpdk_cryptodev": true,
+}
+
+// Engine - vpp crypto engine
+type Engine struct {
+	Name        string
+	Priority    int
+	Description string
+}
+
+// IsAsync - returns true if the engine supports async crypto handoff
+func (e Engine) IsAsync() bool {
+	return asyncEngines[e.Name]
+}
+
+// IsHardware - returns true if the engine offloads the crypto operations to the hardware
+func (e Engine) IsHardware() bool {
+	return hardwareEngines[e.Name]
+}
+
+// Probe - returns the crypto engines registered in vpp
+func Probe(ctx context.Context, vppConn api.Connection) ([]Engine, error) {
+	now := time.Now()
+	reply, err := vlib.NewServiceClient(vppConn).CliInband(ctx, &vlib.CliInband{
+		Cmd: showCryptoEnginesCmd,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "vpp error running %q", showCryptoEnginesCmd)
+	}
+	log.FromContext(ctx).
+		WithField("cmd", showCryptoEnginesCmd).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "CliInband").Debug("completed")
+	return parse(reply.Reply), nil
+}
+
+// Select - returns the engine to be used: the async engine with the highest priority if preferAsync is set and
+// such an engine exists, otherwise the sync engine with the highest priority.
+// The ok result is false if engines contain no suitable engine.
+func Select(engines []Engine, preferAsync bool) (engine Engine, ok bool) {
+	if preferAsync {
+		if engine, ok = highestPriority(engines, true); ok {
+			return engine, ok
+		}
+	}
+	return highestPriority(engines, false)
+}
+
+// SetMetric - records the engine in the Metrics of the current PathSegment of the conn
+func SetMetric(conn *networkservice.Connection, engine Engine) {
+	segments := conn.GetPath().GetPathSegments()
+	index := int(conn.GetPath().GetIndex())
+	if index >= len(segments) {
+		return
+	}
+	if segments[index].Metrics == nil {
+		segments[index].Metrics = make(map[string]string)
+	}
+	segments[index].Metrics[MetricKey] = engine.Name
+}
+
+func highestPriority(engines []Engine, isAsync bool) (engine Engine, ok bool) {
+	for _, e := range engines {
+		if e.IsAsync() != isAsync {
+			continue
+		}
+		if !ok || e.Priority > engine.Priority {
+			engine, ok = e, true
+		}
+	}
+	return engine, ok
+}
+
+// parse parses the output of the 'show crypto engines' cli command:
+//
+//	Name                Prio    Description
+//	ipsecmb             80      Intel(R) Multi-Buffer Crypto for IPsec Library 1.2.0
+//	native              100     Native ISA Optimized Crypto
+//	openssl             50      OpenSSL
+func parse(reply string) []Engine {
+	var rv []Engine
+	scanner := bufio.NewScanner(strings.NewReader(reply))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		priority, err := strconv.Atoi(fields[1])
+		if err != nil {
+			// Header line
+			continue
+		}
+		rv = append(rv, Engine{
+			Name:        fields[0],
+			Priority:    priority,
+			Description: strings.Join(fields[2:], " "),
+		})
+	}
+	return rv
+}
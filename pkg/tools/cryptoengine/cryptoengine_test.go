@@ -0,0 +1,88 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cryptoengine_test
+
+import (
+	"context"
+	"testing"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/edwarnicke/govpp/binapi/vlib"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/cryptoengine"
+)
+
+const showCryptoEngines = `Name                Prio    Description
+ipsecmb             80      Intel(R) Multi-Buffer Crypto for IPsec Library 1.1.0
+native              100     Native ISA Optimized Crypto
+openssl             50      OpenSSL
+sw_scheduler        60      SW Scheduler Async Engine
+`
+
+// cliVPP - vpp answering the cli commands with the reply
+type cliVPP struct {
+	reply string
+}
+
+func (v *cliVPP) Invoke(_ context.Context, req, reply api.Message) error {
+	if _, ok := req.(*vlib.CliInband); !ok {
+		return errors.Errorf("unexpected %s", req.GetMessageName())
+	}
+	reply.(*vlib.CliInbandReply).Reply = v.reply
+	return nil
+}
+
+func (v *cliVPP) NewStream(_ context.Context, _ ...api.StreamOption) (api.Stream, error) {
+	return nil, errors.New("not supported")
+}
+
+func Test_Probe(t *testing.T) {
+	engines, err := cryptoengine.Probe(context.Background(), &cliVPP{reply: showCryptoEngines})
+	require.NoError(t, err)
+	require.Len(t, engines, 4)
+	require.Equal(t, cryptoengine.Engine{
+		Name:        "ipsecmb",
+		Priority:    80,
+		Description: "Intel(R) Multi-Buffer Crypto for IPsec Library 1.1.0",
+	}, engines[0])
+	require.True(t, engines[3].IsAsync())
+	require.False(t, engines[1].IsAsync())
+}
+
+func Test_Select(t *testing.T) {
+	engines, err := cryptoengine.Probe(context.Background(), &cliVPP{reply: showCryptoEngines})
+	require.NoError(t, err)
+
+	// The async engine is selected if preferred, regardless of the priority of the sync ones
+	engine, ok := cryptoengine.Select(engines, true)
+	require.True(t, ok)
+	require.Equal(t, "sw_scheduler", engine.Name)
+
+	engine, ok = cryptoengine.Select(engines, false)
+	require.True(t, ok)
+	require.Equal(t, "native", engine.Name)
+
+	// The sync engine is selected if there is no async one
+	engine, ok = cryptoengine.Select(engines[:3], true)
+	require.True(t, ok)
+	require.Equal(t, "native", engine.Name)
+
+	_, ok = cryptoengine.Select(nil, true)
+	require.False(t, ok)
+}
@@ -0,0 +1,20 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cryptoengine provides helpers for probing the vpp crypto engines (native, ipsecmb, openssl,
+// sw_scheduler, dpdk_cryptodev) and selecting the one used by the encrypted tunnels. Selector selects the engine of
+// the tunnels of a mechanism once, enabling the async crypto handoff of the mechanism if possible.
+package cryptoengine
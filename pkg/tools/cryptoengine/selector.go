@@ -0,0 +1,101 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cryptoengine
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"git.fd.io/govpp.git/api"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// SetAsyncModeFunc - enables the async crypto handoff of the tunnels of the mechanism (e.g. IpsecSetAsyncMode)
+type SetAsyncModeFunc func(ctx context.Context, vppConn api.Connection) error
+
+// Selector probes the vpp crypto engines on the first Init and enables the async crypto handoff of the tunnels if it
+// is preferred and an async engine is available
+type Selector struct {
+	vppConn      api.Connection
+	name         string
+	preferAsync  bool
+	setAsyncMode SetAsyncModeFunc
+	engine       Engine
+
+	inited    uint32
+	initMutex sync.Mutex
+}
+
+// NewSelector - returns the Selector of the crypto engine used by the tunnels of the mechanism with the name,
+// setAsyncMode is called to enable the async crypto handoff if preferAsync is set
+func NewSelector(vppConn api.Connection, name string, preferAsync bool, setAsyncMode SetAsyncModeFunc) *Selector {
+	return &Selector{
+		vppConn:      vppConn,
+		name:         name,
+		preferAsync:  preferAsync,
+		setAsyncMode: setAsyncMode,
+	}
+}
+
+// Init selects the crypto engine once. If vpp fails to enable the async crypto handoff, the sync engine with the
+// highest priority is used, so the vpp without the async support still serves the connections.
+func (s *Selector) Init(ctx context.Context) {
+	if atomic.LoadUint32(&s.inited) > 0 {
+		return
+	}
+	s.initMutex.Lock()
+	defer s.initMutex.Unlock()
+	if atomic.LoadUint32(&s.inited) > 0 {
+		return
+	}
+
+	engines, err := Probe(ctx, s.vppConn)
+	if err != nil {
+		log.FromContext(ctx).Warnf("unable to probe crypto engines: %s", err.Error())
+	}
+	engine, ok := Select(engines, s.preferAsync)
+	if s.preferAsync && !engine.IsAsync() {
+		log.FromContext(ctx).Warnf("async crypto is preferred, but no async crypto engine is available")
+	}
+	if ok && engine.IsAsync() {
+		if err := s.setAsyncMode(ctx, s.vppConn); err != nil {
+			log.FromContext(ctx).Warnf("unable to enable async crypto mode, falling back to sync mode: %s", err.Error())
+			engine, ok = Select(engines, false)
+		}
+	}
+	if ok {
+		log.FromContext(ctx).
+			WithField("engine", engine.Name).
+			WithField("async", engine.IsAsync()).
+			WithField("hardware", engine.IsHardware()).
+			Infof("%s crypto engine selected", s.name)
+	}
+
+	s.engine = engine
+	atomic.StoreUint32(&s.inited, 1)
+}
+
+// SetMetric - records the selected engine in the Metrics of the current PathSegment of the conn
+func (s *Selector) SetMetric(conn *networkservice.Connection) {
+	if s.engine.Name == "" {
+		return
+	}
+	SetMetric(conn, s.engine)
+}
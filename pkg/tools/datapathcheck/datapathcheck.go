@@ -0,0 +1,87 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package datapathcheck
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os/exec"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/mechutils"
+)
+
+// Endpoint - local end of the connection datapath
+type Endpoint struct {
+	// NSFilename - network namespace file of the kernel interface
+	NSFilename string
+	// LocalIP - address of the kernel interface
+	LocalIP net.IP
+	// PeerIP - address of the other end of the connection
+	PeerIP net.IP
+}
+
+// ToEndpoint - returns the Endpoint of the conn for the client (NSC) or server (NSE) side
+func ToEndpoint(conn *networkservice.Connection, isClient bool) (*Endpoint, error) {
+	mechanism := kernel.ToMechanism(conn.GetMechanism())
+	if mechanism == nil {
+		return nil, errors.Errorf("connection %s has no kernel mechanism", conn.GetId())
+	}
+	nsFilename, err := mechutils.ToNSFilename(mechanism)
+	if err != nil {
+		return nil, err
+	}
+
+	localAddrs := conn.GetContext().GetIpContext().GetDstIpAddrs()
+	peerAddrs := conn.GetContext().GetIpContext().GetSrcIpAddrs()
+	if isClient {
+		localAddrs, peerAddrs = peerAddrs, localAddrs
+	}
+	if len(localAddrs) == 0 || len(peerAddrs) == 0 {
+		return nil, errors.Errorf("connection %s has no ip addresses", conn.GetId())
+	}
+	localIP, _, err := net.ParseCIDR(localAddrs[0])
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid ip address %s", localAddrs[0])
+	}
+	peerIP, _, err := net.ParseCIDR(peerAddrs[0])
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid ip address %s", peerAddrs[0])
+	}
+
+	return &Endpoint{
+		NSFilename: nsFilename,
+		LocalIP:    localIP,
+		PeerIP:     peerIP,
+	}, nil
+}
+
+func (e *Endpoint) command(ctx context.Context, name string, args ...string) *exec.Cmd {
+	return exec.CommandContext(ctx, "nsenter", append([]string{fmt.Sprintf("--net=%s", e.NSFilename), name}, args...)...)
+}
+
+func (e *Endpoint) isIPv6() bool {
+	return e.PeerIP.To4() == nil
+}
@@ -0,0 +1,26 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package datapathcheck provides helpers validating the datapath of a live connection with a kernel mechanism:
+// ping sweeps with the DF bit set to verify the MTU and iperf3 throughput checks through the created interfaces.
+//
+// The helpers run ping and iperf3 in the network namespace of the kernel interface using nsenter, so the binaries
+// must be available on the host running the checks. They are intended for the downstream e2e suites and the
+// integration tests.
+package datapathcheck
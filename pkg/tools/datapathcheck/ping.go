@@ -0,0 +1,113 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package datapathcheck
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	ipv4Overhead = 20 + 8 // ip + icmp headers
+	ipv6Overhead = 40 + 8 // ipv6 + icmpv6 headers
+)
+
+// Ping - sends a single ping with the DF bit set carrying the IP packet of the mtu size to the peer.
+// Returns true if the reply is received.
+func (e *Endpoint) Ping(ctx context.Context, mtu uint32) (bool, error) {
+	overhead := uint32(ipv4Overhead)
+	if e.isIPv6() {
+		overhead = ipv6Overhead
+	}
+	if mtu <= overhead {
+		return false, errors.Errorf("mtu %d is less than the headers size %d", mtu, overhead)
+	}
+	cmd := e.command(ctx, "ping", "-M", "do", "-c", "1", "-W", "1", "-s", strconv.FormatUint(uint64(mtu-overhead), 10), e.PeerIP.String())
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+		return false, nil
+	}
+	return true, nil
+}
+
+// PingSweep - pings the peer with the DF bit set for each mtu and returns the results in the same order
+func (e *Endpoint) PingSweep(ctx context.Context, mtus ...uint32) ([]bool, error) {
+	rv := make([]bool, 0, len(mtus))
+	for _, mtu := range mtus {
+		ok, err := e.Ping(ctx, mtu)
+		if err != nil {
+			return nil, err
+		}
+		rv = append(rv, ok)
+	}
+	return rv, nil
+}
+
+// MaxMTU - returns the largest mtu in [minMTU, maxMTU] passing the datapath with the DF bit set
+func (e *Endpoint) MaxMTU(ctx context.Context, minMTU, maxMTU uint32) (uint32, error) {
+	mtu, err := searchMaxMTU(ctx, minMTU, maxMTU, e.Ping)
+	if err != nil {
+		return 0, errors.Wrapf(err, "peer %s", e.PeerIP)
+	}
+	return mtu, nil
+}
+
+// searchMaxMTU - binary searches the largest mtu in [minMTU, maxMTU] the ping passes for, the ping must pass for minMTU
+func searchMaxMTU(ctx context.Context, minMTU, maxMTU uint32, ping func(context.Context, uint32) (bool, error)) (uint32, error) {
+	ok, err := ping(ctx, minMTU)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return 0, errors.Errorf("ping of mtu %d failed", minMTU)
+	}
+	for minMTU < maxMTU {
+		mid := minMTU + (maxMTU-minMTU+1)/2
+		ok, err := ping(ctx, mid)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			minMTU = mid
+		} else {
+			maxMTU = mid - 1
+		}
+	}
+	return minMTU, nil
+}
+
+// CheckMTU - verifies that packets of the mtu pass the datapath and packets larger than mtu don't
+func (e *Endpoint) CheckMTU(ctx context.Context, mtu uint32) error {
+	results, err := e.PingSweep(ctx, mtu, mtu+1)
+	if err != nil {
+		return err
+	}
+	if !results[0] {
+		return errors.Errorf("ping of mtu %d to %s failed", mtu, e.PeerIP)
+	}
+	if results[1] {
+		return errors.Errorf("ping of mtu %d to %s passed, but the connection mtu is %d", mtu+1, e.PeerIP, mtu)
+	}
+	return nil
+}
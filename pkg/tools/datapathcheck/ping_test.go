@@ -0,0 +1,90 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package datapathcheck
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// pathPing - the ping of the path passing the packets up to the mtu, records the pinged mtus
+type pathPing struct {
+	mtu    uint32
+	pinged []uint32
+}
+
+func (p *pathPing) ping(_ context.Context, mtu uint32) (bool, error) {
+	p.pinged = append(p.pinged, mtu)
+	return mtu <= p.mtu, nil
+}
+
+func Test_SearchMaxMTU(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		minMTU, maxMTU uint32
+		pathMTU        uint32
+	}{
+		{name: "path mtu in the middle", minMTU: 1280, maxMTU: 9000, pathMTU: 1446},
+		{name: "path mtu is min", minMTU: 1280, maxMTU: 9000, pathMTU: 1280},
+		{name: "path mtu is min+1", minMTU: 1280, maxMTU: 9000, pathMTU: 1281},
+		{name: "path mtu is max-1", minMTU: 1280, maxMTU: 9000, pathMTU: 8999},
+		{name: "path mtu is max", minMTU: 1280, maxMTU: 9000, pathMTU: 9000},
+		{name: "path mtu above max", minMTU: 1280, maxMTU: 9000, pathMTU: 65535},
+		{name: "min equals max", minMTU: 1500, maxMTU: 1500, pathMTU: 9000},
+		{name: "two mtus", minMTU: 1500, maxMTU: 1501, pathMTU: 1500},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			p := &pathPing{mtu: tc.pathMTU}
+			mtu, err := searchMaxMTU(context.Background(), tc.minMTU, tc.maxMTU, p.ping)
+			require.NoError(t, err)
+			expected := tc.pathMTU
+			if expected > tc.maxMTU {
+				expected = tc.maxMTU
+			}
+			require.Equal(t, expected, mtu)
+			for _, pinged := range p.pinged {
+				require.True(t, pinged >= tc.minMTU && pinged <= tc.maxMTU, "mtu %d pinged out of the range", pinged)
+			}
+		})
+	}
+}
+
+func Test_SearchMaxMTU_MinFails(t *testing.T) {
+	p := &pathPing{mtu: 1279}
+	_, err := searchMaxMTU(context.Background(), 1280, 9000, p.ping)
+	require.EqualError(t, err, "ping of mtu 1280 failed")
+	require.Equal(t, []uint32{1280}, p.pinged)
+}
+
+func Test_SearchMaxMTU_PingError(t *testing.T) {
+	pinged := 0
+	_, err := searchMaxMTU(context.Background(), 1280, 9000, func(_ context.Context, mtu uint32) (bool, error) {
+		pinged++
+		if pinged == 3 {
+			return false, errors.New("ping error")
+		}
+		return true, nil
+	})
+	require.EqualError(t, err, "ping error")
+}
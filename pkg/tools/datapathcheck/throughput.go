@@ -0,0 +1,96 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package datapathcheck
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type iperfResult struct {
+	End struct {
+		SumReceived struct {
+			BitsPerSecond float64 `json:"bits_per_second"`
+		} `json:"sum_received"`
+	} `json:"end"`
+	Error string `json:"error"`
+}
+
+// ServeThroughput - runs iperf3 server on the local address for a single test. Blocks until the test is finished
+// or ctx is done.
+func (e *Endpoint) ServeThroughput(ctx context.Context) error {
+	cmd := e.command(ctx, "iperf3", "--server", "--one-off", "--bind", e.LocalIP.String())
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "iperf3 server failed: %s", out)
+	}
+	return nil
+}
+
+// Throughput - runs iperf3 client against the iperf3 server on the peer for the duration and returns the received
+// bits per second. iperf3 runs for whole seconds, so the duration is rounded up to the next second.
+func (e *Endpoint) Throughput(ctx context.Context, duration time.Duration) (float64, error) {
+	seconds, err := iperfSeconds(duration)
+	if err != nil {
+		return 0, err
+	}
+	cmd := e.command(ctx, "iperf3", "--json",
+		"--client", e.PeerIP.String(),
+		"--bind", e.LocalIP.String(),
+		"--time", strconv.Itoa(seconds))
+	out, err := cmd.Output()
+	result := new(iperfResult)
+	if jsonErr := json.Unmarshal(out, result); jsonErr != nil {
+		if err != nil {
+			return 0, errors.Wrap(err, "iperf3 client failed")
+		}
+		return 0, errors.Wrap(jsonErr, "unable to parse iperf3 output")
+	}
+	if result.Error != "" {
+		return 0, errors.Errorf("iperf3 client failed: %s", result.Error)
+	}
+	if err != nil {
+		return 0, errors.Wrap(err, "iperf3 client failed")
+	}
+	return result.End.SumReceived.BitsPerSecond, nil
+}
+
+// iperfSeconds - returns the iperf3 --time of the duration rounded up to whole seconds
+func iperfSeconds(duration time.Duration) (int, error) {
+	if duration <= 0 {
+		return 0, errors.Errorf("invalid iperf3 duration %s", duration)
+	}
+	return int((duration + time.Second - 1) / time.Second), nil
+}
+
+// CheckThroughput - verifies that the throughput to the peer is at least minBitsPerSecond
+func (e *Endpoint) CheckThroughput(ctx context.Context, duration time.Duration, minBitsPerSecond float64) error {
+	bps, err := e.Throughput(ctx, duration)
+	if err != nil {
+		return err
+	}
+	if bps < minBitsPerSecond {
+		return errors.Errorf("throughput to %s is %.0f bits/s, expected at least %.0f bits/s", e.PeerIP, bps, minBitsPerSecond)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package datapathcheck
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_IperfSeconds(t *testing.T) {
+	for duration, expected := range map[time.Duration]int{
+		time.Nanosecond:                   1,
+		time.Second - time.Nanosecond:     1,
+		time.Second:                       1,
+		time.Second + time.Nanosecond:     2,
+		1500 * time.Millisecond:           2,
+		10 * time.Second:                  10,
+		10*time.Second + time.Millisecond: 11,
+	} {
+		seconds, err := iperfSeconds(duration)
+		require.NoError(t, err)
+		require.Equal(t, expected, seconds, "duration %s", duration)
+	}
+}
+
+func Test_IperfSeconds_Invalid(t *testing.T) {
+	for _, duration := range []time.Duration{0, -time.Second} {
+		_, err := iperfSeconds(duration)
+		require.Error(t, err)
+	}
+}
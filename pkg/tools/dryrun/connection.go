@@ -0,0 +1,137 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dryrun
+
+import (
+	"context"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/edwarnicke/govpp/binapi/memclnt"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// Connection aggregates the api.Connection and api.ChannelProvider interfaces
+type Connection interface {
+	api.Connection
+	api.ChannelProvider
+}
+
+type dryRunConnection struct{}
+
+// NewConnection - returns the vpp connection logging the binapi messages instead of sending them to vpp
+func NewConnection() Connection {
+	return new(dryRunConnection)
+}
+
+func (c *dryRunConnection) Invoke(ctx context.Context, req, _ api.Message) error {
+	logMessage(ctx, req)
+	return nil
+}
+
+func (c *dryRunConnection) NewStream(ctx context.Context, _ ...api.StreamOption) (api.Stream, error) {
+	return &dryRunStream{ctx: ctx}, nil
+}
+
+func (c *dryRunConnection) NewAPIChannel() (api.Channel, error) {
+	return new(dryRunChannel), nil
+}
+
+func (c *dryRunConnection) NewAPIChannelBuffered(_, _ int) (api.Channel, error) {
+	return c.NewAPIChannel()
+}
+
+// dryRunStream - the stream of the dump call returning no details
+type dryRunStream struct {
+	ctx context.Context
+}
+
+func (s *dryRunStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *dryRunStream) SendMsg(msg api.Message) error {
+	if _, ok := msg.(*memclnt.ControlPing); !ok {
+		logMessage(s.ctx, msg)
+	}
+	return nil
+}
+
+func (s *dryRunStream) RecvMsg() (api.Message, error) {
+	return &memclnt.ControlPingReply{}, nil
+}
+
+func (s *dryRunStream) Close() error {
+	return nil
+}
+
+// dryRunChannel - the channel logging the requests, the subscribed vpp events never come
+type dryRunChannel struct{}
+
+// IsDryRun returns true if apiChannel is the channel of the dry-run connection, so the elements waiting for the vpp
+// events over it skip the wait
+func IsDryRun(apiChannel api.Channel) bool {
+	_, ok := apiChannel.(*dryRunChannel)
+	return ok
+}
+
+func (c *dryRunChannel) SendRequest(msg api.Message) api.RequestCtx {
+	logMessage(context.Background(), msg)
+	return dryRunRequest{}
+}
+
+func (c *dryRunChannel) SendMultiRequest(msg api.Message) api.MultiRequestCtx {
+	logMessage(context.Background(), msg)
+	return dryRunMultiRequest{}
+}
+
+func (c *dryRunChannel) SubscribeNotification(_ chan api.Message, _ api.Message) (api.SubscriptionCtx, error) {
+	return dryRunRequest{}, nil
+}
+
+func (c *dryRunChannel) SetReplyTimeout(_ time.Duration) {}
+
+func (c *dryRunChannel) CheckCompatiblity(_ ...api.Message) error {
+	return nil
+}
+
+func (c *dryRunChannel) Close() {}
+
+// dryRunRequest - the request with the zero reply and the subscription never notified
+type dryRunRequest struct{}
+
+func (dryRunRequest) ReceiveReply(_ api.Message) error {
+	return nil
+}
+
+func (dryRunRequest) Unsubscribe() error {
+	return nil
+}
+
+// dryRunMultiRequest - the multi request with no replies
+type dryRunMultiRequest struct{}
+
+func (dryRunMultiRequest) ReceiveReply(_ api.Message) (bool, error) {
+	return true, nil
+}
+
+func logMessage(ctx context.Context, msg api.Message) {
+	log.FromContext(ctx).
+		WithField("vppapi", msg.GetMessageName()).
+		WithField("msg", msg).Info("dry-run")
+}
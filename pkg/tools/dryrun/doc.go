@@ -0,0 +1,23 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dryrun provides the vpp connection logging the binapi messages instead of sending them to vpp.
+//
+// The calls succeed with the zero replies and the dumps return no details, so the chain elements compute and log the
+// messages they would send for validating the chain configuration without a live dataplane. The interface indexes
+// returned by vpp are all zero. The vpp events subscribed to over the channels of the connection never come, the
+// elements waiting for them (e.g. for the link-up) skip the wait if IsDryRun.
+package dryrun
@@ -0,0 +1,21 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package hotreload provides the values of the element options updated at runtime from a provided source (a channel or
+// a file), so the elements can apply the new values to the existing connections without the forwarder restart.
+// The ACL rules (acl.WithRules), the storm control policer rate (l2bridgedomain.WithStormControlRate), the MTU override
+// (mtu.WithOverride) and the mechanism priority (mechanismpriority.NewClient) can be updated this way.
+package hotreload
@@ -0,0 +1,74 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hotreload
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"time"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// FromChan stores the values received from ch into v until ctx is done or ch is closed
+func FromChan[T any](ctx context.Context, ch <-chan T, v *Value[T]) {
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case value, ok := <-ch:
+				if !ok {
+					return
+				}
+				v.Store(value)
+			}
+		}
+	}()
+}
+
+// FromFile reads the file every interval and stores its content decoded with decode into v on every change until ctx
+// is done. The file which can't be read or decoded is skipped keeping the previous value.
+func FromFile[T any](ctx context.Context, filename string, interval time.Duration, decode func(data []byte) (T, error), v *Value[T]) {
+	logger := log.FromContext(ctx).WithField("hotreload", filename)
+	go func() {
+		var prev []byte
+		for {
+			data, err := os.ReadFile(filename) // #nosec G304
+			switch {
+			case err != nil:
+				logger.Warnf("unable to read the file: %v", err)
+			case prev == nil || !bytes.Equal(data, prev):
+				value, err := decode(data)
+				if err != nil {
+					logger.Warnf("unable to decode the file: %v", err)
+					break
+				}
+				prev = data
+				logger.Info("the file has changed, applying")
+				v.Store(value)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(interval):
+			}
+		}
+	}()
+}
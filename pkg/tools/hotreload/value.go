@@ -0,0 +1,83 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hotreload
+
+import (
+	"context"
+	"sync"
+)
+
+type watcher[T any] struct {
+	ctx context.Context
+	f   func(ctx context.Context, value T)
+}
+
+// Value - the value of the element option which can be updated at runtime
+type Value[T any] struct {
+	value    T
+	watchers []*watcher[T]
+	mutex    sync.Mutex
+	// storeMutex serializes the Stores, so the watchers get the values in order
+	storeMutex sync.Mutex
+}
+
+// NewValue returns a new Value set to the initial value
+func NewValue[T any](value T) *Value[T] {
+	return &Value[T]{
+		value: value,
+	}
+}
+
+// Load returns the current value
+func (v *Value[T]) Load() T {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	return v.value
+}
+
+// Store sets the value and calls the watchers with it
+func (v *Value[T]) Store(value T) {
+	v.storeMutex.Lock()
+	defer v.storeMutex.Unlock()
+
+	v.mutex.Lock()
+	v.value = value
+	var watchers []*watcher[T]
+	for _, w := range v.watchers {
+		if w.ctx.Err() == nil {
+			watchers = append(watchers, w)
+		}
+	}
+	v.watchers = watchers
+	v.mutex.Unlock()
+
+	for _, w := range watchers {
+		w.f(w.ctx, value)
+	}
+}
+
+// Watch makes f called with every new value until ctx is done
+func (v *Value[T]) Watch(ctx context.Context, f func(ctx context.Context, value T)) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	v.watchers = append(v.watchers, &watcher[T]{
+		ctx: ctx,
+		f:   f,
+	})
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keybinding
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+
+	"github.com/pkg/errors"
+	"github.com/spiffe/go-spiffe/v2/bundle/x509bundle"
+	"github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+const (
+	// SrcSignatureParam - mechanism parameter with the signature of the src key
+	SrcSignatureParam = "src_key_signature"
+	// SrcSVIDParam - mechanism parameter with the X509-SVID certificates the src key is signed with
+	SrcSVIDParam = "src_key_svid"
+	// DstSignatureParam - mechanism parameter with the signature of the dst key
+	DstSignatureParam = "dst_key_signature"
+	// DstSVIDParam - mechanism parameter with the X509-SVID certificates the dst key is signed with
+	DstSVIDParam = "dst_key_svid"
+)
+
+// Binder signs and verifies the tunnel keys. The nil Binder neither signs nor verifies the keys.
+type Binder struct {
+	svidSource   x509svid.Source
+	bundleSource x509bundle.Source
+	authorizer   tlsconfig.Authorizer
+}
+
+// New returns the Binder signing the keys with the X509-SVID from svidSource and verifying the keys of the other
+// side against bundleSource. The SPIFFE ID of the other side is authorized with authorizer.
+func New(svidSource x509svid.Source, bundleSource x509bundle.Source, authorizer tlsconfig.Authorizer) *Binder {
+	if authorizer == nil {
+		authorizer = tlsconfig.AuthorizeAny()
+	}
+	return &Binder{
+		svidSource:   svidSource,
+		bundleSource: bundleSource,
+		authorizer:   authorizer,
+	}
+}
+
+// Sign signs the key with the X509-SVID and stores the signature and the X509-SVID certificates in the mechanism
+// parameters. isSrc selects the src/dst parameters.
+func (b *Binder) Sign(mechanism *networkservice.Mechanism, key string, isSrc bool) error {
+	if b == nil || mechanism == nil {
+		return nil
+	}
+	svid, err := b.svidSource.GetX509SVID()
+	if err != nil {
+		return errors.Wrap(err, "failed to get X509-SVID")
+	}
+
+	digest, opts := []byte(key), crypto.SignerOpts(crypto.Hash(0))
+	if _, ok := svid.PrivateKey.Public().(ed25519.PublicKey); !ok {
+		sum := sha256.Sum256([]byte(key))
+		digest, opts = sum[:], crypto.SHA256
+	}
+	signature, err := svid.PrivateKey.Sign(rand.Reader, digest, opts)
+	if err != nil {
+		return errors.Wrap(err, "failed to sign the key")
+	}
+	var certs []byte
+	for _, cert := range svid.Certificates {
+		certs = append(certs, cert.Raw...)
+	}
+
+	signatureParam, svidParam := params(isSrc)
+	if mechanism.Parameters == nil {
+		mechanism.Parameters = make(map[string]string)
+	}
+	mechanism.Parameters[signatureParam] = base64.StdEncoding.EncodeToString(signature)
+	mechanism.Parameters[svidParam] = base64.StdEncoding.EncodeToString(certs)
+	return nil
+}
+
+// Verify verifies the signature of the key stored in the mechanism parameters. isSrc selects the src/dst parameters.
+func (b *Binder) Verify(mechanism *networkservice.Mechanism, key string, isSrc bool) error {
+	if b == nil {
+		return nil
+	}
+	signatureParam, svidParam := params(isSrc)
+	signature, err := base64.StdEncoding.DecodeString(mechanism.GetParameters()[signatureParam])
+	if err != nil || len(signature) == 0 {
+		return errors.Errorf("no valid %s mechanism parameter", signatureParam)
+	}
+	der, err := base64.StdEncoding.DecodeString(mechanism.GetParameters()[svidParam])
+	if err != nil {
+		return errors.Errorf("no valid %s mechanism parameter", svidParam)
+	}
+	certs, err := x509.ParseCertificates(der)
+	if err != nil || len(certs) == 0 {
+		return errors.Errorf("no valid %s mechanism parameter", svidParam)
+	}
+
+	id, chains, err := x509svid.Verify(certs, b.bundleSource)
+	if err != nil {
+		return errors.Wrap(err, "failed to verify X509-SVID the key is signed with")
+	}
+	if err := b.authorizer(id, chains); err != nil {
+		return errors.Wrapf(err, "%s is not authorized", id)
+	}
+	if err := certs[0].CheckSignature(signatureAlgorithm(certs[0]), []byte(key), signature); err != nil {
+		return errors.Wrapf(err, "invalid key signature of %s", id)
+	}
+	return nil
+}
+
+func params(isSrc bool) (signatureParam, svidParam string) {
+	if isSrc {
+		return SrcSignatureParam, SrcSVIDParam
+	}
+	return DstSignatureParam, DstSVIDParam
+}
+
+func signatureAlgorithm(cert *x509.Certificate) x509.SignatureAlgorithm {
+	switch cert.PublicKeyAlgorithm {
+	case x509.ECDSA:
+		return x509.ECDSAWithSHA256
+	case x509.Ed25519:
+		return x509.PureEd25519
+	default:
+		return x509.SHA256WithRSA
+	}
+}
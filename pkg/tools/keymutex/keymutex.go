@@ -0,0 +1,86 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package keymutex provides the mutexes by key: the elements programming the same vpp interface (MTU, admin state,
+// rx mode, ACLs) from the concurrent Requests and Closes serialize their read-modify-write sequences on the interface
+// mutex, so the vpp programming of the interface is not interleaved
+package keymutex
+
+import (
+	"context"
+	"sync"
+
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+)
+
+type entry struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// Mutex - the mutexes by key. The mutex of the key exists while it is locked or waited for, so the keys don't leak.
+// The zero Mutex is ready to use.
+type Mutex[K comparable] struct {
+	mu      sync.Mutex
+	entries map[K]*entry
+}
+
+// Lock locks the mutex of the key and returns the function unlocking it
+func (m *Mutex[K]) Lock(key K) (unlock func()) {
+	m.mu.Lock()
+	if m.entries == nil {
+		m.entries = make(map[K]*entry)
+	}
+	e, ok := m.entries[key]
+	if !ok {
+		e = new(entry)
+		m.entries[key] = e
+	}
+	e.refs++
+	m.mu.Unlock()
+
+	e.mu.Lock()
+	return func() {
+		e.mu.Unlock()
+
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		e.refs--
+		if e.refs == 0 {
+			delete(m.entries, key)
+		}
+	}
+}
+
+var interfaces Mutex[interface_types.InterfaceIndex]
+
+type heldKey struct {
+	swIfIndex interface_types.InterfaceIndex
+}
+
+// LockInterface locks the mutex of the vpp interface shared by all the elements for the whole read-modify-write
+// sequence of the element programming the interface (e.g. the ACL list dump and set) and returns the context marking
+// the mutex as held and the function unlocking it. LockInterface with the returned context doesn't lock the mutex
+// again, so the helpers locking the interface themselves can be called in the sequence. It must not be held across
+// the rest of the chain.
+func LockInterface(ctx context.Context, swIfIndex interface_types.InterfaceIndex) (context.Context, func()) {
+	key := heldKey{swIfIndex: swIfIndex}
+	if held, _ := ctx.Value(key).(bool); held {
+		return ctx, func() {}
+	}
+	unlock := interfaces.Lock(swIfIndex)
+	return context.WithValue(ctx, key, true), unlock
+}
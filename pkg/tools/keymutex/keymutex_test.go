@@ -0,0 +1,93 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keymutex_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/keymutex"
+)
+
+func Test_Mutex_SerializesSameKey(t *testing.T) {
+	var m keymutex.Mutex[string]
+	var wg sync.WaitGroup
+	counter := 0
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock := m.Lock("a")
+			defer unlock()
+			value := counter
+			time.Sleep(time.Microsecond)
+			counter = value + 1
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, 100, counter)
+}
+
+func Test_Mutex_DoesNotBlockOtherKeys(t *testing.T) {
+	var m keymutex.Mutex[string]
+	unlock := m.Lock("a")
+	defer unlock()
+
+	locked := make(chan struct{})
+	go func() {
+		defer close(locked)
+		m.Lock("b")()
+	}()
+	select {
+	case <-locked:
+	case <-time.After(time.Second):
+		t.Fatal("mutex of another key is blocked")
+	}
+}
+
+func Test_LockInterface_Reentrant(t *testing.T) {
+	ctx, unlock := keymutex.LockInterface(context.Background(), 1)
+
+	// The helper called with the context of the holder doesn't deadlock
+	innerCtx, innerUnlock := keymutex.LockInterface(ctx, 1)
+	require.Equal(t, ctx, innerCtx)
+	innerUnlock()
+
+	// The mutex is still held after the inner unlock
+	locked := make(chan struct{})
+	go func() {
+		defer close(locked)
+		_, otherUnlock := keymutex.LockInterface(context.Background(), 1)
+		otherUnlock()
+	}()
+	select {
+	case <-locked:
+		t.Fatal("interface mutex is acquired while held")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	// Another interface is not locked by the context
+	_, otherUnlock := keymutex.LockInterface(ctx, 2)
+	otherUnlock()
+
+	unlock()
+	<-locked
+}
@@ -0,0 +1,28 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package macsec provides the MACsec encryption of the physical uplink between the forwarder and the top-of-rack
+// switch, so the tunnel-less mechanisms (e.g. vlan) still get the link-layer encryption.
+//
+// vpp has no MACsec, so the kernel MACsec device is created over the NIC with the static keys (the 802.1X MKA key
+// agreement is not done) and vpp attaches its af_packet uplink to it:
+//
+//	ifName, err := macsec.Ensure(ctx, "eth0", macsec.WithKeyFile("/etc/macsec/keys.json"))
+//	...
+//	uplinkName, err := macsec.CreateUplink(ctx, vppConn, ifName)
+//	...
+//	vppConn, err := vppinit.Dial(ctx, vppinit.WithUplink(uplinkName, addrs...))
+package macsec
@@ -0,0 +1,187 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package macsec
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"os/exec"
+	"strings"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/edwarnicke/govpp/binapi/af_packet"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/types"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/uplink"
+)
+
+// Ensure creates the kernel MACsec device over the parent NIC with the keys of the options, sets the parent and the
+// device up and returns the name of the device. If the device already exists, its secure associations are reprogrammed
+// with the keys of the options: the kernel doesn't expose the keys to compare them, and the device left by the previous
+// run may still use the rotated keys
+func Ensure(ctx context.Context, parent string, opts ...Option) (string, error) {
+	o := &options{
+		name: defaultName,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if err := o.loadKeys(); err != nil {
+		return "", err
+	}
+	if o.keys.TxKey.Key == "" {
+		return "", errors.New("MACsec tx key is not set")
+	}
+
+	encrypt := "on"
+	if o.noEncrypt {
+		encrypt = "off"
+	}
+	commands := [][]string{
+		{"link", "set", parent, "up"},
+	}
+	_, err := net.InterfaceByName(o.name)
+	exists := err == nil
+	if exists {
+		out, showErr := ipOutput(ctx, "macsec", "show", o.name)
+		if showErr != nil {
+			return "", errors.Wrapf(showErr, "failed to get the secure channels of the MACsec device %s", o.name)
+		}
+		txSA, rxSCIs := parseSecureChannels(out)
+		commands = append(commands, []string{"link", "set", o.name, "type", "macsec", "encrypt", encrypt})
+		if txSA {
+			commands = append(commands, []string{"macsec", "del", o.name, "tx", "sa", "0"})
+		}
+		for _, sci := range rxSCIs {
+			commands = append(commands, []string{"macsec", "del", o.name, "rx", "sci", sci})
+		}
+	} else {
+		commands = append(commands,
+			[]string{"link", "add", "link", parent, o.name, "type", "macsec", "port", port, "encrypt", encrypt})
+	}
+	commands = append(commands,
+		[]string{"macsec", "add", o.name, "tx", "sa", "0", "pn", "1", "on", "key", o.keys.TxKey.ID, o.keys.TxKey.Key})
+	for _, peer := range o.keys.Peers {
+		commands = append(commands,
+			[]string{"macsec", "add", o.name, "rx", "port", port, "address", peer.Address},
+			[]string{"macsec", "add", o.name, "rx", "port", port, "address", peer.Address,
+				"sa", "0", "pn", "1", "on", "key", peer.Key.ID, peer.Key.Key},
+		)
+	}
+	commands = append(commands, []string{"link", "set", o.name, "up"})
+
+	for _, args := range commands {
+		if err := ip(ctx, args...); err != nil {
+			// The keys are not logged
+			return "", errors.Wrapf(err, "failed to configure the MACsec device %s over %s", o.name, parent)
+		}
+	}
+	msg := "MACsec device created"
+	if exists {
+		msg = "MACsec device keys reprogrammed"
+	}
+	log.FromContext(ctx).
+		WithField("name", o.name).
+		WithField("parent", parent).
+		WithField("peers", len(o.keys.Peers)).
+		Info(msg)
+	return o.name, nil
+}
+
+// CreateUplink creates the vpp af_packet interface attached to the MACsec device (if not yet created) and returns its
+// name to be used as the uplink
+func CreateUplink(ctx context.Context, vppConn api.Connection, ifName string) (string, error) {
+	name := "host-" + ifName
+	if u, err := uplink.ByName(ctx, vppConn, name); err == nil {
+		return u.Name, nil
+	}
+	link, err := net.InterfaceByName(ifName)
+	if err != nil {
+		return "", errors.Wrapf(err, "MACsec device %s not found", ifName)
+	}
+
+	now := time.Now()
+	rsp, err := af_packet.NewServiceClient(vppConn).AfPacketCreate(ctx, &af_packet.AfPacketCreate{
+		HostIfName: ifName,
+		HwAddr:     types.ToVppMacAddress(&link.HardwareAddr),
+	})
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", rsp.SwIfIndex).
+		WithField("hostIfName", ifName).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "AfPacketCreate").Info("completed")
+
+	u, err := uplink.BySwIfIndex(ctx, vppConn, rsp.SwIfIndex)
+	if err != nil {
+		return "", err
+	}
+	return u.Name, nil
+}
+
+// parseSecureChannels returns whether the transmitting secure association 0 exists and the SCIs of the receiving
+// secure channels from the output of 'ip macsec show':
+//
+//	TXSC: 0242ac1100020001 on SA 0
+//	    0: PN 1, state on, key 01000000000000000000000000000000
+//	RXSC: 0242ac1100030001, state on
+//	    0: PN 1, state on, key 02000000000000000000000000000000
+func parseSecureChannels(out string) (txSA bool, rxSCIs []string) {
+	inTXSC := false
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		switch fields[0] {
+		case "TXSC:":
+			inTXSC = true
+		case "RXSC:":
+			inTXSC = false
+			rxSCIs = append(rxSCIs, strings.TrimSuffix(fields[1], ","))
+		case "0:":
+			txSA = txSA || inTXSC
+		}
+	}
+	return txSA, rxSCIs
+}
+
+func ip(ctx context.Context, args ...string) error {
+	_, err := ipOutput(ctx, args...)
+	return err
+}
+
+func ipOutput(ctx context.Context, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "ip", args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", errors.Wrapf(err, "ip %s: %s", args[0]+" "+args[1], strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
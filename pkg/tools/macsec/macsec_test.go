@@ -0,0 +1,52 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package macsec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseSecureChannels(t *testing.T) {
+	out := `11: macsec0: protect on validate strict sc off sa off encrypt on send_sci on end_station off scb off replay off
+    cipher suite: GCM-AES-128, using ICV length 16
+    TXSC: 0242ac1100020001 on SA 0
+        0: PN 153, state on, key 01000000000000000000000000000000
+    RXSC: 0242ac1100030001, state on
+        0: PN 17, state on, key 02000000000000000000000000000000
+    RXSC: 0242ac1100040001, state on
+`
+	txSA, rxSCIs := parseSecureChannels(out)
+	require.True(t, txSA)
+	require.Equal(t, []string{"0242ac1100030001", "0242ac1100040001"}, rxSCIs)
+}
+
+func Test_ParseSecureChannels_NoTxSA(t *testing.T) {
+	out := `11: macsec0: protect on validate strict sc off sa off encrypt on send_sci on end_station off scb off replay off
+    cipher suite: GCM-AES-128, using ICV length 16
+    TXSC: 0242ac1100020001 on SA 0
+    RXSC: 0242ac1100030001, state on
+        0: PN 17, state on, key 02000000000000000000000000000000
+`
+	txSA, rxSCIs := parseSecureChannels(out)
+	require.False(t, txSA)
+	require.Equal(t, []string{"0242ac1100030001"}, rxSCIs)
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package macsec
+
+import (
+	"encoding/json"
+	"net"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultName = "macsec0"
+	// port - the port of the secure channels
+	port = "1"
+)
+
+// Key - MACsec key, the key ID and the key are hex strings
+type Key struct {
+	ID  string `json:"id"`
+	Key string `json:"key"`
+}
+
+// Peer - the receiving secure channel of the peer (the switch)
+type Peer struct {
+	Address string `json:"address"`
+	Key     Key    `json:"key"`
+}
+
+// Keys - the keys of the MACsec device, the format of the key file
+type Keys struct {
+	TxKey Key    `json:"txKey"`
+	Peers []Peer `json:"peers"`
+}
+
+type options struct {
+	name      string
+	noEncrypt bool
+	keys      Keys
+	keyFile   string
+}
+
+// Option is an option pattern for Ensure
+type Option func(o *options)
+
+// WithName sets the name of the MACsec device, macsec0 by default
+func WithName(name string) Option {
+	return func(o *options) {
+		o.name = name
+	}
+}
+
+// WithTxKey sets the key of the transmitting secure association
+func WithTxKey(id, key string) Option {
+	return func(o *options) {
+		o.keys.TxKey = Key{ID: id, Key: key}
+	}
+}
+
+// WithPeer adds the receiving secure channel of the peer with the MAC address and its key
+func WithPeer(address net.HardwareAddr, id, key string) Option {
+	return func(o *options) {
+		o.keys.Peers = append(o.keys.Peers, Peer{
+			Address: address.String(),
+			Key:     Key{ID: id, Key: key},
+		})
+	}
+}
+
+// WithKeyFile sets the JSON file with the keys (the Keys structure) used instead of WithTxKey and WithPeer, so the
+// keys can be mounted from a secret
+func WithKeyFile(filename string) Option {
+	return func(o *options) {
+		o.keyFile = filename
+	}
+}
+
+// WithoutEncryption sets the MACsec device to only authenticate the frames (integrity only)
+func WithoutEncryption() Option {
+	return func(o *options) {
+		o.noEncrypt = true
+	}
+}
+
+func (o *options) loadKeys() error {
+	if o.keyFile == "" {
+		return nil
+	}
+	data, err := os.ReadFile(o.keyFile)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read the MACsec key file %s", o.keyFile)
+	}
+	if err := json.Unmarshal(data, &o.keys); err != nil {
+		return errors.Wrapf(err, "failed to parse the MACsec key file %s", o.keyFile)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mechutils
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// CloseOnError closes the connection with closeFunc in the context postponed with postponeCtxFunc
+// (see postpone.ContextWithValues) and returns err wrapped with the close error if any
+func CloseOnError(postponeCtxFunc func() (context.Context, context.CancelFunc), closeFunc func(ctx context.Context) error, err error) error {
+	closeCtx, cancelClose := postponeCtxFunc()
+	defer cancelClose()
+
+	if closeErr := closeFunc(closeCtx); closeErr != nil {
+		err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+	}
+	return err
+}
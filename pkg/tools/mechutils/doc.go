@@ -0,0 +1,26 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mechutils provides utilities for conververtin kernel.Mechanism to various things and the common plumbing
+// of the mechanism chain elements (typed metadata, close on error, vpp interface create/delete), so a new mechanism
+// only needs to provide the functions creating and deleting its vpp interface:
+//
+//	mechutils.NewServer(&mechutils.Mechanism[*vxlan.Mechanism]{
+//		ToMechanism: vxlan.ToMechanism,
+//		Create:      create,
+//		Delete:      del,
+//	})
+package mechutils
@@ -0,0 +1,66 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package mechutils
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/nshandle"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// DisableIPv6 fully disables IPv6 on the kernel interface in the network namespace: no link local address is
+// generated (addr_gen_mode) and the IPv6 is disabled (disable_ipv6) removing the addresses already assigned. It should
+// be called before the link is set up and the addresses are assigned to avoid racing with them.
+func DisableIPv6(ctx context.Context, netNSURL, ifName string) error {
+	nsHandle, err := nshandle.FromURL(netNSURL)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get net NS handle by URL: %s", netNSURL)
+	}
+	defer func() { _ = nsHandle.Close() }()
+
+	current, err := nshandle.Current()
+	if err != nil {
+		return errors.Wrap(err, "failed to get current net NS")
+	}
+	defer func() { _ = current.Close() }()
+
+	// addr_gen_mode 1 - no link local address, set first so it is not generated even if the IPv6 is enabled later
+	for _, sysctl := range []struct{ name, value string }{{"addr_gen_mode", "1"}, {"disable_ipv6", "1"}} {
+		now := time.Now()
+		path := filepath.Join("/proc/sys/net/ipv6/conf", ifName, sysctl.name)
+		if err := nshandle.RunIn(current, nsHandle, func() error {
+			return os.WriteFile(path, []byte(sysctl.value), 0o600)
+		}); err != nil {
+			return errors.Wrapf(err, "unable to set %s on %s", sysctl.name, ifName)
+		}
+		log.FromContext(ctx).
+			WithField("link.Name", ifName).
+			WithField("value", sysctl.value).
+			WithField("duration", time.Since(now)).
+			WithField("sysctl", path).Debug("completed")
+	}
+	return nil
+}
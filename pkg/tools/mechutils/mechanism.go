@@ -0,0 +1,152 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mechutils
+
+import (
+	"context"
+
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/networkservice/up"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/ifindex"
+)
+
+// Mechanism - vpp interface plumbing of the mechanism of type M (e.g. *vxlan.Mechanism)
+type Mechanism[M comparable] struct {
+	// ToMechanism converts the connection mechanism to M, returning the zero M (nil) for the other mechanisms
+	ToMechanism func(mechanism *networkservice.Mechanism) M
+	// Create creates the vpp interface of the connection
+	Create func(ctx context.Context, conn *networkservice.Connection, mechanism M, isClient bool) (interface_types.InterfaceIndex, error)
+	// Delete deletes the vpp interface of the connection
+	Delete func(ctx context.Context, conn *networkservice.Connection, swIfIndex interface_types.InterfaceIndex, isClient bool) error
+	// WaitForUp makes the up chain element wait for the created vpp interface to be up
+	WaitForUp bool
+}
+
+// request creates the vpp interface of the connection if it is not created yet
+func (m *Mechanism[M]) request(ctx context.Context, conn *networkservice.Connection, isClient bool) error {
+	var zero M
+	mechanism := m.ToMechanism(conn.GetMechanism())
+	if mechanism == zero {
+		return nil
+	}
+	if _, ok := ifindex.Load(ctx, isClient); ok {
+		return nil
+	}
+	swIfIndex, err := m.Create(ctx, conn, mechanism, isClient)
+	if err != nil {
+		return err
+	}
+	ifindex.Store(ctx, isClient, swIfIndex)
+	if m.WaitForUp {
+		up.Store(ctx, isClient, true)
+	}
+	return nil
+}
+
+// close deletes the vpp interface of the connection
+func (m *Mechanism[M]) close(ctx context.Context, conn *networkservice.Connection, isClient bool) {
+	var zero M
+	if m.ToMechanism(conn.GetMechanism()) == zero {
+		return
+	}
+	swIfIndex, ok := ifindex.LoadAndDelete(ctx, isClient)
+	if !ok {
+		return
+	}
+	if err := m.Delete(ctx, conn, swIfIndex, isClient); err != nil {
+		log.FromContext(ctx).Errorf("failed to delete the vpp interface %v: %v", swIfIndex, err)
+	}
+}
+
+type mechanismServer[M comparable] struct {
+	mechanism *Mechanism[M]
+}
+
+// NewServer returns a Server chain element creating the vpp interface of the mechanism after the Request and
+// deleting it on Close
+func NewServer[M comparable](mechanism *Mechanism[M]) networkservice.NetworkServiceServer {
+	return &mechanismServer[M]{
+		mechanism: mechanism,
+	}
+}
+
+func (s *mechanismServer[M]) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.mechanism.request(ctx, conn, metadata.IsClient(s)); err != nil {
+		return nil, CloseOnError(postponeCtxFunc, func(closeCtx context.Context) error {
+			_, closeErr := s.Close(closeCtx, conn)
+			return closeErr
+		}, err)
+	}
+
+	return conn, nil
+}
+
+func (s *mechanismServer[M]) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	s.mechanism.close(ctx, conn, metadata.IsClient(s))
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+type mechanismClient[M comparable] struct {
+	mechanism *Mechanism[M]
+}
+
+// NewClient returns a Client chain element creating the vpp interface of the mechanism after the Request and
+// deleting it on Close
+func NewClient[M comparable](mechanism *Mechanism[M]) networkservice.NetworkServiceClient {
+	return &mechanismClient[M]{
+		mechanism: mechanism,
+	}
+}
+
+func (c *mechanismClient[M]) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.mechanism.request(ctx, conn, metadata.IsClient(c)); err != nil {
+		return nil, CloseOnError(postponeCtxFunc, func(closeCtx context.Context) error {
+			_, closeErr := c.Close(closeCtx, conn, opts...)
+			return closeErr
+		}, err)
+	}
+
+	return conn, nil
+}
+
+func (c *mechanismClient[M]) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	c.mechanism.close(ctx, conn, metadata.IsClient(c))
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
@@ -19,7 +19,6 @@
 //go:build linux
 // +build linux
 
-// Package mechutils provides utilities for conververtin kernel.Mechanism to various things
 package mechutils
 
 import (
@@ -0,0 +1,55 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mechutils
+
+import (
+	"context"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+// Store sets the value of type T stored with the key in per Connection.Id metadata
+func Store[T any](ctx context.Context, isClient bool, key any, value T) {
+	metadata.Map(ctx, isClient).Store(key, value)
+}
+
+// Load returns the value of type T stored with the key in per Connection.Id metadata.
+// The ok result indicates whether the value of type T was found in the per Connection.Id metadata.
+func Load[T any](ctx context.Context, isClient bool, key any) (value T, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).Load(key)
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(T)
+	return value, ok
+}
+
+// LoadAndDelete deletes the value of type T stored with the key in per Connection.Id metadata,
+// returning the previous value if any. The loaded result reports whether the value of type T was present.
+func LoadAndDelete[T any](ctx context.Context, isClient bool, key any) (value T, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).LoadAndDelete(key)
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(T)
+	return value, ok
+}
+
+// Delete deletes the value stored with the key in per Connection.Id metadata
+func Delete(ctx context.Context, isClient bool, key any) {
+	metadata.Map(ctx, isClient).Delete(key)
+}
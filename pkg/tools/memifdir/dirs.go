@@ -0,0 +1,135 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memifdir
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	socketName = "memif.socket"
+	// The base directory can be traversed but not listed by the clients
+	baseDirMode  = 0o711
+	dirMode      = 0o700
+	socketMode   = 0o600
+	selinuxXattr = "security.selinux"
+)
+
+type options struct {
+	selinuxLabel string
+	gid          int
+}
+
+// Option is an option for the memif socket directories
+type Option func(o *options)
+
+// WithSELinuxLabel sets the SELinux label of the directories and the sockets, e.g.
+// "system_u:object_r:container_file_t:s0"
+func WithSELinuxLabel(label string) Option {
+	return func(o *options) {
+		o.selinuxLabel = label
+	}
+}
+
+// WithGID sets the group owning the directories and the sockets, vpp must be able to reach the sockets if it doesn't
+// run as root
+func WithGID(gid int) Option {
+	return func(o *options) {
+		o.gid = gid
+	}
+}
+
+// Dirs - the memif socket directories of the connections in the base directory
+type Dirs struct {
+	baseDir      string
+	selinuxLabel string
+	gid          int
+}
+
+// New returns the memif socket directories in baseDir. The baseDir must be mounted at the same path to vpp and to the
+// clients.
+func New(baseDir string, opts ...Option) *Dirs {
+	o := &options{
+		gid: -1,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &Dirs{
+		baseDir:      baseDir,
+		selinuxLabel: o.selinuxLabel,
+		gid:          o.gid,
+	}
+}
+
+// SocketFile returns the memif socket file of the connection
+func (d *Dirs) SocketFile(connID string) string {
+	return filepath.Join(d.baseDir, connID, socketName)
+}
+
+// Create creates the directory of the connection owned by uid (-1 keeps the owner), only the owner has access to it
+func (d *Dirs) Create(connID string, uid int) error {
+	if connID == "" || strings.ContainsRune(connID, filepath.Separator) || connID == "." || connID == ".." {
+		return errors.Errorf("invalid connection ID for the memif socket directory: %q", connID)
+	}
+	if err := os.MkdirAll(d.baseDir, baseDirMode); err != nil {
+		return errors.Wrapf(err, "failed to create the memif socket base directory %s", d.baseDir)
+	}
+	dir := filepath.Join(d.baseDir, connID)
+	if err := os.Mkdir(dir, dirMode); err != nil && !os.IsExist(err) {
+		return errors.Wrapf(err, "failed to create the memif socket directory %s", dir)
+	}
+	// The mode of Mkdir is masked by the umask
+	if err := os.Chmod(dir, dirMode); err != nil {
+		return errors.Wrapf(err, "failed to set the mode of the memif socket directory %s", dir)
+	}
+	return d.own(dir, uid)
+}
+
+// Secure passes the ownership of the socket file created by vpp to uid and makes it accessible only by the owner
+func (d *Dirs) Secure(socketFile string, uid int) error {
+	if err := os.Chmod(socketFile, socketMode); err != nil {
+		return errors.Wrapf(err, "failed to set the mode of the memif socket %s", socketFile)
+	}
+	return d.own(socketFile, uid)
+}
+
+// Delete deletes the directory of the connection
+func (d *Dirs) Delete(connID string) error {
+	if connID == "" || strings.ContainsRune(connID, filepath.Separator) || connID == "." || connID == ".." {
+		return nil
+	}
+	return errors.WithStack(os.RemoveAll(filepath.Join(d.baseDir, connID)))
+}
+
+func (d *Dirs) own(path string, uid int) error {
+	if err := os.Lchown(path, uid, d.gid); err != nil {
+		return errors.Wrapf(err, "failed to change the owner of %s to %d:%d", path, uid, d.gid)
+	}
+	if d.selinuxLabel == "" {
+		return nil
+	}
+	if err := unix.Lsetxattr(path, selinuxXattr, []byte(d.selinuxLabel), 0); err != nil {
+		return errors.Wrapf(err, "failed to set the SELinux label %s of %s", d.selinuxLabel, path)
+	}
+	return nil
+}
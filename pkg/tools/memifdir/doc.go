@@ -0,0 +1,21 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package memifdir provides the per connection memif socket directories: the directory is accessible only by the
+// client owning the connection (the UID of the client pod) instead of the world readable directory shared by all the
+// connections. The directory can be labeled for SELinux, its path is predictable for the AppArmor profiles:
+// <baseDir>/<connection ID>/memif.socket.
+package memifdir
@@ -0,0 +1,86 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mtupath
+
+import (
+	"context"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+type key struct{}
+
+const (
+	// UplinkHop - the uplink carrying the tunnels, its MTU is the L3 MTU of the uplink interface
+	UplinkHop = "uplink"
+	// KernelHop - the kernel interface of the connection, its MTU is the MTU of the kernel link
+	KernelHop = "kernel"
+	// InterfaceHop - the vpp interface of the connection, its MTU is the max frame size of the interface
+	InterfaceHop = "interface"
+)
+
+// Hop - element of the connection data path limiting the MTU
+type Hop struct {
+	// Name - name of the hop (e.g. "vxlan", "wireguard", "requester")
+	Name string
+	// MTU - maximum MTU the hop is able to carry
+	MTU uint32
+}
+
+// Store records the MTU limit of the hop in per Connection.Id metadata.
+// Storing the hop with the same name again overwrites its MTU.
+func Store(ctx context.Context, isClient bool, name string, mtu uint32) {
+	hops, _ := Load(ctx, isClient)
+	rv := []Hop{{Name: name, MTU: mtu}}
+	for _, hop := range hops {
+		if hop.Name != name {
+			rv = append(rv, hop)
+		}
+	}
+	metadata.Map(ctx, isClient).Store(key{}, rv)
+}
+
+// Load returns the hops stored in per Connection.Id metadata, or nil if no
+// value is present.
+// The ok result indicates whether value was found in the per Connection.Id metadata.
+func Load(ctx context.Context, isClient bool) (value []Hop, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).Load(key{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.([]Hop)
+	return value, ok
+}
+
+// Delete deletes the hops stored in per Connection.Id metadata
+func Delete(ctx context.Context, isClient bool) {
+	metadata.Map(ctx, isClient).Delete(key{})
+}
+
+// Constraint returns the hop with the smallest MTU.
+// The ok result is false if hops contain no hop with non zero MTU.
+func Constraint(hops ...Hop) (hop Hop, ok bool) {
+	for _, h := range hops {
+		if h.MTU == 0 {
+			continue
+		}
+		if !ok || h.MTU < hop.MTU {
+			hop, ok = h, true
+		}
+	}
+	return hop, ok
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mtupath_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/checks/checkcontext"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/mtupath"
+)
+
+func Test_Constraint(t *testing.T) {
+	hop, ok := mtupath.Constraint(
+		mtupath.Hop{Name: "requester", MTU: 9000},
+		mtupath.Hop{Name: mtupath.KernelHop, MTU: 0},
+		mtupath.Hop{Name: "vxlan", MTU: 1450},
+		mtupath.Hop{Name: mtupath.UplinkHop, MTU: 1500},
+	)
+	require.True(t, ok)
+	require.Equal(t, mtupath.Hop{Name: "vxlan", MTU: 1450}, hop)
+
+	// The first of the hops with the same MTU is reported
+	hop, ok = mtupath.Constraint(
+		mtupath.Hop{Name: "wireguard", MTU: 1420},
+		mtupath.Hop{Name: "remote", MTU: 1420},
+	)
+	require.True(t, ok)
+	require.Equal(t, "wireguard", hop.Name)
+
+	_, ok = mtupath.Constraint(mtupath.Hop{Name: mtupath.KernelHop})
+	require.False(t, ok)
+	_, ok = mtupath.Constraint()
+	require.False(t, ok)
+}
+
+func Test_Store_OverwritesHop(t *testing.T) {
+	ctx := context.Background()
+	var hops []mtupath.Hop
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		checkcontext.NewServer(t, func(t *testing.T, ctx context.Context) {
+			mtupath.Store(ctx, false, mtupath.UplinkHop, 1500)
+			mtupath.Store(ctx, false, "vxlan", 1450)
+			mtupath.Store(ctx, false, mtupath.UplinkHop, 9000)
+			hops, _ = mtupath.Load(ctx, false)
+		}),
+	)
+	_, err := server.Request(ctx, &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{Id: "id"},
+	})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []mtupath.Hop{{Name: mtupath.UplinkHop, MTU: 9000}, {Name: "vxlan", MTU: 1450}}, hops)
+}
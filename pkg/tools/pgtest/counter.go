@@ -0,0 +1,64 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pgtest
+
+import (
+	"context"
+
+	"git.fd.io/govpp.git/api"
+	"git.fd.io/govpp.git/core"
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+	"github.com/pkg/errors"
+)
+
+// Counter - returns the number of the packets counted so far
+type Counter func(ctx context.Context) (uint64, error)
+
+// InterfaceRxCounter - returns the Counter of the packets received on the interface
+func InterfaceRxCounter(statsConn *core.StatsConnection, swIfIndex interface_types.InterfaceIndex) Counter {
+	return func(ctx context.Context) (uint64, error) {
+		counters, err := interfaceCounters(statsConn, swIfIndex)
+		if err != nil {
+			return 0, err
+		}
+		return counters.Rx.Packets, nil
+	}
+}
+
+// InterfaceTxCounter - returns the Counter of the packets sent from the interface
+func InterfaceTxCounter(statsConn *core.StatsConnection, swIfIndex interface_types.InterfaceIndex) Counter {
+	return func(ctx context.Context) (uint64, error) {
+		counters, err := interfaceCounters(statsConn, swIfIndex)
+		if err != nil {
+			return 0, err
+		}
+		return counters.Tx.Packets, nil
+	}
+}
+
+func interfaceCounters(statsConn *core.StatsConnection, swIfIndex interface_types.InterfaceIndex) (*api.InterfaceCounters, error) {
+	stats := new(api.InterfaceStats)
+	if err := statsConn.GetInterfaceStats(stats); err != nil {
+		return nil, errors.Wrap(err, "getting interface stats failed")
+	}
+	for idx := range stats.Interfaces {
+		if stats.Interfaces[idx].InterfaceIndex == uint32(swIfIndex) {
+			return &stats.Interfaces[idx], nil
+		}
+	}
+	return nil, errors.Errorf("no stats found for the interface %d", swIfIndex)
+}
@@ -0,0 +1,24 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pgtest provides a debug API generating the traffic across a connection with the vpp packet generator (pg)
+// and measuring the rate and the loss delivered to the far side. Intended for the automated acceptance testing of
+// the connections after Request.
+//
+// The packets are injected as if received on the connection interface of the forwarder, so they go through the same
+// graph nodes (xconnect, tunnel encap, ...) as the traffic of the workload. The far side is measured with a Counter,
+// e.g. the rx counter of the connection interface in the vpp on the other end.
+package pgtest
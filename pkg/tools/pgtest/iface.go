@@ -0,0 +1,63 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pgtest
+
+import (
+	"context"
+	"io"
+
+	"git.fd.io/govpp.git/api"
+	interfaces "github.com/edwarnicke/govpp/binapi/interface"
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+	"github.com/pkg/errors"
+)
+
+// Interface - vpp interface of the connection
+type Interface struct {
+	SwIfIndex interface_types.InterfaceIndex
+	Name      string
+}
+
+// FindInterface - returns the interface tagged with the connection id
+func FindInterface(ctx context.Context, vppConn api.Connection, connID string) (*Interface, error) {
+	client, err := interfaces.NewServiceClient(vppConn).SwInterfaceDump(ctx, &interfaces.SwInterfaceDump{
+		SwIfIndex: ^interface_types.InterfaceIndex(0),
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	var rv *Interface
+	for {
+		details, err := client.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if rv == nil && details.Tag == connID {
+			rv = &Interface{
+				SwIfIndex: details.SwIfIndex,
+				Name:      details.InterfaceName,
+			}
+		}
+	}
+	if rv == nil {
+		return nil, errors.Errorf("no interface found for the connection %s", connID)
+	}
+	return rv, nil
+}
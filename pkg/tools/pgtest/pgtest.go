@@ -0,0 +1,166 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pgtest
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/edwarnicke/govpp/binapi/pg"
+	"github.com/edwarnicke/govpp/binapi/vlib"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const (
+	srcPort = 4321
+	dstPort = 1234
+	// settleTime - time given to the last packets to reach the far side
+	settleTime = time.Second
+)
+
+// Stream - IPv4 UDP traffic injected as if received on the interface
+type Stream struct {
+	// Interface - the packets are injected as if received on it
+	Interface *Interface
+	SrcIP     net.IP
+	DstIP     net.IP
+	// SrcMAC, DstMAC - the packets get the ethernet header if set, required for the ethernet payload connections
+	SrcMAC net.HardwareAddr
+	DstMAC net.HardwareAddr
+	// PacketSize - IP packet size
+	PacketSize uint32
+	// Rate - packets per second
+	Rate uint64
+	// Count - number of the packets
+	Count uint64
+}
+
+// Result - the traffic delivered to the far side
+type Result struct {
+	Sent     uint64
+	Received uint64
+	Duration time.Duration
+}
+
+// Loss - returns the ratio of the packets lost
+func (r *Result) Loss() float64 {
+	if r.Sent == 0 || r.Received >= r.Sent {
+		return 0
+	}
+	return float64(r.Sent-r.Received) / float64(r.Sent)
+}
+
+// PacketsPerSecond - returns the delivered rate
+func (r *Result) PacketsPerSecond() float64 {
+	if r.Duration <= 0 {
+		return 0
+	}
+	return float64(r.Received) / r.Duration.Seconds()
+}
+
+// Run - generates the stream with vpp pg and returns the traffic counted by the far side Counter
+func Run(ctx context.Context, vppConn api.Connection, stream *Stream, farSide Counter) (*Result, error) {
+	if stream.Rate == 0 || stream.Count == 0 {
+		return nil, errors.New("stream rate and count must be set")
+	}
+	if stream.SrcIP.To4() == nil || stream.DstIP.To4() == nil {
+		return nil, errors.New("only IPv4 streams are supported")
+	}
+	name := fmt.Sprintf("pgtest-%d", stream.Interface.SwIfIndex)
+
+	before, err := farSide(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = cli(ctx, vppConn, stream.command(name)); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if delErr := cli(ctx, vppConn, "packet-generator delete "+name); delErr != nil {
+			log.FromContext(ctx).Errorf("unable to delete pg stream %s: %v", name, delErr)
+		}
+	}()
+
+	now := time.Now()
+	if _, err = pg.NewServiceClient(vppConn).PgEnableDisable(ctx, &pg.PgEnableDisable{
+		IsEnabled:  true,
+		StreamName: name,
+	}); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	duration := time.Duration(stream.Count) * time.Second / time.Duration(stream.Rate)
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(duration + settleTime):
+	}
+
+	after, err := farSide(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := &Result{
+		Sent:     stream.Count,
+		Received: after - before,
+		Duration: duration,
+	}
+	log.FromContext(ctx).
+		WithField("stream", name).
+		WithField("sent", result.Sent).
+		WithField("received", result.Received).
+		WithField("duration", time.Since(now)).
+		Info("pg stream completed")
+	return result, nil
+}
+
+func (s *Stream) command(name string) string {
+	node := "ip4-input"
+	data := ""
+	if s.SrcMAC != nil && s.DstMAC != nil {
+		node = "ethernet-input"
+		data = fmt.Sprintf("IP4: %s -> %s ", s.SrcMAC, s.DstMAC)
+	}
+	data += fmt.Sprintf("UDP: %s -> %s UDP: %d -> %d length %d checksum 0 incrementing 1",
+		s.SrcIP, s.DstIP, srcPort, dstPort, s.PacketSize)
+	return fmt.Sprintf("packet-generator new { name %s limit %d rate %d size %d-%d interface %s node %s data { %s } }",
+		name, s.Count, s.Rate, s.PacketSize, s.PacketSize, s.Interface.Name, node, data)
+}
+
+func cli(ctx context.Context, vppConn api.Connection, cmd string) error {
+	now := time.Now()
+	reply, err := vlib.NewServiceClient(vppConn).CliInband(ctx, &vlib.CliInband{
+		Cmd: cmd,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "vpp error running %q", cmd)
+	}
+	// The cli commands report the errors in the reply
+	if msg := strings.TrimSpace(reply.Reply); msg != "" {
+		return errors.Errorf("vpp error running %q: %s", cmd, msg)
+	}
+	log.FromContext(ctx).
+		WithField("cmd", cmd).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "CliInband").Debug("completed")
+	return nil
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tunnelip
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/uplink"
+)
+
+// CandidatesParam - mechanism parameter with the comma separated underlay IPs the requesting side is reachable on, in
+// the order of preference
+const CandidatesParam = "src_ip_candidates"
+
+// ChosenParam - mechanism parameter with the candidate the remote side has chosen to reach the requesting side on
+const ChosenParam = "src_ip_chosen"
+
+// SetCandidates sets the distinct non-nil ips as the CandidatesParam of the mechanism, the first one is the most
+// preferred. A single candidate is not advertised.
+func SetCandidates(mechanism *networkservice.Mechanism, ips ...net.IP) {
+	var candidates []string
+	for i, ip := range ips {
+		if ip != nil && !contains(ips[:i], ip) {
+			candidates = append(candidates, ip.String())
+		}
+	}
+	if len(candidates) < 2 {
+		delete(mechanism.GetParameters(), CandidatesParam)
+		return
+	}
+	if mechanism.Parameters == nil {
+		mechanism.Parameters = make(map[string]string)
+	}
+	mechanism.Parameters[CandidatesParam] = strings.Join(candidates, ",")
+}
+
+func contains(ips []net.IP, ip net.IP) bool {
+	for _, i := range ips {
+		if i.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// Candidates returns the IPs of the CandidatesParam of the mechanism, the invalid ones are skipped
+func Candidates(mechanism *networkservice.Mechanism) []net.IP {
+	var rv []net.IP
+	for _, candidate := range strings.Split(mechanism.GetParameters()[CandidatesParam], ",") {
+		if ip := net.ParseIP(strings.TrimSpace(candidate)); ip != nil {
+			rv = append(rv, ip)
+		}
+	}
+	return rv
+}
+
+// SetChosen sets the ip as the ChosenParam of the mechanism, the nil ip removes it
+func SetChosen(mechanism *networkservice.Mechanism, ip net.IP) {
+	if ip == nil {
+		delete(mechanism.GetParameters(), ChosenParam)
+		return
+	}
+	if mechanism.Parameters == nil {
+		mechanism.Parameters = make(map[string]string)
+	}
+	mechanism.Parameters[ChosenParam] = ip.String()
+}
+
+// Chosen returns the IP of the ChosenParam of the mechanism or nil if there is none
+func Chosen(mechanism *networkservice.Mechanism) net.IP {
+	return net.ParseIP(mechanism.GetParameters()[ChosenParam])
+}
+
+// Choose returns the first of the remote candidates vpp has a specific route to via a link up interface and the local
+// tunnel IP of its family, so the dual stack underlay with the partial reachability falls back to the other family.
+// The default route is not taken as the proof of reachability. The ok result is false if no candidate is reachable.
+func Choose(ctx context.Context, vppConn api.Connection, candidates []net.IP, tunnelIPs ...net.IP) (remote, local net.IP, ok bool) {
+	for _, candidate := range candidates {
+		local = nil
+		for _, tunnelIP := range tunnelIPs {
+			if tunnelIP != nil && IsIPv6(tunnelIP) == IsIPv6(candidate) {
+				local = tunnelIP
+				break
+			}
+		}
+		if local == nil {
+			log.FromContext(ctx).WithField("candidate", candidate).Debug("no tunnel IP of the candidate family")
+			continue
+		}
+		if err := probe(ctx, vppConn, candidate); err != nil {
+			log.FromContext(ctx).WithField("candidate", candidate).Debugf("candidate is not reachable: %v", err)
+			continue
+		}
+		return candidate, local, true
+	}
+	return nil, nil, false
+}
+
+func probe(ctx context.Context, vppConn api.Connection, candidate net.IP) error {
+	swIfIndexes, err := uplink.SpecificRouteInterfaces(ctx, vppConn, candidate)
+	if err != nil {
+		return err
+	}
+	for _, swIfIndex := range swIfIndexes {
+		u, err := uplink.BySwIfIndex(ctx, vppConn, swIfIndex)
+		if err != nil {
+			return err
+		}
+		if u.LinkUp {
+			return nil
+		}
+	}
+	return errors.Errorf("no specific route via a link up interface to %s", candidate)
+}
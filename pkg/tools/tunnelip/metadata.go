@@ -0,0 +1,59 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tunnelip
+
+import (
+	"context"
+	"net"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+type key struct{}
+
+// Store sets the tunnel IP to be used for the connection instead of the default one, stored in per Connection.Id metadata.
+func Store(ctx context.Context, isClient bool, tunnelIP net.IP) {
+	metadata.Map(ctx, isClient).Store(key{}, tunnelIP)
+}
+
+// Delete deletes the tunnel IP stored in per Connection.Id metadata
+func Delete(ctx context.Context, isClient bool) {
+	metadata.Map(ctx, isClient).Delete(key{})
+}
+
+// Load returns the tunnel IP stored in per Connection.Id metadata, or nil if no
+// value is present.
+// The ok result indicates whether value was found in the per Connection.Id metadata.
+func Load(ctx context.Context, isClient bool) (value net.IP, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).Load(key{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(net.IP)
+	return value, ok
+}
+
+// LoadAndDelete deletes the tunnel IP stored in per Connection.Id metadata,
+// returning the previous value if any. The loaded result reports whether the key was present.
+func LoadAndDelete(ctx context.Context, isClient bool) (value net.IP, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).LoadAndDelete(key{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(net.IP)
+	return value, ok
+}
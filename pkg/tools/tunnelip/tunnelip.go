@@ -0,0 +1,58 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tunnelip provides helpers for selecting the local underlay address used as a tunnel source
+package tunnelip
+
+import (
+	"net"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+)
+
+// IsIPv6 - returns true if ip is a non-nil IPv6 address
+func IsIPv6(ip net.IP) bool {
+	return ip != nil && ip.To4() == nil
+}
+
+// Select - returns the first of tunnelIPs having the same IP family as remoteIP.
+// If remoteIP is nil or none of tunnelIPs matches its family, the first non-nil tunnelIP is returned.
+func Select(remoteIP net.IP, tunnelIPs ...net.IP) net.IP {
+	var rv net.IP
+	for _, tunnelIP := range tunnelIPs {
+		if tunnelIP == nil {
+			continue
+		}
+		if rv == nil {
+			rv = tunnelIP
+		}
+		if remoteIP != nil && IsIPv6(remoteIP) == IsIPv6(tunnelIP) {
+			return tunnelIP
+		}
+	}
+	return rv
+}
+
+// RemoteIP - returns the IP of the remote end of the tunnel advertised on the mechanism: the destination IP on the
+// client side, the source IP on the server side. Returns nil if the remote side has not advertised it yet.
+func RemoteIP(mechanism *networkservice.Mechanism, isClient bool) net.IP {
+	ipKey := common.SrcIP
+	if isClient {
+		ipKey = common.DstIP
+	}
+	return net.ParseIP(mechanism.GetParameters()[ipKey])
+}
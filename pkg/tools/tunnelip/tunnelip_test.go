@@ -0,0 +1,202 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tunnelip_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/edwarnicke/govpp/binapi/fib_types"
+	interfaces "github.com/edwarnicke/govpp/binapi/interface"
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+	"github.com/edwarnicke/govpp/binapi/ip"
+	"github.com/edwarnicke/govpp/binapi/memclnt"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/tunnelip"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/types"
+)
+
+type route struct {
+	prefix    *net.IPNet
+	swIfIndex interface_types.InterfaceIndex
+	drop      bool
+}
+
+// routeVPP - vpp looking up the routes in the table and dumping the interfaces with their link state
+type routeVPP struct {
+	routes []route
+	linkUp map[interface_types.InterfaceIndex]bool
+}
+
+func prefix(s string) *net.IPNet {
+	_, ipNet, _ := net.ParseCIDR(s)
+	return ipNet
+}
+
+func newRouteVPP() *routeVPP {
+	return &routeVPP{
+		routes: []route{
+			{prefix: prefix("0.0.0.0/0"), swIfIndex: 1},
+			{prefix: prefix("10.0.0.0/24"), swIfIndex: 2},
+			{prefix: prefix("10.0.1.0/24"), swIfIndex: 3},
+			{prefix: prefix("10.0.2.0/24"), drop: true},
+			{prefix: prefix("fd00::/64"), swIfIndex: 2},
+		},
+		linkUp: map[interface_types.InterfaceIndex]bool{1: true, 2: true, 3: false},
+	}
+}
+
+func (v *routeVPP) Invoke(_ context.Context, req, reply api.Message) error {
+	lookup, ok := req.(*ip.IPRouteLookup)
+	if !ok {
+		return errors.Errorf("unexpected %s", req.GetMessageName())
+	}
+	addr := types.FromVppPrefix(lookup.Prefix).IP
+	// The longest prefix match
+	var best *route
+	bestLen := -1
+	for i := range v.routes {
+		if ones, _ := v.routes[i].prefix.Mask.Size(); v.routes[i].prefix.Contains(addr) && ones > bestLen {
+			best, bestLen = &v.routes[i], ones
+		}
+	}
+	if best == nil {
+		return errors.New("no route")
+	}
+	path := fib_types.FibPath{SwIfIndex: uint32(best.swIfIndex)}
+	if best.drop {
+		path = fib_types.FibPath{SwIfIndex: ^uint32(0), Type: fib_types.FIB_API_PATH_TYPE_DROP}
+	}
+	reply.(*ip.IPRouteLookupReply).Route = ip.IPRoute{
+		Prefix: types.ToVppPrefix(best.prefix),
+		NPaths: 1,
+		Paths:  []fib_types.FibPath{path},
+	}
+	return nil
+}
+
+func (v *routeVPP) NewStream(ctx context.Context, _ ...api.StreamOption) (api.Stream, error) {
+	return &routeStream{ctx: ctx, vpp: v}, nil
+}
+
+type routeStream struct {
+	ctx     context.Context
+	vpp     *routeVPP
+	replies []api.Message
+}
+
+func (s *routeStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *routeStream) SendMsg(msg api.Message) error {
+	switch m := msg.(type) {
+	case *interfaces.SwInterfaceDump:
+		for swIfIndex, linkUp := range s.vpp.linkUp {
+			if m.SwIfIndex != ^interface_types.InterfaceIndex(0) && m.SwIfIndex != swIfIndex {
+				continue
+			}
+			details := &interfaces.SwInterfaceDetails{SwIfIndex: swIfIndex, SupSwIfIndex: uint32(swIfIndex)}
+			if linkUp {
+				details.Flags = interface_types.IF_STATUS_API_FLAG_ADMIN_UP | interface_types.IF_STATUS_API_FLAG_LINK_UP
+			}
+			s.replies = append(s.replies, details)
+		}
+	case *ip.IPAddressDump:
+	case *memclnt.ControlPing:
+		s.replies = append(s.replies, &memclnt.ControlPingReply{})
+	default:
+		return errors.Errorf("unexpected %s", msg.GetMessageName())
+	}
+	return nil
+}
+
+func (s *routeStream) RecvMsg() (api.Message, error) {
+	if len(s.replies) == 0 {
+		return nil, io.EOF
+	}
+	msg := s.replies[0]
+	s.replies = s.replies[1:]
+	return msg, nil
+}
+
+func (s *routeStream) Close() error {
+	return nil
+}
+
+var (
+	tunnelIPv4 = net.ParseIP("10.0.0.100")
+	tunnelIPv6 = net.ParseIP("fd00::100")
+)
+
+func Test_Select(t *testing.T) {
+	require.Equal(t, tunnelIPv6, tunnelip.Select(net.ParseIP("fd00::1"), tunnelIPv4, tunnelIPv6))
+	require.Equal(t, tunnelIPv4, tunnelip.Select(net.ParseIP("10.0.0.1"), nil, tunnelIPv4, tunnelIPv6))
+	// The first tunnel IP is used if none matches the family of the remote side
+	require.Equal(t, tunnelIPv4, tunnelip.Select(net.ParseIP("fd00::1"), tunnelIPv4))
+	require.Equal(t, tunnelIPv4, tunnelip.Select(nil, nil, tunnelIPv4, tunnelIPv6))
+	require.Nil(t, tunnelip.Select(net.ParseIP("10.0.0.1")))
+}
+
+func Test_Candidates(t *testing.T) {
+	mechanism := &networkservice.Mechanism{}
+	tunnelip.SetCandidates(mechanism, tunnelIPv6, tunnelIPv4, nil, tunnelIPv6)
+	require.Equal(t, "fd00::100,10.0.0.100", mechanism.GetParameters()[tunnelip.CandidatesParam])
+	require.Equal(t, []net.IP{tunnelIPv6, tunnelIPv4}, tunnelip.Candidates(mechanism))
+
+	// A single candidate is not advertised
+	tunnelip.SetCandidates(mechanism, tunnelIPv4, nil)
+	require.NotContains(t, mechanism.GetParameters(), tunnelip.CandidatesParam)
+	require.Empty(t, tunnelip.Candidates(mechanism))
+
+	tunnelip.SetChosen(mechanism, tunnelIPv4)
+	require.True(t, tunnelIPv4.Equal(tunnelip.Chosen(mechanism)))
+	tunnelip.SetChosen(mechanism, nil)
+	require.Nil(t, tunnelip.Chosen(mechanism))
+}
+
+func Test_Choose(t *testing.T) {
+	ctx := context.Background()
+	vpp := newRouteVPP()
+
+	// The candidate reachable only via the default route is skipped
+	remote, local, ok := tunnelip.Choose(ctx, vpp, []net.IP{net.ParseIP("192.168.0.1"), net.ParseIP("fd00::1")}, tunnelIPv4, tunnelIPv6)
+	require.True(t, ok)
+	require.Equal(t, "fd00::1", remote.String())
+	require.Equal(t, tunnelIPv6, local)
+
+	// The candidate routed via the link down interface is skipped
+	remote, local, ok = tunnelip.Choose(ctx, vpp, []net.IP{net.ParseIP("10.0.1.1"), net.ParseIP("10.0.0.1")}, tunnelIPv4, tunnelIPv6)
+	require.True(t, ok)
+	require.Equal(t, "10.0.0.1", remote.String())
+	require.Equal(t, tunnelIPv4, local)
+
+	// The dropped candidate is not reachable
+	_, _, ok = tunnelip.Choose(ctx, vpp, []net.IP{net.ParseIP("10.0.2.1")}, tunnelIPv4, tunnelIPv6)
+	require.False(t, ok)
+
+	// The candidate with no tunnel IP of its family is skipped
+	_, _, ok = tunnelip.Choose(ctx, vpp, []net.IP{net.ParseIP("fd00::1")}, tunnelIPv4)
+	require.False(t, ok)
+}
@@ -0,0 +1,22 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package uplink provides discovery of the forwarder uplink vpp interface.
+//
+// The uplink can be found by its name, its PCI address, one of its IP addresses or by looking up the
+// default route in the vpp FIB. The resulting Uplink exposes the swIfIndex, MTU and addresses of the interface,
+// so mechanisms don't have to resolve it on their own.
+package uplink
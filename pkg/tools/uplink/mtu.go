@@ -0,0 +1,55 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uplink
+
+import (
+	"context"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	interfaces "github.com/edwarnicke/govpp/binapi/interface"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// RaiseMTU raises the L3 MTU of the uplink to mtu, or to the LinkMTU if the link can't carry mtu. The MTU already
+// larger than mtu is kept. The uplink MTU is updated on success.
+func RaiseMTU(ctx context.Context, vppConn api.Connection, u *Uplink, mtu uint32) error {
+	if u.LinkMTU != 0 && mtu > u.LinkMTU {
+		mtu = u.LinkMTU
+	}
+	if u.MTU >= mtu {
+		return nil
+	}
+	now := time.Now()
+	if _, err := interfaces.NewServiceClient(vppConn).SwInterfaceSetMtu(ctx, &interfaces.SwInterfaceSetMtu{
+		SwIfIndex: u.SwIfIndex,
+		Mtu:       []uint32{mtu, mtu, mtu, mtu},
+	}); err != nil {
+		return errors.Wrapf(err, "failed to raise the MTU of uplink %q from %d to %d", u.Name, u.MTU, mtu)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", u.SwIfIndex).
+		WithField("name", u.Name).
+		WithField("prevMTU", u.MTU).
+		WithField("MTU", mtu).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "SwInterfaceSetMtu").Debug("completed")
+	u.MTU = mtu
+	return nil
+}
@@ -0,0 +1,323 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uplink
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/edwarnicke/govpp/binapi/fib_types"
+	interfaces "github.com/edwarnicke/govpp/binapi/interface"
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+	"github.com/edwarnicke/govpp/binapi/ip"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/types"
+)
+
+// Uplink - vpp interface used by the forwarder to reach other forwarders
+type Uplink struct {
+	SwIfIndex interface_types.InterfaceIndex
+	Name      string
+	// MTU - L3 MTU of the interface
+	MTU uint32
+	// LinkMTU - max MTU the link of the interface is able to carry, the L3 MTU can be raised up to it
+	LinkMTU uint32
+	// LinkUp - the link of the interface is up
+	LinkUp    bool
+	Addresses []*net.IPNet
+}
+
+// IP - returns the first address of the uplink of the requested family or nil if there is no such address
+func (u *Uplink) IP(isIPv6 bool) net.IP {
+	for _, addr := range u.Addresses {
+		if (addr.IP.To4() == nil) == isIPv6 {
+			return addr.IP
+		}
+	}
+	return nil
+}
+
+// ByName - returns the uplink with the interface name
+func ByName(ctx context.Context, vppConn api.Connection, name string) (*Uplink, error) {
+	return find(ctx, vppConn, fmt.Sprintf("name %q", name), func(details *interfaces.SwInterfaceDetails) (bool, error) {
+		return details.InterfaceName == name, nil
+	})
+}
+
+// ByPCIAddress - returns the uplink with the PCI address (e.g. "0000:00:08.0").
+// It relies on the dpdk naming of interfaces where the name ends with "<bus>/<device>/<function>" in hex.
+func ByPCIAddress(ctx context.Context, vppConn api.Connection, pciAddress string) (*Uplink, error) {
+	suffix, err := pciToNameSuffix(pciAddress)
+	if err != nil {
+		return nil, err
+	}
+	return find(ctx, vppConn, fmt.Sprintf("PCI address %q", pciAddress), func(details *interfaces.SwInterfaceDetails) (bool, error) {
+		if details.SupSwIfIndex != uint32(details.SwIfIndex) || !strings.HasSuffix(details.InterfaceName, suffix) {
+			return false, nil
+		}
+		// Make sure "0/8/0" doesn't match "GigabitEthernet10/8/0"
+		prefix := strings.TrimSuffix(details.InterfaceName, suffix)
+		return prefix != "" && !strings.ContainsAny(prefix[len(prefix)-1:], "0123456789abcdef/"), nil
+	})
+}
+
+// ByIP - returns the uplink having the IP address
+func ByIP(ctx context.Context, vppConn api.Connection, ipAddr net.IP) (*Uplink, error) {
+	return find(ctx, vppConn, fmt.Sprintf("IP %q", ipAddr), func(details *interfaces.SwInterfaceDetails) (bool, error) {
+		addrs, err := addresses(ctx, vppConn, details.SwIfIndex, ipAddr.To4() == nil)
+		if err != nil {
+			return false, err
+		}
+		for _, addr := range addrs {
+			if addr.IP.Equal(ipAddr) {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+}
+
+// ByDefaultRoute - returns the uplink the default route of the default vrf points to
+func ByDefaultRoute(ctx context.Context, vppConn api.Connection, isIPv6 bool) (*Uplink, error) {
+	prefix := &net.IPNet{IP: net.IPv4zero, Mask: net.CIDRMask(0, net.IPv4len*8)}
+	if isIPv6 {
+		prefix = &net.IPNet{IP: net.IPv6zero, Mask: net.CIDRMask(0, net.IPv6len*8)}
+	}
+	route, err := lookup(ctx, vppConn, prefix, true)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to lookup default route %s", prefix)
+	}
+	for i := range route.Paths {
+		swIfIndex := interface_types.InterfaceIndex(route.Paths[i].SwIfIndex)
+		if swIfIndex == ^interface_types.InterfaceIndex(0) {
+			continue
+		}
+		return BySwIfIndex(ctx, vppConn, swIfIndex)
+	}
+	return nil, errors.Errorf("default route %s has no path via interface", prefix)
+}
+
+// maxRecursion - max number of the next hop lookups resolving the recursive routes
+const maxRecursion = 4
+
+// RouteInterfaces returns the interfaces vpp sends the packets destined to ipAddr via, looking up the route in the
+// default vrf. All the paths of the ECMP routes are returned, the recursive routes are resolved via their next hops,
+// the drop and unreachable paths are skipped.
+func RouteInterfaces(ctx context.Context, vppConn api.Connection, ipAddr net.IP) ([]interface_types.InterfaceIndex, error) {
+	var rv []interface_types.InterfaceIndex
+	if err := routeInterfaces(ctx, vppConn, ipAddr, 0, false, &rv); err != nil {
+		return nil, err
+	}
+	return rv, nil
+}
+
+// SpecificRouteInterfaces is RouteInterfaces ignoring the default route: no interfaces are returned if ipAddr is only
+// reachable via the default route of the default vrf.
+func SpecificRouteInterfaces(ctx context.Context, vppConn api.Connection, ipAddr net.IP) ([]interface_types.InterfaceIndex, error) {
+	var rv []interface_types.InterfaceIndex
+	if err := routeInterfaces(ctx, vppConn, ipAddr, 0, true, &rv); err != nil {
+		return nil, err
+	}
+	return rv, nil
+}
+
+func routeInterfaces(ctx context.Context, vppConn api.Connection, ipAddr net.IP, depth int, skipDefault bool, rv *[]interface_types.InterfaceIndex) error {
+	if depth > maxRecursion {
+		return errors.Errorf("failed to resolve the route to %s: too many recursive routes", ipAddr)
+	}
+	isIPv6 := ipAddr.To4() == nil
+	prefix := &net.IPNet{IP: ipAddr.To4(), Mask: net.CIDRMask(net.IPv4len*8, net.IPv4len*8)}
+	if isIPv6 {
+		prefix = &net.IPNet{IP: ipAddr, Mask: net.CIDRMask(net.IPv6len*8, net.IPv6len*8)}
+	}
+	route, err := lookup(ctx, vppConn, prefix, false)
+	if err != nil {
+		return errors.Wrapf(err, "failed to lookup route to %s", ipAddr)
+	}
+	// The next hops of the specific route may still be resolved via the default one
+	if skipDefault && depth == 0 && route.Prefix.Len == 0 {
+		return nil
+	}
+	for i := range route.Paths {
+		path := &route.Paths[i]
+		switch path.Type {
+		case fib_types.FIB_API_PATH_TYPE_DROP, fib_types.FIB_API_PATH_TYPE_ICMP_UNREACH, fib_types.FIB_API_PATH_TYPE_ICMP_PROHIBIT:
+			continue
+		}
+		swIfIndex := interface_types.InterfaceIndex(path.SwIfIndex)
+		if swIfIndex != ^interface_types.InterfaceIndex(0) {
+			if !containsIndex(*rv, swIfIndex) {
+				*rv = append(*rv, swIfIndex)
+			}
+			continue
+		}
+		nh := types.FromVppIPAddressUnion(path.Nh.Address, isIPv6)
+		if nh == nil || nh.IsUnspecified() {
+			continue
+		}
+		if err := routeInterfaces(ctx, vppConn, nh, depth+1, skipDefault, rv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func lookup(ctx context.Context, vppConn api.Connection, prefix *net.IPNet, exact bool) (*ip.IPRoute, error) {
+	now := time.Now()
+	req := &ip.IPRouteLookup{
+		TableID: 0,
+		Prefix:  types.ToVppPrefix(prefix),
+	}
+	if exact {
+		req.Exact = 1
+	}
+	reply, err := ip.NewServiceClient(vppConn).IPRouteLookup(ctx, req)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	log.FromContext(ctx).
+		WithField("prefix", prefix).
+		WithField("exact", exact).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "IPRouteLookup").Debug("completed")
+	return &reply.Route, nil
+}
+
+func containsIndex(indexes []interface_types.InterfaceIndex, swIfIndex interface_types.InterfaceIndex) bool {
+	for _, index := range indexes {
+		if index == swIfIndex {
+			return true
+		}
+	}
+	return false
+}
+
+// BySwIfIndex - returns the uplink with the swIfIndex
+func BySwIfIndex(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex) (*Uplink, error) {
+	return find(ctx, vppConn, fmt.Sprintf("swIfIndex %d", swIfIndex), func(details *interfaces.SwInterfaceDetails) (bool, error) {
+		return details.SwIfIndex == swIfIndex, nil
+	})
+}
+
+func find(ctx context.Context, vppConn api.Connection, what string, match func(details *interfaces.SwInterfaceDetails) (bool, error)) (*Uplink, error) {
+	now := time.Now()
+	client, err := interfaces.NewServiceClient(vppConn).SwInterfaceDump(ctx, &interfaces.SwInterfaceDump{
+		SwIfIndex: ^interface_types.InterfaceIndex(0),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "error attempting to get interface dump client to find uplink by %s", what)
+	}
+	defer func() { _ = client.Close() }()
+
+	for {
+		details, err := client.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "error attempting to get interface details to find uplink by %s", what)
+		}
+		ok, err := match(details)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		log.FromContext(ctx).
+			WithField("swIfIndex", details.SwIfIndex).
+			WithField("name", details.InterfaceName).
+			WithField("duration", time.Since(now)).
+			WithField("vppapi", "SwInterfaceDump").Debugf("found uplink by %s", what)
+
+		rv := &Uplink{
+			SwIfIndex: details.SwIfIndex,
+			Name:      details.InterfaceName,
+		}
+		if len(details.Mtu) > 0 {
+			rv.MTU = details.Mtu[0]
+		}
+		rv.LinkMTU = uint32(details.LinkMtu)
+		rv.LinkUp = details.Flags&interface_types.IF_STATUS_API_FLAG_LINK_UP != 0
+		for _, isIPv6 := range []bool{false, true} {
+			addrs, err := addresses(ctx, vppConn, details.SwIfIndex, isIPv6)
+			if err != nil {
+				return nil, err
+			}
+			rv.Addresses = append(rv.Addresses, addrs...)
+		}
+		return rv, nil
+	}
+	return nil, errors.Errorf("unable to find uplink in vpp by %s", what)
+}
+
+func addresses(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, isIPv6 bool) ([]*net.IPNet, error) {
+	client, err := ip.NewServiceClient(vppConn).IPAddressDump(ctx, &ip.IPAddressDump{
+		SwIfIndex: swIfIndex,
+		IsIPv6:    isIPv6,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "error attempting to get ip address dump client for swIfIndex %d", swIfIndex)
+	}
+	defer func() { _ = client.Close() }()
+
+	var rv []*net.IPNet
+	for {
+		details, err := client.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "error attempting to get ip address for swIfIndex %d", swIfIndex)
+		}
+		rv = append(rv, types.FromVppAddressWithPrefix(details.Prefix))
+	}
+	return rv, nil
+}
+
+// pciToNameSuffix - converts "[domain:]bus:device.function" to the "bus/device/function" suffix of dpdk interface names
+func pciToNameSuffix(pciAddress string) (string, error) {
+	parts := strings.Split(pciAddress, ":")
+	if len(parts) == 3 {
+		parts = parts[1:]
+	}
+	if len(parts) != 2 {
+		return "", errors.Errorf("invalid PCI address %q", pciAddress)
+	}
+	devFunc := strings.Split(parts[1], ".")
+	if len(devFunc) != 2 {
+		return "", errors.Errorf("invalid PCI address %q", pciAddress)
+	}
+	var values []string
+	for _, s := range []string{parts[0], devFunc[0], devFunc[1]} {
+		v, err := strconv.ParseUint(s, 16, 8)
+		if err != nil {
+			return "", errors.Wrapf(err, "invalid PCI address %q", pciAddress)
+		}
+		values = append(values, strconv.FormatUint(v, 16))
+	}
+	return strings.Join(values, "/"), nil
+}
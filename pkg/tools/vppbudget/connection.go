@@ -0,0 +1,139 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vppbudget
+
+import (
+	"context"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+)
+
+// Connection aggregates the api.Connection and api.ChannelProvider interfaces
+type Connection interface {
+	api.Connection
+	api.ChannelProvider
+}
+
+type budgetConnection struct {
+	Connection
+	reserve        time.Duration
+	maxCallTimeout time.Duration
+}
+
+// NewConnection - returns the vppConn bounding the binapi calls by the deadline of their context
+func NewConnection(vppConn Connection, opts ...Option) Connection {
+	o := &options{
+		reserve: defaultReserve,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return &budgetConnection{
+		Connection:     vppConn,
+		reserve:        o.reserve,
+		maxCallTimeout: o.maxCallTimeout,
+	}
+}
+
+func (c *budgetConnection) Invoke(ctx context.Context, req, reply api.Message) error {
+	callCtx, cancel, budget, ok := c.callContext(ctx)
+	if !ok {
+		return &TimeoutError{Message: req.GetMessageName()}
+	}
+	defer cancel()
+
+	err := c.Connection.Invoke(callCtx, req, reply)
+	if err != nil && ctx.Err() == nil && callCtx.Err() == context.DeadlineExceeded {
+		return &TimeoutError{Message: req.GetMessageName(), Budget: budget}
+	}
+	return err
+}
+
+func (c *budgetConnection) NewStream(ctx context.Context, options ...api.StreamOption) (api.Stream, error) {
+	callCtx, cancel, budget, ok := c.callContext(ctx)
+	if !ok {
+		return nil, &TimeoutError{Message: "stream"}
+	}
+	stream, err := c.Connection.NewStream(callCtx, options...)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return &budgetStream{
+		Stream:  stream,
+		ctx:     ctx,
+		callCtx: callCtx,
+		cancel:  cancel,
+		budget:  budget,
+	}, nil
+}
+
+// callContext returns the context of the call with the deadline of the call budget. The ok result is false if there
+// is no time left for the call.
+func (c *budgetConnection) callContext(ctx context.Context) (callCtx context.Context, cancel context.CancelFunc, budget time.Duration, ok bool) {
+	deadline, hasDeadline := ctx.Deadline()
+	if hasDeadline {
+		deadline = deadline.Add(-c.reserve)
+	}
+	if c.maxCallTimeout > 0 && (!hasDeadline || time.Until(deadline) > c.maxCallTimeout) {
+		deadline, hasDeadline = time.Now().Add(c.maxCallTimeout), true
+	}
+	if !hasDeadline {
+		return ctx, func() {}, 0, true
+	}
+	if budget = time.Until(deadline); budget <= 0 {
+		return nil, nil, 0, false
+	}
+	callCtx, cancel = context.WithDeadline(ctx, deadline)
+	return callCtx, cancel, budget, true
+}
+
+// budgetStream - the stream of the dump call, the message sent first names the call
+type budgetStream struct {
+	api.Stream
+	ctx     context.Context
+	callCtx context.Context
+	cancel  context.CancelFunc
+	budget  time.Duration
+	message string
+}
+
+func (s *budgetStream) SendMsg(msg api.Message) error {
+	if s.message == "" {
+		s.message = msg.GetMessageName()
+	}
+	return s.wrap(s.Stream.SendMsg(msg))
+}
+
+func (s *budgetStream) RecvMsg() (api.Message, error) {
+	msg, err := s.Stream.RecvMsg()
+	return msg, s.wrap(err)
+}
+
+func (s *budgetStream) Close() error {
+	defer s.cancel()
+	return s.Stream.Close()
+}
+
+func (s *budgetStream) wrap(err error) error {
+	if err != nil && s.ctx.Err() == nil && s.callCtx.Err() == context.DeadlineExceeded {
+		return &TimeoutError{Message: s.message, Budget: s.budget}
+	}
+	return err
+}
@@ -0,0 +1,25 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package vppbudget provides the vpp connection bounding the binapi calls by the deadline of the Request context.
+//
+// The calls get the time left till the deadline less the reserve kept for rolling the Request back and returning the
+// response, so the vpp calls of the chain elements don't collectively exceed the NSM request timeout. The call
+// running out of the time fails with the TimeoutError naming the binapi message:
+//
+//	var timeoutErr *vppbudget.TimeoutError
+//	if errors.As(err, &timeoutErr) { ... timeoutErr.Message ... }
+package vppbudget
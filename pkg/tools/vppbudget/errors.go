@@ -0,0 +1,43 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vppbudget
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// TimeoutError - the binapi call has run out of the time left for it
+type TimeoutError struct {
+	// Message - name of the binapi message of the call
+	Message string
+	// Budget - the time the call has had, not positive if the call has not been done at all
+	Budget time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	if e.Budget <= 0 {
+		return fmt.Sprintf("no time left for the vpp call %s before the request deadline", e.Message)
+	}
+	return fmt.Sprintf("vpp call %s has not completed within %s left before the request deadline", e.Message, e.Budget.Round(time.Millisecond))
+}
+
+// Unwrap returns context.DeadlineExceeded, so the error is classified as the unavailable vpp
+func (e *TimeoutError) Unwrap() error {
+	return context.DeadlineExceeded
+}
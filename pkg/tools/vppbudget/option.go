@@ -0,0 +1,47 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vppbudget
+
+import "time"
+
+const (
+	defaultReserve = 500 * time.Millisecond
+)
+
+type options struct {
+	reserve        time.Duration
+	maxCallTimeout time.Duration
+}
+
+// Option is an option pattern for NewConnection
+type Option func(o *options)
+
+// WithReserve sets the time kept from the Request deadline for rolling the Request back and returning the response,
+// 500ms by default
+func WithReserve(reserve time.Duration) Option {
+	return func(o *options) {
+		o.reserve = reserve
+	}
+}
+
+// WithMaxCallTimeout sets the max time of a single call, also applied to the calls without the deadline, 0 - not
+// limited (default)
+func WithMaxCallTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.maxCallTimeout = timeout
+	}
+}
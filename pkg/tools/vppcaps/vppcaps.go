@@ -0,0 +1,128 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vppcaps
+
+import (
+	"bufio"
+	"context"
+	"strings"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/edwarnicke/govpp/binapi/vlib"
+	"github.com/edwarnicke/govpp/binapi/vpe"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const (
+	showPluginsCmd = "show plugins"
+	pluginSuffix   = "_plugin.so"
+)
+
+// Plugin - vpp plugin name, the plugin file name without the "_plugin.so" suffix
+type Plugin string
+
+const (
+	// ACL - acl plugin
+	ACL Plugin = "acl"
+	// GTPU - gtpu plugin
+	GTPU Plugin = "gtpu"
+	// LinuxCP - linux-cp plugin
+	LinuxCP Plugin = "linux_cp"
+	// NAT - nat plugin
+	NAT Plugin = "nat"
+	// NSim - nsim plugin
+	NSim Plugin = "nsim"
+	// Wireguard - wireguard plugin
+	Wireguard Plugin = "wireguard"
+)
+
+// ErrPluginMissing - the vpp plugin required by the element is not loaded
+var ErrPluginMissing = errors.New("vpp plugin missing")
+
+// Caps - the vpp version and the loaded plugins
+type Caps struct {
+	Version string
+	plugins map[Plugin]bool
+}
+
+// Probe - returns the capabilities of the vpp
+func Probe(ctx context.Context, vppConn api.Connection) (*Caps, error) {
+	now := time.Now()
+	version, err := vpe.NewServiceClient(vppConn).ShowVersion(ctx, &vpe.ShowVersion{})
+	if err != nil {
+		return nil, errors.Wrap(err, "vpp error getting the version")
+	}
+	log.FromContext(ctx).
+		WithField("version", version.Version).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "ShowVersion").Debug("completed")
+
+	now = time.Now()
+	reply, err := vlib.NewServiceClient(vppConn).CliInband(ctx, &vlib.CliInband{
+		Cmd: showPluginsCmd,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "vpp error running %q", showPluginsCmd)
+	}
+	log.FromContext(ctx).
+		WithField("cmd", showPluginsCmd).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "CliInband").Debug("completed")
+
+	return &Caps{
+		Version: version.Version,
+		plugins: parse(reply.Reply),
+	}, nil
+}
+
+// Has - returns true if the plugin is loaded
+func (c *Caps) Has(plugin Plugin) bool {
+	return c.plugins[plugin]
+}
+
+// Require - returns ErrPluginMissing naming the vpp version if any of the plugins is not loaded
+func (c *Caps) Require(plugins ...Plugin) error {
+	for _, plugin := range plugins {
+		if !c.Has(plugin) {
+			return errors.Wrapf(ErrPluginMissing, "%s plugin is not loaded in vpp %s", plugin, c.Version)
+		}
+	}
+	return nil
+}
+
+// parse parses the output of the 'show plugins' cli command:
+//
+//	Plugin path is: /usr/lib/x86_64-linux-gnu/vpp_plugins
+//	   Plugin                                   Version                          Description
+//	1. acl_plugin.so                            22.10-release                    Access Control Lists (ACL)
+//	2. wireguard_plugin.so                      22.10-release                    Wireguard Protocol
+func parse(reply string) map[Plugin]bool {
+	rv := make(map[Plugin]bool)
+	scanner := bufio.NewScanner(strings.NewReader(reply))
+	for scanner.Scan() {
+		for _, field := range strings.Fields(scanner.Text()) {
+			if strings.HasSuffix(field, pluginSuffix) {
+				rv[Plugin(strings.TrimSuffix(field, pluginSuffix))] = true
+				break
+			}
+		}
+	}
+	return rv
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpperrors
+
+import (
+	"context"
+	"reflect"
+
+	"git.fd.io/govpp.git/api"
+)
+
+// Connection aggregates the api.Connection and api.ChannelProvider interfaces
+type Connection interface {
+	api.Connection
+	api.ChannelProvider
+}
+
+type classifyingConnection struct {
+	Connection
+}
+
+// NewConnection - returns the vppConn classifying the errors of the binapi calls
+func NewConnection(vppConn Connection) Connection {
+	return &classifyingConnection{
+		Connection: vppConn,
+	}
+}
+
+func (c *classifyingConnection) Invoke(ctx context.Context, req, reply api.Message) error {
+	if err := c.Connection.Invoke(ctx, req, reply); err != nil {
+		return Classify(err)
+	}
+	if retval := reflect.Indirect(reflect.ValueOf(reply)).FieldByName("Retval"); retval.IsValid() && retval.Kind() == reflect.Int32 {
+		return Classify(api.RetvalToVPPApiError(int32(retval.Int())))
+	}
+	return nil
+}
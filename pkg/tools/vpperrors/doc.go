@@ -0,0 +1,24 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package vpperrors provides the typed errors returned by the chain elements, so the chain consumers and the heal
+// logic can tell the retryable failures from the permanent ones with errors.Is instead of matching the govpp error
+// strings:
+//
+//	if errors.Is(err, vpperrors.ErrVPPUnavailable) { ... }
+//
+// The vpp errors are classified by the Connection wrapping the vpp connection of the chain.
+package vpperrors
@@ -0,0 +1,116 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpperrors
+
+import (
+	"context"
+	"syscall"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrResourceExhausted - vpp or the forwarder has no resources left (table size, ports, VNIs, addresses).
+	// Retryable after the other connections are closed.
+	ErrResourceExhausted = errors.New("resource exhausted")
+	// ErrAlreadyExists - the object already exists, usually created by the previous attempt or another forwarder
+	ErrAlreadyExists = errors.New("already exists")
+	// ErrNotFound - the object doesn't exist, usually deleted by the vpp restart
+	ErrNotFound = errors.New("not found")
+	// ErrInvalidArgument - vpp rejected the parameters. Permanent.
+	ErrInvalidArgument = errors.New("invalid argument")
+	// ErrVPPUnavailable - vpp doesn't respond. Retryable.
+	ErrVPPUnavailable = errors.New("vpp unavailable")
+)
+
+var vppAPIErrors = map[api.VPPApiError]error{
+	api.TABLE_TOO_BIG:        ErrResourceExhausted,
+	api.LIMIT_EXCEEDED:       ErrResourceExhausted,
+	api.VALUE_EXIST:          ErrAlreadyExists,
+	api.ENTRY_ALREADY_EXISTS: ErrAlreadyExists,
+	api.ADDRESS_IN_USE:       ErrAlreadyExists,
+	api.NO_SUCH_ENTRY:        ErrNotFound,
+	api.NO_SUCH_FIB:          ErrNotFound,
+	api.INVALID_SW_IF_INDEX:  ErrNotFound,
+	api.INVALID_VALUE:        ErrInvalidArgument,
+	api.UNIMPLEMENTED:        ErrInvalidArgument,
+}
+
+var classes = []error{
+	ErrResourceExhausted,
+	ErrAlreadyExists,
+	ErrNotFound,
+	ErrInvalidArgument,
+	ErrVPPUnavailable,
+}
+
+// classifiedError - the error with its class. errors.Is matches both the class and the wrapped error.
+type classifiedError struct {
+	class error
+	err   error
+}
+
+func (e *classifiedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *classifiedError) Unwrap() error {
+	return e.err
+}
+
+func (e *classifiedError) Is(target error) bool {
+	return e.class == target
+}
+
+// Classify - returns err wrapped with its class, err itself if it can't be classified or is already classified
+func Classify(err error) error {
+	if err == nil || Class(err) != nil {
+		return err
+	}
+	var class error
+	var vppAPIError api.VPPApiError
+	switch {
+	case errors.As(err, &vppAPIError):
+		class = vppAPIErrors[vppAPIError]
+	case errors.Is(err, context.DeadlineExceeded),
+		errors.Is(err, syscall.ECONNREFUSED),
+		errors.Is(err, syscall.ECONNRESET),
+		errors.Is(err, syscall.EPIPE),
+		errors.Is(err, syscall.ENOENT):
+		class = ErrVPPUnavailable
+	}
+	if class == nil {
+		return err
+	}
+	return &classifiedError{class: class, err: err}
+}
+
+// Class - returns the class of the err, nil if it is not classified
+func Class(err error) error {
+	for _, class := range classes {
+		if errors.Is(err, class) {
+			return class
+		}
+	}
+	return nil
+}
+
+// IsRetryable - returns true if the failure may go away without changing the request
+func IsRetryable(err error) bool {
+	return errors.Is(err, ErrVPPUnavailable) || errors.Is(err, ErrResourceExhausted)
+}
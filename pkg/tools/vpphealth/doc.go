@@ -0,0 +1,21 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package vpphealth provides the health of the vpp api connection: whether it is connected, the number of the
+// reconnects and the last error. The health is fed by the connection events of govpp, by the periodic control pings
+// or by vppinit.Dial (see vppinit.WithHealth), and is exposed via the subscriptions, the readiness check and the
+// optional metrics, so the forwarders can set the readiness probes on the dataplane availability
+package vpphealth
@@ -0,0 +1,219 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpphealth
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	"git.fd.io/govpp.git/core"
+	"github.com/edwarnicke/govpp/binapi/memclnt"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// Status - the health of the vpp api connection
+type Status struct {
+	// Connected is true while the vpp api connection is up
+	Connected bool
+	// Reconnects is the number of times the connection has been reestablished after being lost
+	Reconnects int
+	// LastError is the last error of the connection, kept after the connection is reestablished
+	LastError error
+	// Since is the time of the last change of Connected
+	Since time.Time
+}
+
+// Health - the health of the vpp api connection shared between its sources and its subscribers
+type Health struct {
+	mu            sync.Mutex
+	status        Status
+	everConnected bool
+	subscribers   map[int]func(Status)
+	nextID        int
+	metrics       *metrics
+}
+
+// New - returns a new disconnected Health
+func New(opts ...Option) *Health {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	h := &Health{
+		status:      Status{Since: time.Now()},
+		subscribers: make(map[int]func(Status)),
+	}
+	if o.metrics {
+		h.metrics = newMetrics(o.name, h)
+	}
+	return h
+}
+
+// Status returns the current health of the connection
+func (h *Health) Status() Status {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.status
+}
+
+// Ready returns nil while the connection is up, otherwise the error with the last connection error, to be used as
+// the readiness check of the forwarder
+func (h *Health) Ready() error {
+	status := h.Status()
+	if status.Connected {
+		return nil
+	}
+	if status.LastError != nil {
+		return errors.Wrapf(status.LastError, "vpp api is disconnected since %s", status.Since.Format(time.RFC3339))
+	}
+	return errors.Errorf("vpp api is disconnected since %s", status.Since.Format(time.RFC3339))
+}
+
+// Subscribe calls fn on each change of the health (starting with the current one) until the returned unsubscribe
+// is called. fn is called with the health lock held in order of the changes, so it must not block nor call Health
+func (h *Health) Subscribe(fn func(Status)) (unsubscribe func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	id := h.nextID
+	h.nextID++
+	h.subscribers[id] = fn
+	fn(h.status)
+
+	return func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		delete(h.subscribers, id)
+	}
+}
+
+// Connected records the connection is up, counting a reconnect if it has been up before
+func (h *Health) Connected() {
+	h.update(func(status *Status) bool {
+		if status.Connected {
+			return false
+		}
+		if h.everConnected {
+			status.Reconnects++
+			h.metrics.reconnected()
+		}
+		h.everConnected = true
+		status.Connected = true
+		status.Since = time.Now()
+		return true
+	})
+}
+
+// Disconnected records the connection is down with the err causing it (may be nil)
+func (h *Health) Disconnected(err error) {
+	h.update(func(status *Status) bool {
+		changed := status.Connected || err != nil
+		if status.Connected {
+			status.Connected = false
+			status.Since = time.Now()
+		}
+		if err != nil {
+			status.LastError = err
+		}
+		return changed
+	})
+}
+
+// Error records err as the last error keeping the state of the connection, e.g. a failed connection attempt
+func (h *Health) Error(err error) {
+	if err == nil {
+		return
+	}
+	h.update(func(status *Status) bool {
+		status.LastError = err
+		return true
+	})
+}
+
+func (h *Health) update(change func(status *Status) bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !change(&h.status) {
+		return
+	}
+	for _, fn := range h.subscribers {
+		fn(h.status)
+	}
+}
+
+// Watch feeds the health with the connection events returned by core.AsyncConnect until ctx is done or the events
+// channel is closed
+func (h *Health) Watch(ctx context.Context, events <-chan core.ConnectionEvent) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			switch event.State {
+			case core.Connected:
+				h.Connected()
+			case core.Failed:
+				err := event.Error
+				if err == nil {
+					err = errors.New("vpp api reconnecting failed")
+				}
+				h.Disconnected(err)
+			default:
+				h.Disconnected(event.Error)
+			}
+			log.FromContext(ctx).
+				WithField("state", event.State).
+				WithField("error", event.Error).
+				Debug("vpp api connection event")
+		}
+	}
+}
+
+// Probe feeds the health with the result of the control ping sent over vppConn each interval until ctx is done, for
+// the connections established by core.Connect that have no connection events
+func (h *Health) Probe(ctx context.Context, vppConn api.Connection, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		pingCtx, cancelPing := context.WithTimeout(ctx, interval)
+		_, err := memclnt.NewServiceClient(vppConn).ControlPing(pingCtx, &memclnt.ControlPing{})
+		cancelPing()
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			h.Disconnected(errors.Wrap(err, "vpp api control ping failed"))
+		} else {
+			h.Connected()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpphealth
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const (
+	// connectedMetric - 1 while the vpp api connection is up, 0 otherwise
+	connectedMetric = "vpp_api_connected"
+	// reconnectsMetric - the number of times the vpp api connection has been reestablished
+	reconnectsMetric = "vpp_api_reconnects_total"
+)
+
+type metrics struct {
+	attrs      []attribute.KeyValue
+	reconnects syncint64.Counter
+}
+
+func newMetrics(name string, h *Health) *metrics {
+	logger := log.FromContext(context.Background())
+	meter := global.Meter("")
+	m := &metrics{
+		attrs: []attribute.KeyValue{attribute.String("vpp", name)},
+	}
+
+	var err error
+	if m.reconnects, err = meter.SyncInt64().Counter(reconnectsMetric); err != nil {
+		logger.Warnf("failed to create %s counter: %v", reconnectsMetric, err)
+	}
+	connected, err := meter.AsyncInt64().Gauge(connectedMetric)
+	if err != nil {
+		logger.Warnf("failed to create %s gauge: %v", connectedMetric, err)
+		return m
+	}
+	err = meter.RegisterCallback([]instrument.Asynchronous{connected}, func(ctx context.Context) {
+		var value int64
+		if h.Status().Connected {
+			value = 1
+		}
+		connected.Observe(ctx, value, m.attrs...)
+	})
+	if err != nil {
+		logger.Warnf("failed to register %s callback: %v", connectedMetric, err)
+	}
+	return m
+}
+
+func (m *metrics) reconnected() {
+	if m == nil || m.reconnects == nil {
+		return
+	}
+	m.reconnects.Add(context.Background(), 1, m.attrs...)
+}
@@ -0,0 +1,34 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpphealth
+
+type options struct {
+	metrics bool
+	name    string
+}
+
+// Option is an option pattern for New
+type Option func(o *options)
+
+// WithMetrics enables the vpp_api_connected gauge and the vpp_api_reconnects_total counter, the name is set as the
+// vpp attribute of the metrics to tell apart several vpp connections
+func WithMetrics(name string) Option {
+	return func(o *options) {
+		o.metrics = true
+		o.name = name
+	}
+}
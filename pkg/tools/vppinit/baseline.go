@@ -0,0 +1,141 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vppinit
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"git.fd.io/govpp.git/api"
+	"github.com/edwarnicke/govpp/binapi/fib_types"
+	interfaces "github.com/edwarnicke/govpp/binapi/interface"
+	"github.com/edwarnicke/govpp/binapi/interface_types"
+	"github.com/edwarnicke/govpp/binapi/ip"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/bond"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/types"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/uplink"
+)
+
+func configure(ctx context.Context, vppConn api.Connection, opts *options) error {
+	if len(opts.bondMembers) > 0 {
+		b, err := bond.Ensure(ctx, vppConn, opts.bondID, opts.bondMembers, opts.bondOpts...)
+		if err != nil {
+			return err
+		}
+		if opts.uplink == "" {
+			opts.uplink = b.Name
+		}
+	}
+	if opts.uplink == "" {
+		if len(opts.defaultRoutes) > 0 {
+			return errors.New("default routes require the uplink interface")
+		}
+		return nil
+	}
+	u, err := uplink.ByName(ctx, vppConn, opts.uplink)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if _, err = interfaces.NewServiceClient(vppConn).SwInterfaceSetFlags(ctx, &interfaces.SwInterfaceSetFlags{
+		SwIfIndex: u.SwIfIndex,
+		Flags:     interface_types.IF_STATUS_API_FLAG_ADMIN_UP,
+	}); err != nil {
+		return errors.Wrapf(err, "failed to set the uplink %s up", u.Name)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", u.SwIfIndex).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "SwInterfaceSetFlags").Debug("completed")
+
+	for _, addr := range opts.uplinkAddrs {
+		if hasAddress(u, addr) {
+			continue
+		}
+		now = time.Now()
+		if _, err = interfaces.NewServiceClient(vppConn).SwInterfaceAddDelAddress(ctx, &interfaces.SwInterfaceAddDelAddress{
+			SwIfIndex: u.SwIfIndex,
+			IsAdd:     true,
+			Prefix:    types.ToVppAddressWithPrefix(addr),
+		}); err != nil {
+			return errors.Wrapf(err, "failed to add the address %s to the uplink %s", addr, u.Name)
+		}
+		log.FromContext(ctx).
+			WithField("swIfIndex", u.SwIfIndex).
+			WithField("prefix", addr).
+			WithField("duration", time.Since(now)).
+			WithField("vppapi", "SwInterfaceAddDelAddress").Debug("completed")
+	}
+
+	for _, gw := range opts.defaultRoutes {
+		if err := addDefaultRoute(ctx, vppConn, u.SwIfIndex, gw); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func hasAddress(u *uplink.Uplink, addr *net.IPNet) bool {
+	for _, a := range u.Addresses {
+		if a.IP.Equal(addr.IP) {
+			return true
+		}
+	}
+	return false
+}
+
+func addDefaultRoute(ctx context.Context, vppConn api.Connection, swIfIndex interface_types.InterfaceIndex, gw net.IP) error {
+	isIPv6 := gw.To4() == nil
+	prefix := &net.IPNet{IP: net.IPv4zero, Mask: net.CIDRMask(0, net.IPv4len*8)}
+	if isIPv6 {
+		prefix = &net.IPNet{IP: net.IPv6zero, Mask: net.CIDRMask(0, net.IPv6len*8)}
+	}
+	route := ip.IPRoute{
+		Prefix: types.ToVppPrefix(prefix),
+		NPaths: 1,
+		Paths: []fib_types.FibPath{
+			{
+				SwIfIndex: uint32(swIfIndex),
+				Weight:    1,
+				Type:      fib_types.FIB_API_PATH_TYPE_NORMAL,
+				Flags:     fib_types.FIB_API_PATH_FLAG_NONE,
+				Proto:     types.IsV6toFibProto(isIPv6),
+				Nh:        fib_types.FibPathNh{Address: types.ToVppAddress(gw).Un},
+			},
+		},
+	}
+	now := time.Now()
+	if _, err := ip.NewServiceClient(vppConn).IPRouteAddDel(ctx, &ip.IPRouteAddDel{
+		IsAdd: true,
+		Route: route,
+	}); err != nil {
+		return errors.Wrapf(err, "failed to add the default route via %s", gw)
+	}
+	log.FromContext(ctx).
+		WithField("swIfIndex", swIfIndex).
+		WithField("prefix", prefix).
+		WithField("gateway", gw).
+		WithField("duration", time.Since(now)).
+		WithField("vppapi", "IPRouteAddDel").Debug("completed")
+	return nil
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vppinit
+
+import (
+	"context"
+	"time"
+
+	"git.fd.io/govpp.git/adapter/socketclient"
+	"git.fd.io/govpp.git/core"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// Dial connects to the running vpp, retrying until vpp is ready, and programs the baseline configuration set by the
+// options. The connection is disconnected when ctx is done.
+func Dial(ctx context.Context, opts ...Option) (*core.Connection, error) {
+	o := &options{
+		socket:           socketclient.DefaultSocketName,
+		retryInterval:    defaultRetryInterval,
+		maxRetryInterval: defaultMaxRetryInterval,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	vppConn, err := connect(ctx, o)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		<-ctx.Done()
+		vppConn.Disconnect()
+		if o.health != nil {
+			o.health.Disconnected(nil)
+		}
+	}()
+	if o.health != nil && o.probeInterval > 0 {
+		go o.health.Probe(ctx, vppConn, o.probeInterval)
+	}
+
+	if err := configure(ctx, vppConn, o); err != nil {
+		vppConn.Disconnect()
+		if o.health != nil {
+			o.health.Disconnected(err)
+		}
+		return nil, err
+	}
+	return vppConn, nil
+}
+
+func connect(ctx context.Context, opts *options) (*core.Connection, error) {
+	logger := log.FromContext(ctx).WithField("socket", opts.socket)
+	interval := opts.retryInterval
+	for attempt := 1; ; attempt++ {
+		vppAPI := opts.adapter
+		if vppAPI == nil {
+			vppAPI = socketclient.NewVppClient(opts.socket)
+		}
+		now := time.Now()
+		vppConn, err := core.Connect(vppAPI)
+		if err == nil {
+			logger.WithField("attempt", attempt).
+				WithField("duration", time.Since(now)).
+				Info("connected to vpp")
+			if opts.health != nil {
+				opts.health.Connected()
+			}
+			return vppConn, nil
+		}
+		if opts.health != nil {
+			opts.health.Error(err)
+		}
+		if opts.maxAttempts > 0 && attempt >= opts.maxAttempts {
+			return nil, errors.Wrapf(err, "failed to connect to vpp %s after %d attempts", opts.socket, attempt)
+		}
+		logger.WithField("attempt", attempt).Debugf("failed to connect to vpp, retrying in %s: %v", interval, err)
+
+		select {
+		case <-ctx.Done():
+			return nil, errors.Wrapf(ctx.Err(), "failed to connect to vpp %s: %s", opts.socket, err.Error())
+		case <-time.After(interval):
+		}
+		if interval *= 2; interval > opts.maxRetryInterval {
+			interval = opts.maxRetryInterval
+		}
+	}
+}
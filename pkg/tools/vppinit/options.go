@@ -0,0 +1,120 @@
+// Copyright (c) 2023 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vppinit
+
+import (
+	"net"
+	"time"
+
+	"git.fd.io/govpp.git/adapter"
+
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/bond"
+	"github.com/networkservicemesh/sdk-vpp/pkg/tools/vpphealth"
+)
+
+const (
+	defaultRetryInterval    = 100 * time.Millisecond
+	defaultMaxRetryInterval = 5 * time.Second
+)
+
+type options struct {
+	socket           string
+	adapter          adapter.VppAPI
+	maxAttempts      int
+	retryInterval    time.Duration
+	maxRetryInterval time.Duration
+
+	uplink        string
+	uplinkAddrs   []*net.IPNet
+	defaultRoutes []net.IP
+
+	bondID      uint32
+	bondMembers []string
+	bondOpts    []bond.Option
+
+	health        *vpphealth.Health
+	probeInterval time.Duration
+}
+
+// Option is an option pattern for Dial
+type Option func(o *options)
+
+// WithSocket sets the vpp binary api socket (default socketclient.DefaultSocketName)
+func WithSocket(socket string) Option {
+	return func(o *options) {
+		o.socket = socket
+	}
+}
+
+// WithAdapter sets the vpp api adapter used instead of the socket client, e.g. the shared memory client configured
+// with the api segment prefix and the input queue size
+func WithAdapter(vppAPI adapter.VppAPI) Option {
+	return func(o *options) {
+		o.adapter = vppAPI
+	}
+}
+
+// WithRetry sets the max number of the connection attempts, 0 means retrying until the context is done (default)
+func WithRetry(maxAttempts int) Option {
+	return func(o *options) {
+		o.maxAttempts = maxAttempts
+	}
+}
+
+// WithBackoff sets the interval between the connection attempts, doubled after each failed attempt up to the max
+// interval (default 100ms up to 5s)
+func WithBackoff(interval, maxInterval time.Duration) Option {
+	return func(o *options) {
+		o.retryInterval = interval
+		o.maxRetryInterval = maxInterval
+	}
+}
+
+// WithUplink sets the uplink interface to set admin up and to add the addresses to, once connected
+func WithUplink(name string, addrs ...*net.IPNet) Option {
+	return func(o *options) {
+		o.uplink = name
+		o.uplinkAddrs = addrs
+	}
+}
+
+// WithDefaultRoutes sets the gateways to add the default routes via the uplink interface to (one per IP family),
+// requires WithUplink
+func WithDefaultRoutes(gateways ...net.IP) Option {
+	return func(o *options) {
+		o.defaultRoutes = gateways
+	}
+}
+
+// WithBond sets the member interfaces of the bond with the id to create (LACP by default), the bond is the uplink
+// interface unless WithUplink sets another one
+func WithBond(id uint32, members []string, opts ...bond.Option) Option {
+	return func(o *options) {
+		o.bondID = id
+		o.bondMembers = members
+		o.bondOpts = opts
+	}
+}
+
+// WithHealth sets the health to record the connection attempts to, the connection is probed with the control ping
+// each probeInterval once connected (0 disables probing) and is recorded disconnected when the context is done
+func WithHealth(health *vpphealth.Health, probeInterval time.Duration) Option {
+	return func(o *options) {
+		o.health = health
+		o.probeInterval = probeInterval
+	}
+}